@@ -0,0 +1,175 @@
+package analytics
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// BestBid is the highest bid, 0 without bids.
+// The helpers read the levels in order, bids by Rate descending and asks ascending.
+// Normalize the maker of an adapter first, the adapters don't all return them sorted.
+//
+//	maker, err := analytics.Normalize(maker)
+//	spread := analytics.SpreadBps(maker)
+//	vwap, err := analytics.VWAP(maker, exchange.Buy, 2)
+func BestBid(m *exchange.Maker) float64 {
+	if m == nil || len(m.Bids) == 0 {
+		return 0
+	}
+	return m.Bids[0].Rate
+}
+
+// BestAsk is the lowest ask, 0 without asks
+func BestAsk(m *exchange.Maker) float64 {
+	if m == nil || len(m.Asks) == 0 {
+		return 0
+	}
+	return m.Asks[0].Rate
+}
+
+// Mid is between the best bid and the best ask, 0 if a side is empty
+func Mid(m *exchange.Maker) float64 {
+	bid, ask := BestBid(m), BestAsk(m)
+	if bid <= 0 || ask <= 0 {
+		return 0
+	}
+	return (bid + ask) / 2
+}
+
+// Spread is the best ask - the best bid, 0 if a side is empty
+func Spread(m *exchange.Maker) float64 {
+	bid, ask := BestBid(m), BestAsk(m)
+	if bid <= 0 || ask <= 0 {
+		return 0
+	}
+	return ask - bid
+}
+
+// SpreadBps is the Spread in basis points of the Mid, negative for a crossed book
+func SpreadBps(m *exchange.Maker) float64 {
+	mid := Mid(m)
+	if mid == 0 {
+		return 0
+	}
+	return Spread(m) / mid * 10000
+}
+
+// side is the levels a taker of direction takes, the asks for a Buy and the bids for a Sell
+func side(m *exchange.Maker, direction exchange.TradeDirection) ([]exchange.Order, error) {
+	if m == nil {
+		return nil, fmt.Errorf("nil maker")
+	}
+	switch direction {
+	case exchange.Buy:
+		return m.Asks, nil
+	case exchange.Sell:
+		return m.Bids, nil
+	}
+	return nil, fmt.Errorf("invalid direction: %v", direction)
+}
+
+// VWAP is the average rate of a market order of quantity, the Target coin. Without enough depth
+// it is the average of all of the levels with an error.
+func VWAP(m *exchange.Maker, direction exchange.TradeDirection, quantity float64) (float64, error) {
+	levels, err := side(m, direction)
+	if err != nil {
+		return 0, fmt.Errorf("VWAP %v", err)
+	} else if quantity <= 0 {
+		return 0, fmt.Errorf("VWAP invalid quantity: %v", quantity)
+	}
+
+	left, cost := quantity, 0.0
+	for _, level := range levels {
+		if left <= 0 {
+			break
+		}
+		take := level.Quantity
+		if take >= left {
+			// no rounding residue left when the level covers the rest
+			take, left = left, 0
+		} else {
+			left -= take
+		}
+		cost += take * level.Rate
+	}
+
+	filled := quantity - left
+	if filled <= 0 {
+		return 0, fmt.Errorf("VWAP no %v levels", direction)
+	} else if left > 0 {
+		return cost / filled, fmt.Errorf("VWAP not enough depth: %v of %v", filled, quantity)
+	}
+	return cost / filled, nil
+}
+
+// Slippage is how much worse the VWAP of a market order of quantity is than the best rate, in basis points
+func Slippage(m *exchange.Maker, direction exchange.TradeDirection, quantity float64) (float64, error) {
+	vwap, err := VWAP(m, direction, quantity)
+	if vwap == 0 {
+		return 0, err
+	}
+	if direction == exchange.Buy {
+		best := BestAsk(m)
+		return (vwap - best) / best * 10000, err
+	}
+	best := BestBid(m)
+	return (best - vwap) / best * 10000, err
+}
+
+// Depth is the quantity and its value in the Base coin of the levels of direction within percent of the Mid,
+// the asks for a Buy and the bids for a Sell, the levels a market order takes as in VWAP
+func Depth(m *exchange.Maker, direction exchange.TradeDirection, percent float64) (quantity, value float64) {
+	mid := Mid(m)
+	if mid == 0 {
+		return 0, 0
+	}
+	switch direction {
+	case exchange.Buy:
+		limit := mid * (1 + percent/100)
+		for _, level := range m.Asks {
+			if level.Rate > limit {
+				break
+			}
+			quantity += level.Quantity
+			value += level.Quantity * level.Rate
+		}
+	case exchange.Sell:
+		limit := mid * (1 - percent/100)
+		for _, level := range m.Bids {
+			if level.Rate < limit {
+				break
+			}
+			quantity += level.Quantity
+			value += level.Quantity * level.Rate
+		}
+	}
+	return quantity, value
+}
+
+// Imbalance is (bids - asks) / (bids + asks) of the quantities of the first levels of each side, all of them if 0.
+// It is between -1, only asks, and 1, only bids.
+func Imbalance(m *exchange.Maker, levels int) float64 {
+	if m == nil {
+		return 0
+	}
+	sum := func(orders []exchange.Order) float64 {
+		total := 0.0
+		for i, level := range orders {
+			if levels > 0 && i >= levels {
+				break
+			}
+			total += level.Quantity
+		}
+		return total
+	}
+	bids, asks := sum(m.Bids), sum(m.Asks)
+	if bids+asks == 0 {
+		return 0
+	}
+	return (bids - asks) / (bids + asks)
+}
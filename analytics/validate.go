@@ -0,0 +1,139 @@
+package analytics
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// ErrInvalidBook is wrapped by every BookValidationError
+var ErrInvalidBook = errors.New("invalid orderbook")
+
+// BookValidationError lists what is wrong with a maker, Crossed is a best bid at or above the best ask
+type BookValidationError struct {
+	UnsortedBids bool
+	UnsortedAsks bool
+	Crossed      bool
+	Empty        int // levels of a zero or negative quantity
+	Invalid      int // levels of a zero or negative rate
+}
+
+func (e *BookValidationError) Error() string {
+	reasons := []string{}
+	if e.UnsortedBids {
+		reasons = append(reasons, "bids not sorted")
+	}
+	if e.UnsortedAsks {
+		reasons = append(reasons, "asks not sorted")
+	}
+	if e.Crossed {
+		reasons = append(reasons, "crossed")
+	}
+	if e.Empty > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d levels without quantity", e.Empty))
+	}
+	if e.Invalid > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d levels without rate", e.Invalid))
+	}
+	return fmt.Sprintf("%v: %s", ErrInvalidBook, strings.Join(reasons, ", "))
+}
+
+func (e *BookValidationError) Unwrap() error {
+	return ErrInvalidBook
+}
+
+// Validate checks the bids are by Rate descending, the asks ascending, the book is not crossed
+// and every level has a rate and a quantity. The error is a *BookValidationError.
+func Validate(m *exchange.Maker) error {
+	if m == nil {
+		return fmt.Errorf("Validate nil maker")
+	}
+
+	e := &BookValidationError{}
+	check := func(levels []exchange.Order, sorted func(prev, next float64) bool) bool {
+		ok := true
+		for i, level := range levels {
+			if level.Quantity <= 0 {
+				e.Empty++
+			}
+			if level.Rate <= 0 {
+				e.Invalid++
+			}
+			if i > 0 && !sorted(levels[i-1].Rate, level.Rate) {
+				ok = false
+			}
+		}
+		return ok
+	}
+	e.UnsortedBids = !check(m.Bids, func(prev, next float64) bool { return prev >= next })
+	e.UnsortedAsks = !check(m.Asks, func(prev, next float64) bool { return prev <= next })
+	e.Crossed = Crossed(m)
+
+	if e.UnsortedBids || e.UnsortedAsks || e.Crossed || e.Empty > 0 || e.Invalid > 0 {
+		return e
+	}
+	return nil
+}
+
+// Crossed is a best bid at or above the best ask, the highest bid and the lowest ask whatever their order
+func Crossed(m *exchange.Maker) bool {
+	if m == nil || len(m.Bids) == 0 || len(m.Asks) == 0 {
+		return false
+	}
+	bid, ask := 0.0, 0.0
+	for _, level := range m.Bids {
+		if level.Quantity > 0 && level.Rate > bid {
+			bid = level.Rate
+		}
+	}
+	for _, level := range m.Asks {
+		if level.Quantity > 0 && level.Rate > 0 && (ask == 0 || level.Rate < ask) {
+			ask = level.Rate
+		}
+	}
+	return bid > 0 && ask > 0 && bid >= ask
+}
+
+// Normalize is a copy of the maker without the levels of a zero quantity or rate, the bids sorted
+// by Rate descending and the asks ascending. A crossed book can't be fixed, it is returned with an error.
+func Normalize(m *exchange.Maker) (*exchange.Maker, error) {
+	if m == nil {
+		return nil, fmt.Errorf("Normalize nil maker")
+	}
+
+	normalized := *m
+	normalized.Bids = filter(m.Bids)
+	normalized.Asks = filter(m.Asks)
+	// most adapters are sorted already, the levels are large to swap
+	bids := func(i, j int) bool { return normalized.Bids[i].Rate > normalized.Bids[j].Rate }
+	if !sort.SliceIsSorted(normalized.Bids, bids) {
+		sort.SliceStable(normalized.Bids, bids)
+	}
+	asks := func(i, j int) bool { return normalized.Asks[i].Rate < normalized.Asks[j].Rate }
+	if !sort.SliceIsSorted(normalized.Asks, asks) {
+		sort.SliceStable(normalized.Asks, asks)
+	}
+
+	if Crossed(&normalized) {
+		return &normalized, &BookValidationError{Crossed: true}
+	}
+	return &normalized, nil
+}
+
+// filter copies the levels with a rate and a quantity
+func filter(levels []exchange.Order) []exchange.Order {
+	filtered := make([]exchange.Order, 0, len(levels))
+	for _, level := range levels {
+		if level.Quantity > 0 && level.Rate > 0 {
+			filtered = append(filtered, level)
+		}
+	}
+	return filtered
+}
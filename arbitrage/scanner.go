@@ -0,0 +1,273 @@
+package arbitrage
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+)
+
+const (
+	DEFAULT_BLOCK_TIME = time.Minute
+)
+
+// BlockTimes of the common coins and chains, key: coin code or ChainType
+var BlockTimes = map[string]time.Duration{
+	"BTC":                  10 * time.Minute,
+	"BCH":                  10 * time.Minute,
+	"BSV":                  10 * time.Minute,
+	"LTC":                  150 * time.Second,
+	"DOGE":                 time.Minute,
+	"DASH":                 150 * time.Second,
+	"ZEC":                  75 * time.Second,
+	"ETH":                  13 * time.Second,
+	"ETC":                  13 * time.Second,
+	"XRP":                  4 * time.Second,
+	"XLM":                  5 * time.Second,
+	"EOS":                  time.Second / 2,
+	"TRX":                  3 * time.Second,
+	"NEO":                  15 * time.Second,
+	"BNB":                  time.Second,
+	"ADA":                  20 * time.Second,
+	"XMR":                  2 * time.Minute,
+	string(exchange.ERC20): 13 * time.Second,
+	string(exchange.TRC20): 3 * time.Second,
+	string(exchange.OMNI):  10 * time.Minute,
+	string(exchange.BEP2):  time.Second,
+	string(exchange.NEP5):  15 * time.Second,
+}
+
+// Opportunity is buying Quantity of the Target coin on Buy, withdrawing it to Sell and selling what arrives.
+// The prices are averages with the taker fees, the amounts are in the Base coin.
+type Opportunity struct {
+	Pair          *pair.Pair            `json:"pair"`
+	Buy           exchange.ExchangeName `json:"buy"`
+	Sell          exchange.ExchangeName `json:"sell"`
+	Quantity      float64               `json:"quantity"`     // bought
+	WithdrawFee   float64               `json:"withdraw_fee"` // in the Target coin, Quantity - WithdrawFee is sold
+	BuyPrice      float64               `json:"buy_price"`
+	SellPrice     float64               `json:"sell_price"`
+	Cost          float64               `json:"cost"`
+	Proceeds      float64               `json:"proceeds"`
+	Spread        float64               `json:"spread"` // (SellPrice - BuyPrice) / BuyPrice in %, before the withdraw fee
+	Profit        float64               `json:"profit"` // Proceeds - Cost
+	ProfitPercent float64               `json:"profit_percent"`
+	Confirmations int                   `json:"confirmations"` // of a deposit on Sell
+	TransferTime  time.Duration         `json:"transfer_time"` // Confirmations blocks and the WithdrawDelay
+}
+
+// Scanner looks for the pairs cheaper on one exchange than another after the taker fees and the withdraw fee,
+// for a size in the Base coin. The coins which can't be withdrawn from the buy exchange or deposited
+// on the sell one are skipped.
+//
+//	s := arbitrage.NewScanner(map[string]float64{"USDT": 1000, "BTC": 0.1})
+//	opportunities, err := s.Scan()
+type Scanner struct {
+	Exchanges     []exchange.Exchange                         // the exchanges of the ExchangeManager if empty
+	Feeds         map[exchange.ExchangeName]exchange.BookFeed // orderbooks of an exchange, the exchange itself if not set
+	Sizes         map[string]float64                          // Base coin spent on a buy, key: Base coin code. A pair of another Base is skipped
+	MinProfit     float64                                     // ProfitPercent of a reported opportunity, above 0 if 0
+	BlockTimes    map[string]time.Duration                    // added to the BlockTimes of the package
+	WithdrawDelay time.Duration                               // processing of a withdrawal, added to TransferTime
+}
+
+func NewScanner(sizes map[string]float64) *Scanner {
+	return &Scanner{
+		Feeds:      make(map[exchange.ExchangeName]exchange.BookFeed),
+		Sizes:      sizes,
+		BlockTimes: make(map[string]time.Duration),
+	}
+}
+
+type route struct {
+	buy, sell exchange.Exchange
+	pair      *pair.Pair
+}
+
+type bookKey struct {
+	name   exchange.ExchangeName
+	pairID int
+}
+
+// Scan checks every pair two exchanges have in common both ways, the opportunities are sorted by ProfitPercent.
+// The orderbooks are fetched once, the exchanges in parallel. The errors of the orderbooks are returned
+// together with the opportunities found without them.
+func (s *Scanner) Scan() ([]*Opportunity, error) {
+	exchanges := s.Exchanges
+	if len(exchanges) == 0 {
+		exchanges = exchange.CreateExchangeManager().GetExchanges()
+	}
+
+	routes := []*route{}
+	needed := make(map[exchange.ExchangeName]map[int]*pair.Pair)
+	need := func(e exchange.Exchange, p *pair.Pair) {
+		if needed[e.GetName()] == nil {
+			needed[e.GetName()] = make(map[int]*pair.Pair)
+		}
+		needed[e.GetName()][p.ID] = p
+	}
+	manager := exchange.CreateExchangeManager()
+	for _, buy := range exchanges {
+		for _, sell := range exchanges {
+			if buy == nil || sell == nil || buy.GetName() == sell.GetName() {
+				continue
+			}
+			for _, p := range manager.SubsetPairs(buy, sell) {
+				if _, ok := s.Sizes[p.Base.Code]; !ok {
+					continue
+				} else if !buy.CanWithdraw(p.Target) || !sell.CanDeposit(p.Target) {
+					continue
+				}
+				routes = append(routes, &route{buy: buy, sell: sell, pair: p})
+				need(buy, p)
+				need(sell, p)
+			}
+		}
+	}
+
+	books, errs := s.fetch(exchanges, needed)
+
+	opportunities := []*Opportunity{}
+	for _, r := range routes {
+		buyBook, ok := books[bookKey{r.buy.GetName(), r.pair.ID}]
+		if !ok {
+			continue
+		}
+		sellBook, ok := books[bookKey{r.sell.GetName(), r.pair.ID}]
+		if !ok {
+			continue
+		}
+		o := s.Check(r.buy, r.sell, r.pair, buyBook, sellBook)
+		if o != nil && o.ProfitPercent > s.MinProfit {
+			opportunities = append(opportunities, o)
+		}
+	}
+
+	sort.SliceStable(opportunities, func(i, j int) bool {
+		return opportunities[i].ProfitPercent > opportunities[j].ProfitPercent
+	})
+	if len(errs) > 0 {
+		return opportunities, fmt.Errorf("Scan: %s", strings.Join(errs, "; "))
+	}
+	return opportunities, nil
+}
+
+// Check is the opportunity of buying the pair on buy and selling it on sell with their orderbooks,
+// nil if Sizes has no size of the Base coin, the books are too thin or the withdraw fee is more than the quantity
+func (s *Scanner) Check(buy, sell exchange.Exchange, p *pair.Pair, buyMaker, sellMaker *exchange.Maker) *Opportunity {
+	size, ok := s.Sizes[p.Base.Code]
+	if !ok || size <= 0 || buyMaker == nil || sellMaker == nil {
+		return nil
+	}
+
+	buyBook := orderbook.Merge(p, map[exchange.ExchangeName]*exchange.Maker{buy.GetName(): buyMaker},
+		map[exchange.ExchangeName]float64{buy.GetName(): buy.GetFee(p)})
+	sellBook := orderbook.Merge(p, map[exchange.ExchangeName]*exchange.Maker{sell.GetName(): sellMaker},
+		map[exchange.ExchangeName]float64{sell.GetName(): sell.GetFee(p)})
+	if buyBook.BestAsk() == nil || sellBook.BestBid() == nil {
+		return nil
+	}
+
+	quantity := buyBook.BuyQuantity(size)
+	if quantity <= 0 {
+		return nil
+	}
+	bought, err := buyBook.BestExecution(exchange.Buy, quantity)
+	if err != nil {
+		return nil
+	}
+	withdrawFee := buy.GetTxFee(p.Target)
+	arrived := decimal.NewFromFloat(bought.Quantity).Sub(decimal.NewFromFloat(withdrawFee)).Float64()
+	if arrived <= 0 {
+		return nil
+	}
+	sold, err := sellBook.BestExecution(exchange.Sell, arrived)
+	if err != nil {
+		return nil
+	}
+
+	confirmations := sell.GetConfirmation(p.Target)
+	o := &Opportunity{
+		Pair:          p,
+		Buy:           buy.GetName(),
+		Sell:          sell.GetName(),
+		Quantity:      bought.Quantity,
+		WithdrawFee:   withdrawFee,
+		BuyPrice:      bought.Price,
+		SellPrice:     sold.Price,
+		Cost:          bought.Cost,
+		Proceeds:      sold.Cost,
+		Spread:        (sold.Price - bought.Price) / bought.Price * 100,
+		Profit:        sold.Cost - bought.Cost,
+		Confirmations: confirmations,
+		TransferTime:  time.Duration(confirmations)*s.blockTime(sell, p) + s.WithdrawDelay,
+	}
+	if o.Cost > 0 {
+		o.ProfitPercent = o.Profit / o.Cost * 100
+	}
+	return o
+}
+
+// blockTime of the Target coin by its code, then its chain on the sell exchange
+func (s *Scanner) blockTime(sell exchange.Exchange, p *pair.Pair) time.Duration {
+	keys := []string{p.Target.Code}
+	if constraint := sell.GetCoinConstraint(p.Target); constraint != nil && constraint.ChainType != "" {
+		keys = append(keys, string(constraint.ChainType))
+	}
+	for _, key := range keys {
+		if blockTime, ok := s.BlockTimes[key]; ok {
+			return blockTime
+		} else if blockTime, ok := BlockTimes[key]; ok {
+			return blockTime
+		}
+	}
+	return DEFAULT_BLOCK_TIME
+}
+
+// fetch gets the needed orderbooks, the exchanges in parallel and the pairs of an exchange one after the other
+func (s *Scanner) fetch(exchanges []exchange.Exchange, needed map[exchange.ExchangeName]map[int]*pair.Pair) (map[bookKey]*exchange.Maker, []string) {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	books := make(map[bookKey]*exchange.Maker)
+	errs := []string{}
+
+	for _, e := range exchanges {
+		pairs, ok := needed[e.GetName()]
+		if !ok {
+			continue
+		}
+		var feed exchange.BookFeed = e
+		if f, ok := s.Feeds[e.GetName()]; ok && f != nil {
+			feed = f
+		}
+
+		wg.Add(1)
+		go func(name exchange.ExchangeName, feed exchange.BookFeed, pairs map[int]*pair.Pair) {
+			defer wg.Done()
+			for _, p := range pairs {
+				maker, err := feed.OrderBook(p)
+				mutex.Lock()
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("%s %s: %v", name, p.Name, err))
+				} else if maker != nil {
+					books[bookKey{name, p.ID}] = maker
+				}
+				mutex.Unlock()
+			}
+		}(e.GetName(), feed, pairs)
+	}
+	wg.Wait()
+
+	sort.Strings(errs)
+	return books, errs
+}
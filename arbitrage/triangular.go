@@ -0,0 +1,352 @@
+package arbitrage
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+)
+
+const (
+	DEFAULT_SIZE_STEPS       = 20
+	DEFAULT_SEQUENCE_POLL    = time.Second
+	DEFAULT_SEQUENCE_TIMEOUT = 30 * time.Second
+)
+
+// Leg is an order of a Cycle, a Buy spends the Base coin of the pair for its Target and a Sell the Target for the Base
+type Leg struct {
+	Pair      *pair.Pair              `json:"pair"`
+	Direction exchange.TradeDirection `json:"direction"`
+	From      *coin.Coin              `json:"from"`
+	To        *coin.Coin              `json:"to"`
+}
+
+// Cycle is Coin -> A -> B -> Coin through three pairs of an exchange
+type Cycle struct {
+	Coin *coin.Coin `json:"coin"`
+	Legs []*Leg     `json:"legs"`
+}
+
+func (c *Cycle) String() string {
+	codes := []string{c.Coin.Code}
+	for _, leg := range c.Legs {
+		codes = append(codes, leg.To.Code)
+	}
+	return strings.Join(codes, " -> ")
+}
+
+// Cycles are the cycles of start in the pairs of the exchange, both directions of a triangle are two cycles.
+// The pairs of a constraint not Listed are left out.
+func Cycles(e exchange.Exchange, start *coin.Coin) []*Cycle {
+	legs := make(map[int][]*Leg) // key: From coin id
+	for _, p := range e.GetPairs() {
+		if constraint := e.GetPairConstraint(p); constraint != nil && !constraint.Listed {
+			continue
+		}
+		legs[p.Base.ID] = append(legs[p.Base.ID], &Leg{Pair: p, Direction: exchange.Buy, From: p.Base, To: p.Target})
+		legs[p.Target.ID] = append(legs[p.Target.ID], &Leg{Pair: p, Direction: exchange.Sell, From: p.Target, To: p.Base})
+	}
+
+	cycles := []*Cycle{}
+	for _, first := range legs[start.ID] {
+		for _, second := range legs[first.To.ID] {
+			if second.To.ID == start.ID || second.Pair.ID == first.Pair.ID {
+				continue
+			}
+			for _, third := range legs[second.To.ID] {
+				if third.To.ID == start.ID {
+					cycles = append(cycles, &Cycle{Coin: start, Legs: []*Leg{first, second, third}})
+				}
+			}
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i].String() < cycles[j].String()
+	})
+	return cycles
+}
+
+// LegExecution is a leg at the size of a Triangle. Quantity is the order quantity in the Target coin,
+// rounded down to LotSize, and Rate the worst level it takes, the limit rate of the order.
+// The taker fee is taken from what the leg receives.
+type LegExecution struct {
+	*Leg
+	Quantity    float64 `json:"quantity"`
+	Rate        float64 `json:"rate"`
+	AverageRate float64 `json:"average_rate"`
+	Fee         float64 `json:"fee"`
+	In          float64 `json:"in"`  // of the From coin spent
+	Out         float64 `json:"out"` // of the To coin received
+}
+
+// Triangle is a Cycle evaluated with the orderbooks for Amount of its Coin
+type Triangle struct {
+	Exchange      exchange.ExchangeName `json:"exchange"`
+	Cycle         *Cycle                `json:"cycle"`
+	Amount        float64               `json:"amount"` // spent by the first leg
+	Return        float64               `json:"return"` // received by the last leg
+	Profit        float64               `json:"profit"` // Return - Amount
+	ReturnPercent float64               `json:"return_percent"`
+	Legs          []*LegExecution       `json:"legs"`
+}
+
+// TriangularFinder evaluates the cycles of a coin on one exchange with its orderbooks,
+// the taker fees and the lot sizes of the PairConstraint.
+//
+//	f := arbitrage.NewTriangularFinder(e)
+//	triangles, err := f.Find(coin.GetCoin("USDT"), 1000)
+//	orders, err := arbitrage.NewSequencer(e).Execute(triangles[0])
+type TriangularFinder struct {
+	Exchange  exchange.Exchange
+	Feed      exchange.BookFeed // orderbooks, the Exchange if nil
+	MinReturn float64           // ReturnPercent of a triangle found, above 0 if 0
+	SizeSteps int               // of the search of the size when the amount is not executable
+}
+
+func NewTriangularFinder(e exchange.Exchange) *TriangularFinder {
+	return &TriangularFinder{
+		Exchange:  e,
+		SizeSteps: DEFAULT_SIZE_STEPS,
+	}
+}
+
+// Find evaluates every cycle of start up to amount, sorted by ReturnPercent. The orderbooks are fetched once.
+// If amount is not executable with a ReturnPercent above MinReturn, the size is the largest one found which is.
+// The errors of the orderbooks are returned together with the triangles found without them.
+func (f *TriangularFinder) Find(start *coin.Coin, amount float64) ([]*Triangle, error) {
+	cycles := Cycles(f.Exchange, start)
+
+	var feed exchange.BookFeed = f.Exchange
+	if f.Feed != nil {
+		feed = f.Feed
+	}
+	books := make(map[int]*exchange.Maker)
+	errs := []string{}
+	for _, cycle := range cycles {
+		for _, leg := range cycle.Legs {
+			if _, ok := books[leg.Pair.ID]; ok {
+				continue
+			}
+			maker, err := feed.OrderBook(leg.Pair)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", leg.Pair.Name, err))
+			}
+			books[leg.Pair.ID] = maker
+		}
+	}
+
+	steps := f.SizeSteps
+	if steps <= 0 {
+		steps = DEFAULT_SIZE_STEPS
+	}
+	triangles := []*Triangle{}
+	for _, cycle := range cycles {
+		best, err := f.Evaluate(cycle, amount, books)
+		if err != nil || best.ReturnPercent <= f.MinReturn {
+			best = nil
+			low, high := 0.0, amount
+			for i := 0; i < steps; i++ {
+				size := (low + high) / 2
+				t, err := f.Evaluate(cycle, size, books)
+				if err == nil && t.ReturnPercent > f.MinReturn {
+					best, low = t, size
+				} else {
+					high = size
+				}
+			}
+		}
+		if best != nil {
+			triangles = append(triangles, best)
+		}
+	}
+
+	sort.SliceStable(triangles, func(i, j int) bool {
+		return triangles[i].ReturnPercent > triangles[j].ReturnPercent
+	})
+	if len(errs) > 0 {
+		return triangles, fmt.Errorf("%s Find: %s", f.Exchange.GetName(), strings.Join(errs, "; "))
+	}
+	return triangles, nil
+}
+
+// Evaluate is the cycle for amount of its Coin with the orderbooks of its pairs, key: pair id.
+// It fails if an orderbook is missing or too thin, or a quantity is under the constraint of its pair.
+func (f *TriangularFinder) Evaluate(cycle *Cycle, amount float64, books map[int]*exchange.Maker) (*Triangle, error) {
+	t := &Triangle{
+		Exchange: f.Exchange.GetName(),
+		Cycle:    cycle,
+		Legs:     []*LegExecution{},
+	}
+
+	in := amount
+	for _, leg := range cycle.Legs {
+		maker := books[leg.Pair.ID]
+		if maker == nil {
+			return nil, fmt.Errorf("%s no orderbook of %s", f.Exchange.GetName(), leg.Pair.Name)
+		}
+		// the book without fees, the fee is taken from what is received
+		book := orderbook.Merge(leg.Pair, map[exchange.ExchangeName]*exchange.Maker{f.Exchange.GetName(): maker}, nil)
+
+		quantity := in
+		if leg.Direction == exchange.Buy {
+			quantity = book.BuyQuantity(in)
+		}
+		constraint := f.Exchange.GetPairConstraint(leg.Pair)
+		if constraint == nil {
+			return nil, fmt.Errorf("%s %s has no constraint", f.Exchange.GetName(), leg.Pair.Name)
+		}
+		quantity = constraint.QuantityDecimal(quantity).Float64()
+		if quantity <= 0 {
+			return nil, fmt.Errorf("%s %s %v of %s rounded to 0", f.Exchange.GetName(), leg.Pair.Name, in, leg.From.Code)
+		} else if quantity < constraint.MinTradeQuantity {
+			return nil, fmt.Errorf("%s %s quantity %v less than MinTradeQuantity %v", f.Exchange.GetName(), leg.Pair.Name, quantity, constraint.MinTradeQuantity)
+		}
+
+		execution, err := book.BestExecution(leg.Direction, quantity)
+		if err != nil {
+			return nil, err
+		} else if execution.Cost < constraint.MinTradeBaseQuantity {
+			return nil, fmt.Errorf("%s %s notional %v less than MinTradeBaseQuantity %v", f.Exchange.GetName(), leg.Pair.Name, execution.Cost, constraint.MinTradeBaseQuantity)
+		}
+
+		fee := f.Exchange.GetFee(leg.Pair)
+		keep := decimal.NewFromFloat(1 - fee)
+		l := &LegExecution{
+			Leg:         leg,
+			Quantity:    quantity,
+			Rate:        execution.Fills[len(execution.Fills)-1].Rate,
+			AverageRate: execution.Price,
+			Fee:         fee,
+		}
+		if leg.Direction == exchange.Buy {
+			l.In = execution.Cost
+			l.Out = decimal.NewFromFloat(quantity).Mul(keep).Float64()
+		} else {
+			l.In = quantity
+			l.Out = decimal.NewFromFloat(execution.Cost).Mul(keep).Float64()
+		}
+		t.Legs = append(t.Legs, l)
+		in = l.Out
+	}
+
+	t.Amount = t.Legs[0].In
+	t.Return = t.Legs[len(t.Legs)-1].Out
+	t.Profit = decimal.NewFromFloat(t.Return).Sub(decimal.NewFromFloat(t.Amount)).Float64()
+	if t.Amount > 0 {
+		t.ReturnPercent = t.Profit / t.Amount * 100
+	}
+	return t, nil
+}
+
+// Sequencer places the legs of a Triangle through the adapter one after the other, a leg spends
+// what the previous one received. The limit rate of a leg is its worst level moved by Slippage.
+type Sequencer struct {
+	Exchange exchange.Exchange
+	Poll     time.Duration // between two OrderStatus of a leg
+	Timeout  time.Duration // a leg not Filled in Timeout is cancelled and the sequence stops
+	Slippage float64       // in %
+}
+
+func NewSequencer(e exchange.Exchange) *Sequencer {
+	return &Sequencer{
+		Exchange: e,
+		Poll:     DEFAULT_SEQUENCE_POLL,
+		Timeout:  DEFAULT_SEQUENCE_TIMEOUT,
+	}
+}
+
+// Execute places the legs of t and returns the orders placed. On an error the sequence stops,
+// the coins are left in the From coin of the failed leg and in what a cancelled leg filled.
+func (s *Sequencer) Execute(t *Triangle) ([]*exchange.Order, error) {
+	orders := []*exchange.Order{}
+	in := 0.0
+	for i, leg := range t.Legs {
+		rate := leg.Rate
+		if leg.Direction == exchange.Buy {
+			rate = rate * (1 + s.Slippage/100)
+		} else {
+			rate = rate * (1 - s.Slippage/100)
+		}
+
+		quantity := leg.Quantity
+		if i > 0 {
+			quantity = in
+			if leg.Direction == exchange.Buy {
+				quantity = in / rate
+			}
+		}
+		quantity, rate, err := exchange.ValidateOrder(s.Exchange, leg.Pair, leg.Direction, quantity, rate, true)
+		if err != nil {
+			return orders, fmt.Errorf("%s leg %d %s: %v", s.Exchange.GetName(), i+1, leg.Pair.Name, err)
+		}
+
+		var order *exchange.Order
+		if leg.Direction == exchange.Buy {
+			order, err = s.Exchange.LimitBuy(leg.Pair, quantity, rate)
+		} else {
+			order, err = s.Exchange.LimitSell(leg.Pair, quantity, rate)
+		}
+		if err != nil {
+			return orders, fmt.Errorf("%s leg %d %s: %v", s.Exchange.GetName(), i+1, leg.Pair.Name, err)
+		}
+		orders = append(orders, order)
+
+		if err := s.wait(order); err != nil {
+			return orders, fmt.Errorf("%s leg %d %s: %v", s.Exchange.GetName(), i+1, leg.Pair.Name, err)
+		}
+
+		dealRate := order.DealRate
+		if dealRate == 0 {
+			dealRate = rate
+		}
+		keep := decimal.NewFromFloat(1 - s.Exchange.GetFee(leg.Pair))
+		if leg.Direction == exchange.Buy {
+			in = decimal.NewFromFloat(order.DealQuantity).Mul(keep).Float64()
+		} else {
+			in = decimal.NewFromFloat(order.DealQuantity).Mul(decimal.NewFromFloat(dealRate)).Mul(keep).Float64()
+		}
+	}
+	return orders, nil
+}
+
+// wait polls the order until it is Filled, it is cancelled after Timeout, DEFAULT_SEQUENCE_TIMEOUT if not set
+func (s *Sequencer) wait(order *exchange.Order) error {
+	poll := s.Poll
+	if poll <= 0 {
+		poll = DEFAULT_SEQUENCE_POLL
+	}
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = DEFAULT_SEQUENCE_TIMEOUT
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		switch order.Status {
+		case exchange.Filled:
+			return nil
+		case exchange.Cancelled, exchange.Rejected, exchange.Expired:
+			return fmt.Errorf("order %s %s", order.OrderID, order.Status)
+		}
+		if time.Now().After(deadline) {
+			if err := s.Exchange.CancelOrder(order); err != nil {
+				return fmt.Errorf("order %s not filled in %v, cancel failed: %v", order.OrderID, timeout, err)
+			}
+			return fmt.Errorf("order %s not filled in %v, cancelled with %v filled", order.OrderID, timeout, order.DealQuantity)
+		}
+		time.Sleep(poll)
+		if err := s.Exchange.OrderStatus(order); err != nil {
+			return err
+		}
+	}
+}
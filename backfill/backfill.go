@@ -0,0 +1,420 @@
+package backfill
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+const (
+	DEFAULT_BACKFILL_DELAY       = time.Second
+	DEFAULT_BACKFILL_MAX_DELAY   = time.Minute
+	DEFAULT_BACKFILL_PAGE_SIZE   = 500
+	DEFAULT_BACKFILL_MAX_RETRY   = 5
+	DEFAULT_BACKFILL_EMPTY_PAGES = 3
+
+	DEFAULT_BACKFILL_TRADE_WINDOW = 24 * time.Hour
+)
+
+// Job is a series to backfill. Since is the oldest OpenTime or TimeStamp wanted in ms,
+// with 0 the walk back stops when the exchange has no older data.
+type Job struct {
+	Exchange exchange.Exchange
+	Pair     *pair.Pair
+	Wallet   exchange.WalletType    // SpotWallet if empty
+	Interval exchange.KlineInterval // the klines of the interval, the trades if empty
+	Since    int64
+}
+
+func (j *Job) Series() Series {
+	return Series{
+		Exchange: j.Exchange.GetName(),
+		Pair:     j.Pair.Name,
+		Interval: j.Interval,
+	}
+}
+
+// Backfill fills the Store with the KLine and TradeHistory of the jobs. A job resumes from what is stored:
+// first the series is brought up to now from its last timestamp, then it is walked back from its first one.
+// The requests to an exchange are at least Delay apart, a failed request is retried with a doubling delay.
+//
+//	store, _ := backfill.NewFileStore("history")
+//	b := backfill.NewBackfill(store)
+//	err := b.Run(&backfill.Job{Exchange: e, Pair: p, Interval: exchange.KLINE_1HOUR})
+type Backfill struct {
+	Store Store
+
+	Delay         time.Duration                           // between two requests to an exchange
+	Delays        map[exchange.ExchangeName]time.Duration // Delay of an exchange, if different
+	MaxDelay      time.Duration                           // of a retry
+	PageSize      int                                     // klines of a request
+	TradeWindow   time.Duration                           // of a TradeHistory request with a range
+	MaxRetry      int
+	MaxEmptyPages int // pages without data before the walk back stops
+	Proxy         string
+
+	mutex sync.Mutex
+	next  map[exchange.ExchangeName]time.Time
+}
+
+func NewBackfill(store Store) *Backfill {
+	return &Backfill{
+		Store:         store,
+		Delay:         DEFAULT_BACKFILL_DELAY,
+		Delays:        make(map[exchange.ExchangeName]time.Duration),
+		MaxDelay:      DEFAULT_BACKFILL_MAX_DELAY,
+		PageSize:      DEFAULT_BACKFILL_PAGE_SIZE,
+		TradeWindow:   DEFAULT_BACKFILL_TRADE_WINDOW,
+		MaxRetry:      DEFAULT_BACKFILL_MAX_RETRY,
+		MaxEmptyPages: DEFAULT_BACKFILL_EMPTY_PAGES,
+		next:          make(map[exchange.ExchangeName]time.Time),
+	}
+}
+
+// Run runs the jobs of different exchanges in parallel and the jobs of an exchange one after the other.
+// A failed job doesn't stop the others, the errors are returned together.
+func (b *Backfill) Run(jobs ...*Job) error {
+	byExchange := make(map[exchange.ExchangeName][]*Job)
+	names := []exchange.ExchangeName{}
+	for _, job := range jobs {
+		name := job.Exchange.GetName()
+		if _, ok := byExchange[name]; !ok {
+			names = append(names, name)
+		}
+		byExchange[name] = append(byExchange[name], job)
+	}
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	errs := []string{}
+	for _, name := range names {
+		wg.Add(1)
+		go func(jobs []*Job) {
+			defer wg.Done()
+			for _, job := range jobs {
+				if _, err := b.RunJob(job); err != nil {
+					mutex.Lock()
+					errs = append(errs, err.Error())
+					mutex.Unlock()
+				}
+			}
+		}(byExchange[name])
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("Backfill: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// RunJob backfills one series and returns the number of klines or trades saved
+func (b *Backfill) RunJob(job *Job) (int, error) {
+	if job.Exchange == nil || job.Pair == nil {
+		return 0, fmt.Errorf("Backfill invalid job: %+v", job)
+	}
+	if job.Wallet == "" {
+		job.Wallet = exchange.SpotWallet
+	}
+	if job.Interval == "" {
+		return b.trades(job)
+	} else if !job.Interval.Valid() {
+		return 0, fmt.Errorf("%s invalid kline interval: %v", job.Exchange.GetName(), job.Interval)
+	}
+	return b.klines(job)
+}
+
+func (b *Backfill) klines(job *Job) (int, error) {
+	series := job.Series()
+	step := int64(job.Interval.Duration() / time.Millisecond)
+	pageSize := b.PageSize
+	if pageSize <= 0 {
+		pageSize = DEFAULT_BACKFILL_PAGE_SIZE
+	}
+	// only the closed klines are saved
+	now := time.Now().UnixNano()/1e6 - step
+
+	return b.walk(job, series, now, step*int64(pageSize), false, func(start, end int64, keep func(timestamp int64) bool) ([]int64, error) {
+		klines, err := b.loadKlines(job, start, end)
+		if err != nil {
+			return nil, err
+		}
+		timestamps := []int64{}
+		kept := []*exchange.KlineDetail{}
+		for _, kline := range klines {
+			if openTime := int64(kline.OpenTime); keep(openTime) {
+				timestamps = append(timestamps, openTime)
+				kept = append(kept, kline)
+			}
+		}
+		if len(kept) == 0 {
+			return nil, nil
+		}
+		return timestamps, b.Store.SaveKlines(series, kept)
+	})
+}
+
+// walk brings the series up to now from its last timestamp, then walks back from its first one to job.Since,
+// in windows of page ms. window loads [start, end] and saves what keep returns true for, it returns the saved timestamps.
+// inclusive loads the first and the last timestamp again, for the trades of the same ms not all saved,
+// window skips the ones saved. A window which returns ErrTradeRange or ErrKlineRange starts before what
+// the exchange can fetch, its timestamps are added and it ends the walk back.
+func (b *Backfill) walk(job *Job, series Series, now, page int64, inclusive bool, window func(start, end int64, keep func(timestamp int64) bool) ([]int64, error)) (int, error) {
+	first, last, ok, err := b.Store.Range(series)
+	if err != nil {
+		return 0, err
+	}
+
+	saved := 0
+	add := func(timestamps []int64) {
+		for _, timestamp := range timestamps {
+			if !ok || timestamp < first {
+				first = timestamp
+			}
+			if !ok || timestamp > last {
+				last = timestamp
+			}
+			ok = true
+		}
+		saved += len(timestamps)
+	}
+
+	// the first and the last timestamp of the store, again if inclusive
+	margin := int64(1)
+	if inclusive {
+		margin = 0
+	}
+
+	// up to now
+	if ok {
+		for from := last + margin; from <= now; from += page {
+			to := from + page - 1
+			if to > now {
+				to = now
+			}
+			timestamps, err := window(from, to, func(timestamp int64) bool {
+				return timestamp >= last+margin && timestamp <= now
+			})
+			if err != nil && !isRange(err) {
+				return saved, err
+			}
+			add(timestamps)
+		}
+	}
+
+	// walk back
+	end := now
+	if ok {
+		end = first - margin
+	}
+	for empty := 0; end > 0 && end >= job.Since && empty < b.maxEmptyPages(); {
+		start := end - page + 1
+		if start < job.Since {
+			start = job.Since
+		}
+		if start < 1 {
+			start = 1
+		}
+		timestamps, err := window(start, end, func(timestamp int64) bool {
+			return timestamp >= start && timestamp <= end && (!ok || timestamp <= first-margin)
+		})
+		if err != nil && !isRange(err) {
+			return saved, err
+		}
+		add(timestamps)
+		if err != nil {
+			break
+		}
+		if len(timestamps) == 0 {
+			empty++
+		} else {
+			empty = 0
+		}
+		end = start - 1
+	}
+
+	return saved, nil
+}
+
+func (b *Backfill) loadKlines(job *Job, start, end int64) ([]*exchange.KlineDetail, error) {
+	var klines []*exchange.KlineDetail
+	err := b.load(job.Exchange, func() *exchange.PublicOperation {
+		return &exchange.PublicOperation{
+			Type:           exchange.KLine,
+			EX:             job.Exchange.GetName(),
+			Pair:           job.Pair,
+			Wallet:         job.Wallet,
+			KlineInterval:  job.Interval,
+			KlineStartTime: start,
+			KlineEndTime:   end,
+			Proxy:          b.Proxy,
+		}
+	}, func(operation *exchange.PublicOperation) {
+		klines = operation.Kline
+	})
+	return klines, err
+}
+
+// trades walks the TradeHistory in windows of TradeWindow. An exchange without pages only returns
+// its latest trades: a window which starts before them saves the ones in it and ends the walk back.
+func (b *Backfill) trades(job *Job) (int, error) {
+	series := job.Series()
+	window := b.TradeWindow
+	if window <= 0 {
+		window = DEFAULT_BACKFILL_TRADE_WINDOW
+	}
+	now := time.Now().UnixNano() / 1e6
+
+	// the trades saved at the first and the last timestamp, walk loads them again
+	saved := make(map[string]bool)
+	first, last, ok, err := b.Store.Range(series)
+	if err != nil {
+		return 0, err
+	} else if ok {
+		for _, timestamp := range []int64{first, last} {
+			ids, err := b.Store.TradeIDs(series, timestamp)
+			if err != nil {
+				return 0, err
+			}
+			for _, id := range ids {
+				saved[fmt.Sprintf("%s|%d", id, timestamp)] = true
+			}
+		}
+	}
+
+	return b.walk(job, series, now, int64(window/time.Millisecond), true, func(start, end int64, keep func(timestamp int64) bool) ([]int64, error) {
+		trades, rangeErr := b.loadTrades(job, start, end)
+		if errors.Is(rangeErr, exchange.ErrTradeRange) {
+			// the window starts before the latest trades, the ones in it are saved and the walk back ends
+			var err error
+			if trades, err = b.loadTrades(job, 0, 0); err != nil {
+				return nil, err
+			}
+		} else if rangeErr != nil {
+			return nil, rangeErr
+		}
+
+		timestamps := []int64{}
+		kept := []*exchange.TradeDetail{}
+		seen := make(map[string]bool)
+		for _, trade := range trades {
+			key := fmt.Sprintf("%s|%d", trade.ID, trade.TimeStamp)
+			if seen[key] || saved[key] || !keep(trade.TimeStamp) {
+				continue
+			}
+			// without an id a trade of the first or the last timestamp can't be told from a saved one
+			if ok && trade.ID == "" && (trade.TimeStamp == first || trade.TimeStamp == last) {
+				continue
+			}
+			seen[key] = true
+			timestamps = append(timestamps, trade.TimeStamp)
+			kept = append(kept, trade)
+		}
+		if len(kept) > 0 {
+			if err := b.Store.SaveTrades(series, kept); err != nil {
+				return nil, err
+			}
+		}
+		return timestamps, rangeErr
+	})
+}
+
+// loadTrades loads the TradeHistory of [start, end], the latest trades without a range
+func (b *Backfill) loadTrades(job *Job, start, end int64) ([]*exchange.TradeDetail, error) {
+	var trades []*exchange.TradeDetail
+	err := b.load(job.Exchange, func() *exchange.PublicOperation {
+		return &exchange.PublicOperation{
+			Type:           exchange.TradeHistory,
+			EX:             job.Exchange.GetName(),
+			Pair:           job.Pair,
+			Wallet:         job.Wallet,
+			TradeStartTime: start,
+			TradeEndTime:   end,
+			Proxy:          b.Proxy,
+		}
+	}, func(operation *exchange.PublicOperation) {
+		trades = operation.TradeHistory
+	})
+	return trades, err
+}
+
+// load calls LoadPublicData with a new operation until it succeeds, at most MaxRetry retries.
+// An operation the exchange doesn't support or a range it can't fetch is not retried.
+func (b *Backfill) load(e exchange.Exchange, operation func() *exchange.PublicOperation, done func(*exchange.PublicOperation)) error {
+	delay := b.delay(e.GetName())
+	for retry := 0; ; retry++ {
+		b.wait(e.GetName())
+		op := operation()
+		err := e.LoadPublicData(op)
+		if err == nil {
+			err = op.Error
+		}
+		if err == nil {
+			done(op)
+			return nil
+		}
+		if isPermanent(err) || retry >= b.MaxRetry {
+			return err
+		}
+
+		log.Printf("Backfill %s %v retry in %v: %v", e.GetName(), op.Type, delay, err)
+		time.Sleep(delay)
+		delay *= 2
+		if b.MaxDelay > 0 && delay > b.MaxDelay {
+			delay = b.MaxDelay
+		}
+	}
+}
+
+func isPermanent(err error) bool {
+	return errors.Is(err, exchange.ErrNotSupported) || isRange(err) || strings.Contains(err.Error(), "Operation type invalid")
+}
+
+func isRange(err error) bool {
+	return errors.Is(err, exchange.ErrTradeRange) || errors.Is(err, exchange.ErrKlineRange)
+}
+
+func (b *Backfill) delay(name exchange.ExchangeName) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if delay, ok := b.Delays[name]; ok {
+		return delay
+	}
+	return b.Delay
+}
+
+// wait takes the next request slot of the exchange
+func (b *Backfill) wait(name exchange.ExchangeName) {
+	delay := b.delay(name)
+
+	b.mutex.Lock()
+	if b.next == nil {
+		b.next = make(map[exchange.ExchangeName]time.Time)
+	}
+	now := time.Now()
+	slot := b.next[name]
+	if slot.Before(now) {
+		slot = now
+	}
+	b.next[name] = slot.Add(delay)
+	b.mutex.Unlock()
+
+	time.Sleep(time.Until(slot))
+}
+
+func (b *Backfill) maxEmptyPages() int {
+	if b.MaxEmptyPages <= 0 {
+		return DEFAULT_BACKFILL_EMPTY_PAGES
+	}
+	return b.MaxEmptyPages
+}
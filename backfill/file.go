@@ -0,0 +1,247 @@
+package backfill
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// FileStore saves every series to a json lines file in Dir, eg. BINANCE_BTC-ETH_1hour.jsonl.
+// The lines are in the order they were saved, not by time, Klines and Trades sort them.
+type FileStore struct {
+	Dir string
+
+	mutex  sync.Mutex
+	ranges map[Series]*fileRange
+}
+
+type fileRange struct {
+	first, last int64
+	ok          bool
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("FileStore: %v", err)
+	}
+	return &FileStore{
+		Dir:    dir,
+		ranges: make(map[Series]*fileRange),
+	}, nil
+}
+
+func (s *FileStore) Path(series Series) string {
+	return filepath.Join(s.Dir, series.Name()+".jsonl")
+}
+
+// Range reads the file once, then it is kept up to date by the saves
+func (s *FileStore) Range(series Series) (int64, int64, bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r, err := s.loadRange(series)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return r.first, r.last, r.ok, nil
+}
+
+func (s *FileStore) loadRange(series Series) (*fileRange, error) {
+	if r, ok := s.ranges[series]; ok {
+		return r, nil
+	}
+
+	r := &fileRange{}
+	err := s.scan(series, func(timestamp int64) {
+		r.add(timestamp)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.ranges[series] = r
+	return r, nil
+}
+
+func (r *fileRange) add(timestamp int64) {
+	if !r.ok || timestamp < r.first {
+		r.first = timestamp
+	}
+	if !r.ok || timestamp > r.last {
+		r.last = timestamp
+	}
+	r.ok = true
+}
+
+// scan calls found with the timestamp of every line
+func (s *FileStore) scan(series Series, found func(timestamp int64)) error {
+	return s.readLines(series, func(data []byte) error {
+		if series.IsTrades() {
+			trade := &exchange.TradeDetail{}
+			if err := json.Unmarshal(data, trade); err != nil {
+				return err
+			}
+			found(trade.TimeStamp)
+		} else {
+			kline := &exchange.KlineDetail{}
+			if err := json.Unmarshal(data, kline); err != nil {
+				return err
+			}
+			found(int64(kline.OpenTime))
+		}
+		return nil
+	})
+}
+
+func (s *FileStore) SaveKlines(series Series, klines []*exchange.KlineDetail) error {
+	timestamps := []int64{}
+	records := []interface{}{}
+	for _, kline := range klines {
+		timestamps = append(timestamps, int64(kline.OpenTime))
+		records = append(records, kline)
+	}
+	return s.append(series, timestamps, records)
+}
+
+func (s *FileStore) SaveTrades(series Series, trades []*exchange.TradeDetail) error {
+	timestamps := []int64{}
+	records := []interface{}{}
+	for _, trade := range trades {
+		timestamps = append(timestamps, trade.TimeStamp)
+		records = append(records, trade)
+	}
+	return s.append(series, timestamps, records)
+}
+
+func (s *FileStore) append(series Series, timestamps []int64, records []interface{}) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	r, err := s.loadRange(series)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.Path(series), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("FileStore: %v", err)
+	}
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			file.Close()
+			return fmt.Errorf("FileStore: %s Marshal Err: %v", s.Path(series), err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return fmt.Errorf("FileStore: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("FileStore: %v", err)
+	}
+
+	for _, timestamp := range timestamps {
+		r.add(timestamp)
+	}
+	return nil
+}
+
+// Klines reads the klines of the series sorted by OpenTime
+func (s *FileStore) Klines(series Series) ([]*exchange.KlineDetail, error) {
+	klines := []*exchange.KlineDetail{}
+	err := s.read(series, func(data []byte) error {
+		kline := &exchange.KlineDetail{}
+		if err := json.Unmarshal(data, kline); err != nil {
+			return err
+		}
+		klines = append(klines, kline)
+		return nil
+	})
+	sort.SliceStable(klines, func(i, j int) bool {
+		return klines[i].OpenTime < klines[j].OpenTime
+	})
+	return klines, err
+}
+
+// Trades reads the trades of the series sorted by TimeStamp
+func (s *FileStore) Trades(series Series) ([]*exchange.TradeDetail, error) {
+	trades := []*exchange.TradeDetail{}
+	err := s.read(series, func(data []byte) error {
+		trade := &exchange.TradeDetail{}
+		if err := json.Unmarshal(data, trade); err != nil {
+			return err
+		}
+		trades = append(trades, trade)
+		return nil
+	})
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].TimeStamp < trades[j].TimeStamp
+	})
+	return trades, err
+}
+
+// TradeIDs reads the file
+func (s *FileStore) TradeIDs(series Series, timestamp int64) ([]string, error) {
+	ids := []string{}
+	err := s.read(series, func(data []byte) error {
+		trade := &exchange.TradeDetail{}
+		if err := json.Unmarshal(data, trade); err != nil {
+			return err
+		}
+		if trade.TimeStamp == timestamp {
+			ids = append(ids, trade.ID)
+		}
+		return nil
+	})
+	return ids, err
+}
+
+func (s *FileStore) read(series Series, line func(data []byte) error) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.readLines(series, line)
+}
+
+func (s *FileStore) readLines(series Series, line func(data []byte) error) error {
+	file, err := os.Open(s.Path(series))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("FileStore: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if err := line(scanner.Bytes()); err != nil {
+			return fmt.Errorf("FileStore: %s Unmarshal Err: %v", s.Path(series), err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("FileStore: %s %v", s.Path(series), err)
+	}
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	return nil
+}
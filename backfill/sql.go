@@ -0,0 +1,150 @@
+package backfill
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// SQLStore saves the series to the tables gored_klines and gored_trades of a database/sql database.
+// The driver is imported by the caller, the statements work on SQLite and Postgres:
+//
+//	import _ "github.com/mattn/go-sqlite3"
+//	db, _ := sql.Open("sqlite3", "history.db")
+//	store, err := backfill.NewSQLStore(db)
+type SQLStore struct {
+	DB *sql.DB
+}
+
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS gored_klines (
+		exchange VARCHAR(32) NOT NULL,
+		pair VARCHAR(64) NOT NULL,
+		kline_interval VARCHAR(16) NOT NULL,
+		open_time BIGINT NOT NULL,
+		open DOUBLE PRECISION NOT NULL,
+		high DOUBLE PRECISION NOT NULL,
+		low DOUBLE PRECISION NOT NULL,
+		close DOUBLE PRECISION NOT NULL,
+		volume DOUBLE PRECISION NOT NULL,
+		close_time BIGINT NOT NULL,
+		quote_asset_volume DOUBLE PRECISION NOT NULL,
+		trades_count DOUBLE PRECISION NOT NULL,
+		taker_buy_base_volume DOUBLE PRECISION NOT NULL,
+		taker_buy_quote_volume DOUBLE PRECISION NOT NULL,
+		PRIMARY KEY (exchange, pair, kline_interval, open_time)
+	)`,
+	`CREATE TABLE IF NOT EXISTS gored_trades (
+		exchange VARCHAR(32) NOT NULL,
+		pair VARCHAR(64) NOT NULL,
+		id VARCHAR(64) NOT NULL,
+		timestamp BIGINT NOT NULL,
+		rate DOUBLE PRECISION NOT NULL,
+		quantity DOUBLE PRECISION NOT NULL,
+		direction VARCHAR(8) NOT NULL
+	)`,
+	// not every exchange has trade ids, the trades have no primary key
+	`CREATE INDEX IF NOT EXISTS gored_trades_timestamp ON gored_trades (exchange, pair, timestamp)`,
+}
+
+// NewSQLStore creates the tables if they don't exist
+func NewSQLStore(db *sql.DB) (*SQLStore, error) {
+	for _, statement := range sqlSchema {
+		if _, err := db.Exec(statement); err != nil {
+			return nil, fmt.Errorf("SQLStore: %v", err)
+		}
+	}
+	return &SQLStore{DB: db}, nil
+}
+
+func (s *SQLStore) Range(series Series) (int64, int64, bool, error) {
+	var first, last sql.NullInt64
+	var row *sql.Row
+	if series.IsTrades() {
+		row = s.DB.QueryRow(`SELECT MIN(timestamp), MAX(timestamp) FROM gored_trades WHERE exchange = $1 AND pair = $2`,
+			string(series.Exchange), series.Pair)
+	} else {
+		row = s.DB.QueryRow(`SELECT MIN(open_time), MAX(open_time) FROM gored_klines WHERE exchange = $1 AND pair = $2 AND kline_interval = $3`,
+			string(series.Exchange), series.Pair, string(series.Interval))
+	}
+	if err := row.Scan(&first, &last); err != nil {
+		return 0, 0, false, fmt.Errorf("SQLStore: %s Range Err: %v", series, err)
+	}
+	return first.Int64, last.Int64, first.Valid, nil
+}
+
+func (s *SQLStore) SaveKlines(series Series, klines []*exchange.KlineDetail) error {
+	return s.insert(series, `INSERT INTO gored_klines (exchange, pair, kline_interval, open_time, open, high, low, close, volume,
+		close_time, quote_asset_volume, trades_count, taker_buy_base_volume, taker_buy_quote_volume)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`, len(klines), func(i int) []interface{} {
+		k := klines[i]
+		return []interface{}{string(series.Exchange), series.Pair, string(series.Interval), int64(k.OpenTime), k.Open, k.High, k.Low, k.Close, k.Volume,
+			int64(k.CloseTime), k.QuoteAssetVolume, k.TradesCount, k.TakerBuyBaseVolume, k.TakerBuyQuoteVolume}
+	})
+}
+
+func (s *SQLStore) SaveTrades(series Series, trades []*exchange.TradeDetail) error {
+	return s.insert(series, `INSERT INTO gored_trades (exchange, pair, id, timestamp, rate, quantity, direction)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`, len(trades), func(i int) []interface{} {
+		t := trades[i]
+		return []interface{}{string(series.Exchange), series.Pair, t.ID, t.TimeStamp, t.Rate, t.Quantity, string(t.Direction)}
+	})
+}
+
+func (s *SQLStore) TradeIDs(series Series, timestamp int64) ([]string, error) {
+	rows, err := s.DB.Query(`SELECT id FROM gored_trades WHERE exchange = $1 AND pair = $2 AND timestamp = $3`,
+		string(series.Exchange), series.Pair, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("SQLStore: %s TradeIDs Err: %v", series, err)
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("SQLStore: %s TradeIDs Err: %v", series, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// insert saves a batch in one transaction
+func (s *SQLStore) insert(series Series, query string, count int, args func(i int) []interface{}) error {
+	if count == 0 {
+		return nil
+	}
+
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("SQLStore: %v", err)
+	}
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("SQLStore: %v", err)
+	}
+	defer stmt.Close()
+
+	for i := 0; i < count; i++ {
+		if _, err := stmt.Exec(args(i)...); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("SQLStore: %s insert Err: %v", series, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("SQLStore: %v", err)
+	}
+	return nil
+}
+
+// Close closes the database
+func (s *SQLStore) Close() error {
+	return s.DB.Close()
+}
@@ -0,0 +1,55 @@
+package backfill
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// Series is the stored data of a pair on an exchange, the klines of Interval or the trades if Interval is empty
+type Series struct {
+	Exchange exchange.ExchangeName  `json:"exchange"`
+	Pair     string                 `json:"pair"` // pair.Pair.Name
+	Interval exchange.KlineInterval `json:"interval"`
+}
+
+func (s Series) IsTrades() bool {
+	return s.Interval == ""
+}
+
+func (s Series) String() string {
+	if s.IsTrades() {
+		return fmt.Sprintf("%s %s trades", s.Exchange, s.Pair)
+	}
+	return fmt.Sprintf("%s %s %s", s.Exchange, s.Pair, s.Interval)
+}
+
+// Name is the series as a file name, eg. BINANCE_BTC|ETH_1hour becomes BINANCE_BTC-ETH_1hour
+func (s Series) Name() string {
+	kind := string(s.Interval)
+	if s.IsTrades() {
+		kind = "trades"
+	}
+	name := fmt.Sprintf("%s_%s_%s", s.Exchange, s.Pair, kind)
+	return strings.NewReplacer("|", "-", "/", "-", "\\", "-", ":", "-").Replace(name)
+}
+
+// Store saves the backfilled series. Backfill only saves what is older than the first or newer than the last
+// timestamp of Range, and the trades of these timestamps not in TradeIDs, so a Store doesn't need to check for duplicates.
+// FileStore and SQLStore are included, a Store for another format, eg. Parquet, only implements the interface.
+type Store interface {
+	// Range is the first and the last OpenTime of the klines, or TimeStamp of the trades, in ms.
+	// ok is false if nothing is stored.
+	Range(series Series) (first, last int64, ok bool, err error)
+	SaveKlines(series Series, klines []*exchange.KlineDetail) error
+	SaveTrades(series Series, trades []*exchange.TradeDetail) error
+	// TradeIDs are the ids of the trades saved at timestamp, the trades resume from the first and the last
+	// timestamp of Range and skip them
+	TradeIDs(series Series, timestamp int64) ([]string, error)
+	Close() error
+}
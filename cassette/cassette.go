@@ -0,0 +1,366 @@
+package cassette
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Cassette records the http calls of the adapters to a file and replays them.
+// It replaces http.DefaultTransport, which is used by exchange.HttpGetRequest, utils.HttpGetRequest
+// and the ApiKey* functions of the adapters, so no adapter has to be changed.
+//
+//	c, _ := cassette.Start("testdata/binance_orderbook.json", cassette.Record)
+//	c.AddSecret(API_KEY, API_SECRET)
+//	... test ...
+//	c.Stop()
+//
+// Api keys, signatures and passphrases are redacted in the file. Timestamps and nonces are ignored
+// when a request is matched, so the replay is deterministic.
+type Cassette struct {
+	Path string `json:"-"`
+	Mode Mode   `json:"-"`
+
+	Interactions []*Interaction `json:"interactions"`
+
+	mutex   sync.Mutex
+	base    http.RoundTripper
+	secrets []string
+	played  map[string]int
+}
+
+type Mode string
+
+const (
+	Record Mode = "record"
+	Replay Mode = "replay"
+)
+
+// Interaction is a request and its response, as saved in the file
+type Interaction struct {
+	Method        string            `json:"method"`
+	URL           string            `json:"url"`
+	RequestHeader map[string]string `json:"requestHeader,omitempty"`
+	RequestBody   string            `json:"requestBody,omitempty"`
+	StatusCode    int               `json:"statusCode"`
+	ResponseBody  string            `json:"responseBody"`
+}
+
+const REDACTED = "REDACTED"
+
+// names of headers, params and json fields, compared in lower case by substring
+var (
+	SensitiveNames = []string{"key", "sign", "secret", "passphrase", "password", "token", "auth"}
+	VolatileNames  = []string{"timestamp", "nonce", "tonce", "recvwindow", "expires", "ts"}
+)
+
+var active *Cassette
+var activeMutex sync.Mutex
+
+// Start installs the cassette, Replay loads the file first. Only one cassette can be active.
+func Start(path string, mode Mode) (*Cassette, error) {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+	if active != nil {
+		return nil, fmt.Errorf("cassette: %s is already started", active.Path)
+	}
+
+	c := &Cassette{
+		Path:   path,
+		Mode:   mode,
+		base:   http.DefaultTransport,
+		played: make(map[string]int),
+	}
+	switch mode {
+	case Record:
+	case Replay:
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cassette: %v", err)
+		}
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("cassette: %s Unmarshal Err: %v", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("cassette: invalid mode %q", mode)
+	}
+
+	active = c
+	http.DefaultTransport = c
+	return c, nil
+}
+
+// Stop restores http.DefaultTransport, in Record mode the interactions are saved
+func (c *Cassette) Stop() error {
+	activeMutex.Lock()
+	if active == c {
+		http.DefaultTransport = c.base
+		active = nil
+	}
+	activeMutex.Unlock()
+
+	if c.Mode != Record {
+		return nil
+	}
+
+	// no \u0026 for the & of the queries
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	c.mutex.Lock()
+	err := encoder.Encode(c)
+	c.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("cassette: Marshal Err: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+		return fmt.Errorf("cassette: %v", err)
+	}
+	return ioutil.WriteFile(c.Path, buffer.Bytes(), 0644)
+}
+
+// AddSecret redacts the values wherever they appear, e.g. an api key in the path
+func (c *Cassette) AddSecret(values ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, value := range values {
+		if value != "" {
+			c.secrets = append(c.secrets, value)
+		}
+	}
+}
+
+// Transport wraps a transport of its own, e.g. with a proxy, with the active cassette
+func Transport(base http.RoundTripper) http.RoundTripper {
+	activeMutex.Lock()
+	defer activeMutex.Unlock()
+	if active == nil {
+		return base
+	}
+	return &transport{cassette: active, base: base}
+}
+
+type transport struct {
+	cassette *Cassette
+	base     http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.cassette.roundTrip(req, t.base)
+}
+
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	return c.roundTrip(req, c.base)
+}
+
+func (c *Cassette) roundTrip(req *http.Request, base http.RoundTripper) (*http.Response, error) {
+	requestBody := []byte{}
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		requestBody = data
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+
+	if c.Mode == Replay {
+		return c.replay(req, requestBody)
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(responseBody))
+
+	interaction := &Interaction{
+		Method:        req.Method,
+		URL:           c.redactURL(req.URL),
+		RequestHeader: c.redactHeader(req.Header),
+		RequestBody:   c.redactBody(string(requestBody)),
+		StatusCode:    resp.StatusCode,
+		ResponseBody:  c.redactSecrets(string(responseBody)),
+	}
+	c.mutex.Lock()
+	c.Interactions = append(c.Interactions, interaction)
+	c.mutex.Unlock()
+
+	return resp, nil
+}
+
+// replay serves the interactions of the same request in the recorded order, the last one is repeated
+func (c *Cassette) replay(req *http.Request, requestBody []byte) (*http.Response, error) {
+	key := c.matchKey(req.Method, c.redactURL(req.URL), c.redactBody(string(requestBody)))
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	matched := []*Interaction{}
+	for _, interaction := range c.Interactions {
+		if c.matchKey(interaction.Method, interaction.URL, interaction.RequestBody) == key {
+			matched = append(matched, interaction)
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("cassette: no interaction for %s %s in %s", req.Method, c.redactURL(req.URL), c.Path)
+	}
+
+	i := c.played[key]
+	if i >= len(matched) {
+		i = len(matched) - 1
+	}
+	c.played[key] = i + 1
+	interaction := matched[i]
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(interaction.ResponseBody)),
+		ContentLength: int64(len(interaction.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// matchKey is the method, the url and the body without the volatile params
+func (c *Cassette) matchKey(method, rawURL, body string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return method + " " + rawURL + " " + body
+	}
+	query := withoutVolatile(u.Query())
+	u.RawQuery = ""
+
+	if values, err := url.ParseQuery(body); err == nil && strings.Contains(body, "=") && !strings.HasPrefix(body, "{") {
+		body = withoutVolatile(values)
+	} else {
+		fields := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(body), &fields); err == nil {
+			for name := range fields {
+				if isName(name, VolatileNames) {
+					delete(fields, name)
+				}
+			}
+			data, _ := json.Marshal(fields)
+			body = string(data)
+		}
+	}
+	return fmt.Sprintf("%s %s?%s %s", method, u.String(), query, body)
+}
+
+func withoutVolatile(values url.Values) string {
+	for name := range values {
+		if isName(name, VolatileNames) {
+			values.Del(name)
+		}
+	}
+	return values.Encode()
+}
+
+func isName(name string, names []string) bool {
+	lower := strings.ToLower(name)
+	for _, n := range names {
+		if n == "ts" {
+			// too short for a substring
+			if lower == n {
+				return true
+			}
+		} else if strings.Contains(lower, n) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cassette) redactSecrets(str string) string {
+	for _, secret := range c.secrets {
+		str = strings.Replace(str, secret, REDACTED, -1)
+	}
+	return str
+}
+
+func (c *Cassette) redactValues(values url.Values) url.Values {
+	for name := range values {
+		if isName(name, SensitiveNames) {
+			values.Set(name, REDACTED)
+		}
+	}
+	return values
+}
+
+func (c *Cassette) redactURL(u *url.URL) string {
+	redacted := *u
+	redacted.RawQuery = c.redactValues(u.Query()).Encode()
+	return c.redactSecrets(redacted.String())
+}
+
+func (c *Cassette) redactHeader(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	names := []string{}
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value := header.Get(name)
+		if isName(name, SensitiveNames) {
+			value = REDACTED
+		}
+		result[name] = c.redactSecrets(value)
+	}
+	return result
+}
+
+// redactBody redacts a form or a json object, other bodies only by the secrets
+func (c *Cassette) redactBody(body string) string {
+	if body == "" {
+		return body
+	}
+
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(body), &fields); err == nil {
+		redactJSON(fields)
+		data, _ := json.Marshal(fields)
+		return c.redactSecrets(string(data))
+	}
+	if values, err := url.ParseQuery(body); err == nil && strings.Contains(body, "=") {
+		return c.redactSecrets(c.redactValues(values).Encode())
+	}
+	return c.redactSecrets(body)
+}
+
+func redactJSON(fields map[string]interface{}) {
+	for name, value := range fields {
+		if isName(name, SensitiveNames) {
+			fields[name] = REDACTED
+		} else if object, ok := value.(map[string]interface{}); ok {
+			redactJSON(object)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	cmap "github.com/orcaman/concurrent-map"
 )
@@ -31,6 +32,7 @@ type Coin struct { // might also understand as public chain or token
 
 var LastID int
 var coinMap cmap.ConcurrentMap
+var healthLock sync.RWMutex
 
 func Init() {
 	if coinMap == nil {
@@ -38,6 +40,19 @@ func Init() {
 	}
 }
 
+// SetHealth sets the Health of a coin, the coins are shared: read it with GetHealth while it may be set
+func SetHealth(coin *Coin, health string) {
+	healthLock.Lock()
+	defer healthLock.Unlock()
+	coin.Health = health
+}
+
+func GetHealth(coin *Coin) string {
+	healthLock.RLock()
+	defer healthLock.RUnlock()
+	return coin.Health
+}
+
 func GenerateCoinID() int {
 	return LastID + 1
 }
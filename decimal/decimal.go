@@ -0,0 +1,318 @@
+package decimal
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is a fixed-point number, value = coef * 10^-scale
+// the zero value is 0, a Decimal is immutable
+type Decimal struct {
+	coef  *big.Int
+	scale int32
+}
+
+var Zero = Decimal{}
+
+var ten = big.NewInt(10)
+
+// New returns coef * 10^-scale, New(123, 2) is 1.23
+func New(coef int64, scale int32) Decimal {
+	d := Decimal{coef: big.NewInt(coef), scale: scale}
+	if scale < 0 {
+		d = d.rescale(0)
+	}
+	return d
+}
+
+// NewFromString parses "1.23", "-0.001", "1e-8" and "1.5E+3"
+func NewFromString(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Zero, fmt.Errorf("decimal: can't parse empty string")
+	}
+
+	exp := int64(0)
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(str[i+1:], 10, 32)
+		if err != nil {
+			return Zero, fmt.Errorf("decimal: can't parse exponent of %q", s)
+		}
+		exp = e
+		str = str[:i]
+	}
+
+	digits := str
+	scale := int64(0)
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		digits = str[:i] + str[i+1:]
+		scale = int64(len(str) - i - 1)
+	}
+	if digits == "" || digits == "-" || digits == "+" {
+		return Zero, fmt.Errorf("decimal: can't parse %q", s)
+	}
+
+	coef, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Zero, fmt.Errorf("decimal: can't parse %q", s)
+	}
+
+	scale -= exp
+	if scale > math.MaxInt32 || scale < math.MinInt32 {
+		return Zero, fmt.Errorf("decimal: exponent of %q out of range", s)
+	}
+	d := Decimal{coef: coef, scale: int32(scale)}
+	if d.scale < 0 {
+		d = d.rescale(0)
+	}
+	return d, nil
+}
+
+// RequireFromString is NewFromString for constants, it panics on error
+func RequireFromString(s string) Decimal {
+	d, err := NewFromString(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// NewFromFloat rounds f to 15 significant digits, the noise of the float arithmetic is after them
+// 0.1 is 0.1 instead of 0.1000000000000000055511151231257827 and 0.7-0.1 is 0.6 instead of 0.59999999999999998
+// NaN and Inf panic
+func NewFromFloat(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("decimal: can't convert %v", f))
+	}
+	return RequireFromString(strconv.FormatFloat(f, 'g', 15, 64)).normalize()
+}
+
+// NewFromFloatWithPlaces rounds f to places decimals, -1 is the shortest representation
+func NewFromFloatWithPlaces(f float64, places int) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		panic(fmt.Sprintf("decimal: can't convert %v", f))
+	}
+	return RequireFromString(strconv.FormatFloat(f, 'f', places, 64))
+}
+
+func (d Decimal) value() *big.Int {
+	if d.coef == nil {
+		return new(big.Int)
+	}
+	return d.coef
+}
+
+// rescale to another scale, a lower scale truncates toward zero
+func (d Decimal) rescale(scale int32) Decimal {
+	if scale == d.scale {
+		return Decimal{coef: d.value(), scale: scale}
+	}
+	if scale > d.scale {
+		factor := new(big.Int).Exp(ten, big.NewInt(int64(scale-d.scale)), nil)
+		return Decimal{coef: new(big.Int).Mul(d.value(), factor), scale: scale}
+	}
+	factor := new(big.Int).Exp(ten, big.NewInt(int64(d.scale-scale)), nil)
+	return Decimal{coef: new(big.Int).Quo(d.value(), factor), scale: scale}
+}
+
+func align(d1, d2 Decimal) (Decimal, Decimal) {
+	if d1.scale > d2.scale {
+		return d1, d2.rescale(d1.scale)
+	}
+	return d1.rescale(d2.scale), d2
+}
+
+// normalize removes the trailing zeros
+func (d Decimal) normalize() Decimal {
+	coef := new(big.Int).Set(d.value())
+	scale := d.scale
+	if coef.Sign() == 0 {
+		return Zero
+	}
+	rem := new(big.Int)
+	for scale > 0 {
+		q, r := new(big.Int).QuoRem(coef, ten, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		coef = q
+		scale--
+	}
+	return Decimal{coef: coef, scale: scale}
+}
+
+func (d Decimal) Add(d2 Decimal) Decimal {
+	a, b := align(d, d2)
+	return Decimal{coef: new(big.Int).Add(a.value(), b.value()), scale: a.scale}
+}
+
+func (d Decimal) Sub(d2 Decimal) Decimal {
+	a, b := align(d, d2)
+	return Decimal{coef: new(big.Int).Sub(a.value(), b.value()), scale: a.scale}
+}
+
+func (d Decimal) Mul(d2 Decimal) Decimal {
+	return Decimal{coef: new(big.Int).Mul(d.value(), d2.value()), scale: d.scale + d2.scale}
+}
+
+// DivRound divides and rounds half away from zero to places decimals, divide by zero panics
+func (d Decimal) DivRound(d2 Decimal, places int32) Decimal {
+	if d2.Sign() == 0 {
+		panic("decimal: division by zero")
+	}
+	// at least one more digit than needed for the rounding
+	scale := d2.scale + places + 1
+	if d.scale > scale {
+		scale = d.scale
+	}
+	num := d.rescale(scale)
+	q := new(big.Int).Quo(num.value(), d2.value())
+	return Decimal{coef: q, scale: scale - d2.scale}.Round(places)
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{coef: new(big.Int).Neg(d.value()), scale: d.scale}
+}
+
+func (d Decimal) Abs() Decimal {
+	return Decimal{coef: new(big.Int).Abs(d.value()), scale: d.scale}
+}
+
+// Cmp returns -1, 0 or 1
+func (d Decimal) Cmp(d2 Decimal) int {
+	a, b := align(d, d2)
+	return a.value().Cmp(b.value())
+}
+
+func (d Decimal) Equal(d2 Decimal) bool {
+	return d.Cmp(d2) == 0
+}
+
+func (d Decimal) LessThan(d2 Decimal) bool {
+	return d.Cmp(d2) < 0
+}
+
+func (d Decimal) GreaterThan(d2 Decimal) bool {
+	return d.Cmp(d2) > 0
+}
+
+func (d Decimal) Sign() int {
+	return d.value().Sign()
+}
+
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Places is the number of significant decimals, 0.0010 is 3 and 100 is 0
+func (d Decimal) Places() int32 {
+	return d.normalize().scale
+}
+
+// Truncate drops the decimals after places, toward zero
+func (d Decimal) Truncate(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	return d.rescale(places)
+}
+
+// Round rounds half away from zero to places decimals
+func (d Decimal) Round(places int32) Decimal {
+	if places >= d.scale {
+		return d
+	}
+	truncated := d.rescale(places)
+	rem := d.Sub(truncated).Abs()
+	half := Decimal{coef: big.NewInt(5), scale: places + 1}
+	if rem.Cmp(half) >= 0 {
+		unit := Decimal{coef: big.NewInt(int64(d.Sign())), scale: places}
+		return truncated.Add(unit)
+	}
+	return truncated
+}
+
+// RoundStep rounds to a multiple of step, floor or ceil with up, a step not greater than 0 returns d
+func (d Decimal) RoundStep(step Decimal, up bool) Decimal {
+	if step.Sign() <= 0 {
+		return d
+	}
+	a, b := align(d, step)
+	// Euclidean division is the floor for a positive divisor
+	q, m := new(big.Int).DivMod(a.value(), b.value(), new(big.Int))
+	if up && m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return Decimal{coef: q.Mul(q, b.value()), scale: a.scale}.Truncate(step.Places())
+}
+
+// IsMultipleOf reports whether d is on the step, a step not greater than 0 is always true
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	return d.RoundStep(step, false).Equal(d)
+}
+
+// Float64 may lose precision, it's the closest float64
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String never uses the scientific notation and has no trailing zeros
+func (d Decimal) String() string {
+	n := d.normalize()
+	return n.format(n.scale)
+}
+
+// StringFixed rounds half away from zero to places decimals and pads with zeros, StringFixed(2) of 1.5 is "1.50"
+func (d Decimal) StringFixed(places int32) string {
+	if places < 0 {
+		places = 0
+	}
+	return d.Round(places).rescale(places).format(places)
+}
+
+func (d Decimal) format(scale int32) string {
+	digits := new(big.Int).Abs(d.value()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if scale <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= int(scale) {
+		digits = strings.Repeat("0", int(scale)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalJSON is a string, floats in json would lose the precision again
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(d.String())), nil
+}
+
+// UnmarshalJSON accepts both "1.23" and 1.23
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	str := string(data)
+	if str == "null" {
+		*d = Zero
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(str); err == nil {
+		str = unquoted
+	}
+	parsed, err := NewFromString(str)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -20,7 +20,7 @@ import (
 	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://api.abcc.com"
 )
 
@@ -324,10 +324,15 @@ func (e *Abcc) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonResponse := &JsonResponse{}
 	uuid := Uuid{}
@@ -361,10 +366,15 @@ func (e *Abcc) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonResponse := &JsonResponse{}
 	uuid := Uuid{}
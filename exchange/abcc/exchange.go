@@ -247,6 +247,19 @@ func (e *Abcc) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Abcc) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Abcc) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
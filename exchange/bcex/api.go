@@ -29,7 +29,7 @@ import (
 	"strconv"
 )
 
-const (
+var (
 	API_URL string = "http://api.bcex.top"
 )
 
@@ -358,6 +358,11 @@ func (e *Bcex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/api_market/placeOrder"
@@ -367,8 +372,8 @@ func (e *Bcex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	mapParams["market"] = e.GetSymbolByCoin(pair.Base)
 	mapParams["token"] = e.GetSymbolByCoin(pair.Target)
 	mapParams["type"] = "2"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -398,6 +403,11 @@ func (e *Bcex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/api_market/placeOrder"
@@ -407,8 +417,8 @@ func (e *Bcex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 	mapParams["market"] = e.GetSymbolByCoin(pair.Base)
 	mapParams["token"] = e.GetSymbolByCoin(pair.Target)
 	mapParams["type"] = "1"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -247,6 +247,19 @@ func (e *Bcex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bcex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bcex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
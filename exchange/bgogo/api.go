@@ -22,7 +22,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://bgogo.com"
 )
 
@@ -339,6 +339,11 @@ func (e *Bgogo) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -347,8 +352,8 @@ func (e *Bgogo) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -377,6 +382,11 @@ func (e *Bgogo) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -385,8 +395,8 @@ func (e *Bgogo) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
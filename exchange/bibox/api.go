@@ -19,7 +19,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://api.bibox.com"
 )
 
@@ -483,6 +483,11 @@ func (e *Bibox) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/orderpending"
@@ -495,8 +500,8 @@ func (e *Bibox) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	body["account_type"] = 0
 	body["order_type"] = 2
 	body["order_side"] = 2
-	body["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	body["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	body["price"] = pairConstraint.FormatRate(rate)
+	body["amount"] = pairConstraint.FormatQuantity(quantity)
 
 	mapParams["body"] = body
 
@@ -528,6 +533,11 @@ func (e *Bibox) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/orderpending"
@@ -540,8 +550,8 @@ func (e *Bibox) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	body["account_type"] = 0
 	body["order_type"] = 2
 	body["order_side"] = 1
-	body["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	body["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	body["price"] = pairConstraint.FormatRate(rate)
+	body["amount"] = pairConstraint.FormatQuantity(quantity)
 
 	mapParams["body"] = body
 
@@ -247,6 +247,23 @@ func (e *Bibox) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Transfer: {exchange.SpotWallet},
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bibox) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bibox) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -21,7 +21,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://big.one/api/v3"
 )
 
@@ -348,14 +348,16 @@ func (e *Bigone) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	placeOrder := PlaceOrder{}
 	strRequest := "/viewer/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["asset_pair_name"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "ASK"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest(strRequest, mapParams, "POST")
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -389,14 +391,16 @@ func (e *Bigone) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	placeOrder := PlaceOrder{}
 	strRequest := "/viewer/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["asset_pair_name"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BID"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest(strRequest, mapParams, "POST")
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
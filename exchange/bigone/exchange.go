@@ -247,6 +247,19 @@ func (e *Bigone) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bigone) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bigone) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -22,7 +22,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://api.biki.com"
 )
 
@@ -319,15 +319,17 @@ func (e *Biki) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	placeOrder := PlaceOrder{}
 	strRequest := "/open/api/create_order"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "1"
-	mapParams["volume"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["volume"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -361,15 +363,17 @@ func (e *Biki) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 	placeOrder := PlaceOrder{}
 	strRequest := "/open/api/create_order"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "1"
-	mapParams["volume"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["volume"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
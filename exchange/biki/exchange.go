@@ -248,6 +248,19 @@ func (e *Biki) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Biki) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Biki) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
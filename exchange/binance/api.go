@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -21,10 +20,11 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL              = "https://api.binance.com"
 	CONTRACT_URL         = "https://fapi.binance.com"
-	CONTRACT_TESTNET_URL = "https://testnet.binancefuture.com" ////
+	CONTRACT_TESTNET_URL = "https://testnet.binancefuture.com"  ////
+	BTSE_URL             = "https://api.btse.com/spot/api/v3.1" // TestApi
 )
 
 /*API Base Knowledge
@@ -436,16 +436,20 @@ func (e *Binance) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v3/order"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	quantity, rate, err := exchange.ValidateOrder(e, pair, exchange.Sell, quantity, rate, true)
+	if err != nil {
+		return nil, err
+	}
+	pairConstraint := e.GetPairConstraint(pair)
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
 	mapParams["timeInForce"] = "GTC"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 
@@ -482,16 +486,20 @@ func (e *Binance) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v3/order"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	quantity, rate, err := exchange.ValidateOrder(e, pair, exchange.Buy, quantity, rate, true)
+	if err != nil {
+		return nil, err
+	}
+	pairConstraint := e.GetPairConstraint(pair)
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
 	mapParams["timeInForce"] = "GTC"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 
@@ -712,7 +720,7 @@ func (e *Binance) WApiKeyRequest(strMethod string, mapParams map[string]string,
 func (e *Binance) TestApi() string {
 	key := "NWFjY2U2OWQzMjM0NDczMTgwODcwMDNjOWU2M2NhMDE="
 	secret := "NWFmNWQ0NGRiMTlhNGMz"
-	strUrl := "https://api.btse.com/spot/api/v3.1/user/wallet"
+	strUrl := BTSE_URL + "/user/wallet"
 	nonce := fmt.Sprintf("%d", time.Now().UTC().UnixNano()/int64(time.Millisecond))
 
 	mapParams := make(map[string]string)
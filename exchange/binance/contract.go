@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -239,29 +240,79 @@ func (e *Binance) doContractGetTransferHistory(operation *exchange.AccountOperat
 	return nil
 }
 
+// GetPositionInfo returns every symbol's position risk, GetPositions only the non-zero ones
 func (e *Binance) doGetPositionInfo(operation *exchange.AccountOperation) error {
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
-	// positionInfo := PositionInfo{}
+	positionInfo := PositionInfo{}
 	strRequest := "/fapi/v2/positionRisk"
 
-	// log.Printf("strRequest: [%s]", strRequest)
+	mapParams := make(map[string]string)
+	if operation.Pair != nil {
+		mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	}
 
-	jsonPositionInfoReturn := e.ContractApiKeyRequest("GET", make(map[string]string), strRequest, operation.TestMode)
+	jsonPositionInfoReturn := e.ContractApiKeyRequest("GET", mapParams, strRequest, operation.TestMode)
 	if operation.DebugMode {
 		operation.RequestURI = strRequest
 		operation.CallResponce = jsonPositionInfoReturn
 	}
 
-	// log.Printf("jsonPositionInfoReturn: %v", jsonPositionInfoReturn)
-	if err := json.Unmarshal([]byte(jsonPositionInfoReturn), &operation.BinanceOpenPositionList); err != nil {
+	if err := json.Unmarshal([]byte(jsonPositionInfoReturn), &positionInfo); err != nil {
 		operation.Error = fmt.Errorf("%s doGetPositionInfo Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPositionInfoReturn)
 		log.Print(operation.Error)
 		return operation.Error
 	}
 
+	operation.Positions = []*exchange.Position{}
+	for _, p := range positionInfo {
+		size, err := strconv.ParseFloat(p.PositionAmt, 64)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doGetPositionInfo parse size Err: %v, %v", e.GetName(), err, p.PositionAmt)
+			return operation.Error
+		}
+		if size == 0 && operation.Type == exchange.GetPositions {
+			continue
+		}
+
+		position := &exchange.Position{
+			Pair:       e.GetPairBySymbol(p.Symbol),
+			Instrument: p.Symbol,
+			Size:       math.Abs(size),
+			Timestamp:  p.UpdateTime,
+		}
+		position.EntryPrice, _ = strconv.ParseFloat(p.EntryPrice, 64)
+		position.MarkPrice, _ = strconv.ParseFloat(p.MarkPrice, 64)
+		position.LiquidationPrice, _ = strconv.ParseFloat(p.LiquidationPrice, 64)
+		position.Leverage, _ = strconv.ParseFloat(p.Leverage, 64)
+		position.UnrealizedPnl, _ = strconv.ParseFloat(p.UnRealizedProfit, 64)
+
+		switch p.PositionSide {
+		case "LONG":
+			position.Side = exchange.PositionLong
+		case "SHORT":
+			position.Side = exchange.PositionShort
+		default: // BOTH
+			if size > 0 {
+				position.Side = exchange.PositionLong
+			} else if size < 0 {
+				position.Side = exchange.PositionShort
+			} else {
+				position.Side = exchange.PositionBoth
+			}
+		}
+
+		if p.MarginType == "isolated" {
+			position.MarginMode = exchange.IsolatedMargin
+		} else {
+			position.MarginMode = exchange.CrossMargin
+		}
+
+		operation.Positions = append(operation.Positions, position)
+	}
+
 	return nil
 }
 
@@ -387,9 +438,135 @@ func (e *Binance) doSetFutureLeverage(operation *exchange.AccountOperation) erro
 		return operation.Error
 	}
 
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: futureLeverage.Symbol,
+		Leverage:   float64(futureLeverage.Leverage),
+	}
+
+	return nil
+}
+
+func (e *Binance) doSetMarginType(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s SetMarginType Pair is empty", e.GetName())
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	switch operation.MarginMode {
+	case exchange.CrossMargin:
+		mapParams["marginType"] = "CROSSED"
+	case exchange.IsolatedMargin:
+		mapParams["marginType"] = "ISOLATED"
+	default:
+		return fmt.Errorf("%s SetMarginType invalid MarginMode: %v", e.GetName(), operation.MarginMode)
+	}
+
+	// -4046: no need to change margin type
+	if _, err := e.futureSetting(operation, "/fapi/v1/marginType", mapParams, -4046); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: mapParams["symbol"],
+		MarginMode: operation.MarginMode,
+	}
+
+	return nil
+}
+
+// the position mode applies to every symbol of the account
+func (e *Binance) doSetPositionMode(operation *exchange.AccountOperation) error {
+	mapParams := make(map[string]string)
+	switch operation.PositionMode {
+	case exchange.OneWayMode:
+		mapParams["dualSidePosition"] = "false"
+	case exchange.HedgeMode:
+		mapParams["dualSidePosition"] = "true"
+	default:
+		return fmt.Errorf("%s SetPositionMode invalid PositionMode: %v", e.GetName(), operation.PositionMode)
+	}
+
+	// -4059: no need to change position side
+	if _, err := e.futureSetting(operation, "/fapi/v1/positionSide/dual", mapParams, -4059); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		PositionMode: operation.PositionMode,
+	}
+
 	return nil
 }
 
+// PositionSide selects the position in hedge mode, leave it empty for one-way mode
+func (e *Binance) doAdjustIsolatedMargin(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s AdjustIsolatedMargin Pair is empty", e.GetName())
+	} else if operation.MarginAmount == 0 {
+		return fmt.Errorf("%s AdjustIsolatedMargin MarginAmount is 0", e.GetName())
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	mapParams["amount"] = strconv.FormatFloat(math.Abs(operation.MarginAmount), 'f', -1, 64)
+	if operation.MarginAmount > 0 {
+		mapParams["type"] = "1"
+	} else {
+		mapParams["type"] = "2"
+	}
+	switch operation.PositionSide {
+	case exchange.PositionLong:
+		mapParams["positionSide"] = "LONG"
+	case exchange.PositionShort:
+		mapParams["positionSide"] = "SHORT"
+	}
+
+	if _, err := e.futureSetting(operation, "/fapi/v1/positionMargin", mapParams); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: mapParams["symbol"],
+		MarginMode: exchange.IsolatedMargin,
+	}
+
+	return nil
+}
+
+// success returns code 200, noChangeCodes are treated as success as the setting is already in place
+func (e *Binance) futureSetting(operation *exchange.AccountOperation, strRequestUrl string, mapParams map[string]string, noChangeCodes ...int) (*FutureSetting, error) {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return nil, fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	futureSetting := &FutureSetting{}
+	jsonSettingReturn := e.ContractApiKeyRequest("POST", mapParams, strRequestUrl, operation.TestMode)
+	if operation.DebugMode {
+		operation.RequestURI = strRequestUrl
+		operation.CallResponce = jsonSettingReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonSettingReturn), futureSetting); err != nil {
+		operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonSettingReturn)
+		return nil, operation.Error
+	}
+	if futureSetting.Code == 200 {
+		return futureSetting, nil
+	}
+	for _, code := range noChangeCodes {
+		if futureSetting.Code == code {
+			return futureSetting, nil
+		}
+	}
+
+	operation.Error = fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, jsonSettingReturn)
+	return nil, operation.Error
+}
+
 // type: TRADE_LIMIT, TRADE_MARKET, Trade_STOP_LIMIT, Trade_STOP_MARKET
 // Stop order need 'StopRate' param
 func (e *Binance) doContractPlaceOrder(operation *exchange.AccountOperation) error {
@@ -416,15 +593,16 @@ func (e *Binance) doContractPlaceOrder(operation *exchange.AccountOperation) err
 	} else if operation.OrderDirection == exchange.Sell {
 		mapParams["side"] = "SELL"
 	}
+	pairConstraint := e.GetPairConstraint(operation.Pair)
 	if operation.TradeType == exchange.Trade_STOP_LIMIT || operation.TradeType == exchange.Trade_STOP_MARKET {
-		mapParams["stopPrice"] = fmt.Sprintf("%f", operation.StopRate)
+		mapParams["stopPrice"] = pairConstraint.FormatRate(operation.StopRate)
 	}
 	mapParams["type"] = string(operation.TradeType) // "LIMIT"
 	if operation.Rate != 0 {
-		mapParams["price"] = fmt.Sprintf("%f", operation.Rate)
+		mapParams["price"] = pairConstraint.FormatRate(operation.Rate)
 	}
 	if operation.Quantity != 0 {
-		mapParams["quantity"] = fmt.Sprintf("%f", operation.Quantity)
+		mapParams["quantity"] = pairConstraint.FormatQuantity(operation.Quantity)
 	}
 	if operation.OrderType != "" {
 		mapParams["timeInForce"] = string(operation.OrderType) //"GTC"
@@ -249,6 +249,59 @@ func (e *Binance) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainF
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory:   {exchange.SpotWallet},
+		exchange.Orderbook:      {exchange.SpotWallet, exchange.ContractWallet},
+		exchange.KLine:          {exchange.SpotWallet, exchange.ContractWallet},
+		exchange.GetTickerPrice: {exchange.SpotWallet},
+		exchange.Ticker:         {exchange.SpotWallet},
+		exchange.FundingRate:    {exchange.ContractWallet},
+		exchange.SystemStatus:   {exchange.SpotWallet},
+		exchange.WalletStatus:   {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw:             {exchange.SpotWallet},
+		exchange.SubAccountTransfer:   {exchange.SpotWallet},
+		exchange.GetFutureBalance:     {exchange.ContractWallet},
+		exchange.SetFutureLeverage:    {exchange.ContractWallet},
+		exchange.SetLeverage:          {exchange.ContractWallet},
+		exchange.SetMarginType:        {exchange.ContractWallet},
+		exchange.SetPositionMode:      {exchange.ContractWallet},
+		exchange.AdjustIsolatedMargin: {exchange.ContractWallet},
+		exchange.PlaceOrder:           {exchange.MarginWallet, exchange.ContractWallet},
+		exchange.MarginOperation:      {exchange.MarginWallet},
+		exchange.GetOrderStatus:       {exchange.ContractWallet},
+		exchange.CancelOrder:          {exchange.ContractWallet},
+		exchange.BalanceList:          {exchange.SpotWallet, exchange.ContractWallet},
+		exchange.GetOpenOrder:         {exchange.SpotWallet, exchange.ContractWallet},
+		exchange.GetOrderHistory:      {exchange.SpotWallet, exchange.ContractWallet},
+		exchange.GetWithdrawalHistory: {exchange.SpotWallet},
+		exchange.GetDepositHistory:    {exchange.SpotWallet},
+		exchange.GetTransferHistory:   {exchange.SpotWallet, exchange.ContractWallet},
+		exchange.GetDepositAddress:    {exchange.SpotWallet},
+		exchange.GetPositions:         {exchange.ContractWallet},
+		exchange.GetPositionInfo:      {exchange.ContractWallet},
+		exchange.SubBalanceList:       {exchange.SpotWallet},
+		exchange.GetSubAccountList:    {exchange.SpotWallet},
+		exchange.SubAllBalanceList:    {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet:     {exchange.TRADE_LIMIT},
+		exchange.MarginWallet:   {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+		exchange.ContractWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET, exchange.Trade_STOP_LIMIT, exchange.Trade_STOP_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet:     {exchange.GTC},
+		exchange.MarginWallet:   {exchange.GTC, exchange.IOC, exchange.FOK},
+		exchange.ContractWallet: {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+	},
+}
+
+func (e *Binance) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Binance) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
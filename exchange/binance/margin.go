@@ -0,0 +1,350 @@
+package binance
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Binance) doMarginOperation(operation *exchange.AccountOperation) error {
+	if operation.Margin == nil {
+		return fmt.Errorf("%s MarginOperation empty Margin: %+v", e.GetName(), operation)
+	} else if operation.MarginMode == exchange.IsolatedMargin && operation.Margin.Pair == nil {
+		return fmt.Errorf("%s MarginOperation isolated margin without pair", e.GetName())
+	}
+
+	switch operation.Margin.Action {
+	case exchange.LOAN_REQUEST:
+		return e.doMarginLoan(operation, "/sapi/v1/margin/loan")
+	case exchange.LOAN_REPAY:
+		return e.doMarginLoan(operation, "/sapi/v1/margin/repay")
+	case exchange.TRANSFER_IN, exchange.TRANSFER_OUT:
+		return e.doMarginTransfer(operation)
+	case exchange.BALANCE:
+		if operation.MarginMode == exchange.IsolatedMargin {
+			return e.doIsolatedMarginBalance(operation)
+		}
+		return e.doMarginBalance(operation)
+	case exchange.INTEREST_HISTORY:
+		return e.doMarginInterestHistory(operation)
+	}
+
+	return fmt.Errorf("%s MarginOperation action invalid: %v", e.GetName(), operation.Margin.Action)
+}
+
+// borrow or repay, the tranId is returned in Margin.MarginOrder
+func (e *Binance) doMarginLoan(operation *exchange.AccountOperation, strRequest string) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	loan := MarginTransaction{}
+
+	mapParams := make(map[string]string)
+	mapParams["asset"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["amount"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if operation.MarginMode == exchange.IsolatedMargin {
+		mapParams["isIsolated"] = "TRUE"
+		mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+	}
+
+	jsonLoanReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonLoanReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonLoanReturn), &loan); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginLoan Json Unmarshal Err: %v, %s", e.GetName(), err, jsonLoanReturn)
+		return operation.Error
+	} else if loan.TranID == 0 {
+		operation.Error = fmt.Errorf("%s doMarginLoan Failed: %v", e.GetName(), jsonLoanReturn)
+		return operation.Error
+	}
+
+	margin.MarginOrder = &exchange.MarginOrder{
+		ID:       fmt.Sprintf("%d", loan.TranID),
+		Currency: margin.Currency,
+		Pair:     margin.Pair,
+	}
+	if margin.Action == exchange.LOAN_REQUEST {
+		margin.MarginOrder.LoanAmount = margin.Quantity
+	}
+
+	return nil
+}
+
+// TRANSFER_IN: spot -> margin, TRANSFER_OUT: margin -> spot
+func (e *Binance) doMarginTransfer(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	transfer := MarginTransaction{}
+	strRequest := "/sapi/v1/margin/transfer"
+
+	mapParams := make(map[string]string)
+	mapParams["asset"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["amount"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if operation.MarginMode == exchange.IsolatedMargin {
+		strRequest = "/sapi/v1/margin/isolated/transfer"
+		mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+		if margin.Action == exchange.TRANSFER_IN {
+			mapParams["transFrom"] = "SPOT"
+			mapParams["transTo"] = "ISOLATED_MARGIN"
+		} else {
+			mapParams["transFrom"] = "ISOLATED_MARGIN"
+			mapParams["transTo"] = "SPOT"
+		}
+	} else if margin.Action == exchange.TRANSFER_IN {
+		mapParams["type"] = "1"
+	} else {
+		mapParams["type"] = "2"
+	}
+
+	jsonTransferReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonTransferReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonTransferReturn), &transfer); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+		return operation.Error
+	} else if transfer.TranID == 0 {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Failed: %v", e.GetName(), jsonTransferReturn)
+		return operation.Error
+	}
+
+	margin.TransferID = fmt.Sprintf("%d", transfer.TranID)
+
+	return nil
+}
+
+func (e *Binance) doMarginBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	account := MarginAccount{}
+	strRequest := "/sapi/v1/margin/account"
+
+	mapParams := make(map[string]string)
+
+	jsonBalanceReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &account); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if account.Code != 0 {
+		operation.Error = fmt.Errorf("%s doMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+
+	balance := &exchange.MarginBalance{
+		MarginMode: exchange.CrossMargin,
+	}
+	balance.RiskRate, _ = strconv.ParseFloat(account.MarginLevel, 64)
+	for _, asset := range account.UserAssets {
+		c := e.GetCoinBySymbol(asset.Asset)
+		if c == nil {
+			continue
+		}
+		balance.Assets = append(balance.Assets, e.marginAsset(c, asset))
+	}
+
+	operation.Margin.MarginBalance = balance
+
+	return nil
+}
+
+func (e *Binance) doIsolatedMarginBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	account := IsolatedMarginAccount{}
+	strRequest := "/sapi/v1/margin/isolated/account"
+
+	mapParams := make(map[string]string)
+	mapParams["symbols"] = e.GetSymbolByPair(operation.Margin.Pair)
+
+	jsonBalanceReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &account); err != nil {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if account.Code != 0 || len(account.Assets) == 0 {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+
+	data := account.Assets[0]
+	balance := &exchange.MarginBalance{
+		Pair:       operation.Margin.Pair,
+		MarginMode: exchange.IsolatedMargin,
+		Assets: []*exchange.MarginAsset{
+			e.marginAsset(operation.Margin.Pair.Target, data.BaseAsset),
+			e.marginAsset(operation.Margin.Pair.Base, data.QuoteAsset),
+		},
+	}
+	balance.RiskRate, _ = strconv.ParseFloat(data.MarginLevel, 64)
+	balance.LiquidationPrice, _ = strconv.ParseFloat(data.LiquidatePrice, 64)
+
+	operation.Margin.MarginBalance = balance
+
+	return nil
+}
+
+func (e *Binance) marginAsset(c *coin.Coin, asset MarginUserAsset) *exchange.MarginAsset {
+	marginAsset := &exchange.MarginAsset{
+		Coin: c,
+	}
+	marginAsset.Free, _ = strconv.ParseFloat(asset.Free, 64)
+	marginAsset.Locked, _ = strconv.ParseFloat(asset.Locked, 64)
+	marginAsset.Borrowed, _ = strconv.ParseFloat(asset.Borrowed, 64)
+	marginAsset.Interest, _ = strconv.ParseFloat(asset.Interest, 64)
+	marginAsset.NetAsset, _ = strconv.ParseFloat(asset.NetAsset, 64)
+
+	return marginAsset
+}
+
+// interest records of the last 7 days if no StartTime, filter by Margin.Currency if set
+func (e *Binance) doMarginInterestHistory(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	history := MarginInterestHistory{}
+	strRequest := "/sapi/v1/margin/interestHistory"
+
+	mapParams := make(map[string]string)
+	mapParams["size"] = "100"
+	if margin.Currency != nil {
+		mapParams["asset"] = e.GetSymbolByCoin(margin.Currency)
+	}
+	if operation.MarginMode == exchange.IsolatedMargin {
+		mapParams["isolatedSymbol"] = e.GetSymbolByPair(margin.Pair)
+	}
+	if operation.StartTime != 0 {
+		mapParams["startTime"] = fmt.Sprintf("%v", operation.StartTime)
+	}
+	if operation.EndTime != 0 {
+		mapParams["endTime"] = fmt.Sprintf("%v", operation.EndTime)
+	}
+
+	jsonHistoryReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonHistoryReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonHistoryReturn), &history); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginInterestHistory Json Unmarshal Err: %v, %s", e.GetName(), err, jsonHistoryReturn)
+		return operation.Error
+	} else if history.Code != 0 {
+		operation.Error = fmt.Errorf("%s doMarginInterestHistory Failed: %v", e.GetName(), jsonHistoryReturn)
+		return operation.Error
+	}
+
+	margin.History = []*exchange.MarginOrder{}
+	for _, row := range history.Rows {
+		record := &exchange.MarginOrder{
+			ID:        fmt.Sprintf("%d", row.TxID),
+			Currency:  e.GetCoinBySymbol(row.Asset),
+			State:     row.Type,
+			Timestamp: row.InterestAccuredTime,
+		}
+		if row.IsolatedSymbol != "" {
+			record.Pair = e.GetPairBySymbol(row.IsolatedSymbol)
+		}
+		record.LoanBalance, _ = strconv.ParseFloat(row.Principal, 64)
+		record.InterestAmount, _ = strconv.ParseFloat(row.Interest, 64)
+		record.InterestRate, _ = strconv.ParseFloat(row.InterestRate, 64)
+		margin.History = append(margin.History, record)
+	}
+
+	return nil
+}
+
+// type: TRADE_LIMIT, TRADE_MARKET
+func (e *Binance) doMarginPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.OrderDirection == "" {
+		return fmt.Errorf("%s MarginPlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.TradeType == "" {
+		return fmt.Errorf("%s MarginPlaceOrder empty TradeType: %+v", e.GetName(), operation)
+	}
+
+	placeOrder := PlaceOrder{}
+	strRequest := "/sapi/v1/margin/order"
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	if operation.OrderDirection == exchange.Buy {
+		mapParams["side"] = "BUY"
+	} else if operation.OrderDirection == exchange.Sell {
+		mapParams["side"] = "SELL"
+	}
+	mapParams["type"] = string(operation.TradeType)
+	pairConstraint := e.GetPairConstraint(operation.Pair)
+	if operation.Rate != 0 {
+		mapParams["price"] = pairConstraint.FormatRate(operation.Rate)
+	}
+	if operation.Quantity != 0 {
+		mapParams["quantity"] = pairConstraint.FormatQuantity(operation.Quantity)
+	}
+	if operation.TradeType == exchange.TRADE_LIMIT {
+		mapParams["timeInForce"] = "GTC"
+		if operation.OrderType != "" {
+			mapParams["timeInForce"] = string(operation.OrderType)
+		}
+	}
+	if operation.MarginMode == exchange.IsolatedMargin {
+		mapParams["isIsolated"] = "TRUE"
+	}
+
+	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if placeOrder.Code != 0 || placeOrder.OrderID == 0 {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	}
+
+	operation.Order = &exchange.Order{
+		Pair:         operation.Pair,
+		OrderID:      fmt.Sprintf("%d", placeOrder.OrderID),
+		Rate:         operation.Rate,
+		Quantity:     operation.Quantity,
+		Direction:    operation.OrderDirection,
+		Status:       exchange.New,
+		Timestamp:    placeOrder.TransactTime,
+		JsonResponse: jsonPlaceReturn,
+	}
+
+	return nil
+}
@@ -182,14 +182,15 @@ type CoinsData []struct {
 // 	LegalMoney              bool        `json:"legalMoney"`
 // }
 
-type TradeHistory []struct {
-	ID           int    `json:"id"`
-	Price        string `json:"price"`
-	Qty          string `json:"qty"`
-	QuoteQty     string `json:"quoteQty"`
-	Time         int64  `json:"time"`
-	IsBuyerMaker bool   `json:"isBuyerMaker"`
-	IsBestMatch  bool   `json:"isBestMatch"`
+type AggTrades []struct {
+	AggID        int64  `json:"a"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	FirstID      int64  `json:"f"`
+	LastID       int64  `json:"l"`
+	Time         int64  `json:"T"`
+	IsBuyerMaker bool   `json:"m"`
+	IsBestMatch  bool   `json:"M"`
 }
 
 type ContractPlaceOrder struct {
@@ -317,6 +318,13 @@ type FutureLeverage struct {
 	Symbol           string `json:"symbol"`
 }
 
+type FutureSetting struct {
+	Code   int     `json:"code"`
+	Msg    string  `json:"msg"`
+	Amount float64 `json:"amount"`
+	Type   int     `json:"type"`
+}
+
 // private operation
 type OpenOrders []struct {
 	Symbol              string `json:"symbol"`
@@ -432,20 +440,23 @@ type ContractTransferHistory struct {
 	Total int `json:"total"`
 }
 
-// type PositionInfo []struct {//V1
-// 	EntryPrice       string `json:"entryPrice"`
-// 	MarginType       string `json:"marginType"`
-// 	IsAutoAddMargin  string `json:"isAutoAddMargin"`
-// 	IsolatedMargin   string `json:"isolatedMargin"`
-// 	Leverage         string `json:"leverage"`
-// 	LiquidationPrice string `json:"liquidationPrice"`
-// 	MarkPrice        string `json:"markPrice"`
-// 	MaxNotionalValue string `json:"maxNotionalValue"`
-// 	PositionAmt      string `json:"positionAmt"`
-// 	Symbol           string `json:"symbol"`
-// 	UnRealizedProfit string `json:"unRealizedProfit"`
-// 	PositionSide     string `json:"positionSide"`
-// }
+type PositionInfo []struct { //V2
+	Symbol           string `json:"symbol"`
+	PositionAmt      string `json:"positionAmt"`
+	EntryPrice       string `json:"entryPrice"`
+	MarkPrice        string `json:"markPrice"`
+	UnRealizedProfit string `json:"unRealizedProfit"`
+	LiquidationPrice string `json:"liquidationPrice"`
+	Leverage         string `json:"leverage"`
+	MaxNotionalValue string `json:"maxNotionalValue"`
+	MarginType       string `json:"marginType"`
+	IsolatedMargin   string `json:"isolatedMargin"`
+	IsAutoAddMargin  string `json:"isAutoAddMargin"`
+	PositionSide     string `json:"positionSide"`
+	Notional         string `json:"notional"`
+	IsolatedWallet   string `json:"isolatedWallet"`
+	UpdateTime       int64  `json:"updateTime"`
+}
 
 type SubAccountBalances struct {
 	Success  bool `json:"success"`
@@ -473,7 +484,105 @@ type TickerPrice []struct {
 	Price  string `json:"price"`
 }
 
+type Ticker []struct {
+	Symbol             string `json:"symbol"`
+	PriceChange        string `json:"priceChange"`
+	PriceChangePercent string `json:"priceChangePercent"`
+	LastPrice          string `json:"lastPrice"`
+	BidPrice           string `json:"bidPrice"`
+	BidQty             string `json:"bidQty"`
+	AskPrice           string `json:"askPrice"`
+	AskQty             string `json:"askQty"`
+	OpenPrice          string `json:"openPrice"`
+	HighPrice          string `json:"highPrice"`
+	LowPrice           string `json:"lowPrice"`
+	Volume             string `json:"volume"`
+	QuoteVolume        string `json:"quoteVolume"`
+	CloseTime          int64  `json:"closeTime"`
+}
+
 type SubTransfer struct {
 	Success bool   `json:"success"`
 	TxnID   string `json:"txnId"`
 }
+
+type PremiumIndex struct {
+	Symbol          string `json:"symbol"`
+	MarkPrice       string `json:"markPrice"`
+	IndexPrice      string `json:"indexPrice"`
+	LastFundingRate string `json:"lastFundingRate"`
+	NextFundingTime int64  `json:"nextFundingTime"`
+	InterestRate    string `json:"interestRate"`
+	Time            int64  `json:"time"`
+}
+
+type FundingRateHistory []struct {
+	Symbol      string `json:"symbol"`
+	FundingRate string `json:"fundingRate"`
+	FundingTime int64  `json:"fundingTime"`
+}
+
+type MarginTransaction struct {
+	TranID int64  `json:"tranId"`
+	Code   int    `json:"code"`
+	Msg    string `json:"msg"`
+}
+
+type MarginUserAsset struct {
+	Asset    string `json:"asset"`
+	Free     string `json:"free"`
+	Locked   string `json:"locked"`
+	Borrowed string `json:"borrowed"`
+	Interest string `json:"interest"`
+	NetAsset string `json:"netAsset"`
+}
+
+type MarginAccount struct {
+	BorrowEnabled       bool              `json:"borrowEnabled"`
+	MarginLevel         string            `json:"marginLevel"`
+	TotalAssetOfBtc     string            `json:"totalAssetOfBtc"`
+	TotalLiabilityOfBtc string            `json:"totalLiabilityOfBtc"`
+	TotalNetAssetOfBtc  string            `json:"totalNetAssetOfBtc"`
+	TradeEnabled        bool              `json:"tradeEnabled"`
+	TransferEnabled     bool              `json:"transferEnabled"`
+	UserAssets          []MarginUserAsset `json:"userAssets"`
+	Code                int               `json:"code"`
+	Msg                 string            `json:"msg"`
+}
+
+type IsolatedMarginAccount struct {
+	Assets []struct {
+		BaseAsset       MarginUserAsset `json:"baseAsset"`
+		QuoteAsset      MarginUserAsset `json:"quoteAsset"`
+		Symbol          string          `json:"symbol"`
+		IsolatedCreated bool            `json:"isolatedCreated"`
+		MarginLevel     string          `json:"marginLevel"`
+		MarginRatio     string          `json:"marginRatio"`
+		LiquidatePrice  string          `json:"liquidatePrice"`
+		TradeEnabled    bool            `json:"tradeEnabled"`
+	} `json:"assets"`
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+type MarginInterestHistory struct {
+	Rows []struct {
+		TxID                int64  `json:"txId"`
+		Asset               string `json:"asset"`
+		RawAsset            string `json:"rawAsset"`
+		Principal           string `json:"principal"`
+		Interest            string `json:"interest"`
+		InterestRate        string `json:"interestRate"`
+		Type                string `json:"type"`
+		IsolatedSymbol      string `json:"isolatedSymbol"`
+		InterestAccuredTime int64  `json:"interestAccuredTime"`
+	} `json:"rows"`
+	Total int    `json:"total"`
+	Code  int    `json:"code"`
+	Msg   string `json:"msg"`
+}
+
+type SystemStatus struct {
+	Status int    `json:"status"`
+	Msg    string `json:"msg"`
+}
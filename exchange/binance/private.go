@@ -34,9 +34,31 @@ func (e *Binance) DoAccountOperation(operation *exchange.AccountOperation) error
 		if operation.Wallet == exchange.ContractWallet {
 			return e.doSetFutureLeverage(operation)
 		}
+	case exchange.SetLeverage:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetFutureLeverage(operation)
+		}
+	case exchange.SetMarginType:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetMarginType(operation)
+		}
+	case exchange.SetPositionMode:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetPositionMode(operation)
+		}
+	case exchange.AdjustIsolatedMargin:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doAdjustIsolatedMargin(operation)
+		}
 	case exchange.PlaceOrder:
 		if operation.Wallet == exchange.ContractWallet {
 			return e.doContractPlaceOrder(operation)
+		} else if operation.Wallet == exchange.MarginWallet {
+			return e.doMarginPlaceOrder(operation)
+		}
+	case exchange.MarginOperation:
+		if operation.Wallet == exchange.MarginWallet {
+			return e.doMarginOperation(operation)
 		}
 	case exchange.GetOrderStatus: // operation model changed
 		if operation.Wallet == exchange.ContractWallet {
@@ -88,10 +110,10 @@ func (e *Binance) DoAccountOperation(operation *exchange.AccountOperation) error
 		if operation.Wallet == exchange.SpotWallet {
 			return e.doGetDepositAddress(operation)
 		}
-	// case exchange.GetPositions:
-	// 	if operation.Wallet == exchange.ContractWallet {
-	// 		return e.doGetPositionInfo(operation)
-	// 	}
+	case exchange.GetPositions:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetPositionInfo(operation)
+		}
 	case exchange.GetPositionInfo:
 		if operation.Wallet == exchange.ContractWallet {
 			return e.doGetPositionInfo(operation)
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	exchange "github.com/bitontop/gored/exchange"
@@ -39,6 +40,20 @@ func (e *Binance) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doTickerPrice(operation)
 		}
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
+	case exchange.FundingRate:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doContractFundingRate(operation)
+		}
+	case exchange.SystemStatus:
+		return e.doSystemStatus(operation)
+	case exchange.WalletStatus:
+		return e.doWalletStatus(operation)
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
@@ -101,128 +116,215 @@ func (e *Binance) doTickerPrice(operation *exchange.PublicOperation) error {
 	return nil
 }
 
-// interval options: 1min, 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 8hour, 12hour, 1day, 3day, 1week, 1month
-func (e *Binance) doSpotKline(operation *exchange.PublicOperation) error {
-	interval := "5m"
-	if operation.KlineInterval != "" {
-		switch operation.KlineInterval {
-		case "1min":
-			interval = "1m"
-		case "3min":
-			interval = "3m"
-		case "5min":
-			interval = "5m"
-		case "15min":
-			interval = "15m"
-		case "30min":
-			interval = "30m"
-		case "1hour":
-			interval = "1h"
-		case "2hour":
-			interval = "2h"
-		case "4hour":
-			interval = "4h"
-		case "6hour":
-			interval = "6h"
-		case "8hour":
-			interval = "8h"
-		case "12hour":
-			interval = "12h"
-		case "1day":
-			interval = "1d"
-		case "3day":
-			interval = "3d"
-		case "1week":
-			interval = "1w"
-		case "1month":
-			interval = "1M"
-		}
-	}
+func (e *Binance) doTicker(operation *exchange.PublicOperation) error {
+	ticker := Ticker{}
 
 	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://api.binance.com/api/v3/klines?symbol=%v&interval=%v&limit=1000", // 1500478320000
-			e.GetSymbolByPair(operation.Pair), // BTCUSDT
-			interval,
-		),
-		Proxy: operation.Proxy,
+		URI:       fmt.Sprintf("%s/api/v3/ticker/24hr", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
 	}
 
-	if operation.KlineStartTime != 0 {
-		get.URI += fmt.Sprintf("&startTime=%v", operation.KlineStartTime)
+	if err := json.Unmarshal(get.ResponseBody, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
 	}
-	if operation.KlineEndTime != 0 {
-		get.URI += fmt.Sprintf("&endTime=%v", operation.KlineEndTime)
+
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range ticker {
+		p := e.GetPairBySymbol(t.Symbol)
+		if p == nil {
+			continue
+		}
+
+		values, err := exchange.ParseFloats(t.LastPrice, t.BidPrice, t.BidQty, t.AskPrice, t.AskQty, t.OpenPrice,
+			t.HighPrice, t.LowPrice, t.Volume, t.QuoteVolume, t.PriceChange, t.PriceChangePercent)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), t.Symbol, err)
+			return operation.Error
+		}
+
+		operation.Ticker = append(operation.Ticker, &exchange.TickerDetail{
+			Pair:            p,
+			Last:            values[0],
+			BestBid:         values[1],
+			BestBidQuantity: values[2],
+			BestAsk:         values[3],
+			BestAskQuantity: values[4],
+			Open:            values[5],
+			High:            values[6],
+			Low:             values[7],
+			Volume:          values[8],
+			QuoteVolume:     values[9],
+			Change:          values[10],
+			ChangePercent:   values[11],
+			Timestamp:       t.CloseTime,
+		})
 	}
 
-	err := utils.HttpGetRequest(get)
+	return nil
+}
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1m",
+	exchange.KLINE_3MIN:   "3m",
+	exchange.KLINE_5MIN:   "5m",
+	exchange.KLINE_15MIN:  "15m",
+	exchange.KLINE_30MIN:  "30m",
+	exchange.KLINE_1HOUR:  "1h",
+	exchange.KLINE_2HOUR:  "2h",
+	exchange.KLINE_4HOUR:  "4h",
+	exchange.KLINE_6HOUR:  "6h",
+	exchange.KLINE_8HOUR:  "8h",
+	exchange.KLINE_12HOUR: "12h",
+	exchange.KLINE_1DAY:   "1d",
+	exchange.KLINE_3DAY:   "3d",
+	exchange.KLINE_1WEEK:  "1w",
+	exchange.KLINE_1MONTH: "1M",
+}
 
+func (e *Binance) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
 	if err != nil {
-		log.Printf("%+v", err)
 		operation.Error = err
 		return err
-
 	}
 
-	if operation.DebugMode {
-		operation.RequestURI = get.URI
-		operation.CallResponce = string(get.ResponseBody)
+	return exchange.FetchKline(operation, 1000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/api/v3/klines?symbol=%v&interval=%v&limit=1000", API_URL, // 1500478320000
+				e.GetSymbolByPair(operation.Pair), // BTCUSDT
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
+
+		if start != 0 {
+			get.URI += fmt.Sprintf("&startTime=%v", start)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&endTime=%v", end)
+		}
+
+		if err := utils.HttpGetRequest(get); err != nil {
+			log.Printf("%+v", err)
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		var rawKline [][]interface{}
+		if err := json.Unmarshal(get.ResponseBody, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		}
+		return e.klineDetails(operation, rawKline)
+	})
+}
+
+func (e *Binance) doContractKline(operation *exchange.PublicOperation) error {
+	// the contract klines took the binance intervals before KlineInterval, eg. "1m"
+	operation.KlineInterval = klineIntervals.Normalize(operation.KlineInterval)
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
 	}
 
-	var rawKline [][]interface{}
-	if err := json.Unmarshal(get.ResponseBody, &rawKline); err != nil {
-		operation.Error = fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
-		return operation.Error
+	contractURL := CONTRACT_URL
+	if operation.TestMode {
+		contractURL = CONTRACT_TESTNET_URL
 	}
 
-	operation.Kline = []*exchange.KlineDetail{}
+	return exchange.FetchKline(operation, 1500, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/fapi/v1/klines?symbol=%s&interval=%s&limit=1500",
+				contractURL,
+				e.GetSymbolByPair(operation.Pair), // BTCUSDT
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
+
+		if start != 0 {
+			get.URI += fmt.Sprintf("&startTime=%v", start)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&endTime=%v", end)
+		}
+
+		if err := utils.HttpGetRequest(get); err != nil {
+			log.Printf("%+v", err)
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		var rawKline [][]interface{}
+		if err := json.Unmarshal(get.ResponseBody, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doContractKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		}
+		return e.klineDetails(operation, rawKline)
+	})
+}
+
+// klineDetails parses the klines of spot and contract, they have the same format
+func (e *Binance) klineDetails(operation *exchange.PublicOperation, rawKline [][]interface{}) ([]*exchange.KlineDetail, error) {
+	details := []*exchange.KlineDetail{}
 	for _, k := range rawKline {
 		open, err := strconv.ParseFloat(k[1].(string), 64)
 		if err != nil {
 			log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 		high, err := strconv.ParseFloat(k[2].(string), 64)
 		if err != nil {
 			log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[2])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 		low, err := strconv.ParseFloat(k[3].(string), 64)
 		if err != nil {
 			log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[3])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 		close, err := strconv.ParseFloat(k[4].(string), 64)
 		if err != nil {
 			log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[4])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 		volume, err := strconv.ParseFloat(k[5].(string), 64)
 		if err != nil {
 			log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[5])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 		quoteAssetVolume, err := strconv.ParseFloat(k[7].(string), 64)
 		if err != nil {
 			log.Printf("%s quoteAssetVolume parse Err: %v %v", e.GetName(), err, k[7])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 		takerBuyBaseVolume, err := strconv.ParseFloat(k[9].(string), 64)
 		if err != nil {
 			log.Printf("%s takerBuyBaseVolume parse Err: %v %v", e.GetName(), err, k[9])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 		takerBuyQuoteVolume, err := strconv.ParseFloat(k[10].(string), 64)
 		if err != nil {
 			log.Printf("%s takerBuyQuoteVolume parse Err: %v %v", e.GetName(), err, k[10])
-			operation.Error = err
-			return err
+			return nil, err
 		}
 
 		detail := &exchange.KlineDetail{
@@ -241,46 +343,30 @@ func (e *Binance) doSpotKline(operation *exchange.PublicOperation) error {
 			TakerBuyQuoteVolume: takerBuyQuoteVolume,
 		}
 
-		operation.Kline = append(operation.Kline, detail)
+		details = append(details, detail)
 	}
-
-	return nil
+	return details, nil
 }
 
-// interval options: 1m, 3m, 5m, 15m, 30m, 1h, 2h, 4h, 6h, 8h, 12h, 1d, 3d, 1w, 1M
-func (e *Binance) doContractKline(operation *exchange.PublicOperation) error {
-	interval := "5m"
-	if operation.KlineInterval != "" {
-		interval = operation.KlineInterval
-	}
-
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("%s/fapi/v1/klines?symbol=%s&interval=%s&limit=1500",
-			CONTRACT_URL,
-			e.GetSymbolByPair(operation.Pair), // BTCUSDT
-			interval,
-		),
-		Proxy: operation.Proxy,
-	}
+// binance only publishes the rate of the running period, PredictedRate is left empty
+func (e *Binance) doContractFundingRate(operation *exchange.PublicOperation) error {
+	premiumIndex := PremiumIndex{}
+	fundingHistory := FundingRateHistory{}
+	symbol := e.GetSymbolByPair(operation.Pair)
 
+	contractURL := CONTRACT_URL
 	if operation.TestMode {
-		get.URI = fmt.Sprintf("%s/fapi/v1/klines?symbol=%s&interval=%s&limit=1500",
-			CONTRACT_TESTNET_URL,
-			e.GetSymbolByPair(operation.Pair), // BTCUSDT
-			interval,
-		)
+		contractURL = CONTRACT_TESTNET_URL
 	}
 
-	if operation.KlineStartTime != 0 {
-		get.URI += fmt.Sprintf("&startTime=%v", operation.KlineStartTime)
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/fapi/v1/premiumIndex?symbol=%s", contractURL, symbol),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
 	}
-
-	err := utils.HttpGetRequest(get)
-	if err != nil {
-		log.Printf("%+v", err)
+	if err := utils.HttpGetRequest(get); err != nil {
 		operation.Error = err
-		return err
-
+		return operation.Error
 	}
 
 	if operation.DebugMode {
@@ -288,149 +374,146 @@ func (e *Binance) doContractKline(operation *exchange.PublicOperation) error {
 		operation.CallResponce = string(get.ResponseBody)
 	}
 
-	var rawKline [][]interface{}
-	if err := json.Unmarshal(get.ResponseBody, &rawKline); err != nil {
-		operation.Error = fmt.Errorf("%s doContractKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+	if err := json.Unmarshal(get.ResponseBody, &premiumIndex); err != nil {
+		operation.Error = fmt.Errorf("%s doContractFundingRate Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
 		return operation.Error
 	}
 
-	operation.Kline = []*exchange.KlineDetail{}
-	for _, k := range rawKline {
-		open, err := strconv.ParseFloat(k[1].(string), 64)
-		if err != nil {
-			log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
-			operation.Error = err
-			return err
-		}
-		high, err := strconv.ParseFloat(k[2].(string), 64)
-		if err != nil {
-			log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[2])
-			operation.Error = err
-			return err
-		}
-		low, err := strconv.ParseFloat(k[3].(string), 64)
-		if err != nil {
-			log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[3])
-			operation.Error = err
-			return err
-		}
-		close, err := strconv.ParseFloat(k[4].(string), 64)
-		if err != nil {
-			log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[4])
-			operation.Error = err
-			return err
-		}
-		volume, err := strconv.ParseFloat(k[5].(string), 64)
-		if err != nil {
-			log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[5])
-			operation.Error = err
-			return err
-		}
-		quoteAssetVolume, err := strconv.ParseFloat(k[7].(string), 64)
-		if err != nil {
-			log.Printf("%s quoteAssetVolume parse Err: %v %v", e.GetName(), err, k[7])
-			operation.Error = err
-			return err
-		}
-		takerBuyBaseVolume, err := strconv.ParseFloat(k[9].(string), 64)
-		if err != nil {
-			log.Printf("%s takerBuyBaseVolume parse Err: %v %v", e.GetName(), err, k[9])
-			operation.Error = err
-			return err
-		}
-		takerBuyQuoteVolume, err := strconv.ParseFloat(k[10].(string), 64)
-		if err != nil {
-			log.Printf("%s takerBuyQuoteVolume parse Err: %v %v", e.GetName(), err, k[10])
-			operation.Error = err
-			return err
-		}
+	operation.FundingRate = &exchange.FundingRateDetail{
+		Pair:            operation.Pair,
+		Instrument:      symbol,
+		NextFundingTime: premiumIndex.NextFundingTime,
+		History:         []*exchange.FundingRateHistory{},
+	}
+	operation.FundingRate.CurrentRate, _ = strconv.ParseFloat(premiumIndex.LastFundingRate, 64)
 
-		detail := &exchange.KlineDetail{
-			Exchange:            e.GetName(),
-			Pair:                operation.Pair.Name,
-			OpenTime:            k[0].(float64),
-			Open:                open,
-			High:                high,
-			Low:                 low,
-			Close:               close,
-			Volume:              volume,
-			CloseTime:           k[6].(float64),
-			QuoteAssetVolume:    quoteAssetVolume,
-			TradesCount:         k[8].(float64),
-			TakerBuyBaseVolume:  takerBuyBaseVolume,
-			TakerBuyQuoteVolume: takerBuyQuoteVolume,
-		}
+	get = &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/fapi/v1/fundingRate?symbol=%s&limit=1000", contractURL, symbol),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if operation.FundingStartTime != 0 {
+		get.URI += fmt.Sprintf("&startTime=%v", operation.FundingStartTime)
+	}
+	if operation.FundingEndTime != 0 {
+		get.URI += fmt.Sprintf("&endTime=%v", operation.FundingEndTime)
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
 
-		operation.Kline = append(operation.Kline, detail)
+	if err := json.Unmarshal(get.ResponseBody, &fundingHistory); err != nil {
+		operation.Error = fmt.Errorf("%s doContractFundingRate history Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	}
+
+	for _, f := range fundingHistory {
+		rate, err := strconv.ParseFloat(f.FundingRate, 64)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doContractFundingRate parse Err: %v %v", e.GetName(), err, f.FundingRate)
+			return operation.Error
+		}
+		operation.FundingRate.History = append(operation.FundingRate.History, &exchange.FundingRateHistory{
+			Rate:      rate,
+			Timestamp: f.FundingTime,
+		})
 	}
 
 	return nil
 }
 
+// the trade history is the aggregate trades, paged or not, the ID is the aggregate trade id:
+// the last ID of a history is the TradeFromID of the next one.
+// With a range or TradeFromID they are followed by fromId, a startTime without fromId gets at most an hour,
+// the next hour is asked if it has no trades.
 func (e *Binance) doTradeHistory(operation *exchange.PublicOperation) error {
+	symbol := e.GetSymbolByPair(operation.Pair)
+	hour := int64(time.Hour / time.Millisecond)
 
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://api.binance.com/api/v3/trades?symbol=%s&limit=%d",
-			e.GetSymbolByPair(operation.Pair),
-			1000, //TRADE_HISTORY_MAX_LIMIT,
-		),
-		Proxy: operation.Proxy,
+	start, end := operation.TradeStartTime, operation.TradeEndTime
+	if end == 0 {
+		end = time.Now().UnixNano() / 1e6
+	}
+	if start == 0 {
+		start = end - hour
 	}
 
-	err := utils.HttpGetRequest(get)
+	return exchange.FetchTrades(operation, false, func(cursor string) ([]*exchange.TradeDetail, string, error) {
+		aggTrades := AggTrades{}
 
-	if err != nil {
-		log.Printf("%+v", err)
-		operation.Error = err
-		return err
+		get := &utils.HttpGet{
+			URI:   fmt.Sprintf("%s/api/v3/aggTrades?symbol=%s&limit=1000", API_URL, symbol),
+			Proxy: operation.Proxy,
+		}
+		// the cursor is the fromId of the page or the startTime of the hour, the latest trades without a range
+		windowEnd := int64(0)
+		switch {
+		case !operation.IsTradePaged():
+		case cursor == "" && operation.TradeFromID != "":
+			fromID, err := strconv.ParseInt(operation.TradeFromID, 10, 64)
+			if err != nil {
+				return nil, "", fmt.Errorf("%s doTradeHistory invalid TradeFromID: %v", e.GetName(), operation.TradeFromID)
+			}
+			get.URI += fmt.Sprintf("&fromId=%d", fromID+1)
+		case cursor == "" || strings.HasPrefix(cursor, "time:"):
+			from := start
+			if cursor != "" {
+				from, _ = strconv.ParseInt(strings.TrimPrefix(cursor, "time:"), 10, 64)
+			}
+			windowEnd = from + hour - 1
+			get.URI += fmt.Sprintf("&startTime=%d&endTime=%d", from, windowEnd)
+		default:
+			get.URI += fmt.Sprintf("&fromId=%s", cursor)
+		}
+
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, "", err
+		}
 
-	} else {
-		// log.Printf("%+v  ERR:%+v", string(get.ResponseBody), err) // ======================
 		if operation.DebugMode {
 			operation.RequestURI = get.URI
 			operation.CallResponce = string(get.ResponseBody)
 		}
 
-		tradeHistory := TradeHistory{}
-		if err := json.Unmarshal(get.ResponseBody, &tradeHistory); err != nil {
-			operation.Error = err
-			return err
-		} else {
-			// log.Printf("%+v ", tradeHistory)
+		if err := json.Unmarshal(get.ResponseBody, &aggTrades); err != nil {
+			return nil, "", fmt.Errorf("%s doTradeHistory Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
 		}
 
-		operation.TradeHistory = []*exchange.TradeDetail{}
-		for _, trade := range tradeHistory {
+		trades := []*exchange.TradeDetail{}
+		for _, trade := range aggTrades {
 			price, err := strconv.ParseFloat(trade.Price, 64)
 			if err != nil {
-				log.Printf("%s price parse Err: %v %v", e.GetName(), err, trade.Price)
-				operation.Error = err
-				return err
+				return nil, "", fmt.Errorf("%s price parse Err: %v %v", e.GetName(), err, trade.Price)
 			}
-			amount, err := strconv.ParseFloat(trade.Qty, 64)
+			amount, err := strconv.ParseFloat(trade.Quantity, 64)
 			if err != nil {
-				log.Printf("%s amount parse Err: %v %v", e.GetName(), err, trade.Qty)
-				operation.Error = err
-				return err
+				return nil, "", fmt.Errorf("%s amount parse Err: %v %v", e.GetName(), err, trade.Quantity)
 			}
 
 			td := &exchange.TradeDetail{
-				ID:        fmt.Sprintf("%v", trade.ID),
+				ID:        fmt.Sprintf("%v", trade.AggID),
 				Quantity:  amount,
 				TimeStamp: trade.Time,
 				Rate:      price,
+				BestMatch: trade.IsBestMatch,
 			}
 			if trade.IsBuyerMaker {
 				td.Direction = exchange.Buy
-			} else if !trade.IsBuyerMaker {
+			} else {
 				td.Direction = exchange.Sell
 			}
-
-			operation.TradeHistory = append(operation.TradeHistory, td)
+			trades = append(trades, td)
 		}
-	}
 
-	return nil
+		next := ""
+		if len(aggTrades) > 0 {
+			next = fmt.Sprintf("%d", aggTrades[len(aggTrades)-1].AggID+1)
+		} else if windowEnd != 0 && windowEnd < end {
+			next = fmt.Sprintf("time:%d", windowEnd+1)
+		}
+		return trades, next, nil
+	})
 }
 
 func (e *Binance) doContractOrderBook(operation *exchange.PublicOperation) error {
@@ -550,3 +633,101 @@ func (e *Binance) doSpotOrderBook(op *exchange.PublicOperation) error {
 	op.Maker = maker
 	return nil
 }
+
+func (e *Binance) doSystemStatus(operation *exchange.PublicOperation) error {
+	systemStatus := SystemStatus{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/sapi/v1/system/status", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &systemStatus); err != nil {
+		operation.Error = fmt.Errorf("%s doSystemStatus Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	}
+
+	// 0: normal, 1: system maintenance, binance has no api of the announcements
+	operation.SystemStatus = &exchange.SystemStatusDetail{
+		Status:       exchange.HealthNormal,
+		Message:      systemStatus.Msg,
+		Maintenances: []*exchange.MaintenanceDetail{},
+		Timestamp:    time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if systemStatus.Status == 1 {
+		operation.SystemStatus.Status = exchange.HealthMaintenance
+	}
+
+	return nil
+}
+
+// doWalletStatus is the status of the default network of every coin, it needs the API Key
+func (e *Binance) doWalletStatus(operation *exchange.PublicOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		operation.Error = fmt.Errorf("%s doWalletStatus API Key or Secret Key are nil", e.GetName())
+		return operation.Error
+	}
+
+	coinsData := CoinsData{}
+	strRequest := "/sapi/v1/capital/config/getall"
+
+	jsonCurrencyReturn := e.ApiKeyGet(make(map[string]string), strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonCurrencyReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
+		operation.Error = fmt.Errorf("%s doWalletStatus Json Unmarshal Err: %v %s", e.GetName(), err, jsonCurrencyReturn)
+		return operation.Error
+	}
+
+	operation.WalletStatus = []*exchange.WalletStatusDetail{}
+	for _, data := range coinsData {
+		c := e.GetCoinBySymbol(data.Coin)
+		if c == nil {
+			continue
+		}
+
+		for _, network := range data.NetworkList {
+			if !network.IsDefault {
+				continue
+			}
+			status := &exchange.WalletStatusDetail{
+				Coin:     c,
+				Deposit:  network.DepositEnable,
+				Withdraw: network.WithdrawEnable,
+			}
+			issues := []string{}
+			if !network.DepositEnable {
+				issues = append(issues, strings.TrimSpace("deposit suspended "+network.DepositDesc))
+			}
+			if !network.WithdrawEnable {
+				issues = append(issues, strings.TrimSpace("withdraw suspended "+network.WithdrawDesc))
+			}
+			status.Issue = strings.Join(issues, "; ")
+
+			if coinConstraint := e.GetCoinConstraint(c); coinConstraint != nil {
+				// a copy, the constraint may be read meanwhile
+				updated := *coinConstraint
+				updated.Deposit = status.Deposit
+				updated.Withdraw = status.Withdraw
+				updated.Issue = status.Issue
+				e.SetCoinConstraint(&updated)
+			}
+			operation.WalletStatus = append(operation.WalletStatus, status)
+		}
+	}
+
+	return nil
+}
@@ -17,7 +17,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://dex.binance.org"
 )
 
@@ -294,6 +294,11 @@ func (e *BinanceDex) Withdraw(coin *coin.Coin, quantity float64, addr, tag strin
 }
 
 func (e *BinanceDex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	if fmt.Sprintf("%s", e.API_KEY) == "" || fmt.Sprintf("%s", e.API_SECRET) == "" {
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
@@ -306,8 +311,8 @@ func (e *BinanceDex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchan
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -332,6 +337,11 @@ func (e *BinanceDex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchan
 }
 
 func (e *BinanceDex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	if fmt.Sprintf("%s", e.API_KEY) == "" || fmt.Sprintf("%s", e.API_SECRET) == "" {
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
@@ -344,8 +354,8 @@ func (e *BinanceDex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchang
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
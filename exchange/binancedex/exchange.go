@@ -267,6 +267,19 @@ func (e *BinanceDex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constra
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *BinanceDex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *BinanceDex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
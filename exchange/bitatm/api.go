@@ -20,7 +20,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://open.bitatm.com"
 )
 
@@ -23,7 +23,7 @@ import (
 	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://api.bitbay.net/rest"
 )
 
@@ -309,8 +309,8 @@ func (e *Bitbay) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	order := &exchange.Order{
 		Pair:         pair,
 		OrderID:      placeOrder.OfferID,
-		Rate:         rate,
-		Quantity:     quantity,
+		Rate:         price,
+		Quantity:     amount,
 		Direction:    exchange.Sell,
 		Status:       exchange.New,
 		JsonResponse: jsonPlaceReturn,
@@ -345,8 +345,8 @@ func (e *Bitbay) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	order := &exchange.Order{
 		Pair:         pair,
 		OrderID:      placeOrder.OfferID,
-		Rate:         rate,
-		Quantity:     quantity,
+		Rate:         price,
+		Quantity:     amount,
 		Direction:    exchange.Buy,
 		Status:       exchange.New,
 		JsonResponse: jsonPlaceReturn,
@@ -21,8 +21,9 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://api.bitbns.com"
+	WEB_URL = "https://bitbns.com" // the tickers with the coins and pairs
 )
 
 /*API Base Knowledge
@@ -54,7 +55,7 @@ func (e *Bitbns) GetCoinsData() error {
 	coinsData := CoinsData{}
 
 	strRequestPath := "/order/getTickerWithVolume/" // "/api/trade/v1/tickers"
-	strUrl := "https://bitbns.com" + strRequestPath
+	strUrl := WEB_URL + strRequestPath
 
 	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
 	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
@@ -115,7 +116,7 @@ func (e *Bitbns) GetPairsData() error {
 	pairsData := CoinsData{}
 
 	strRequestPath := "/order/getTickerWithVolume/" // "/api/trade/v1/tickers"
-	strUrl := "https://bitbns.com" + strRequestPath
+	strUrl := WEB_URL + strRequestPath
 
 	jsonSymbolsReturn := exchange.HttpGetRequest(strUrl, nil)
 	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &pairsData); err != nil {
@@ -318,6 +319,11 @@ func (e *Bitbns) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -326,8 +332,8 @@ func (e *Bitbns) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -356,6 +362,11 @@ func (e *Bitbns) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -364,8 +375,8 @@ func (e *Bitbns) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
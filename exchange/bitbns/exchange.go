@@ -256,6 +256,19 @@ func (e *Bitbns) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bitbns) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitbns) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
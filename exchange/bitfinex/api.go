@@ -13,7 +13,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
 	"regexp"
 	"strconv"
@@ -26,7 +25,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://api.bitfinex.com"
 )
 
@@ -391,13 +390,15 @@ func (e *Bitfinex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/order/new"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "sell"
 	mapParams["type"] = "exchange limit"
 
@@ -428,13 +429,15 @@ func (e *Bitfinex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/order/new"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "buy"
 	mapParams["type"] = "exchange limit"
 
@@ -249,6 +249,28 @@ func (e *Bitfinex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrain
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.Orderbook:    {exchange.SpotWallet},
+		exchange.KLine:        {exchange.SpotWallet},
+		exchange.Ticker:       {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bitfinex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitfinex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -20,6 +20,14 @@ func (e *Bitfinex) LoadPublicData(operation *exchange.PublicOperation) error {
 		if operation.Wallet == exchange.SpotWallet {
 			return e.doSpotOrderBook(operation)
 		}
+	case exchange.KLine:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doSpotKline(operation)
+		}
+	case exchange.Ticker:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doTicker(operation)
+		}
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
@@ -93,30 +101,49 @@ func (e *Bitfinex) doSpotOrderBook(op *exchange.PublicOperation) error {
 }
 
 // Symbol that is not same as API.
+// [ID, MTS, AMOUNT, PRICE], with a range the pages are followed by start, sorted old to new.
+// TradeFromID is not supported.
 func (e *Bitfinex) doTradeHistory(operation *exchange.PublicOperation) error {
+	if operation.TradeFromID != "" {
+		operation.Error = fmt.Errorf("%s doTradeHistory TradeFromID: %w", e.GetName(), exchange.ErrNotSupported)
+		return operation.Error
+	}
 	symbol := e.GetSymbolByPair(operation.Pair)
 	symbol = fmt.Sprintf("t%v", strings.ToUpper(symbol))
 	// log.Printf("Symbol: %s", symbol)
 
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("%s/v2/trades/%s/hist", API_URL, symbol),
-	}
+	return exchange.FetchTrades(operation, false, func(cursor string) ([]*exchange.TradeDetail, string, error) {
+		get := &utils.HttpGet{
+			URI:   fmt.Sprintf("%s/v2/trades/%s/hist", API_URL, symbol),
+			Proxy: operation.Proxy,
+		}
+		if operation.IsTradePaged() {
+			start := cursor
+			if start == "" {
+				start = fmt.Sprintf("%d", operation.TradeStartTime)
+			}
+			get.URI += fmt.Sprintf("?limit=10000&sort=1&start=%s", start)
+			if operation.TradeEndTime != 0 {
+				get.URI += fmt.Sprintf("&end=%d", operation.TradeEndTime)
+			}
+		}
 
-	err := utils.HttpGetRequest(get)
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, "", err
+		}
 
-	if err != nil {
-		return err
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
 
-	} else {
 		tradeHistory := TradeHistory{}
 		if err := json.Unmarshal(get.ResponseBody, &tradeHistory); err != nil {
-			return err
+			return nil, "", fmt.Errorf("%s doTradeHistory Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
 		}
 
-		operation.TradeHistory = []*exchange.TradeDetail{}
-		// for _, d := range tradeHistory {
-		for i := len(tradeHistory) - 1; i > 0; i-- {
-			d := tradeHistory[i]
+		trades := []*exchange.TradeDetail{}
+		for _, d := range tradeHistory {
 			td := &exchange.TradeDetail{}
 
 			td.ID = fmt.Sprintf("%.0f", d[0])
@@ -132,9 +159,148 @@ func (e *Bitfinex) doTradeHistory(operation *exchange.PublicOperation) error {
 
 			td.TimeStamp = int64(d[1])
 
-			operation.TradeHistory = append(operation.TradeHistory, td)
+			trades = append(trades, td)
+		}
+
+		// the next page starts at the last ms, its trades are returned again and skipped
+		next := ""
+		if len(tradeHistory) == 10000 {
+			next = fmt.Sprintf("%.0f", tradeHistory[len(tradeHistory)-1][1])
 		}
+		return trades, next, nil
+	})
+}
+
+// tickers of the v2 api, a trading pair is
+// [SYMBOL, BID, BID_SIZE, ASK, ASK_SIZE, DAILY_CHANGE, DAILY_CHANGE_RELATIVE, LAST_PRICE, VOLUME, HIGH, LOW]
+func (e *Bitfinex) doTicker(operation *exchange.PublicOperation) error {
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/v2/tickers?symbols=ALL", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	rawTicker := [][]interface{}{}
+	if err := json.Unmarshal(get.ResponseBody, &rawTicker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	}
+
+	timestamp := time.Now().UnixNano() / 1e6
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range rawTicker {
+		// the funding symbols start with "f"
+		if len(t) < 11 {
+			continue
+		}
+		symbol, ok := t[0].(string)
+		if !ok || !strings.HasPrefix(symbol, "t") {
+			continue
+		}
+		p := e.GetPairBySymbol(strings.ToLower(symbol[1:]))
+		if p == nil {
+			continue
+		}
+
+		values := make([]float64, 10)
+		for i := range values {
+			values[i], _ = t[i+1].(float64)
+		}
+		detail := &exchange.TickerDetail{
+			Pair:            p,
+			BestBid:         values[0],
+			BestBidQuantity: values[1],
+			BestAsk:         values[2],
+			BestAskQuantity: values[3],
+			Change:          values[4],
+			ChangePercent:   values[5] * 100,
+			Last:            values[6],
+			Volume:          values[7],
+			High:            values[8],
+			Low:             values[9],
+			Timestamp:       timestamp,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
 	}
 
 	return nil
 }
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1m",
+	exchange.KLINE_5MIN:   "5m",
+	exchange.KLINE_15MIN:  "15m",
+	exchange.KLINE_30MIN:  "30m",
+	exchange.KLINE_1HOUR:  "1h",
+	exchange.KLINE_6HOUR:  "6h",
+	exchange.KLINE_12HOUR: "12h",
+	exchange.KLINE_1DAY:   "1D",
+	exchange.KLINE_1WEEK:  "1W",
+	exchange.KLINE_1MONTH: "1M",
+}
+
+// candles of the v2 api, the trading symbol is "t" + the v1 symbol in upper case, eg. tBTCUSD
+func (e *Bitfinex) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	symbol := "t" + strings.ToUpper(e.GetSymbolByPair(operation.Pair))
+
+	return exchange.FetchKline(operation, 10000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/v2/candles/trade:%s:%s/hist?limit=10000&sort=1", API_URL, interval, symbol),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&start=%v", start)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&end=%v", end)
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		// [MTS, OPEN, CLOSE, HIGH, LOW, VOLUME]
+		rawKline := [][]float64{}
+		if err := json.Unmarshal(get.ResponseBody, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, k := range rawKline {
+			if len(k) < 6 {
+				return nil, fmt.Errorf("%s doSpotKline invalid kline: %v", e.GetName(), k)
+			}
+			details = append(details, &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: k[0],
+				Open:     k[1],
+				Close:    k[2],
+				High:     k[3],
+				Low:      k[4],
+				Volume:   k[5],
+			})
+		}
+		return details, nil
+	})
+}
@@ -20,7 +20,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://api.bitforex.com/api"
 )
 
@@ -304,14 +304,19 @@ func (e *Bitforex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/trade/placeOrder"
 
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["tradeType"] = "2"
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
@@ -342,14 +347,19 @@ func (e *Bitforex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/v1/trade/placeOrder"
 
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["tradeType"] = "1"
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
@@ -247,6 +247,28 @@ func (e *Bithumb) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainF
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Transfer:    {exchange.SpotWallet},
+		exchange.BalanceList: {exchange.SpotWallet},
+		exchange.Balance:     {exchange.SpotWallet},
+		exchange.Withdraw:    {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bithumb) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bithumb) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -20,7 +20,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://openapi.bitmart.com"
 )
 
@@ -269,14 +269,16 @@ func (e *Bitmart) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 	placeOrder := PlaceOrder{}
 	strRequest := "/v2/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "sell"
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -306,14 +308,16 @@ func (e *Bitmart) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	placeOrder := PlaceOrder{}
 	strRequest := "/v2/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "buy"
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -464,7 +468,7 @@ func (e *Bitmart) GetToken(key string, secret string, memo string) string {
 	mapParams["client_secret"] = token
 
 	accessToken := AccessToken{}
-	strRequest := "https://openapi.bitmart.com/v2/authentication"
+	strRequest := API_URL + "/v2/authentication"
 
 	jsonBitmart := e.TokenReq(strRequest, mapParams)
 	err := json.Unmarshal([]byte(jsonBitmart), &accessToken)
@@ -23,7 +23,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://bitmax.io"
 )
 
@@ -325,6 +325,11 @@ func (e *Bitmax) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestUrl := fmt.Sprintf("/%v/api/v1/order", e.Account_Group)
@@ -332,8 +337,8 @@ func (e *Bitmax) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	mapParams := make(map[string]string)
 	mapParams["coid"] = fmt.Sprintf("%v%v", time.Now().UTC().UnixNano(), time.Now().UTC().UnixNano()/1000000)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["orderPrice"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["orderQty"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["orderPrice"] = pairConstraint.FormatRate(rate)
+	mapParams["orderQty"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["orderType"] = "limit"
 	mapParams["side"] = "sell"
 
@@ -367,6 +372,11 @@ func (e *Bitmax) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestUrl := fmt.Sprintf("/%v/api/v1/order", e.Account_Group)
@@ -374,8 +384,8 @@ func (e *Bitmax) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	mapParams := make(map[string]string)
 	mapParams["coid"] = fmt.Sprintf("%v%v", time.Now().UTC().UnixNano(), time.Now().UTC().UnixNano()/1000000)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["orderPrice"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["orderQty"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["orderPrice"] = pairConstraint.FormatRate(rate)
+	mapParams["orderQty"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["orderType"] = "limit"
 	mapParams["side"] = "buy"
 
@@ -252,6 +252,19 @@ func (e *Bitmax) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bitmax) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitmax) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
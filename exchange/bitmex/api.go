@@ -20,7 +20,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://www.bitmex.com/api/v1"
 )
 
@@ -243,21 +243,31 @@ func (e *Bitmex) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
 	return maker, nil
 }
 
-func (e *Bitmex) LoadPublicData(operation *exchange.PublicOperation) error {
-	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
-}
-
 /*************** Private API ***************/
-func (e *Bitmex) DoAccountOperation(operation *exchange.AccountOperation) error {
-	return nil
-}
-
 func (e *Bitmex) UpdateAllBalances() {
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		log.Printf("%s API Key or Secret Key are nil.", e.GetName())
 		return
 	}
 
+	userMargin := UserMargin{}
+	strRequest := "/user/margin"
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = "all"
+
+	jsonBalanceReturn := e.ApiKeyGet(mapParams, strRequest)
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &userMargin); err != nil {
+		log.Printf("%s UpdateAllBalances json Unmarshal error: %v %s", e.GetName(), err, jsonBalanceReturn)
+		return
+	} else {
+		for _, balance := range userMargin {
+			c := e.getCoinByCurrency(balance.Currency)
+			if c != nil {
+				balanceMap.Set(c.Code, float64(balance.AvailableMargin)*currencyUnit(balance.Currency))
+			}
+		}
+	}
 }
 
 /* Withdraw(coin *coin.Coin, quantity float64, addr, tag string) */
@@ -274,15 +284,20 @@ func (e *Bitmex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	errResponse := ErrorResponse{}
 	placeOrder := PlaceOrder{}
-	strRequest := "/api/v1/order"
+	strRequest := "/order"
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "Sell"
-	mapParams["simpleOrderQty"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["simpleOrderQty"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyPost(mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -310,15 +325,20 @@ func (e *Bitmex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	errResponse := ErrorResponse{}
 	placeOrder := PlaceOrder{}
-	strRequest := "/api/v1/order"
+	strRequest := "/order"
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "Buy"
-	mapParams["simpleOrderQty"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["simpleOrderQty"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyPost(mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
@@ -348,7 +368,7 @@ func (e *Bitmex) OrderStatus(order *exchange.Order) error {
 
 	errResponse := ErrorResponse{}
 	orderStatus := []PlaceOrder{}
-	strRequest := "/api/v1/order"
+	strRequest := "/order"
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(order.Pair)
@@ -392,10 +412,48 @@ func (e *Bitmex) CancelOrder(order *exchange.Order) error {
 		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	errResponse := ErrorResponse{}
+	cancelOrder := CancelOrder{}
+	strRequest := "/order"
+
+	mapParams := make(map[string]string)
+	mapParams["orderID"] = order.OrderID
+
+	jsonCancelOrder := e.ApiKeyRequest("DELETE", mapParams, strRequest)
+	if err := json.Unmarshal([]byte(jsonCancelOrder), &cancelOrder); err != nil {
+		if err := json.Unmarshal([]byte(jsonCancelOrder), &errResponse); err != nil {
+			return fmt.Errorf("%s CancelOrder Unmarshal Err: %v %v", e.GetName(), err, jsonCancelOrder)
+		} else {
+			return fmt.Errorf("%s CancelOrder Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		}
+	} else if len(cancelOrder) == 0 || cancelOrder[0].Error != "" {
+		return fmt.Errorf("%s CancelOrder Failed: %v", e.GetName(), jsonCancelOrder)
+	}
+
+	order.Status = exchange.Canceling
+	order.CancelStatus = jsonCancelOrder
+
 	return nil
 }
 
 func (e *Bitmex) CancelAllOrder() error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	errResponse := ErrorResponse{}
+	cancelOrder := CancelOrder{}
+	strRequest := "/order/all"
+
+	jsonCancelAll := e.ApiKeyRequest("DELETE", nil, strRequest)
+	if err := json.Unmarshal([]byte(jsonCancelAll), &cancelOrder); err != nil {
+		if err := json.Unmarshal([]byte(jsonCancelAll), &errResponse); err != nil {
+			return fmt.Errorf("%s CancelAllOrder Unmarshal Err: %v %v", e.GetName(), err, jsonCancelAll)
+		} else {
+			return fmt.Errorf("%s CancelAllOrder Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		}
+	}
+
 	return nil
 }
 
@@ -412,17 +470,11 @@ func (e *Bitmex) ApiKeyGet(mapParams map[string]string, strRequestPath string) s
 	if nil == mapParams {
 		strRequestUrl = strRequestPath
 	} else {
-		strParams := exchange.Map2UrlQuery(mapParams)
+		// filter and columns are json, the signature covers the escaped query
+		strParams := exchange.Map2UrlQueryUrl(mapParams)
 		strRequestUrl = strRequestPath + "?" + strParams
 	}
 
-	strPayload := fmt.Sprintf("%s%s%d", strMethod, strRequestUrl, timestamp)
-
-	mapParams2Sign := make(map[string]string)
-	mapParams2Sign["api-expires"] = strconv.FormatInt(timestamp, 10)
-	mapParams2Sign["api-key"] = e.API_KEY
-	mapParams2Sign["api-signature"] = exchange.ComputeHmac256Base64(strPayload, e.API_SECRET)
-
 	strUrl := API_URL + strRequestUrl
 
 	httpClient := &http.Client{}
@@ -432,6 +484,14 @@ func (e *Bitmex) ApiKeyGet(mapParams map[string]string, strRequestPath string) s
 	if nil != err {
 		return err.Error()
 	}
+
+	// the signature covers the full path sent, API_URL already contains /api/v1
+	strPayload := fmt.Sprintf("%s%s%d", strMethod, request.URL.RequestURI(), timestamp)
+
+	mapParams2Sign := make(map[string]string)
+	mapParams2Sign["api-expires"] = strconv.FormatInt(timestamp, 10)
+	mapParams2Sign["api-key"] = e.API_KEY
+	mapParams2Sign["api-signature"] = exchange.ComputeHmac256Base64(strPayload, e.API_SECRET)
 	request.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 6.1; WOW64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/39.0.2171.71 Safari/537.36")
 	request.Header.Set("api-expires", mapParams2Sign["api-expires"])
 	request.Header.Set("api-key", mapParams2Sign["api-key"])
@@ -458,7 +518,13 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Bitmex) ApiKeyPost(mapParams map[string]string, strRequestPath string) string {
-	strMethod := "POST"
+	return e.ApiKeyRequest("POST", mapParams, strRequestPath)
+}
+
+/*Method: API Request with json body (POST, PUT, DELETE) and Signature is required
+Step 1: Change Instance Name    (e *<exchange Instance Name>)
+Step 2: Create mapParams Depend on API Signature request*/
+func (e *Bitmex) ApiKeyRequest(strMethod string, mapParams map[string]string, strRequestPath string) string {
 	timestamp := time.Now().Unix() + 5
 
 	jsonParams := ""
@@ -479,7 +545,7 @@ func (e *Bitmex) ApiKeyPost(mapParams map[string]string, strRequestPath string)
 	request.Header.Add("Content-Type", "application/json")
 	request.Header.Add("api-expires", strconv.FormatInt(timestamp, 10))
 	request.Header.Add("api-key", e.API_KEY)
-	strPayload := fmt.Sprintf("%s%s%d%s", strMethod, strRequestPath, timestamp, jsonParams)
+	strPayload := fmt.Sprintf("%s%s%d%s", strMethod, request.URL.RequestURI(), timestamp, jsonParams)
 	request.Header.Add("api-signature", exchange.ComputeHmac256Base64(strPayload, e.API_SECRET))
 
 	// 发出请求
@@ -249,6 +249,39 @@ func (e *Bitmex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.FundingRate: {exchange.ContractWallet},
+		exchange.KLine:       {exchange.ContractWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList:          {exchange.ContractWallet},
+		exchange.PlaceOrder:           {exchange.ContractWallet},
+		exchange.GetOrderStatus:       {exchange.ContractWallet},
+		exchange.CancelOrder:          {exchange.ContractWallet},
+		exchange.CancelAllAfter:       {exchange.ContractWallet},
+		exchange.GetOpenOrder:         {exchange.ContractWallet},
+		exchange.GetOrderHistory:      {exchange.ContractWallet},
+		exchange.GetExecutionHistory:  {exchange.ContractWallet},
+		exchange.GetPositions:         {exchange.ContractWallet},
+		exchange.SetLeverage:          {exchange.ContractWallet},
+		exchange.SetFutureLeverage:    {exchange.ContractWallet},
+		exchange.SetMarginType:        {exchange.ContractWallet},
+		exchange.SetPositionMode:      {exchange.ContractWallet},
+		exchange.AdjustIsolatedMargin: {exchange.ContractWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.ContractWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET, exchange.Trade_STOP_LIMIT, exchange.Trade_STOP_MARKET, exchange.Trade_TAKE_PROFIT, exchange.Trade_TAKE_PROFIT_MARKET, exchange.Trade_TRAILING_STOP_MARKET, exchange.Trade_PEGGED},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.ContractWallet: {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+	},
+}
+
+func (e *Bitmex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitmex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -36,11 +36,11 @@ type PlaceOrder struct {
 	Symbol                string      `json:"symbol"`
 	Side                  string      `json:"side"`
 	SimpleOrderQty        float64     `json:"simpleOrderQty"`
-	OrderQty              interface{} `json:"orderQty"`
+	OrderQty              float64     `json:"orderQty"`
 	Price                 float64     `json:"price"`
 	DisplayQty            interface{} `json:"displayQty"`
-	StopPx                interface{} `json:"stopPx"`
-	PegOffsetValue        interface{} `json:"pegOffsetValue"`
+	StopPx                float64     `json:"stopPx"`
+	PegOffsetValue        float64     `json:"pegOffsetValue"`
 	PegPriceType          string      `json:"pegPriceType"`
 	Currency              string      `json:"currency"`
 	SettlCurrency         string      `json:"settlCurrency"`
@@ -64,6 +64,45 @@ type PlaceOrder struct {
 	Timestamp             time.Time   `json:"timestamp"`
 }
 
+type CancelOrder []struct {
+	PlaceOrder
+	Error string `json:"error"`
+}
+
+// amounts in the smallest unit of the currency, XBt (satoshi) or USDt
+type UserMargin []struct {
+	Account            int64     `json:"account"`
+	Currency           string    `json:"currency"`
+	WalletBalance      int64     `json:"walletBalance"`
+	MarginBalance      int64     `json:"marginBalance"`
+	AvailableMargin    int64     `json:"availableMargin"`
+	WithdrawableMargin int64     `json:"withdrawableMargin"`
+	InitMargin         int64     `json:"initMargin"`
+	MaintMargin        int64     `json:"maintMargin"`
+	UnrealisedPnl      int64     `json:"unrealisedPnl"`
+	RealisedPnl        int64     `json:"realisedPnl"`
+	MarginLeverage     float64   `json:"marginLeverage"`
+	Timestamp          time.Time `json:"timestamp"`
+}
+
+type Execution []struct {
+	ExecID           string    `json:"execID"`
+	OrderID          string    `json:"orderID"`
+	ClOrdID          string    `json:"clOrdID"`
+	Symbol           string    `json:"symbol"`
+	Side             string    `json:"side"`
+	LastQty          float64   `json:"lastQty"`
+	LastPx           float64   `json:"lastPx"`
+	LastLiquidityInd string    `json:"lastLiquidityInd"`
+	OrdType          string    `json:"ordType"`
+	ExecType         string    `json:"execType"`
+	Commission       float64   `json:"commission"`
+	ExecComm         int64     `json:"execComm"`
+	SettlCurrency    string    `json:"settlCurrency"`
+	TransactTime     time.Time `json:"transactTime"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
 type OrderBook []struct {
 	Symbol string  `json:"symbol"`
 	ID     int64   `json:"id"`
@@ -177,3 +216,53 @@ type PairsData []struct {
 	SettledPrice                   interface{} `json:"settledPrice"`
 	Timestamp                      time.Time   `json:"timestamp"`
 }
+
+type Position []PositionDetail
+
+type PositionDetail struct {
+	Account          int64     `json:"account"`
+	Symbol           string    `json:"symbol"`
+	Currency         string    `json:"currency"`
+	Underlying       string    `json:"underlying"`
+	QuoteCurrency    string    `json:"quoteCurrency"`
+	Leverage         float64   `json:"leverage"`
+	CrossMargin      bool      `json:"crossMargin"`
+	CurrentQty       float64   `json:"currentQty"`
+	AvgEntryPrice    float64   `json:"avgEntryPrice"`
+	MarkPrice        float64   `json:"markPrice"`
+	LiquidationPrice float64   `json:"liquidationPrice"`
+	UnrealisedPnl    float64   `json:"unrealisedPnl"`
+	RealisedPnl      float64   `json:"realisedPnl"`
+	PosMargin        float64   `json:"posMargin"`
+	MaintMargin      float64   `json:"maintMargin"`
+	IsOpen           bool      `json:"isOpen"`
+	Timestamp        time.Time `json:"timestamp"`
+}
+
+type Instrument []struct {
+	Symbol                string    `json:"symbol"`
+	State                 string    `json:"state"`
+	FundingRate           float64   `json:"fundingRate"`
+	IndicativeFundingRate float64   `json:"indicativeFundingRate"`
+	FundingTimestamp      time.Time `json:"fundingTimestamp"`
+	MarkPrice             float64   `json:"markPrice"`
+	Timestamp             time.Time `json:"timestamp"`
+}
+
+type Funding []struct {
+	Timestamp        time.Time `json:"timestamp"`
+	Symbol           string    `json:"symbol"`
+	FundingInterval  time.Time `json:"fundingInterval"`
+	FundingRate      float64   `json:"fundingRate"`
+	FundingRateDaily float64   `json:"fundingRateDaily"`
+}
+
+type TradeBin []struct {
+	Timestamp time.Time `json:"timestamp"`
+	Symbol    string    `json:"symbol"`
+	Open      float64   `json:"open"`
+	High      float64   `json:"high"`
+	Low       float64   `json:"low"`
+	Close     float64   `json:"close"`
+	Volume    float64   `json:"volume"`
+}
@@ -0,0 +1,717 @@
+package bitmex
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Bitmex) DoAccountOperation(operation *exchange.AccountOperation) error {
+	switch operation.Type {
+	case exchange.BalanceList:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doAllBalance(operation)
+		}
+	case exchange.PlaceOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doPlaceOrder(operation)
+		}
+	case exchange.GetOrderStatus:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doOrderStatus(operation)
+		}
+	case exchange.CancelOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doCancelOrder(operation)
+		}
+	case exchange.CancelAllAfter:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doCancelAllAfter(operation)
+		}
+	case exchange.GetOpenOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetOpenOrder(operation)
+		}
+	case exchange.GetOrderHistory:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetOrderHistory(operation)
+		}
+	case exchange.GetExecutionHistory:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetExecutionHistory(operation)
+		}
+	case exchange.GetPositions:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetPositions(operation)
+		}
+	case exchange.SetLeverage, exchange.SetFutureLeverage:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetLeverage(operation)
+		}
+	case exchange.SetMarginType:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetMarginType(operation)
+		}
+	case exchange.SetPositionMode:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetPositionMode(operation)
+		}
+	case exchange.AdjustIsolatedMargin:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doAdjustIsolatedMargin(operation)
+		}
+	}
+	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
+}
+
+// bitmex reports amounts in the smallest unit of the currency, XBt (satoshi) or USDt
+func currencyUnit(currency string) float64 {
+	switch currency {
+	case "XBt":
+		return 1e-8
+	case "USDt":
+		return 1e-6
+	}
+	return 1.0
+}
+
+// XBt -> XBT, USDt -> USDT
+func (e *Bitmex) getCoinByCurrency(currency string) *coin.Coin {
+	symbol := strings.ToUpper(currency)
+	if c := e.GetCoinBySymbol(symbol); c != nil {
+		return c
+	}
+	return coin.GetCoin(symbol)
+}
+
+// margin balances of the XBt and USDt wallets
+func (e *Bitmex) doAllBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	userMargin := UserMargin{}
+	errResponse := ErrorResponse{}
+	strRequest := "/user/margin"
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = "all"
+
+	jsonAllBalanceReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonAllBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonAllBalanceReturn), &userMargin); err != nil {
+		if err := json.Unmarshal([]byte(jsonAllBalanceReturn), &errResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doAllBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonAllBalanceReturn)
+		} else {
+			operation.Error = fmt.Errorf("%s doAllBalance Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		}
+		return operation.Error
+	}
+
+	operation.BalanceList = []exchange.AssetBalance{}
+	for _, balance := range userMargin {
+		c := e.getCoinByCurrency(balance.Currency)
+		if c == nil {
+			continue
+		}
+
+		unit := currencyUnit(balance.Currency)
+		b := exchange.AssetBalance{
+			Coin:             c,
+			Balance:          float64(balance.MarginBalance) * unit,
+			BalanceAvailable: float64(balance.AvailableMargin) * unit,
+		}
+		b.BalanceFrozen = b.Balance - b.BalanceAvailable
+		operation.BalanceList = append(operation.BalanceList, b)
+	}
+
+	return nil
+}
+
+// type: TRADE_LIMIT, TRADE_MARKET, Trade_STOP_LIMIT, Trade_STOP_MARKET, Trade_TAKE_PROFIT (LimitIfTouched),
+// Trade_TAKE_PROFIT_MARKET (MarketIfTouched), Trade_TRAILING_STOP_MARKET and Trade_PEGGED.
+// Quantity in contracts, stop and if-touched orders need 'StopRate', trailing and pegged orders 'PegOffset'
+func (e *Bitmex) doPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Pair == nil {
+		return fmt.Errorf("%s PlaceOrder Pair is empty", e.GetName())
+	} else if operation.OrderDirection == "" {
+		return fmt.Errorf("%s PlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.TradeType == "" {
+		return fmt.Errorf("%s PlaceOrder empty TradeType: %+v", e.GetName(), operation)
+	}
+
+	placeOrder := PlaceOrder{}
+	errResponse := ErrorResponse{}
+	strRequest := "/order"
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	pairConstraint := e.GetPairConstraint(operation.Pair)
+	mapParams["orderQty"] = pairConstraint.FormatQuantity(operation.Quantity)
+	if operation.OrderDirection == exchange.Buy {
+		mapParams["side"] = "Buy"
+	} else if operation.OrderDirection == exchange.Sell {
+		mapParams["side"] = "Sell"
+	}
+
+	switch operation.TradeType {
+	case exchange.TRADE_LIMIT:
+		mapParams["ordType"] = "Limit"
+	case exchange.TRADE_MARKET:
+		mapParams["ordType"] = "Market"
+	case exchange.Trade_STOP_LIMIT:
+		mapParams["ordType"] = "StopLimit"
+	case exchange.Trade_STOP_MARKET:
+		mapParams["ordType"] = "Stop"
+	case exchange.Trade_TAKE_PROFIT:
+		mapParams["ordType"] = "LimitIfTouched"
+	case exchange.Trade_TAKE_PROFIT_MARKET:
+		mapParams["ordType"] = "MarketIfTouched"
+	case exchange.Trade_TRAILING_STOP_MARKET:
+		mapParams["ordType"] = "Stop"
+		mapParams["pegPriceType"] = "TrailingStopPeg"
+		mapParams["pegOffsetValue"] = pairConstraint.FormatRate(operation.PegOffset)
+	case exchange.Trade_PEGGED:
+		mapParams["ordType"] = "Pegged"
+		mapParams["pegPriceType"] = "PrimaryPeg"
+		mapParams["pegOffsetValue"] = pairConstraint.FormatRate(operation.PegOffset)
+	default:
+		return fmt.Errorf("%s PlaceOrder invalid TradeType: %v", e.GetName(), operation.TradeType)
+	}
+
+	switch operation.TradeType {
+	case exchange.TRADE_LIMIT, exchange.Trade_STOP_LIMIT, exchange.Trade_TAKE_PROFIT:
+		mapParams["price"] = pairConstraint.FormatRate(operation.Rate)
+	}
+	switch operation.TradeType {
+	case exchange.Trade_STOP_LIMIT, exchange.Trade_STOP_MARKET, exchange.Trade_TAKE_PROFIT, exchange.Trade_TAKE_PROFIT_MARKET:
+		mapParams["stopPx"] = pairConstraint.FormatRate(operation.StopRate)
+	}
+
+	switch operation.OrderType {
+	case exchange.GTC:
+		mapParams["timeInForce"] = "GoodTillCancel"
+	case exchange.IOC:
+		mapParams["timeInForce"] = "ImmediateOrCancel"
+	case exchange.FOK:
+		mapParams["timeInForce"] = "FillOrKill"
+	case exchange.GTX:
+		mapParams["execInst"] = "ParticipateDoNotInitiate"
+	}
+
+	jsonPlaceReturn := e.ApiKeyPost(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &errResponse); err == nil && errResponse.Error.Message != "" {
+		operation.Error = fmt.Errorf("%s PlaceOrder Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		return operation.Error
+	}
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s PlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if placeOrder.OrderID == "" {
+		operation.Error = fmt.Errorf("%s PlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	}
+
+	operation.Order = e.toOrder(&placeOrder)
+	operation.Order.JsonResponse = jsonPlaceReturn
+
+	return nil
+}
+
+func (e *Bitmex) doOrderStatus(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Order == nil {
+		return fmt.Errorf("%s OrderStatus Order is empty", e.GetName())
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["filter"] = fmt.Sprintf(`{"orderID":"%s"}`, operation.Order.OrderID)
+
+	orders, err := e.getOrders(operation, mapParams)
+	if err != nil {
+		return err
+	} else if len(orders) == 0 {
+		operation.Error = fmt.Errorf("%s OrderStatus Could not find Order: %v", e.GetName(), operation.Order.OrderID)
+		return operation.Error
+	}
+
+	// order pointer from operation
+	order := operation.Order
+	order.Status = orders[0].Status
+	order.Direction = orders[0].Direction
+	order.DealRate = orders[0].DealRate
+	order.DealQuantity = orders[0].DealQuantity
+
+	return nil
+}
+
+func (e *Bitmex) doCancelOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Order == nil {
+		return fmt.Errorf("%s CancelOrder Order is empty", e.GetName())
+	}
+
+	cancelOrder := CancelOrder{}
+	errResponse := ErrorResponse{}
+	strRequest := "/order"
+
+	mapParams := make(map[string]string)
+	mapParams["orderID"] = operation.Order.OrderID
+
+	jsonCancelOrder := e.ApiKeyRequest("DELETE", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonCancelOrder
+	}
+
+	if err := json.Unmarshal([]byte(jsonCancelOrder), &cancelOrder); err != nil {
+		if err := json.Unmarshal([]byte(jsonCancelOrder), &errResponse); err != nil {
+			operation.Error = fmt.Errorf("%s CancelOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonCancelOrder)
+		} else {
+			operation.Error = fmt.Errorf("%s CancelOrder Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		}
+		return operation.Error
+	} else if len(cancelOrder) == 0 || cancelOrder[0].Error != "" {
+		operation.Error = fmt.Errorf("%s CancelOrder Failed: %v", e.GetName(), jsonCancelOrder)
+		return operation.Error
+	}
+
+	operation.Order.Status = exchange.Canceling
+	operation.Order.CancelStatus = jsonCancelOrder
+
+	return nil
+}
+
+// every call restarts the countdown, the client is expected to call it again well before CancelTimeout
+func (e *Bitmex) doCancelAllAfter(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.CancelTimeout < 0 {
+		return fmt.Errorf("%s CancelAllAfter invalid CancelTimeout: %v", e.GetName(), operation.CancelTimeout)
+	}
+
+	errResponse := ErrorResponse{}
+	strRequest := "/order/cancelAllAfter"
+
+	mapParams := make(map[string]string)
+	mapParams["timeout"] = strconv.FormatInt(operation.CancelTimeout, 10)
+
+	jsonCancelAllAfter := e.ApiKeyPost(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonCancelAllAfter
+	}
+
+	if err := json.Unmarshal([]byte(jsonCancelAllAfter), &errResponse); err != nil {
+		operation.Error = fmt.Errorf("%s CancelAllAfter Json Unmarshal Err: %v, %s", e.GetName(), err, jsonCancelAllAfter)
+		return operation.Error
+	} else if errResponse.Error.Message != "" {
+		operation.Error = fmt.Errorf("%s CancelAllAfter Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		return operation.Error
+	}
+
+	return nil
+}
+
+func (e *Bitmex) doGetOpenOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["filter"] = `{"open":true}`
+	mapParams["count"] = "500"
+	if operation.Pair != nil {
+		mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	}
+
+	orders, err := e.getOrders(operation, mapParams)
+	if err != nil {
+		return err
+	}
+	operation.OpenOrders = orders
+
+	return nil
+}
+
+// the latest 500 closed orders, StartTime and EndTime in ms
+func (e *Bitmex) doGetOrderHistory(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["count"] = "500"
+	mapParams["reverse"] = "true"
+	if operation.Pair != nil {
+		mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	}
+	setTimeRange(operation, mapParams)
+
+	orders, err := e.getOrders(operation, mapParams)
+	if err != nil {
+		return err
+	}
+
+	operation.OrderHistory = []*exchange.Order{}
+	for _, order := range orders {
+		if order.Status != exchange.New && order.Status != exchange.Partial {
+			operation.OrderHistory = append(operation.OrderHistory, order)
+		}
+	}
+
+	return nil
+}
+
+// the latest 500 fills, StartTime and EndTime in ms
+func (e *Bitmex) doGetExecutionHistory(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	executions := Execution{}
+	errResponse := ErrorResponse{}
+	strRequest := "/execution/tradeHistory"
+
+	mapParams := make(map[string]string)
+	mapParams["count"] = "500"
+	mapParams["reverse"] = "true"
+	if operation.Pair != nil {
+		mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	}
+	setTimeRange(operation, mapParams)
+
+	jsonExecutionReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonExecutionReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonExecutionReturn), &executions); err != nil {
+		if err := json.Unmarshal([]byte(jsonExecutionReturn), &errResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doGetExecutionHistory Json Unmarshal Err: %v, %s", e.GetName(), err, jsonExecutionReturn)
+		} else {
+			operation.Error = fmt.Errorf("%s doGetExecutionHistory Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		}
+		return operation.Error
+	}
+
+	operation.ExecutionHistory = []*exchange.Execution{}
+	for _, ex := range executions {
+		if ex.ExecType != "Trade" {
+			continue
+		}
+
+		execution := &exchange.Execution{
+			ID:         ex.ExecID,
+			OrderID:    ex.OrderID,
+			Pair:       e.GetPairBySymbol(ex.Symbol),
+			Instrument: ex.Symbol,
+			Rate:       ex.LastPx,
+			Quantity:   ex.LastQty,
+			Fee:        float64(ex.ExecComm) * currencyUnit(ex.SettlCurrency),
+			FeeCoin:    e.getCoinByCurrency(ex.SettlCurrency),
+			Maker:      ex.LastLiquidityInd == "AddedLiquidity",
+			Timestamp:  ex.TransactTime.UnixNano() / int64(time.Millisecond),
+		}
+		if ex.Side == "Buy" {
+			execution.Direction = exchange.Buy
+		} else {
+			execution.Direction = exchange.Sell
+		}
+
+		operation.ExecutionHistory = append(operation.ExecutionHistory, execution)
+	}
+
+	return nil
+}
+
+func setTimeRange(operation *exchange.AccountOperation, mapParams map[string]string) {
+	if operation.StartTime != 0 {
+		mapParams["startTime"] = time.Unix(0, operation.StartTime*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+	}
+	if operation.EndTime != 0 {
+		mapParams["endTime"] = time.Unix(0, operation.EndTime*int64(time.Millisecond)).UTC().Format(time.RFC3339)
+	}
+}
+
+// getOrders queries /order and converts the result to exchange orders
+func (e *Bitmex) getOrders(operation *exchange.AccountOperation, mapParams map[string]string) ([]*exchange.Order, error) {
+	orderList := []PlaceOrder{}
+	errResponse := ErrorResponse{}
+	strRequest := "/order"
+
+	jsonOrdersReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonOrdersReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonOrdersReturn), &orderList); err != nil {
+		if err := json.Unmarshal([]byte(jsonOrdersReturn), &errResponse); err != nil {
+			operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonOrdersReturn)
+		} else {
+			operation.Error = fmt.Errorf("%s %s Failed: %v %v", e.GetName(), operation.Type, errResponse.Error.Name, errResponse.Error.Message)
+		}
+		return nil, operation.Error
+	}
+
+	orders := []*exchange.Order{}
+	for i := range orderList {
+		orders = append(orders, e.toOrder(&orderList[i]))
+	}
+
+	return orders, nil
+}
+
+func (e *Bitmex) toOrder(o *PlaceOrder) *exchange.Order {
+	order := &exchange.Order{
+		EX:           e.GetName(),
+		Pair:         e.GetPairBySymbol(o.Symbol),
+		OrderID:      o.OrderID,
+		Rate:         o.Price,
+		Quantity:     o.OrderQty,
+		DealRate:     o.AvgPx,
+		DealQuantity: o.CumQty,
+		Timestamp:    o.Timestamp.UnixNano() / int64(time.Millisecond),
+	}
+
+	switch o.Side {
+	case "Buy":
+		order.Direction = exchange.Buy
+	case "Sell":
+		order.Direction = exchange.Sell
+	}
+
+	switch o.OrdStatus {
+	case "New":
+		order.Status = exchange.New
+	case "PartiallyFilled":
+		order.Status = exchange.Partial
+	case "Filled":
+		order.Status = exchange.Filled
+	case "Canceled":
+		order.Status = exchange.Cancelled
+	case "Rejected":
+		order.Status = exchange.Rejected
+	case "Expired":
+		order.Status = exchange.Expired
+	default:
+		order.Status = exchange.Other
+	}
+	if o.OrdRejReason != "" {
+		order.StatusMessage = o.OrdRejReason
+	}
+
+	return order
+}
+
+func (e *Bitmex) doGetPositions(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	positions := Position{}
+	errResponse := ErrorResponse{}
+	strRequest := "/position"
+
+	mapParams := make(map[string]string)
+	if operation.Pair != nil {
+		mapParams["filter"] = fmt.Sprintf(`{"symbol":"%s"}`, e.GetSymbolByPair(operation.Pair))
+	}
+
+	jsonPositionReturn := e.ApiKeyGet(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPositionReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPositionReturn), &positions); err != nil {
+		if err := json.Unmarshal([]byte(jsonPositionReturn), &errResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doGetPositions Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPositionReturn)
+		} else {
+			operation.Error = fmt.Errorf("%s doGetPositions Failed: %v %v", e.GetName(), errResponse.Error.Name, errResponse.Error.Message)
+		}
+		return operation.Error
+	}
+
+	operation.Positions = []*exchange.Position{}
+	for _, p := range positions {
+		if !p.IsOpen || p.CurrentQty == 0 {
+			continue
+		}
+
+		// PnL is reported in the smallest unit of the settle currency
+		unit := currencyUnit(p.Currency)
+
+		position := &exchange.Position{
+			Pair:             e.GetPairBySymbol(p.Symbol),
+			Instrument:       p.Symbol,
+			Size:             math.Abs(p.CurrentQty),
+			EntryPrice:       p.AvgEntryPrice,
+			MarkPrice:        p.MarkPrice,
+			LiquidationPrice: p.LiquidationPrice,
+			Leverage:         p.Leverage,
+			UnrealizedPnl:    p.UnrealisedPnl * unit,
+			RealizedPnl:      p.RealisedPnl * unit,
+			Timestamp:        p.Timestamp.UnixNano() / int64(1e6),
+		}
+		if p.CurrentQty > 0 {
+			position.Side = exchange.PositionLong
+		} else {
+			position.Side = exchange.PositionShort
+		}
+		if p.CrossMargin {
+			position.MarginMode = exchange.CrossMargin
+		} else {
+			position.MarginMode = exchange.IsolatedMargin
+		}
+
+		operation.Positions = append(operation.Positions, position)
+	}
+
+	return nil
+}
+
+// leverage 0 switches the position to cross margin on bitmex
+func (e *Bitmex) doSetLeverage(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s SetLeverage Pair is empty", e.GetName())
+	} else if operation.Leverage < 0 || operation.Leverage > 100 {
+		return fmt.Errorf("%s SetLeverage is between 0 to 100: %d", e.GetName(), operation.Leverage)
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	mapParams["leverage"] = fmt.Sprintf("%d", operation.Leverage)
+
+	return e.positionRequest(operation, "/position/leverage", mapParams)
+}
+
+func (e *Bitmex) doSetMarginType(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s SetMarginType Pair is empty", e.GetName())
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	switch operation.MarginMode {
+	case exchange.IsolatedMargin:
+		mapParams["enabled"] = "true"
+	case exchange.CrossMargin:
+		mapParams["enabled"] = "false"
+	default:
+		return fmt.Errorf("%s SetMarginType invalid MarginMode: %v", e.GetName(), operation.MarginMode)
+	}
+
+	return e.positionRequest(operation, "/position/isolate", mapParams)
+}
+
+// bitmex positions are always one-way
+func (e *Bitmex) doSetPositionMode(operation *exchange.AccountOperation) error {
+	if operation.PositionMode != exchange.OneWayMode {
+		return fmt.Errorf("%s SetPositionMode %v: %w", e.GetName(), operation.PositionMode, exchange.ErrNotSupported)
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		PositionMode: exchange.OneWayMode,
+	}
+
+	return nil
+}
+
+// MarginAmount in XBT or USDT, converted to the smallest unit of the settle currency
+func (e *Bitmex) doAdjustIsolatedMargin(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s AdjustIsolatedMargin Pair is empty", e.GetName())
+	} else if operation.MarginAmount == 0 {
+		return fmt.Errorf("%s AdjustIsolatedMargin MarginAmount is 0", e.GetName())
+	}
+
+	unit := 1e8
+	if operation.Pair.Base.Code == "USDT" {
+		unit = 1e6
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	mapParams["amount"] = fmt.Sprintf("%d", int64(math.Round(operation.MarginAmount*unit)))
+
+	return e.positionRequest(operation, "/position/transferMargin", mapParams)
+}
+
+// positionRequest posts the position setting and fills LeverageSetting with the returned position
+func (e *Bitmex) positionRequest(operation *exchange.AccountOperation, strRequest string, mapParams map[string]string) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	position := PositionDetail{}
+	errResponse := ErrorResponse{}
+
+	jsonPositionReturn := e.ApiKeyPost(mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPositionReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPositionReturn), &errResponse); err == nil && errResponse.Error.Message != "" {
+		operation.Error = fmt.Errorf("%s %s Failed: %v %v", e.GetName(), operation.Type, errResponse.Error.Name, errResponse.Error.Message)
+		return operation.Error
+	}
+	if err := json.Unmarshal([]byte(jsonPositionReturn), &position); err != nil {
+		operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonPositionReturn)
+		return operation.Error
+	} else if position.Symbol == "" {
+		operation.Error = fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, jsonPositionReturn)
+		return operation.Error
+	}
+
+	unit := currencyUnit(position.Currency)
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:         e.GetPairBySymbol(position.Symbol),
+		Instrument:   position.Symbol,
+		Leverage:     position.Leverage,
+		PositionMode: exchange.OneWayMode,
+	}
+	if position.CrossMargin {
+		operation.LeverageSetting.MarginMode = exchange.CrossMargin
+	} else {
+		operation.LeverageSetting.MarginMode = exchange.IsolatedMargin
+		operation.LeverageSetting.IsolatedMargin = position.PosMargin * unit
+	}
+
+	return nil
+}
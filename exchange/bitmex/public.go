@@ -0,0 +1,170 @@
+package bitmex
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
+)
+
+/*************** PUBLIC  API ***************/
+func (e *Bitmex) LoadPublicData(operation *exchange.PublicOperation) error {
+	switch operation.Type {
+	case exchange.FundingRate:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doFundingRate(operation)
+		}
+	case exchange.KLine:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doContractKline(operation)
+		}
+	}
+	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
+}
+
+// fundingRate is charged at fundingTimestamp, indicativeFundingRate is the estimate for the period after
+func (e *Bitmex) doFundingRate(operation *exchange.PublicOperation) error {
+	instrument := Instrument{}
+	funding := Funding{}
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/instrument?symbol=%s", API_URL, symbol),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &instrument); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if len(instrument) == 0 {
+		operation.Error = fmt.Errorf("%s doFundingRate got empty return: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+
+	operation.FundingRate = &exchange.FundingRateDetail{
+		Pair:            operation.Pair,
+		Instrument:      symbol,
+		CurrentRate:     instrument[0].FundingRate,
+		PredictedRate:   instrument[0].IndicativeFundingRate,
+		NextFundingTime: instrument[0].FundingTimestamp.UnixNano() / int64(time.Millisecond),
+		History:         []*exchange.FundingRateHistory{},
+	}
+
+	get = &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/funding?symbol=%s&count=500&reverse=true", API_URL, symbol),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if operation.FundingStartTime != 0 {
+		get.URI += "&startTime=" + url.QueryEscape(time.Unix(0, operation.FundingStartTime*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+	}
+	if operation.FundingEndTime != 0 {
+		get.URI += "&endTime=" + url.QueryEscape(time.Unix(0, operation.FundingEndTime*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &funding); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	}
+
+	// reverse=true returns the newest first, history is kept in time order
+	for i := len(funding) - 1; i >= 0; i-- {
+		operation.FundingRate.History = append(operation.FundingRate.History, &exchange.FundingRateHistory{
+			Rate:      funding[i].FundingRate,
+			Timestamp: funding[i].Timestamp.UnixNano() / int64(time.Millisecond),
+		})
+	}
+
+	return nil
+}
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:  "1m",
+	exchange.KLINE_5MIN:  "5m",
+	exchange.KLINE_1HOUR: "1h",
+	exchange.KLINE_1DAY:  "1d",
+}
+
+// the timestamp of a bucket is its close time, the kline opens one interval before
+func (e *Bitmex) doContractKline(operation *exchange.PublicOperation) error {
+	binSize, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	interval := operation.KlineInterval
+	if interval == "" {
+		interval = exchange.DEFAULT_KLINE_INTERVAL
+	}
+	duration := int64(interval.Duration() / time.Millisecond)
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	return exchange.FetchKline(operation, 1000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		tradeBin := TradeBin{}
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/trade/bucketed?binSize=%s&symbol=%s&count=1000&partial=false", API_URL, binSize, symbol),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if start != 0 {
+			get.URI += "&startTime=" + url.QueryEscape(time.Unix(0, (start+duration)*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+		} else {
+			// the latest klines
+			get.URI += "&reverse=true"
+		}
+		if end != 0 {
+			get.URI += "&endTime=" + url.QueryEscape(time.Unix(0, (end+duration)*int64(time.Millisecond)).UTC().Format(time.RFC3339))
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &tradeBin); err != nil {
+			return nil, fmt.Errorf("%s doContractKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, bin := range tradeBin {
+			details = append(details, &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: float64(bin.Timestamp.UnixNano()/int64(time.Millisecond) - duration),
+				Open:     bin.Open,
+				High:     bin.High,
+				Low:      bin.Low,
+				Close:    bin.Close,
+				Volume:   bin.Volume,
+			})
+		}
+		return details, nil
+	})
+}
@@ -21,8 +21,9 @@ import (
 	"strconv"
 )
 
-const (
-	API_URL string = "https://api.expie.com"
+var (
+	API_URL   string = "https://api.expie.com"
+	LOGIN_URL string = "https://pieopen.getcai.com"
 )
 
 /*API Base Knowledge
@@ -358,10 +359,15 @@ func (e *Bitpie) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonResponse := &JsonResponse{}
 	uuid := Uuid{}
@@ -395,10 +401,15 @@ func (e *Bitpie) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonResponse := &JsonResponse{}
 	uuid := Uuid{}
@@ -551,7 +562,7 @@ func (e *Bitpie) TestAuth() string {
 	// these api has been suspended
 	// strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
 	// 获取登陆二维码
-	strUrl := "https://pieopen.getcai.com" + "/api/v1/open/third/party/login/qr"
+	strUrl := LOGIN_URL + "/api/v1/open/third/party/login/qr"
 	// strUrl := "https://pieopen.getcai.com" + "/api/v1/open/third/party/login/qr" + "?" + exchange.Map2UrlQuery(mapParams)
 	// 获取登陆二维码状态
 	// strUrl := "https://pieopen.getcai.com" + "/api/v1/open/third/party/login/query/" + "3ff92e9739dd91accaab394b16aea5d161887ca8b4899fc994b337328d889fdb"
@@ -249,6 +249,19 @@ func (e *Bitpie) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bitpie) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitpie) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -248,6 +248,19 @@ func (e *Bitrue) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bitrue) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitrue) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
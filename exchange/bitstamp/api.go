@@ -19,7 +19,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://www.bitstamp.net/api/v2"
 )
 
@@ -247,6 +247,18 @@ func (e *Bitstamp) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrain
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.Orderbook:    {exchange.SpotWallet},
+		exchange.KLine:        {exchange.SpotWallet},
+	},
+}
+
+func (e *Bitstamp) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitstamp) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -43,3 +43,22 @@ type TradeHistory []struct {
 	Type   string `json:"type"`
 	Amount string `json:"amount"`
 }
+
+type KlineResponse struct {
+	Data struct {
+		Pair string `json:"pair"`
+		Ohlc []struct {
+			Timestamp string `json:"timestamp"`
+			Open      string `json:"open"`
+			High      string `json:"high"`
+			Low       string `json:"low"`
+			Close     string `json:"close"`
+			Volume    string `json:"volume"`
+		} `json:"ohlc"`
+	} `json:"data"`
+	Code   string `json:"code"`
+	Errors []struct {
+		Field   string `json:"field"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
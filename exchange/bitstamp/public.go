@@ -19,6 +19,11 @@ func (e *Bitstamp) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doSpotOrderBook(operation)
 		}
+	case exchange.KLine:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doSpotKline(operation)
+		}
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
@@ -132,3 +137,82 @@ func (e *Bitstamp) doSpotOrderBook(op *exchange.PublicOperation) error {
 	op.Maker = maker
 	return nil
 }
+
+// step in seconds
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "60",
+	exchange.KLINE_3MIN:   "180",
+	exchange.KLINE_5MIN:   "300",
+	exchange.KLINE_15MIN:  "900",
+	exchange.KLINE_30MIN:  "1800",
+	exchange.KLINE_1HOUR:  "3600",
+	exchange.KLINE_2HOUR:  "7200",
+	exchange.KLINE_4HOUR:  "14400",
+	exchange.KLINE_6HOUR:  "21600",
+	exchange.KLINE_12HOUR: "43200",
+	exchange.KLINE_1DAY:   "86400",
+	exchange.KLINE_3DAY:   "259200",
+}
+
+func (e *Bitstamp) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	return exchange.FetchKline(operation, 1000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		kline := KlineResponse{}
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/ohlc/%s/?step=%s&limit=1000", API_URL, symbol, interval),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&start=%v", start/1000)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&end=%v", end/1000)
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &kline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		} else if len(kline.Errors) != 0 || kline.Code != "" {
+			return nil, fmt.Errorf("%s doSpotKline Failed: %s", e.GetName(), get.ResponseBody)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, k := range kline.Data.Ohlc {
+			values := make([]float64, 6)
+			for j, v := range []string{k.Timestamp, k.Open, k.High, k.Low, k.Close, k.Volume} {
+				value, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%s doSpotKline parse Err: %v %v", e.GetName(), err, v)
+				}
+				values[j] = value
+			}
+
+			details = append(details, &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: values[0] * 1000,
+				Open:     values[1],
+				High:     values[2],
+				Low:      values[3],
+				Close:    values[4],
+				Volume:   values[5],
+			})
+		}
+		return details, nil
+	})
+}
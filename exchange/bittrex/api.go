@@ -19,8 +19,9 @@ import (
 	"strconv"
 )
 
-const (
-	API_URL string = "https://bittrex.com/api"
+var (
+	API_URL    string = "https://bittrex.com/api"
+	API_V3_URL string = "https://api.bittrex.com/v3"
 )
 
 /*API Base Knowledge
@@ -337,10 +338,15 @@ func (e *Bittrex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonResponse := &JsonResponse{}
 	uuid := Uuid{}
@@ -374,10 +380,15 @@ func (e *Bittrex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonResponse := &JsonResponse{}
 	uuid := Uuid{}
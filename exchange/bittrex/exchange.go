@@ -247,6 +247,29 @@ func (e *Bittrex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainF
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory:  {exchange.SpotWallet},
+		exchange.CoinChainType: {exchange.SpotWallet},
+		exchange.Orderbook:     {exchange.SpotWallet},
+		exchange.KLine:         {exchange.SpotWallet},
+		exchange.Ticker:        {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bittrex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bittrex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
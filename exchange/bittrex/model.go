@@ -6,6 +6,7 @@ package bittrex
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/bitontop/gored/exchange"
 )
@@ -97,3 +98,35 @@ type TradeHistory []struct {
 	OrderType string  `json:"OrderType"`
 	UUID      string  `json:"Uuid"`
 }
+
+type Kline []struct {
+	StartsAt    time.Time `json:"startsAt"`
+	Open        string    `json:"open"`
+	High        string    `json:"high"`
+	Low         string    `json:"low"`
+	Close       string    `json:"close"`
+	Volume      string    `json:"volume"`
+	QuoteVolume string    `json:"quoteVolume"`
+}
+
+type MarketSummaries []struct {
+	Symbol        string    `json:"symbol"`
+	High          string    `json:"high"`
+	Low           string    `json:"low"`
+	Volume        string    `json:"volume"`
+	QuoteVolume   string    `json:"quoteVolume"`
+	PercentChange string    `json:"percentChange"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type MarketTickers []struct {
+	Symbol        string `json:"symbol"`
+	LastTradeRate string `json:"lastTradeRate"`
+	BidRate       string `json:"bidRate"`
+	AskRate       string `json:"askRate"`
+}
+
+type ErrResponseV3 struct {
+	Code   string `json:"code"`
+	Detail string `json:"detail"`
+}
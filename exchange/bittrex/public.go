@@ -3,6 +3,8 @@ package bittrex
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bitontop/gored/exchange"
@@ -20,6 +22,14 @@ func (e *Bittrex) LoadPublicData(operation *exchange.PublicOperation) error {
 		if operation.Wallet == exchange.SpotWallet {
 			return e.doSpotOrderBook(operation)
 		}
+	case exchange.KLine:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doSpotKline(operation)
+		}
+	case exchange.Ticker:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doTicker(operation)
+		}
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
@@ -189,3 +199,208 @@ func (e *Bittrex) getCoinChainType(operation *exchange.PublicOperation) error {
 
 	return nil
 }
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:  "MINUTE_1",
+	exchange.KLINE_5MIN:  "MINUTE_5",
+	exchange.KLINE_1HOUR: "HOUR_1",
+	exchange.KLINE_1DAY:  "DAY_1",
+}
+
+// the 24h statistics and the best bid/ask are two calls of the v3 api
+func (e *Bittrex) doTicker(operation *exchange.PublicOperation) error {
+	summaries := MarketSummaries{}
+	tickers := MarketTickers{}
+
+	for _, call := range []struct {
+		path   string
+		result interface{}
+	}{
+		{"summaries", &summaries},
+		{"tickers", &tickers},
+	} {
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/markets/%s", API_V3_URL, call.path),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			operation.Error = err
+			return operation.Error
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, call.result); err != nil {
+			errResponse := ErrResponseV3{}
+			if json.Unmarshal(get.ResponseBody, &errResponse) == nil && errResponse.Code != "" {
+				operation.Error = fmt.Errorf("%s doTicker Failed: %s", e.GetName(), get.ResponseBody)
+				return operation.Error
+			}
+			operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+			return operation.Error
+		}
+	}
+
+	details := make(map[string]*exchange.TickerDetail)
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, s := range summaries {
+		// v3 market symbol is target-base, eg. LTC-BTC
+		coins := strings.Split(s.Symbol, "-")
+		if len(coins) != 2 {
+			continue
+		}
+		p := e.GetPairBySymbol(fmt.Sprintf("%s-%s", coins[1], coins[0]))
+		if p == nil {
+			continue
+		}
+
+		values, err := exchange.ParseFloats(s.High, s.Low, s.Volume, s.QuoteVolume, s.PercentChange)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), s.Symbol, err)
+			return operation.Error
+		}
+
+		detail := &exchange.TickerDetail{
+			Pair:          p,
+			High:          values[0],
+			Low:           values[1],
+			Volume:        values[2],
+			QuoteVolume:   values[3],
+			ChangePercent: values[4],
+			Timestamp:     s.UpdatedAt.UnixNano() / 1e6,
+		}
+		details[s.Symbol] = detail
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	for _, t := range tickers {
+		detail, ok := details[t.Symbol]
+		if !ok {
+			continue
+		}
+
+		values, err := exchange.ParseFloats(t.LastTradeRate, t.BidRate, t.AskRate)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), t.Symbol, err)
+			return operation.Error
+		}
+		detail.Last, detail.BestBid, detail.BestAsk = values[0], values[1], values[2]
+	}
+
+	for _, detail := range operation.Ticker {
+		detail.Complete()
+	}
+
+	return nil
+}
+
+func (e *Bittrex) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	// v3 market symbol is target-base, eg. LTC-BTC
+	symbol := fmt.Sprintf("%s-%s", e.GetSymbolByCoin(operation.Pair.Target), e.GetSymbolByCoin(operation.Pair.Base))
+
+	// the candles of a time range are in the historical files of a year, month or day, depending on the interval
+	return exchange.FetchKline(operation, 0, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		paths := []string{"recent"}
+		if start != 0 {
+			paths = klineHistoricalPaths(interval, start, end)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, path := range paths {
+			kline := Kline{}
+
+			get := &utils.HttpGet{
+				URI:       fmt.Sprintf("%s/markets/%s/candles/TRADE/%s/%s", API_V3_URL, symbol, interval, path),
+				Proxy:     operation.Proxy,
+				DebugMode: operation.DebugMode,
+			}
+			if err := utils.HttpGetRequest(get); err != nil {
+				return nil, err
+			}
+
+			if operation.DebugMode {
+				operation.RequestURI = get.URI
+				operation.CallResponce = string(get.ResponseBody)
+			}
+
+			if err := json.Unmarshal(get.ResponseBody, &kline); err != nil {
+				errResponse := ErrResponseV3{}
+				if json.Unmarshal(get.ResponseBody, &errResponse) == nil && errResponse.Code != "" {
+					return nil, fmt.Errorf("%s doSpotKline Failed: %s", e.GetName(), get.ResponseBody)
+				}
+				return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+			}
+
+			for _, k := range kline {
+				values := make([]float64, 5)
+				for j, v := range []string{k.Open, k.High, k.Low, k.Close, k.Volume} {
+					value, err := strconv.ParseFloat(v, 64)
+					if err != nil {
+						return nil, fmt.Errorf("%s doSpotKline parse Err: %v %v", e.GetName(), err, v)
+					}
+					values[j] = value
+				}
+
+				details = append(details, &exchange.KlineDetail{
+					Exchange: e.GetName(),
+					Pair:     operation.Pair.Name,
+					OpenTime: float64(k.StartsAt.UnixNano() / 1e6),
+					Open:     values[0],
+					High:     values[1],
+					Low:      values[2],
+					Close:    values[3],
+					Volume:   values[4],
+				})
+			}
+		}
+		return details, nil
+	})
+}
+
+// klineHistoricalPaths are the historical years (DAY_1), months (HOUR_1) or days (MINUTE_*) from start to end,
+// "recent" instead of the current one
+func klineHistoricalPaths(interval string, start, end int64) []string {
+	now := time.Now().UTC()
+	if end == 0 || end > now.UnixNano()/1e6 {
+		end = now.UnixNano() / 1e6
+	}
+	from, to := time.Unix(0, start*1e6).UTC(), time.Unix(0, end*1e6).UTC()
+
+	var truncate func(t time.Time) time.Time
+	var next func(t time.Time) time.Time
+	var format string
+	switch interval {
+	case "DAY_1":
+		truncate = func(t time.Time) time.Time { return time.Date(t.Year(), 1, 1, 0, 0, 0, 0, time.UTC) }
+		next = func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }
+		format = "2006"
+	case "HOUR_1":
+		truncate = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC) }
+		next = func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }
+		format = "2006/1"
+	default:
+		truncate = func(t time.Time) time.Time { return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC) }
+		next = func(t time.Time) time.Time { return t.AddDate(0, 0, 1) }
+		format = "2006/1/2"
+	}
+
+	paths := []string{}
+	current := truncate(now)
+	for t := truncate(from); !t.After(to); t = next(t) {
+		if !t.Before(current) {
+			paths = append(paths, "recent")
+			break
+		}
+		paths = append(paths, "historical/"+t.Format(format))
+	}
+	return paths
+}
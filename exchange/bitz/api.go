@@ -20,7 +20,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	// API_URL string = "https://apiv2.bit-z.pro" // lookup apiv2.bit-z.pro: no such host
 	API_URL string = "https://apiv2.bitz.com"
 )
@@ -371,13 +371,18 @@ func (e *Bitz) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key, Secret Key or TradePassword are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/Trade/addEntrustSheet"
 
 	mapParams := make(map[string]string)
-	mapParams["number"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["number"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["type"] = "2"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["tradePwd"] = e.TradePassword
@@ -411,13 +416,18 @@ func (e *Bitz) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key, Secret Key or TradePassword are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequest := "/Trade/addEntrustSheet"
 
 	mapParams := make(map[string]string)
-	mapParams["number"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["number"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["type"] = "1"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["tradePwd"] = e.TradePassword
@@ -249,6 +249,26 @@ func (e *Bitz) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.Orderbook:    {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bitz) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bitz) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
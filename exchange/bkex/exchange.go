@@ -249,6 +249,28 @@ func (e *Bkex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.Orderbook:    {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList: {exchange.SpotWallet},
+		exchange.Balance:     {exchange.SpotWallet},
+		exchange.Withdraw:    {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bkex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bkex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
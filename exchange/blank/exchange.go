@@ -249,6 +249,19 @@ func (e *Blank) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Blank) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Blank) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -249,6 +249,19 @@ func (e *Blocktrade) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constra
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Blocktrade) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Blocktrade) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
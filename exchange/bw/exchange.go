@@ -249,6 +249,19 @@ func (e *Bw) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetchM
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Bw) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bw) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
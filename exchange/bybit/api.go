@@ -7,10 +7,12 @@ package bybit
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bitontop/gored/coin"
@@ -18,7 +20,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://api.bybit.com"
 )
 
@@ -48,42 +50,31 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
 func (e *Bybit) GetCoinsData() error {
-	jsonResponse := &JsonResponse{}
-	pairsData := PairsData{}
-
-	strRequestUrl := "/v2/public/symbols"
-	strUrl := API_URL + strRequestUrl
-
-	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &jsonResponse); err != nil {
-		return fmt.Errorf("%s Get Coins Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
-	} else if jsonResponse.RetCode != 0 {
-		return fmt.Errorf("%s Get Coins Failed: %v", e.GetName(), jsonResponse)
-	}
-	if err := json.Unmarshal(jsonResponse.Result, &pairsData); err != nil {
-		return fmt.Errorf("%s Get Coins Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+	instruments, err := e.getInstruments()
+	if err != nil {
+		return fmt.Errorf("%s Get Coins %v", e.GetName(), err)
 	}
 
-	for _, data := range pairsData {
+	for _, data := range instruments {
 		base := &coin.Coin{}
 		target := &coin.Coin{}
 		switch e.Source {
 		case exchange.EXCHANGE_API:
-			base = coin.GetCoin(data.QuoteCurrency)
+			base = coin.GetCoin(data.QuoteCoin)
 			if base == nil {
 				base = &coin.Coin{}
-				base.Code = data.QuoteCurrency
+				base.Code = data.QuoteCoin
 				coin.AddCoin(base)
 			}
-			target = coin.GetCoin(data.BaseCurrency)
+			target = coin.GetCoin(data.BaseCoin)
 			if target == nil {
 				target = &coin.Coin{}
-				target.Code = data.BaseCurrency
+				target.Code = data.BaseCoin
 				coin.AddCoin(target)
 			}
 		case exchange.JSON_FILE:
-			base = e.GetCoinBySymbol(data.QuoteCurrency)
-			target = e.GetCoinBySymbol(data.BaseCurrency)
+			base = e.GetCoinBySymbol(data.QuoteCoin)
+			target = e.GetCoinBySymbol(data.BaseCoin)
 		}
 
 		if base != nil {
@@ -92,7 +83,7 @@ func (e *Bybit) GetCoinsData() error {
 				coinConstraint = &exchange.CoinConstraint{
 					CoinID:       base.ID,
 					Coin:         base,
-					ExSymbol:     data.QuoteCurrency,
+					ExSymbol:     data.QuoteCoin,
 					ChainType:    exchange.MAINNET,
 					TxFee:        DEFAULT_TXFEE,
 					Withdraw:     DEFAULT_WITHDRAW,
@@ -101,7 +92,7 @@ func (e *Bybit) GetCoinsData() error {
 					Listed:       DEFAULT_LISTED,
 				}
 			} else {
-				coinConstraint.ExSymbol = data.QuoteCurrency
+				coinConstraint.ExSymbol = data.QuoteCoin
 			}
 			e.SetCoinConstraint(coinConstraint)
 		}
@@ -112,7 +103,7 @@ func (e *Bybit) GetCoinsData() error {
 				coinConstraint = &exchange.CoinConstraint{
 					CoinID:       target.ID,
 					Coin:         target,
-					ExSymbol:     data.BaseCurrency,
+					ExSymbol:     data.BaseCoin,
 					ChainType:    exchange.MAINNET,
 					TxFee:        DEFAULT_TXFEE,
 					Withdraw:     DEFAULT_WITHDRAW,
@@ -121,7 +112,7 @@ func (e *Bybit) GetCoinsData() error {
 					Listed:       DEFAULT_LISTED,
 				}
 			} else {
-				coinConstraint.ExSymbol = data.BaseCurrency
+				coinConstraint.ExSymbol = data.BaseCoin
 			}
 			e.SetCoinConstraint(coinConstraint)
 		}
@@ -134,53 +125,46 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
 func (e *Bybit) GetPairsData() error {
-	jsonResponse := &JsonResponse{}
-	pairsData := PairsData{}
-
-	strRequestUrl := "/v2/public/symbols"
-	strUrl := API_URL + strRequestUrl
-
-	jsonSymbolsReturn := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &jsonResponse); err != nil {
-		return fmt.Errorf("%s Get Pairs Json Unmarshal Err: %v %v", e.GetName(), err, jsonSymbolsReturn)
-	} else if jsonResponse.RetCode != 0 {
-		return fmt.Errorf("%s Get Pairs Failed: %v", e.GetName(), jsonResponse)
-	}
-	if err := json.Unmarshal(jsonResponse.Result, &pairsData); err != nil {
-		return fmt.Errorf("%s Get Pairs Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+	instruments, err := e.getInstruments()
+	if err != nil {
+		return fmt.Errorf("%s Get Pairs %v", e.GetName(), err)
 	}
 
-	for _, data := range pairsData {
+	for _, data := range instruments {
 		p := &pair.Pair{}
 		switch e.Source {
 		case exchange.EXCHANGE_API:
-			base := coin.GetCoin(data.QuoteCurrency)
-			target := coin.GetCoin(data.BaseCurrency)
+			base := coin.GetCoin(data.QuoteCoin)
+			target := coin.GetCoin(data.BaseCoin)
 			if base != nil && target != nil {
-
 				p = pair.GetPair(base, target)
-
 			}
 		case exchange.JSON_FILE:
-			p = e.GetPairBySymbol(data.Name)
+			p = e.GetPairBySymbol(data.Symbol)
 		}
 		if p != nil {
+			lotSize, _ := strconv.ParseFloat(data.LotSizeFilter.QtyStep, 64)
+			priceFilter, _ := strconv.ParseFloat(data.PriceFilter.TickSize, 64)
+			minTradeQuantity, _ := strconv.ParseFloat(data.LotSizeFilter.MinOrderQty, 64)
+
 			pairConstraint := e.GetPairConstraint(p)
 			if pairConstraint == nil {
 				pairConstraint = &exchange.PairConstraint{
-					PairID:      p.ID,
-					Pair:        p,
-					ExSymbol:    data.Name,
-					MakerFee:    DEFAULT_MAKER_FEE,
-					TakerFee:    DEFAULT_TAKER_FEE,
-					LotSize:     data.LotSizeFilter.QtyStep,
-					PriceFilter: math.Pow10(-1 * data.PriceScale),
-					Listed:      DEFAULT_LISTED,
+					PairID:           p.ID,
+					Pair:             p,
+					ExSymbol:         data.Symbol,
+					MakerFee:         DEFAULT_MAKER_FEE,
+					TakerFee:         DEFAULT_TAKER_FEE,
+					LotSize:          lotSize,
+					PriceFilter:      priceFilter,
+					MinTradeQuantity: minTradeQuantity,
+					Listed:           DEFAULT_LISTED,
 				}
 			} else {
-				pairConstraint.ExSymbol = data.Name
-				pairConstraint.LotSize = data.LotSizeFilter.QtyStep
-				pairConstraint.PriceFilter = math.Pow10(-1 * data.PriceScale)
+				pairConstraint.ExSymbol = data.Symbol
+				pairConstraint.LotSize = lotSize
+				pairConstraint.PriceFilter = priceFilter
+				pairConstraint.MinTradeQuantity = minTradeQuantity
 			}
 			e.SetPairConstraint(pairConstraint)
 		}
@@ -188,6 +172,54 @@ func (e *Bybit) GetPairsData() error {
 	return nil
 }
 
+// getInstruments returns the trading USDT linear and inverse perpetuals
+func (e *Bybit) getInstruments() ([]Instrument, error) {
+	instruments := []Instrument{}
+	for _, category := range []string{"linear", "inverse"} {
+		cursor := ""
+		for {
+			jsonResponse := &JsonResponseV5{}
+			instrumentsInfo := InstrumentsInfo{}
+
+			mapParams := make(map[string]string)
+			mapParams["category"] = category
+			mapParams["limit"] = "1000"
+			if cursor != "" {
+				mapParams["cursor"] = cursor
+			}
+
+			strRequestUrl := "/v5/market/instruments-info"
+			strUrl := API_URL + strRequestUrl
+
+			jsonInstrumentsReturn := exchange.HttpGetRequest(strUrl, mapParams)
+			if err := json.Unmarshal([]byte(jsonInstrumentsReturn), &jsonResponse); err != nil {
+				return nil, fmt.Errorf("Json Unmarshal Err: %v %v", err, jsonInstrumentsReturn)
+			} else if jsonResponse.RetCode != 0 {
+				return nil, fmt.Errorf("Failed: %v", jsonInstrumentsReturn)
+			}
+			if err := json.Unmarshal(jsonResponse.Result, &instrumentsInfo); err != nil {
+				return nil, fmt.Errorf("Result Unmarshal Err: %v %s", err, jsonResponse.Result)
+			}
+
+			for _, instrument := range instrumentsInfo.List {
+				if instrument.Status != "Trading" {
+					continue
+				}
+				if instrument.ContractType == "InversePerpetual" ||
+					(instrument.ContractType == "LinearPerpetual" && instrument.QuoteCoin == "USDT") {
+					instruments = append(instruments, instrument)
+				}
+			}
+
+			if instrumentsInfo.NextPageCursor == "" {
+				break
+			}
+			cursor = instrumentsInfo.NextPageCursor
+		}
+	}
+	return instruments, nil
+}
+
 /*Get Pair Market Depth
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
@@ -196,22 +228,21 @@ Step 4: Modify API Path(strRequestUrl)
 Step 5: Add Params - Depend on API request
 Step 6: Convert the response to Standard Maker struct*/
 func (e *Bybit) OrderBook(pair *pair.Pair) (*exchange.Maker, error) {
-	// Bybit do not have API for Orderbook yet
-
-	/* jsonResponse := &JsonResponse{}
+	jsonResponse := &JsonResponseV5{}
 	orderBook := OrderBook{}
 	symbol := e.GetSymbolByPair(pair)
 
 	mapParams := make(map[string]string)
-	mapParams["market"] = symbol
-	mapParams["type"] = "both"
+	mapParams["category"] = getCategory(symbol)
+	mapParams["symbol"] = symbol
+	mapParams["limit"] = "50"
 
-	strRequestUrl := "/v1.1/public/getorderbook"
+	strRequestUrl := "/v5/market/orderbook"
 	strUrl := API_URL + strRequestUrl
 
 	maker := &exchange.Maker{
 		WorkerIP:        exchange.GetExternalIP(),
-		Source:         exchange.EXCHANGE_API,
+		Source:          exchange.EXCHANGE_API,
 		BeforeTimestamp: float64(time.Now().UnixNano() / 1e6),
 	}
 
@@ -219,38 +250,60 @@ func (e *Bybit) OrderBook(pair *pair.Pair) (*exchange.Maker, error) {
 	if err := json.Unmarshal([]byte(jsonOrderbook), &jsonResponse); err != nil {
 		return nil, fmt.Errorf("%s Get Orderbook Json Unmarshal Err: %v %v", e.GetName(), err, jsonOrderbook)
 	} else if jsonResponse.RetCode != 0 {
-		return nil, fmt.Errorf("%s Get Orderbook Failed: %v", e.GetName(), jsonResponse)
+		return nil, fmt.Errorf("%s Get Orderbook Failed: %v", e.GetName(), jsonOrderbook)
 	}
 	if err := json.Unmarshal(jsonResponse.Result, &orderBook); err != nil {
 		return nil, fmt.Errorf("%s Get Orderbook Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
 	}
 
 	maker.AfterTimestamp = float64(time.Now().UnixNano() / 1e6)
-	for _, bid := range orderBook.Buy {
-		maker.Bids = append(maker.Bids, bid)
+	maker.LastUpdateID = orderBook.UpdateID
+	var err error
+	for _, bid := range orderBook.Bids {
+		buydata := exchange.Order{}
+		buydata.Quantity, err = strconv.ParseFloat(bid[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s OrderBook strconv.ParseFloat Quantity error:%v", e.GetName(), err)
+		}
+
+		buydata.Rate, err = strconv.ParseFloat(bid[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s OrderBook strconv.ParseFloat Rate error:%v", e.GetName(), err)
+		}
+		maker.Bids = append(maker.Bids, buydata)
 	}
-	for _, ask := range orderBook.Sell {
-		maker.Asks = append(maker.Asks, ask)
-	} */
-	// return maker, nil
-	return nil, nil
-}
+	for _, ask := range orderBook.Asks {
+		selldata := exchange.Order{}
+		selldata.Quantity, err = strconv.ParseFloat(ask[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s OrderBook strconv.ParseFloat Quantity error:%v", e.GetName(), err)
+		}
 
-func (e *Bybit) LoadPublicData(operation *exchange.PublicOperation) error {
-	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
+		selldata.Rate, err = strconv.ParseFloat(ask[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s OrderBook strconv.ParseFloat Rate error:%v", e.GetName(), err)
+		}
+		maker.Asks = append(maker.Asks, selldata)
+	}
+	return maker, nil
 }
 
 /*************** Private API ***************/
-func (e *Bybit) DoAccountOperation(operation *exchange.AccountOperation) error {
-	return nil
-}
-
 func (e *Bybit) UpdateAllBalances() {
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		log.Printf("%s API Key or Secret Key are nil.", e.GetName())
 		return
 	}
 
+	balances, err := e.getWalletBalance(&exchange.AccountOperation{Type: exchange.BalanceList})
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+
+	for _, balance := range balances {
+		balanceMap.Set(balance.Coin.Code, balance.BalanceAvailable)
+	}
 }
 
 func (e *Bybit) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) bool {
@@ -268,8 +321,7 @@ func (e *Bybit) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
-	// return order, nil
-	return nil, nil
+	return e.limitOrder(pair, exchange.Sell, quantity, rate)
 }
 
 func (e *Bybit) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
@@ -277,8 +329,28 @@ func (e *Bybit) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
-	// return order, nil
-	return nil, nil
+	return e.limitOrder(pair, exchange.Buy, quantity, rate)
+}
+
+func (e *Bybit) limitOrder(pair *pair.Pair, direction exchange.TradeDirection, quantity, rate float64) (*exchange.Order, error) {
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
+	operation := &exchange.AccountOperation{
+		Type:           exchange.PlaceOrder,
+		Wallet:         exchange.ContractWallet,
+		Pair:           pair,
+		OrderDirection: direction,
+		TradeType:      exchange.TRADE_LIMIT,
+		Quantity:       quantity,
+		Rate:           rate,
+	}
+	if err := e.doContractPlaceOrder(operation); err != nil {
+		return nil, err
+	}
+	return operation.Order, nil
 }
 
 func (e *Bybit) OrderStatus(order *exchange.Order) error {
@@ -286,7 +358,11 @@ func (e *Bybit) OrderStatus(order *exchange.Order) error {
 		return fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
-	return nil
+	return e.doContractOrderStatus(&exchange.AccountOperation{
+		Type:   exchange.GetOrderStatus,
+		Wallet: exchange.ContractWallet,
+		Order:  order,
+	})
 }
 
 func (e *Bybit) ListOrders() ([]*exchange.Order, error) {
@@ -298,10 +374,34 @@ func (e *Bybit) CancelOrder(order *exchange.Order) error {
 		return fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
-	return nil
+	return e.doContractCancelOrder(&exchange.AccountOperation{
+		Type:   exchange.CancelOrder,
+		Wallet: exchange.ContractWallet,
+		Order:  order,
+	})
 }
 
+// cancels the open orders of every USDT linear and inverse contract
 func (e *Bybit) CancelAllOrder() error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
+	}
+
+	requests := []map[string]interface{}{
+		{"category": "linear", "settleCoin": "USDT"},
+		{"category": "inverse"},
+	}
+	for _, mapParams := range requests {
+		jsonResponse := &JsonResponseV5{}
+		strRequest := "/v5/order/cancel-all"
+
+		jsonCancelAll := e.ApiKeyRequest("POST", strRequest, mapParams)
+		if err := json.Unmarshal([]byte(jsonCancelAll), &jsonResponse); err != nil {
+			return fmt.Errorf("%s CancelAllOrder Json Unmarshal Err: %v %v", e.GetName(), err, jsonCancelAll)
+		} else if jsonResponse.RetCode != 0 {
+			return fmt.Errorf("%s CancelAllOrder Failed: %v", e.GetName(), jsonCancelAll)
+		}
+	}
 	return nil
 }
 
@@ -310,33 +410,47 @@ func (e *Bybit) CancelAllOrder() error {
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
-func (e *Bybit) ApiKeyGET(strRequestPath string, mapParams map[string]string) string {
-	mapParams["apikey"] = e.API_KEY
-	mapParams["nonce"] = fmt.Sprintf("%d", time.Now().UnixNano())
-
-	strUrl := API_URL + strRequestPath + "?" + exchange.Map2UrlQuery(mapParams)
-
-	signature := exchange.ComputeHmac512NoDecode(strUrl, e.API_SECRET)
-	httpClient := &http.Client{}
+func (e *Bybit) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[string]interface{}) string {
+	timestamp := fmt.Sprintf("%d", time.Now().UnixNano()/1e6)
+	recvWindow := "5000"
+
+	// v5 signs timestamp + api key + recv window + (query string for GET | json body for POST)
+	strUrl := API_URL + strRequestPath
+	payload := ""
+	var body io.Reader
+	if strMethod == "GET" {
+		if len(mapParams) > 0 {
+			payload = exchange.Map2UrlQueryInterface(mapParams)
+			strUrl += "?" + payload
+		}
+	} else {
+		bytesParams, _ := json.Marshal(mapParams)
+		payload = string(bytesParams)
+		body = strings.NewReader(payload)
+	}
+	signature := exchange.ComputeHmac256NoDecode(timestamp+e.API_KEY+recvWindow+payload, e.API_SECRET)
 
-	request, err := http.NewRequest("GET", strUrl, nil)
+	request, err := http.NewRequest(strMethod, strUrl, body)
 	if nil != err {
 		return err.Error()
 	}
-	request.Header.Add("Content-Type", "application/json;charset=utf-8")
-	request.Header.Add("Accept", "application/json")
-	request.Header.Add("apisign", signature)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("X-BAPI-API-KEY", e.API_KEY)
+	request.Header.Add("X-BAPI-TIMESTAMP", timestamp)
+	request.Header.Add("X-BAPI-RECV-WINDOW", recvWindow)
+	request.Header.Add("X-BAPI-SIGN", signature)
 
+	httpClient := &http.Client{}
 	response, err := httpClient.Do(request)
 	if nil != err {
 		return err.Error()
 	}
 	defer response.Body.Close()
 
-	body, err := ioutil.ReadAll(response.Body)
+	respBody, err := ioutil.ReadAll(response.Body)
 	if nil != err {
 		return err.Error()
 	}
 
-	return string(body)
+	return string(respBody)
 }
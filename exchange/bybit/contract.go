@@ -0,0 +1,381 @@
+package bybit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+)
+
+// type: TRADE_LIMIT, TRADE_MARKET, Trade_STOP_LIMIT, Trade_STOP_MARKET
+// Stop order need 'StopRate' param, OrderType: GTC, IOC, FOK, GTX (post only)
+func (e *Bybit) doContractPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.OrderDirection == "" {
+		return fmt.Errorf("%s ContractPlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.TradeType == "" {
+		return fmt.Errorf("%s ContractPlaceOrder empty TradeType: %+v", e.GetName(), operation)
+	}
+
+	jsonResponse := &JsonResponseV5{}
+	placeOrder := PlaceOrder{}
+	strRequest := "/v5/order/create"
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	mapParams := make(map[string]interface{})
+	mapParams["category"] = getCategory(symbol)
+	mapParams["symbol"] = symbol
+	pairConstraint := e.GetPairConstraint(operation.Pair)
+	mapParams["qty"] = pairConstraint.FormatQuantity(operation.Quantity)
+	if operation.Rate != 0 {
+		mapParams["price"] = pairConstraint.FormatRate(operation.Rate)
+	}
+
+	if operation.OrderDirection == exchange.Buy {
+		mapParams["side"] = "Buy"
+	} else if operation.OrderDirection == exchange.Sell {
+		mapParams["side"] = "Sell"
+	}
+
+	switch operation.TradeType {
+	case exchange.TRADE_LIMIT, exchange.Trade_STOP_LIMIT:
+		mapParams["orderType"] = "Limit"
+	case exchange.TRADE_MARKET, exchange.Trade_STOP_MARKET:
+		mapParams["orderType"] = "Market"
+	default:
+		return fmt.Errorf("%s ContractPlaceOrder invalid TradeType: %v", e.GetName(), operation.TradeType)
+	}
+	if operation.TradeType == exchange.Trade_STOP_LIMIT || operation.TradeType == exchange.Trade_STOP_MARKET {
+		mapParams["triggerPrice"] = pairConstraint.FormatRate(operation.StopRate)
+		// buy stops trigger on the way up, sell stops on the way down
+		if operation.OrderDirection == exchange.Buy {
+			mapParams["triggerDirection"] = 1
+		} else {
+			mapParams["triggerDirection"] = 2
+		}
+	}
+
+	switch operation.OrderType {
+	case exchange.GTC:
+		mapParams["timeInForce"] = "GTC"
+	case exchange.IOC:
+		mapParams["timeInForce"] = "IOC"
+	case exchange.FOK:
+		mapParams["timeInForce"] = "FOK"
+	case exchange.GTX:
+		mapParams["timeInForce"] = "PostOnly"
+	}
+
+	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if jsonResponse.RetCode != 0 {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Result)
+		return operation.Error
+	}
+
+	operation.Order = &exchange.Order{
+		EX:           e.GetName(),
+		Pair:         operation.Pair,
+		OrderID:      placeOrder.OrderID,
+		Rate:         operation.Rate,
+		Quantity:     operation.Quantity,
+		Direction:    operation.OrderDirection,
+		Status:       exchange.New,
+		Timestamp:    jsonResponse.Time,
+		JsonResponse: jsonPlaceReturn,
+	}
+
+	return nil
+}
+
+// realtime orders include the recently closed ones, older orders are looked up in the history
+func (e *Bybit) doContractOrderStatus(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Order == nil {
+		return fmt.Errorf("%s ContractOrderStatus Order is empty", e.GetName())
+	}
+
+	symbol := e.GetSymbolByPair(operation.Order.Pair)
+	mapParams := make(map[string]interface{})
+	mapParams["category"] = getCategory(symbol)
+	mapParams["symbol"] = symbol
+	mapParams["orderId"] = operation.Order.OrderID
+
+	orders, err := e.getOrders(operation, "/v5/order/realtime", mapParams, 1)
+	if err != nil {
+		return err
+	}
+	if len(orders) == 0 {
+		if orders, err = e.getOrders(operation, "/v5/order/history", mapParams, 1); err != nil {
+			return err
+		}
+	}
+	if len(orders) == 0 {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Could not find Order: %v", e.GetName(), operation.Order.OrderID)
+		return operation.Error
+	}
+
+	// order pointer from operation
+	order := operation.Order
+	order.Status = orders[0].Status
+	order.Direction = orders[0].Direction
+	order.DealRate = orders[0].DealRate
+	order.DealQuantity = orders[0].DealQuantity
+
+	return nil
+}
+
+func (e *Bybit) doContractCancelOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Order == nil {
+		return fmt.Errorf("%s ContractCancelOrder Order is empty", e.GetName())
+	}
+
+	jsonResponse := &JsonResponseV5{}
+	strRequest := "/v5/order/cancel"
+	symbol := e.GetSymbolByPair(operation.Order.Pair)
+
+	mapParams := make(map[string]interface{})
+	mapParams["category"] = getCategory(symbol)
+	mapParams["symbol"] = symbol
+	mapParams["orderId"] = operation.Order.OrderID
+
+	jsonCancelOrder := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonCancelOrder
+	}
+
+	if err := json.Unmarshal([]byte(jsonCancelOrder), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonCancelOrder)
+		return operation.Error
+	} else if jsonResponse.RetCode != 0 {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Failed: %v", e.GetName(), jsonCancelOrder)
+		return operation.Error
+	}
+
+	operation.Order.Status = exchange.Canceling
+	operation.Order.CancelStatus = jsonCancelOrder
+
+	return nil
+}
+
+// without Pair the open orders of every USDT linear and inverse contract are returned
+func (e *Bybit) doContractGetOpenOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	operation.OpenOrders = []*exchange.Order{}
+	for _, mapParams := range e.orderRequests(operation) {
+		orders, err := e.getOrders(operation, "/v5/order/realtime", mapParams, 0)
+		if err != nil {
+			return err
+		}
+		operation.OpenOrders = append(operation.OpenOrders, orders...)
+	}
+
+	return nil
+}
+
+// StartTime and EndTime (ms) are optional, bybit returns the last 7 days by default
+func (e *Bybit) doContractGetOrderHistory(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	operation.OrderHistory = []*exchange.Order{}
+	for _, mapParams := range e.orderRequests(operation) {
+		if operation.StartTime != 0 {
+			mapParams["startTime"] = operation.StartTime
+		}
+		if operation.EndTime != 0 {
+			mapParams["endTime"] = operation.EndTime
+		}
+
+		orders, err := e.getOrders(operation, "/v5/order/history", mapParams, 0)
+		if err != nil {
+			return err
+		}
+		operation.OrderHistory = append(operation.OrderHistory, orders...)
+	}
+
+	return nil
+}
+
+func (e *Bybit) orderRequests(operation *exchange.AccountOperation) []map[string]interface{} {
+	if operation.Pair != nil {
+		symbol := e.GetSymbolByPair(operation.Pair)
+		return []map[string]interface{}{{"category": getCategory(symbol), "symbol": symbol}}
+	}
+	return []map[string]interface{}{
+		{"category": "linear", "settleCoin": "USDT"},
+		{"category": "inverse"},
+	}
+}
+
+// getOrders follows the cursor until the last page, limit 0 returns every order
+func (e *Bybit) getOrders(operation *exchange.AccountOperation, strRequest string, mapParams map[string]interface{}, limit int) ([]*exchange.Order, error) {
+	orders := []*exchange.Order{}
+	mapParams["limit"] = 50
+	for {
+		jsonResponse := &JsonResponseV5{}
+		orderList := OrderList{}
+
+		jsonOrdersReturn := e.ApiKeyRequest("GET", strRequest, mapParams)
+		if operation.DebugMode {
+			operation.RequestURI = strRequest
+			operation.CallResponce = jsonOrdersReturn
+		}
+
+		if err := json.Unmarshal([]byte(jsonOrdersReturn), &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonOrdersReturn)
+			return nil, operation.Error
+		} else if jsonResponse.RetCode != 0 {
+			operation.Error = fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, jsonOrdersReturn)
+			return nil, operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Result, &orderList); err != nil {
+			operation.Error = fmt.Errorf("%s %s Result Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonResponse.Result)
+			return nil, operation.Error
+		}
+
+		for i := range orderList.List {
+			orders = append(orders, e.toOrder(&orderList.List[i]))
+		}
+
+		if orderList.NextPageCursor == "" || len(orderList.List) == 0 || (limit > 0 && len(orders) >= limit) {
+			break
+		}
+		mapParams["cursor"] = orderList.NextPageCursor
+	}
+	delete(mapParams, "cursor")
+
+	return orders, nil
+}
+
+func (e *Bybit) toOrder(o *OrderDetail) *exchange.Order {
+	order := &exchange.Order{
+		EX:      e.GetName(),
+		Pair:    e.GetPairBySymbol(o.Symbol),
+		OrderID: o.OrderID,
+	}
+	order.Rate, _ = strconv.ParseFloat(o.Price, 64)
+	order.Quantity, _ = strconv.ParseFloat(o.Qty, 64)
+	order.DealRate, _ = strconv.ParseFloat(o.AvgPrice, 64)
+	order.DealQuantity, _ = strconv.ParseFloat(o.CumExecQty, 64)
+	order.Timestamp, _ = strconv.ParseInt(o.CreatedTime, 10, 64)
+
+	switch o.Side {
+	case "Buy":
+		order.Direction = exchange.Buy
+	case "Sell":
+		order.Direction = exchange.Sell
+	}
+
+	switch o.OrderStatus {
+	case "New", "Untriggered", "Triggered":
+		order.Status = exchange.New
+	case "PartiallyFilled":
+		order.Status = exchange.Partial
+	case "Filled":
+		order.Status = exchange.Filled
+	case "Cancelled", "PartiallyFilledCanceled", "Deactivated":
+		order.Status = exchange.Cancelled
+	case "Rejected":
+		order.Status = exchange.Rejected
+	default:
+		order.Status = exchange.Other
+	}
+
+	return order
+}
+
+func (e *Bybit) doContractAllBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	balances, err := e.getWalletBalance(operation)
+	if err != nil {
+		return err
+	}
+
+	operation.BalanceList = balances
+	return nil
+}
+
+// unified trading account, the frozen balance holds the order and position margin
+func (e *Bybit) getWalletBalance(operation *exchange.AccountOperation) ([]exchange.AssetBalance, error) {
+	jsonResponse := &JsonResponseV5{}
+	walletBalance := WalletBalance{}
+	strRequest := "/v5/account/wallet-balance"
+
+	mapParams := make(map[string]interface{})
+	mapParams["accountType"] = "UNIFIED"
+
+	jsonBalanceReturn := e.ApiKeyRequest("GET", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s getWalletBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return nil, operation.Error
+	} else if jsonResponse.RetCode != 0 {
+		operation.Error = fmt.Errorf("%s getWalletBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return nil, operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &walletBalance); err != nil {
+		operation.Error = fmt.Errorf("%s getWalletBalance Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Result)
+		return nil, operation.Error
+	}
+
+	balances := []exchange.AssetBalance{}
+	for _, account := range walletBalance.List {
+		for _, b := range account.Coin {
+			c := coin.GetCoin(b.Coin)
+			if c == nil {
+				continue
+			}
+
+			total, _ := strconv.ParseFloat(b.WalletBalance, 64)
+			locked, _ := strconv.ParseFloat(b.Locked, 64)
+			orderIM, _ := strconv.ParseFloat(b.TotalOrderIM, 64)
+			positionIM, _ := strconv.ParseFloat(b.TotalPositionIM, 64)
+
+			frozen := locked + orderIM + positionIM
+			balances = append(balances, exchange.AssetBalance{
+				Coin:             c,
+				Balance:          total,
+				BalanceAvailable: total - frozen,
+				BalanceFrozen:    frozen,
+			})
+		}
+	}
+
+	return balances, nil
+}
@@ -110,7 +110,11 @@ func (e *Bybit) GetBalance(coin *coin.Coin) float64 {
 }
 
 func (e *Bybit) GetTradingWebURL(pair *pair.Pair) string {
-	return fmt.Sprintf("https://www.bybit.com/app/exchange/%s", e.GetSymbolByPair(pair))
+	symbol := e.GetSymbolByPair(pair)
+	if getCategory(symbol) == "linear" {
+		return fmt.Sprintf("https://www.bybit.com/trade/usdt/%s", symbol)
+	}
+	return fmt.Sprintf("https://www.bybit.com/trade/inverse/%s", symbol)
 }
 
 /*************** Coins on the Exchanges ***************/
@@ -231,7 +235,7 @@ func (e *Bybit) DeletePair(pair *pair.Pair) {
 func (e *Bybit) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetchMethod {
 	constrainFetchMethod := &exchange.ConstrainFetchMethod{}
 	constrainFetchMethod.PublicAPI = true
-	constrainFetchMethod.PrivateAPI = false
+	constrainFetchMethod.PrivateAPI = true
 	constrainFetchMethod.HealthAPI = true
 	constrainFetchMethod.HasWithdraw = false
 	constrainFetchMethod.HasTransfer = false
@@ -247,6 +251,38 @@ func (e *Bybit) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Orderbook:      {exchange.ContractWallet},
+		exchange.KLine:          {exchange.ContractWallet},
+		exchange.GetTickerPrice: {exchange.ContractWallet},
+		exchange.FundingRate:    {exchange.ContractWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.PlaceOrder:           {exchange.ContractWallet},
+		exchange.GetOrderStatus:       {exchange.ContractWallet},
+		exchange.CancelOrder:          {exchange.ContractWallet},
+		exchange.GetOpenOrder:         {exchange.ContractWallet},
+		exchange.GetOrderHistory:      {exchange.ContractWallet},
+		exchange.BalanceList:          {exchange.ContractWallet},
+		exchange.GetPositions:         {exchange.ContractWallet},
+		exchange.SetLeverage:          {exchange.ContractWallet},
+		exchange.SetMarginType:        {exchange.ContractWallet},
+		exchange.SetPositionMode:      {exchange.ContractWallet},
+		exchange.AdjustIsolatedMargin: {exchange.ContractWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.ContractWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET, exchange.Trade_STOP_LIMIT, exchange.Trade_STOP_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.ContractWallet: {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+	},
+}
+
+func (e *Bybit) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Bybit) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -8,33 +8,150 @@ import (
 	"encoding/json"
 )
 
-type JsonResponse struct {
-	RetCode int             `json:"ret_code"`
-	RetMsg  string          `json:"ret_msg"`
-	ExtCode string          `json:"ext_code"`
-	ExtInfo string          `json:"ext_info"`
-	TimeNow string          `json:"time_now"`
+// v5 response wrapper
+type JsonResponseV5 struct {
+	RetCode int             `json:"retCode"`
+	RetMsg  string          `json:"retMsg"`
 	Result  json.RawMessage `json:"result"`
+	Time    int64           `json:"time"`
 }
 
-type PairsData []struct {
-	Name          string `json:"name"`
-	BaseCurrency  string `json:"base_currency"`
-	QuoteCurrency string `json:"quote_currency"`
-	PriceScale    int    `json:"price_scale"`
-	PriceFilter   struct {
-		MinPrice string `json:"min_price"`
-		MaxPrice string `json:"max_price"`
-		TickSize string `json:"tick_size"`
-	} `json:"price_filter"`
+type InstrumentsInfo struct {
+	Category       string       `json:"category"`
+	NextPageCursor string       `json:"nextPageCursor"`
+	List           []Instrument `json:"list"`
+}
+
+type Instrument struct {
+	Symbol       string `json:"symbol"`
+	ContractType string `json:"contractType"`
+	Status       string `json:"status"`
+	BaseCoin     string `json:"baseCoin"`
+	QuoteCoin    string `json:"quoteCoin"`
+	SettleCoin   string `json:"settleCoin"`
+	PriceScale   string `json:"priceScale"`
+	PriceFilter  struct {
+		MinPrice string `json:"minPrice"`
+		MaxPrice string `json:"maxPrice"`
+		TickSize string `json:"tickSize"`
+	} `json:"priceFilter"`
 	LotSizeFilter struct {
-		MaxTradingQty int     `json:"max_trading_qty"`
-		MinTradingQty int     `json:"min_trading_qty"`
-		QtyStep       float64 `json:"qty_step"`
-	} `json:"lot_size_filter"`
+		MaxOrderQty string `json:"maxOrderQty"`
+		MinOrderQty string `json:"minOrderQty"`
+		QtyStep     string `json:"qtyStep"`
+	} `json:"lotSizeFilter"`
+}
+
+type OrderBook struct {
+	Symbol   string     `json:"s"`
+	Bids     [][]string `json:"b"`
+	Asks     [][]string `json:"a"`
+	Ts       int64      `json:"ts"`
+	UpdateID int64      `json:"u"`
+}
+
+// list items are [startTime, open, high, low, close, volume, turnover], newest first
+type Kline struct {
+	Symbol   string     `json:"symbol"`
+	Category string     `json:"category"`
+	List     [][]string `json:"list"`
 }
 
-/* type OrderBook struct {
-	Buy  []exchange.Order `json:"buy"`
-	Sell []exchange.Order `json:"sell"`
-} */
+type WalletBalance struct {
+	List []struct {
+		AccountType string `json:"accountType"`
+		Coin        []struct {
+			Coin                string `json:"coin"`
+			Equity              string `json:"equity"`
+			WalletBalance       string `json:"walletBalance"`
+			Locked              string `json:"locked"`
+			TotalOrderIM        string `json:"totalOrderIM"`
+			TotalPositionIM     string `json:"totalPositionIM"`
+			UnrealisedPnl       string `json:"unrealisedPnl"`
+			AvailableToWithdraw string `json:"availableToWithdraw"`
+		} `json:"coin"`
+	} `json:"list"`
+}
+
+type PlaceOrder struct {
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+}
+
+type OrderList struct {
+	Category       string        `json:"category"`
+	NextPageCursor string        `json:"nextPageCursor"`
+	List           []OrderDetail `json:"list"`
+}
+
+type OrderDetail struct {
+	OrderID     string `json:"orderId"`
+	OrderLinkID string `json:"orderLinkId"`
+	Symbol      string `json:"symbol"`
+	Price       string `json:"price"`
+	Qty         string `json:"qty"`
+	Side        string `json:"side"`
+	OrderStatus string `json:"orderStatus"`
+	AvgPrice    string `json:"avgPrice"`
+	CumExecQty  string `json:"cumExecQty"`
+	OrderType   string `json:"orderType"`
+	TimeInForce string `json:"timeInForce"`
+	CreatedTime string `json:"createdTime"`
+	UpdatedTime string `json:"updatedTime"`
+}
+
+type PositionList struct {
+	Category       string `json:"category"`
+	NextPageCursor string `json:"nextPageCursor"`
+	List           []struct {
+		PositionIdx    int    `json:"positionIdx"`
+		Symbol         string `json:"symbol"`
+		Side           string `json:"side"`
+		Size           string `json:"size"`
+		AvgPrice       string `json:"avgPrice"`
+		PositionValue  string `json:"positionValue"`
+		TradeMode      int    `json:"tradeMode"`
+		Leverage       string `json:"leverage"`
+		MarkPrice      string `json:"markPrice"`
+		LiqPrice       string `json:"liqPrice"`
+		PositionIM     string `json:"positionIM"`
+		PositionMM     string `json:"positionMM"`
+		UnrealisedPnl  string `json:"unrealisedPnl"`
+		CumRealisedPnl string `json:"cumRealisedPnl"`
+		UpdatedTime    string `json:"updatedTime"`
+	} `json:"list"`
+}
+
+type AddMargin struct {
+	Category    string `json:"category"`
+	Symbol      string `json:"symbol"`
+	PositionIdx int    `json:"positionIdx"`
+	Side        string `json:"side"`
+	Size        string `json:"size"`
+	Leverage    string `json:"leverage"`
+	PositionIM  string `json:"positionIM"`
+	PositionMM  string `json:"positionMM"`
+	LiqPrice    string `json:"liqPrice"`
+}
+
+type Tickers struct {
+	Category string `json:"category"`
+	List     []struct {
+		Symbol          string `json:"symbol"`
+		LastPrice       string `json:"lastPrice"`
+		MarkPrice       string `json:"markPrice"`
+		IndexPrice      string `json:"indexPrice"`
+		FundingRate     string `json:"fundingRate"`
+		NextFundingTime string `json:"nextFundingTime"`
+		OpenInterest    string `json:"openInterest"`
+	} `json:"list"`
+}
+
+type FundingHistory struct {
+	Category string `json:"category"`
+	List     []struct {
+		Symbol               string `json:"symbol"`
+		FundingRate          string `json:"fundingRate"`
+		FundingRateTimestamp string `json:"fundingRateTimestamp"`
+	} `json:"list"`
+}
@@ -0,0 +1,326 @@
+package bybit
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Bybit) DoAccountOperation(operation *exchange.AccountOperation) error {
+	switch operation.Type {
+	case exchange.PlaceOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractPlaceOrder(operation)
+		}
+	case exchange.GetOrderStatus:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractOrderStatus(operation)
+		}
+	case exchange.CancelOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractCancelOrder(operation)
+		}
+	case exchange.GetOpenOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractGetOpenOrder(operation)
+		}
+	case exchange.GetOrderHistory:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractGetOrderHistory(operation)
+		}
+	case exchange.BalanceList:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractAllBalance(operation)
+		}
+	case exchange.GetPositions:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetPositions(operation)
+		}
+	case exchange.SetLeverage:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetLeverage(operation)
+		}
+	case exchange.SetMarginType:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetMarginType(operation)
+		}
+	case exchange.SetPositionMode:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetPositionMode(operation)
+		}
+	case exchange.AdjustIsolatedMargin:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doAdjustIsolatedMargin(operation)
+		}
+	}
+	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
+}
+
+// linear contracts are USDT settled, inverse contracts are settled in the base coin
+func getCategory(symbol string) string {
+	if strings.HasSuffix(symbol, "USDT") {
+		return "linear"
+	}
+	return "inverse"
+}
+
+func (e *Bybit) doGetPositions(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	requests := []map[string]interface{}{}
+	if operation.Pair != nil {
+		symbol := e.GetSymbolByPair(operation.Pair)
+		requests = append(requests, map[string]interface{}{"category": getCategory(symbol), "symbol": symbol})
+	} else {
+		requests = append(requests, map[string]interface{}{"category": "linear", "settleCoin": "USDT"})
+		requests = append(requests, map[string]interface{}{"category": "inverse"})
+	}
+
+	operation.Positions = []*exchange.Position{}
+	for _, mapParams := range requests {
+		for {
+			jsonResponse := &JsonResponseV5{}
+			positionList := PositionList{}
+			strRequest := "/v5/position/list"
+
+			jsonPositionReturn := e.ApiKeyRequest("GET", strRequest, mapParams)
+			if operation.DebugMode {
+				operation.RequestURI = strRequest
+				operation.CallResponce = jsonPositionReturn
+			}
+
+			if err := json.Unmarshal([]byte(jsonPositionReturn), &jsonResponse); err != nil {
+				operation.Error = fmt.Errorf("%s doGetPositions Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPositionReturn)
+				return operation.Error
+			} else if jsonResponse.RetCode != 0 {
+				operation.Error = fmt.Errorf("%s doGetPositions Failed: %v", e.GetName(), jsonPositionReturn)
+				return operation.Error
+			}
+			if err := json.Unmarshal(jsonResponse.Result, &positionList); err != nil {
+				operation.Error = fmt.Errorf("%s doGetPositions Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Result)
+				return operation.Error
+			}
+
+			for _, p := range positionList.List {
+				size, _ := strconv.ParseFloat(p.Size, 64)
+				if size == 0 {
+					continue
+				}
+
+				position := &exchange.Position{
+					Pair:       e.GetPairBySymbol(p.Symbol),
+					Instrument: p.Symbol,
+					Size:       size,
+				}
+				position.EntryPrice, _ = strconv.ParseFloat(p.AvgPrice, 64)
+				position.MarkPrice, _ = strconv.ParseFloat(p.MarkPrice, 64)
+				position.LiquidationPrice, _ = strconv.ParseFloat(p.LiqPrice, 64)
+				position.Leverage, _ = strconv.ParseFloat(p.Leverage, 64)
+				position.UnrealizedPnl, _ = strconv.ParseFloat(p.UnrealisedPnl, 64)
+				position.RealizedPnl, _ = strconv.ParseFloat(p.CumRealisedPnl, 64)
+				position.Timestamp, _ = strconv.ParseInt(p.UpdatedTime, 10, 64)
+
+				if p.Side == "Buy" {
+					position.Side = exchange.PositionLong
+				} else {
+					position.Side = exchange.PositionShort
+				}
+				if p.TradeMode == 1 {
+					position.MarginMode = exchange.IsolatedMargin
+				} else {
+					position.MarginMode = exchange.CrossMargin
+				}
+
+				operation.Positions = append(operation.Positions, position)
+			}
+
+			if positionList.NextPageCursor == "" {
+				break
+			}
+			mapParams["cursor"] = positionList.NextPageCursor
+		}
+	}
+
+	return nil
+}
+
+// leverage of both sides of the symbol
+func (e *Bybit) doSetLeverage(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s SetLeverage Pair is empty", e.GetName())
+	} else if operation.Leverage < 1 || operation.Leverage > 100 {
+		return fmt.Errorf("%s SetLeverage is between 1 to 100: %d", e.GetName(), operation.Leverage)
+	}
+
+	symbol := e.GetSymbolByPair(operation.Pair)
+	leverage := fmt.Sprintf("%d", operation.Leverage)
+
+	mapParams := make(map[string]interface{})
+	mapParams["category"] = getCategory(symbol)
+	mapParams["symbol"] = symbol
+	mapParams["buyLeverage"] = leverage
+	mapParams["sellLeverage"] = leverage
+
+	if _, err := e.positionSetting(operation, "/v5/position/set-leverage", mapParams); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: symbol,
+		Leverage:   float64(operation.Leverage),
+	}
+
+	return nil
+}
+
+// bybit switches the margin mode together with the leverage, Leverage is required
+func (e *Bybit) doSetMarginType(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s SetMarginType Pair is empty", e.GetName())
+	} else if operation.Leverage < 1 || operation.Leverage > 100 {
+		return fmt.Errorf("%s SetMarginType Leverage is between 1 to 100: %d", e.GetName(), operation.Leverage)
+	}
+
+	symbol := e.GetSymbolByPair(operation.Pair)
+	leverage := fmt.Sprintf("%d", operation.Leverage)
+
+	mapParams := make(map[string]interface{})
+	mapParams["category"] = getCategory(symbol)
+	mapParams["symbol"] = symbol
+	mapParams["buyLeverage"] = leverage
+	mapParams["sellLeverage"] = leverage
+	switch operation.MarginMode {
+	case exchange.CrossMargin:
+		mapParams["tradeMode"] = 0
+	case exchange.IsolatedMargin:
+		mapParams["tradeMode"] = 1
+	default:
+		return fmt.Errorf("%s SetMarginType invalid MarginMode: %v", e.GetName(), operation.MarginMode)
+	}
+
+	if _, err := e.positionSetting(operation, "/v5/position/switch-isolated", mapParams); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: symbol,
+		Leverage:   float64(operation.Leverage),
+		MarginMode: operation.MarginMode,
+	}
+
+	return nil
+}
+
+// the position mode is set for the symbol, or for all USDT contracts if Pair is nil
+func (e *Bybit) doSetPositionMode(operation *exchange.AccountOperation) error {
+	mapParams := make(map[string]interface{})
+	if operation.Pair != nil {
+		symbol := e.GetSymbolByPair(operation.Pair)
+		mapParams["category"] = getCategory(symbol)
+		mapParams["symbol"] = symbol
+	} else {
+		mapParams["category"] = "linear"
+		mapParams["coin"] = "USDT"
+	}
+	switch operation.PositionMode {
+	case exchange.OneWayMode:
+		mapParams["mode"] = 0
+	case exchange.HedgeMode:
+		mapParams["mode"] = 3
+	default:
+		return fmt.Errorf("%s SetPositionMode invalid PositionMode: %v", e.GetName(), operation.PositionMode)
+	}
+
+	if _, err := e.positionSetting(operation, "/v5/position/switch-mode", mapParams); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:         operation.Pair,
+		PositionMode: operation.PositionMode,
+	}
+	if operation.Pair != nil {
+		operation.LeverageSetting.Instrument = e.GetSymbolByPair(operation.Pair)
+	}
+
+	return nil
+}
+
+// PositionSide selects the position in hedge mode, leave it empty for one-way mode
+func (e *Bybit) doAdjustIsolatedMargin(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s AdjustIsolatedMargin Pair is empty", e.GetName())
+	} else if operation.MarginAmount == 0 {
+		return fmt.Errorf("%s AdjustIsolatedMargin MarginAmount is 0", e.GetName())
+	}
+
+	symbol := e.GetSymbolByPair(operation.Pair)
+	addMargin := AddMargin{}
+
+	mapParams := make(map[string]interface{})
+	mapParams["category"] = getCategory(symbol)
+	mapParams["symbol"] = symbol
+	mapParams["margin"] = strconv.FormatFloat(operation.MarginAmount, 'f', -1, 64)
+	switch operation.PositionSide {
+	case exchange.PositionLong:
+		mapParams["positionIdx"] = 1
+	case exchange.PositionShort:
+		mapParams["positionIdx"] = 2
+	default:
+		mapParams["positionIdx"] = 0
+	}
+
+	result, err := e.positionSetting(operation, "/v5/position/add-margin", mapParams)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(result, &addMargin); err != nil {
+		operation.Error = fmt.Errorf("%s doAdjustIsolatedMargin Result Unmarshal Err: %v, %s", e.GetName(), err, result)
+		return operation.Error
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: symbol,
+		MarginMode: exchange.IsolatedMargin,
+	}
+	operation.LeverageSetting.Leverage, _ = strconv.ParseFloat(addMargin.Leverage, 64)
+	operation.LeverageSetting.IsolatedMargin, _ = strconv.ParseFloat(addMargin.PositionIM, 64)
+
+	return nil
+}
+
+// 110043 (leverage not modified) and 110025 (position mode not modified) mean the setting is already in place
+func (e *Bybit) positionSetting(operation *exchange.AccountOperation, strRequest string, mapParams map[string]interface{}) (json.RawMessage, error) {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	jsonResponse := &JsonResponseV5{}
+	jsonSettingReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonSettingReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonSettingReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonSettingReturn)
+		return nil, operation.Error
+	} else if jsonResponse.RetCode != 0 && jsonResponse.RetCode != 110043 && jsonResponse.RetCode != 110025 {
+		operation.Error = fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, jsonSettingReturn)
+		return nil, operation.Error
+	}
+
+	return jsonResponse.Result, nil
+}
@@ -0,0 +1,302 @@
+package bybit
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
+)
+
+/*************** PUBLIC  API ***************/
+func (e *Bybit) LoadPublicData(operation *exchange.PublicOperation) error {
+	switch operation.Type {
+	case exchange.Orderbook:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doContractOrderBook(operation)
+		}
+	case exchange.KLine:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doContractKline(operation)
+		}
+	case exchange.GetTickerPrice:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doTickerPrice(operation)
+		}
+	case exchange.FundingRate:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doFundingRate(operation)
+		}
+	}
+	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
+}
+
+func (e *Bybit) doContractOrderBook(operation *exchange.PublicOperation) error {
+	maker, err := e.OrderBook(operation.Pair)
+	if err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	operation.Maker = maker
+	return nil
+}
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1",
+	exchange.KLINE_3MIN:   "3",
+	exchange.KLINE_5MIN:   "5",
+	exchange.KLINE_15MIN:  "15",
+	exchange.KLINE_30MIN:  "30",
+	exchange.KLINE_1HOUR:  "60",
+	exchange.KLINE_2HOUR:  "120",
+	exchange.KLINE_4HOUR:  "240",
+	exchange.KLINE_6HOUR:  "360",
+	exchange.KLINE_12HOUR: "720",
+	exchange.KLINE_1DAY:   "D",
+	exchange.KLINE_1WEEK:  "W",
+	exchange.KLINE_1MONTH: "M",
+}
+
+func (e *Bybit) doContractKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	// CloseTime of a month is approximated
+	duration := exchange.DEFAULT_KLINE_INTERVAL.Duration()
+	if operation.KlineInterval != "" {
+		duration = operation.KlineInterval.Duration()
+	}
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	return exchange.FetchKline(operation, 1000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		jsonResponse := &JsonResponseV5{}
+		kline := Kline{}
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/v5/market/kline?category=%s&symbol=%s&interval=%s&limit=1000", API_URL, getCategory(symbol), symbol, interval),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&start=%v", start)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&end=%v", end)
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+			return nil, fmt.Errorf("%s doContractKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		} else if jsonResponse.RetCode != 0 {
+			return nil, fmt.Errorf("%s doContractKline Failed: %v", e.GetName(), string(get.ResponseBody))
+		}
+		if err := json.Unmarshal(jsonResponse.Result, &kline); err != nil {
+			return nil, fmt.Errorf("%s doContractKline Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, k := range kline.List {
+			if len(k) < 7 {
+				return nil, fmt.Errorf("%s doContractKline invalid kline: %v", e.GetName(), k)
+			}
+
+			values := make([]float64, len(k))
+			for j, v := range k {
+				value, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%s doContractKline parse Err: %v %v", e.GetName(), err, v)
+				}
+				values[j] = value
+			}
+
+			detail := &exchange.KlineDetail{
+				Exchange:         e.GetName(),
+				Pair:             operation.Pair.Name,
+				OpenTime:         values[0],
+				Open:             values[1],
+				High:             values[2],
+				Low:              values[3],
+				Close:            values[4],
+				Volume:           values[5],
+				CloseTime:        values[0] + float64(duration/time.Millisecond) - 1,
+				QuoteAssetVolume: values[6],
+			}
+
+			details = append(details, detail)
+		}
+		// bybit lists the newest first, FetchKline sorts them
+		return details, nil
+	})
+}
+
+// last price of every USDT linear and inverse perpetual
+func (e *Bybit) doTickerPrice(operation *exchange.PublicOperation) error {
+	operation.TickerPrice = []*exchange.TickerPriceDetail{}
+	for _, category := range []string{"linear", "inverse"} {
+		jsonResponse := &JsonResponseV5{}
+		tickers := Tickers{}
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/v5/market/tickers?category=%s", API_URL, category),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			operation.Error = err
+			return operation.Error
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doTickerPrice Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+			return operation.Error
+		} else if jsonResponse.RetCode != 0 {
+			operation.Error = fmt.Errorf("%s doTickerPrice Failed: %v", e.GetName(), string(get.ResponseBody))
+			return operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Result, &tickers); err != nil {
+			operation.Error = fmt.Errorf("%s doTickerPrice Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+			return operation.Error
+		}
+
+		for _, ticker := range tickers.List {
+			p := e.GetPairBySymbol(ticker.Symbol)
+			if p == nil {
+				continue
+			}
+
+			price, err := strconv.ParseFloat(ticker.LastPrice, 64)
+			if err != nil {
+				operation.Error = fmt.Errorf("%s doTickerPrice parse Err: %v %v", e.GetName(), err, ticker.LastPrice)
+				return operation.Error
+			}
+
+			operation.TickerPrice = append(operation.TickerPrice, &exchange.TickerPriceDetail{
+				Pair:  p,
+				Price: price,
+			})
+		}
+	}
+
+	return nil
+}
+
+func (e *Bybit) doFundingRate(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponseV5{}
+	tickers := Tickers{}
+	symbol := e.GetSymbolByPair(operation.Pair)
+	category := getCategory(symbol)
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/v5/market/tickers?category=%s&symbol=%s", API_URL, category, symbol),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if jsonResponse.RetCode != 0 {
+		operation.Error = fmt.Errorf("%s doFundingRate Failed: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &tickers); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		return operation.Error
+	} else if len(tickers.List) == 0 {
+		operation.Error = fmt.Errorf("%s doFundingRate got empty return: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+
+	operation.FundingRate = &exchange.FundingRateDetail{
+		Pair:       operation.Pair,
+		Instrument: symbol,
+		History:    []*exchange.FundingRateHistory{},
+	}
+	operation.FundingRate.CurrentRate, _ = strconv.ParseFloat(tickers.List[0].FundingRate, 64)
+	operation.FundingRate.NextFundingTime, _ = strconv.ParseInt(tickers.List[0].NextFundingTime, 10, 64)
+
+	jsonResponse = &JsonResponseV5{}
+	fundingHistory := FundingHistory{}
+	get = &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/v5/market/funding/history?category=%s&symbol=%s&limit=200", API_URL, category, symbol),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	// startTime is rejected without endTime
+	if operation.FundingStartTime != 0 {
+		endTime := operation.FundingEndTime
+		if endTime == 0 {
+			endTime = time.Now().UnixNano() / int64(time.Millisecond)
+		}
+		get.URI += fmt.Sprintf("&startTime=%v&endTime=%v", operation.FundingStartTime, endTime)
+	} else if operation.FundingEndTime != 0 {
+		get.URI += fmt.Sprintf("&endTime=%v", operation.FundingEndTime)
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if jsonResponse.RetCode != 0 {
+		operation.Error = fmt.Errorf("%s doFundingRate history Failed: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &fundingHistory); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		return operation.Error
+	}
+
+	// newest first
+	for i := len(fundingHistory.List) - 1; i >= 0; i-- {
+		f := fundingHistory.List[i]
+		rate, err := strconv.ParseFloat(f.FundingRate, 64)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doFundingRate parse Err: %v %v", e.GetName(), err, f.FundingRate)
+			return operation.Error
+		}
+		timestamp, _ := strconv.ParseInt(f.FundingRateTimestamp, 10, 64)
+		operation.FundingRate.History = append(operation.FundingRate.History, &exchange.FundingRateHistory{
+			Rate:      rate,
+			Timestamp: timestamp,
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,288 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// walletOrder is the order of the wallets in a report, as declared
+var walletOrder = map[WalletType]int{
+	AssetWallet:    0,
+	SpotWallet:     1,
+	FiatOTCWallet:  2,
+	MarginWallet:   3,
+	ContractWallet: 4,
+	OptionWallet:   5,
+}
+
+// Capabilities is what an adapter does: the wallets of every OperationType of LoadPublicData, Public,
+// and of DoAccountOperation, Account, then the OrderTradeTypes and the time in force (GTC, IOC, FOK, GTX)
+// of its orders by wallet. The spot orders of LimitBuy and LimitSell are TRADE_LIMIT GTC.
+// Every adapter declares it next to its switches, an operation which isn't there returns an error.
+//
+//	if e.GetCapabilities().Supports(exchange.KLine, exchange.ContractWallet) { ... }
+type Capabilities struct {
+	Public      map[OperationType][]WalletType
+	Account     map[OperationType][]WalletType
+	OrderTypes  map[WalletType][]OrderTradeType
+	TimeInForce map[WalletType][]OrderPriceType
+}
+
+// walletOrSpot is SpotWallet if empty, as for the operations
+func walletOrSpot(w WalletType) WalletType {
+	if w == "" {
+		return SpotWallet
+	}
+	return w
+}
+
+func hasWallet(wallets []WalletType, w WalletType) bool {
+	for _, supported := range wallets {
+		if supported == walletOrSpot(w) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasOperation(types []OperationType, op OperationType) bool {
+	for _, t := range types {
+		if t == op {
+			return true
+		}
+	}
+	return false
+}
+
+func hasTradeType(types []OrderTradeType, tradeType OrderTradeType) bool {
+	for _, t := range types {
+		if t == tradeType {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPriceType(types []OrderPriceType, priceType OrderPriceType) bool {
+	for _, t := range types {
+		if t == priceType {
+			return true
+		}
+	}
+	return false
+}
+
+// Supports is whether the adapter does op on wallet, SpotWallet if empty, with LoadPublicData or DoAccountOperation
+func (c *Capabilities) Supports(op OperationType, w WalletType) bool {
+	return c.SupportsPublic(op, w) || c.SupportsAccount(op, w)
+}
+
+// SupportsPublic is whether LoadPublicData does op on wallet, SpotWallet if empty
+func (c *Capabilities) SupportsPublic(op OperationType, w WalletType) bool {
+	return c != nil && hasWallet(c.Public[op], w)
+}
+
+// SupportsAccount is whether DoAccountOperation does op on wallet, SpotWallet if empty
+func (c *Capabilities) SupportsAccount(op OperationType, w WalletType) bool {
+	return c != nil && hasWallet(c.Account[op], w)
+}
+
+// SupportsOrder is whether the adapter places an order of tradeType with the time in force on wallet,
+// tradeType TRADE_LIMIT and tif GTC if empty
+func (c *Capabilities) SupportsOrder(w WalletType, tradeType OrderTradeType, tif OrderPriceType) bool {
+	if c == nil {
+		return false
+	}
+	if tradeType == "" {
+		tradeType = TRADE_LIMIT
+	}
+	if tif == "" {
+		tif = GTC
+	}
+	return hasTradeType(c.OrderTypes[walletOrSpot(w)], tradeType) && hasPriceType(c.TimeInForce[walletOrSpot(w)], tif)
+}
+
+// Wallets is the wallets of op, empty if not supported
+func (c *Capabilities) Wallets(op OperationType) []WalletType {
+	wallets := []WalletType{}
+	if c == nil {
+		return wallets
+	}
+	for _, w := range append(c.Public[op], c.Account[op]...) {
+		if !hasWallet(wallets, w) {
+			wallets = append(wallets, w)
+		}
+	}
+	return wallets
+}
+
+// Types is every OperationType supported, by name
+func (c *Capabilities) Types() []OperationType {
+	types := []OperationType{}
+	if c == nil {
+		return types
+	}
+	for _, operations := range []map[OperationType][]WalletType{c.Public, c.Account} {
+		for op, wallets := range operations {
+			if len(wallets) > 0 && !hasOperation(types, op) {
+				types = append(types, op)
+			}
+		}
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// Merge is a copy of c with the capabilities of other added, eg. the public data of the source of papertrade
+func (c *Capabilities) Merge(other *Capabilities) *Capabilities {
+	merged := &Capabilities{
+		Public:      make(map[OperationType][]WalletType),
+		Account:     make(map[OperationType][]WalletType),
+		OrderTypes:  make(map[WalletType][]OrderTradeType),
+		TimeInForce: make(map[WalletType][]OrderPriceType),
+	}
+	for _, caps := range []*Capabilities{c, other} {
+		if caps == nil {
+			continue
+		}
+		for op, wallets := range caps.Public {
+			for _, w := range wallets {
+				if !hasWallet(merged.Public[op], w) {
+					merged.Public[op] = append(merged.Public[op], w)
+				}
+			}
+		}
+		for op, wallets := range caps.Account {
+			for _, w := range wallets {
+				if !hasWallet(merged.Account[op], w) {
+					merged.Account[op] = append(merged.Account[op], w)
+				}
+			}
+		}
+		for w, types := range caps.OrderTypes {
+			for _, t := range types {
+				if !hasTradeType(merged.OrderTypes[w], t) {
+					merged.OrderTypes[w] = append(merged.OrderTypes[w], t)
+				}
+			}
+		}
+		for w, tifs := range caps.TimeInForce {
+			for _, tif := range tifs {
+				if !hasPriceType(merged.TimeInForce[w], tif) {
+					merged.TimeInForce[w] = append(merged.TimeInForce[w], tif)
+				}
+			}
+		}
+	}
+	return merged
+}
+
+// CoverageRow is the exchanges which do an operation, or place an order type, on a wallet
+type CoverageRow struct {
+	Name      string // OperationType or OrderTradeType
+	Wallet    WalletType
+	Exchanges []ExchangeName
+}
+
+// Coverage is the capabilities of the exchanges side by side, a row of every operation and wallet
+// supported by at least one of them
+type Coverage struct {
+	Exchanges   []ExchangeName
+	Rows        []*CoverageRow
+	Orders      []*CoverageRow
+	TimeInForce []*CoverageRow
+}
+
+// NewCoverage is the coverage of the exchanges, the rows by name then wallet
+func NewCoverage(exchanges ...Exchange) *Coverage {
+	coverage := &Coverage{
+		Exchanges: []ExchangeName{},
+	}
+	rows := make(map[string]*CoverageRow)
+	orders := make(map[string]*CoverageRow)
+	tifs := make(map[string]*CoverageRow)
+	add := func(rows map[string]*CoverageRow, name string, w WalletType, ex ExchangeName) {
+		key := fmt.Sprintf("%s %s", name, w)
+		if rows[key] == nil {
+			rows[key] = &CoverageRow{Name: name, Wallet: w}
+		}
+		rows[key].Exchanges = append(rows[key].Exchanges, ex)
+	}
+
+	for _, e := range exchanges {
+		name := e.GetName()
+		coverage.Exchanges = append(coverage.Exchanges, name)
+		caps := e.GetCapabilities()
+		for _, op := range caps.Types() {
+			for _, w := range caps.Wallets(op) {
+				add(rows, string(op), w, name)
+			}
+		}
+		if caps == nil {
+			continue
+		}
+		for w, types := range caps.OrderTypes {
+			for _, t := range types {
+				add(orders, string(t), w, name)
+			}
+		}
+		for w, types := range caps.TimeInForce {
+			for _, tif := range types {
+				add(tifs, string(tif), w, name)
+			}
+		}
+	}
+
+	coverage.Rows = sortRows(rows)
+	coverage.Orders = sortRows(orders)
+	coverage.TimeInForce = sortRows(tifs)
+	return coverage
+}
+
+func sortRows(rows map[string]*CoverageRow) []*CoverageRow {
+	list := []*CoverageRow{}
+	for _, row := range rows {
+		sort.Slice(row.Exchanges, func(i, j int) bool {
+			return row.Exchanges[i] < row.Exchanges[j]
+		})
+		list = append(list, row)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Name != list[j].Name {
+			return list[i].Name < list[j].Name
+		}
+		return walletOrder[list[i].Wallet] < walletOrder[list[j].Wallet]
+	})
+	return list
+}
+
+// Markdown is the coverage as tables of the operations, the order types and the time in force
+func (c *Coverage) Markdown() string {
+	var b strings.Builder
+	table := func(title string, rows []*CoverageRow) {
+		fmt.Fprintf(&b, "| %s | Wallet | Count | Exchanges |\n", title)
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, row := range rows {
+			names := make([]string, len(row.Exchanges))
+			for i, name := range row.Exchanges {
+				names[i] = string(name)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %d | %s |\n", row.Name, row.Wallet, len(row.Exchanges), strings.Join(names, ", "))
+		}
+	}
+
+	fmt.Fprintf(&b, "# Capabilities\n\n%d exchanges\n\n## Operations\n\n", len(c.Exchanges))
+	table("Operation", c.Rows)
+	b.WriteString("\n## Orders\n\n")
+	table("Order Type", c.Orders)
+	b.WriteString("\n## Time In Force\n\n")
+	table("Time In Force", c.TimeInForce)
+	return b.String()
+}
@@ -258,6 +258,29 @@ func (e *Coinbase) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrain
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.GetCoin:      {exchange.SpotWallet},
+		exchange.GetPair:      {exchange.SpotWallet},
+		exchange.Orderbook:    {exchange.SpotWallet},
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.KLine:        {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Coinbase) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Coinbase) UpdateConstraint() {
 	// e.GetCoinsData()
 	// e.GetPairsData()
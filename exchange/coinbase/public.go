@@ -18,7 +18,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://api.pro.coinbase.com"
 )
 
@@ -36,6 +36,11 @@ func (e *Coinbase) LoadPublicData(operation *exchange.PublicOperation) error {
 		}
 	case exchange.TradeHistory:
 		return e.doTradeHistory(operation)
+	case exchange.KLine:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doSpotKline(operation)
+		}
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
@@ -222,6 +227,9 @@ func (e *Coinbase) doSpotOrderBook(op *exchange.PublicOperation) error {
 }
 
 func (e *Coinbase) doTradeHistory(operation *exchange.PublicOperation) error {
+	if operation.IsTradePaged() {
+		return e.doTradePages(operation)
+	}
 	symbol := e.GetSymbolByPair(operation.Pair)
 	strRequestUrl := fmt.Sprintf("/products/%v/trades", symbol)
 	strUrl := API_URL + strRequestUrl
@@ -286,3 +294,132 @@ func (e *Coinbase) doTradeHistory(operation *exchange.PublicOperation) error {
 
 	return nil
 }
+
+// the pages go back from the newest trade, "after" is the cursor of the older page, the smallest trade_id of the page
+func (e *Coinbase) doTradePages(operation *exchange.PublicOperation) error {
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	return exchange.FetchTrades(operation, true, func(cursor string) ([]*exchange.TradeDetail, string, error) {
+		tradeHistory := TradeHistory{}
+
+		get := &utils.HttpGet{
+			URI:   fmt.Sprintf("%s/products/%s/trades?limit=1000", API_URL, symbol),
+			Proxy: operation.Proxy,
+		}
+		if cursor != "" {
+			get.URI += "&after=" + cursor
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, "", err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &tradeHistory); err != nil {
+			return nil, "", fmt.Errorf("%s doTradePages Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		}
+
+		next := ""
+		minID := 0
+		trades := []*exchange.TradeDetail{}
+		for _, trade := range tradeHistory {
+			price, err := strconv.ParseFloat(trade.Price, 64)
+			if err != nil {
+				return nil, "", fmt.Errorf("%s price parse Err: %v %v", e.GetName(), err, trade.Price)
+			}
+			amount, err := strconv.ParseFloat(trade.Size, 64)
+			if err != nil {
+				return nil, "", fmt.Errorf("%s amount parse Err: %v %v", e.GetName(), err, trade.Size)
+			}
+
+			td := &exchange.TradeDetail{
+				ID:        fmt.Sprintf("%v", trade.TradeID),
+				Quantity:  amount,
+				TimeStamp: trade.Time.UnixNano() / 1e6,
+				Rate:      price,
+			}
+			if trade.Side == "buy" {
+				td.Direction = exchange.Buy
+			} else if trade.Side == "sell" {
+				td.Direction = exchange.Sell
+			}
+			trades = append(trades, td)
+
+			if minID == 0 || trade.TradeID < minID {
+				minID = trade.TradeID
+				next = td.ID
+			}
+		}
+		return trades, next, nil
+	})
+}
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:  "60",
+	exchange.KLINE_5MIN:  "300",
+	exchange.KLINE_15MIN: "900",
+	exchange.KLINE_1HOUR: "3600",
+	exchange.KLINE_6HOUR: "21600",
+	exchange.KLINE_1DAY:  "86400",
+}
+
+func (e *Coinbase) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	// at most 300 candles a request
+	return exchange.FetchKline(operation, 300, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		kline := [][]float64{}
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/products/%s/candles?granularity=%s", API_URL, symbol, interval),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&start=%s", time.Unix(0, start*1e6).UTC().Format(time.RFC3339))
+			if end == 0 {
+				end = time.Now().UnixNano() / 1e6
+			}
+			get.URI += fmt.Sprintf("&end=%s", time.Unix(0, end*1e6).UTC().Format(time.RFC3339))
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &kline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		}
+
+		// [ time, low, high, open, close, volume ], newest first
+		details := []*exchange.KlineDetail{}
+		for _, k := range kline {
+			if len(k) < 6 {
+				return nil, fmt.Errorf("%s doSpotKline invalid candle: %v", e.GetName(), k)
+			}
+			details = append(details, &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: k[0] * 1000,
+				Open:     k[3],
+				High:     k[2],
+				Low:      k[1],
+				Close:    k[4],
+				Volume:   k[5],
+			})
+		}
+		return details, nil
+	})
+}
@@ -20,8 +20,9 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
-	API_URL string = "https://openapi-exchange.coinbene.com" //"http://api.coinbene.com"
+var (
+	API_URL      string = "https://openapi-exchange.coinbene.com" //"http://api.coinbene.com"
+	WITHDRAW_URL string = "http://api.coinbene.com"
 )
 
 /*API Base Knowledge
@@ -405,15 +406,17 @@ func (e *Coinbene) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/exchange/v2/order/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["orderType"] = "1" // 1: Limit price 2: Market price
 	mapParams["direction"] = "2" // 1: buy 2: sell
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -447,15 +450,17 @@ func (e *Coinbene) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/exchange/v2/order/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["orderType"] = "1" // 1: Limit price 2: Market price
 	mapParams["direction"] = "1" // 1: buy 2: sell
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -647,7 +652,7 @@ func (e *Coinbene) ApiKeyWithdraw(strRequestPath string, mapParams map[string]st
 	timestamp = fmt.Sprintf("%v", time.Now().UTC().Format("2006-01-02T15:04:05.009Z"))
 
 	// add condition here
-	strUrl := "http://api.coinbene.com" + strRequestPath //+ "?" + exchange.Map2UrlQuery(mapParams)
+	strUrl := WITHDRAW_URL + strRequestPath //+ "?" + exchange.Map2UrlQuery(mapParams)
 
 	httpClient := &http.Client{}
 
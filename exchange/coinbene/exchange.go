@@ -247,6 +247,25 @@ func (e *Coinbene) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrain
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Coinbene) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Coinbene) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
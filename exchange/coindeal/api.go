@@ -21,8 +21,9 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
-	API_URL = "https://apigateway.coindeal.com"
+var (
+	API_URL    = "https://apigateway.coindeal.com"
+	TICKER_URL = "https://coinmarketcap.coindeal.com/api/v1" // the coins and pairs
 )
 
 /*API Base Knowledge
@@ -52,7 +53,7 @@ Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestPath)*/
 func (e *Coindeal) GetCoinsData() error {
 	coinsData := CoinsData{}
-	strUrl := "https://coinmarketcap.coindeal.com/api/v1/ticker"
+	strUrl := TICKER_URL + "/ticker"
 
 	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
 	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
@@ -132,7 +133,7 @@ Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
 func (e *Coindeal) GetPairsData() error {
 	pairsData := PairsData{}
-	strUrl := "https://coinmarketcap.coindeal.com/api/v1/ticker"
+	strUrl := TICKER_URL + "/ticker"
 
 	jsonSymbolsReturn := exchange.HttpGetRequest(strUrl, nil)
 	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &pairsData); err != nil {
@@ -316,6 +317,11 @@ func (e *Coindeal) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -324,8 +330,8 @@ func (e *Coindeal) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -354,6 +360,11 @@ func (e *Coindeal) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -362,8 +373,8 @@ func (e *Coindeal) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
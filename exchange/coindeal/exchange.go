@@ -249,6 +249,19 @@ func (e *Coindeal) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrain
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Coindeal) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Coindeal) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -247,6 +247,19 @@ func (e *Coineal) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainF
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Coineal) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Coineal) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -249,6 +249,38 @@ func (e *Coinex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory:   {exchange.SpotWallet},
+		exchange.CoinChainType:  {exchange.SpotWallet},
+		exchange.Orderbook:      {exchange.SpotWallet},
+		exchange.KLine:          {exchange.SpotWallet},
+		exchange.GetTickerPrice: {exchange.SpotWallet},
+		exchange.Ticker:         {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.SubAccountTransfer:   {exchange.SpotWallet},
+		exchange.BalanceList:          {exchange.SpotWallet},
+		exchange.Withdraw:             {exchange.SpotWallet},
+		exchange.SubBalanceList:       {exchange.SpotWallet},
+		exchange.SubAllBalanceList:    {exchange.SpotWallet},
+		exchange.GetOpenOrder:         {exchange.SpotWallet},
+		exchange.GetWithdrawalHistory: {exchange.SpotWallet},
+		exchange.GetDepositHistory:    {exchange.SpotWallet},
+		exchange.GetTransferHistory:   {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Coinex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Coinex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -31,100 +31,119 @@ func (e *Coinex) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doTickerPrice(operation)
 		}
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
-// interval options: 1min, 3min, 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 12hour, 1day, 3day, 1week
-func (e *Coinex) doSpotKline(operation *exchange.PublicOperation) error {
-	interval := "5min"
-	if operation.KlineInterval != "" {
-		interval = operation.KlineInterval
-	}
-
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://api.coinex.com/v1/market/kline?market=%v&type=%v&limit=1000", // ETHBTC
-			e.GetSymbolByPair(operation.Pair), // BTCUSDT
-			interval,
-		),
-		Proxy: operation.Proxy,
-	}
-
-	err := utils.HttpGetRequest(get)
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1min",
+	exchange.KLINE_3MIN:   "3min",
+	exchange.KLINE_5MIN:   "5min",
+	exchange.KLINE_15MIN:  "15min",
+	exchange.KLINE_30MIN:  "30min",
+	exchange.KLINE_1HOUR:  "1hour",
+	exchange.KLINE_2HOUR:  "2hour",
+	exchange.KLINE_4HOUR:  "4hour",
+	exchange.KLINE_6HOUR:  "6hour",
+	exchange.KLINE_12HOUR: "12hour",
+	exchange.KLINE_1DAY:   "1day",
+	exchange.KLINE_3DAY:   "3day",
+	exchange.KLINE_1WEEK:  "1week",
+}
 
+func (e *Coinex) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
 	if err != nil {
-		log.Printf("%+v", err)
 		operation.Error = err
 		return err
-
 	}
 
-	if operation.DebugMode {
-		operation.RequestURI = get.URI
-		operation.CallResponce = string(get.ResponseBody)
-	}
-
-	jsonKLine := get.ResponseBody
-	jsonResponse := &JsonResponse{}
-	var rawKline [][]interface{}
+	// no time range, the latest 1000 klines
+	return exchange.FetchKline(operation, 0, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/v1/market/kline?market=%v&type=%v&limit=1000", // ETHBTC
+				API_URL,
+				e.GetSymbolByPair(operation.Pair), // BTCUSDT
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
 
-	if err := json.Unmarshal([]byte(jsonKLine), &jsonResponse); err != nil {
-		return fmt.Errorf("%s doSpotKline Json Unmarshal Err: %s %s", e.GetName(), err, jsonKLine)
-	} else if jsonResponse.Code != 0 {
-		return fmt.Errorf("%s doSpotKline Failed: %s", e.GetName(), jsonKLine)
-	}
-	if err := json.Unmarshal(jsonResponse.Data, &rawKline); err != nil {
-		return fmt.Errorf("%s doSpotKline Result Unmarshal Err: %s %s", e.GetName(), err, jsonResponse.Data)
-	}
+		err := utils.HttpGetRequest(get)
 
-	operation.Kline = []*exchange.KlineDetail{}
-	for _, k := range rawKline {
-		open, err := strconv.ParseFloat(k[1].(string), 64)
-		if err != nil {
-			log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
-			operation.Error = err
-			return err
-		}
-		close, err := strconv.ParseFloat(k[2].(string), 64)
 		if err != nil {
-			log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[2])
-			operation.Error = err
-			return err
+			log.Printf("%+v", err)
+			return nil, err
+
 		}
-		high, err := strconv.ParseFloat(k[3].(string), 64)
-		if err != nil {
-			log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[3])
-			operation.Error = err
-			return err
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
 		}
-		low, err := strconv.ParseFloat(k[4].(string), 64)
-		if err != nil {
-			log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[4])
-			operation.Error = err
-			return err
+
+		jsonKLine := get.ResponseBody
+		jsonResponse := &JsonResponse{}
+		var rawKline [][]interface{}
+
+		if err := json.Unmarshal([]byte(jsonKLine), &jsonResponse); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %s %s", e.GetName(), err, jsonKLine)
+		} else if jsonResponse.Code != 0 {
+			return nil, fmt.Errorf("%s doSpotKline Failed: %s", e.GetName(), jsonKLine)
 		}
-		volume, err := strconv.ParseFloat(k[5].(string), 64)
-		if err != nil {
-			log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[5])
-			operation.Error = err
-			return err
+		if err := json.Unmarshal(jsonResponse.Data, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Result Unmarshal Err: %s %s", e.GetName(), err, jsonResponse.Data)
 		}
 
-		detail := &exchange.KlineDetail{
-			Exchange: e.GetName(),
-			Pair:     operation.Pair.Name,
-			OpenTime: k[0].(float64) * 1000,
-			Open:     open,
-			High:     high,
-			Low:      low,
-			Close:    close,
-			Volume:   volume,
-		}
+		details := []*exchange.KlineDetail{}
+		for _, k := range rawKline {
+			open, err := strconv.ParseFloat(k[1].(string), 64)
+			if err != nil {
+				log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
+				return nil, err
+			}
+			close, err := strconv.ParseFloat(k[2].(string), 64)
+			if err != nil {
+				log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[2])
+				return nil, err
+			}
+			high, err := strconv.ParseFloat(k[3].(string), 64)
+			if err != nil {
+				log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[3])
+				return nil, err
+			}
+			low, err := strconv.ParseFloat(k[4].(string), 64)
+			if err != nil {
+				log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[4])
+				return nil, err
+			}
+			volume, err := strconv.ParseFloat(k[5].(string), 64)
+			if err != nil {
+				log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[5])
+				return nil, err
+			}
 
-		operation.Kline = append(operation.Kline, detail)
-	}
+			detail := &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: k[0].(float64) * 1000,
+				Open:     open,
+				High:     high,
+				Low:      low,
+				Close:    close,
+				Volume:   volume,
+			}
 
-	return nil
+			details = append(details, detail)
+		}
+
+		return details, nil
+	})
 }
 
 func (e *Coinex) doTickerPrice(operation *exchange.PublicOperation) error {
@@ -186,6 +205,70 @@ func (e *Coinex) doTickerPrice(operation *exchange.PublicOperation) error {
 	return nil
 }
 
+func (e *Coinex) doTicker(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	ticker := TickerPrice{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/v1/market/ticker/all", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != 0 {
+		operation.Error = fmt.Errorf("%s doTicker Failed: %s", e.GetName(), get.ResponseBody)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Ticker = []*exchange.TickerDetail{}
+	for symbol, t := range ticker.Ticker {
+		p := e.GetPairBySymbol(symbol)
+		if p == nil {
+			continue
+		}
+
+		values, err := exchange.ParseFloats(t.Last, t.Buy, t.BuyAmount, t.Sell, t.SellAmount, t.Open, t.High, t.Low, t.Vol)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), symbol, err)
+			return operation.Error
+		}
+
+		detail := &exchange.TickerDetail{
+			Pair:            p,
+			Last:            values[0],
+			BestBid:         values[1],
+			BestBidQuantity: values[2],
+			BestAsk:         values[3],
+			BestAskQuantity: values[4],
+			Open:            values[5],
+			High:            values[6],
+			Low:             values[7],
+			Volume:          values[8],
+			Timestamp:       ticker.Date,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	return nil
+}
+
 func (e *Coinex) doSpotOrderBook(op *exchange.PublicOperation) error {
 	jsonResponse := &JsonResponse{}
 	orderBook := OrderBook{}
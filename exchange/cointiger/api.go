@@ -22,7 +22,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://api.cointiger.com/exchange/trading"
 )
 
@@ -328,14 +328,19 @@ func (e *Cointiger) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchang
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/order"
 
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["volume"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["volume"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "1"
 	mapParams["time"] = strconv.FormatInt(time.Now().UTC().UnixNano(), 10)[:13]
@@ -370,14 +375,19 @@ func (e *Cointiger) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/order"
 
 	mapParams := make(map[string]interface{})
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["volume"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["volume"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "1"
 	mapParams["time"] = strconv.FormatInt(time.Now().UTC().UnixNano(), 10)[:13]
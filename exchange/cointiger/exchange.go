@@ -249,6 +249,19 @@ func (e *Cointiger) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrai
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Cointiger) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Cointiger) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
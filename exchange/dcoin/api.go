@@ -23,7 +23,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://openapi.dcoin.com/open/api"
 )
 
@@ -249,6 +249,19 @@ func (e *Dcoin) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Dcoin) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Dcoin) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
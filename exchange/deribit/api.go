@@ -20,7 +20,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://test.deribit.com/api/v2"
 )
 
@@ -215,15 +215,7 @@ func (e *Deribit) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
 	return maker, err
 }
 
-func (e *Deribit) LoadPublicData(operation *exchange.PublicOperation) error {
-	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
-}
-
 /*************** Private API ***************/
-func (e *Deribit) DoAccountOperation(operation *exchange.AccountOperation) error {
-	return nil
-}
-
 func (e *Deribit) UpdateAllBalances() {
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		log.Printf("%s API Key or Secret Key are nil.", e.GetName())
@@ -294,6 +286,11 @@ func (e *Deribit) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -302,8 +299,8 @@ func (e *Deribit) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -332,6 +329,11 @@ func (e *Deribit) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -340,8 +342,8 @@ func (e *Deribit) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -453,52 +455,40 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Deribit) ApiKeyGet(strRequestPath string, mapParams map[string]string) string {
-	mapParams["signature"] = exchange.ComputeHmac256NoDecode(exchange.Map2UrlQuery(mapParams), e.API_SECRET)
-
-	payload := exchange.Map2UrlQuery(mapParams)
-	strUrl := API_URL + strRequestPath + "?" + payload
-
-	request, err := http.NewRequest("GET", strUrl, nil)
-	if nil != err {
-		return err.Error()
+	if len(mapParams) > 0 {
+		strRequestPath += "?" + exchange.Map2UrlQuery(mapParams)
 	}
-	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
-
-	httpClient := &http.Client{}
-	response, err := httpClient.Do(request)
-	if nil != err {
-		return err.Error()
-	}
-	defer response.Body.Close()
-
-	body, err := ioutil.ReadAll(response.Body)
-	if nil != err {
-		return err.Error()
-	}
-
-	return string(body)
+	return e.signedRequest("GET", strRequestPath, "")
 }
 
 /*Method: API Request and Signature is required
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request*/
 func (e *Deribit) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[string]string) string {
-	strUrl := API_URL + strRequestPath
-
-	mapParams["signature"] = exchange.ComputeHmac256NoDecode(exchange.Map2UrlQuery(mapParams), e.API_SECRET)
 	jsonParams := ""
-	if nil != mapParams {
+	if len(mapParams) > 0 {
 		bytesParams, _ := json.Marshal(mapParams)
 		jsonParams = string(bytesParams)
 	}
+	return e.signedRequest(strMethod, strRequestPath, jsonParams)
+}
 
+// deri-hmac-sha256 signature of timestamp, nonce, method, request uri and body
+func (e *Deribit) signedRequest(strMethod, strRequestPath, jsonParams string) string {
+	strUrl := API_URL + strRequestPath
 	request, err := http.NewRequest(strMethod, strUrl, bytes.NewBuffer([]byte(jsonParams)))
 	if nil != err {
 		return err.Error()
 	}
-	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
+	uri := request.URL.RequestURI()
+
+	timestamp := strconv.FormatInt(time.Now().UnixNano()/1e6, 10)
+	nonce := strconv.FormatInt(time.Now().UnixNano(), 36)
+	strPayload := timestamp + "\n" + nonce + "\n" + strMethod + "\n" + uri + "\n" + jsonParams + "\n"
+	signature := exchange.ComputeHmac256NoDecode(strPayload, e.API_SECRET)
+
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Authorization", fmt.Sprintf("deri-hmac-sha256 id=%s,ts=%s,sig=%s,nonce=%s", e.API_KEY, timestamp, signature, nonce))
 
 	httpClient := &http.Client{}
 	response, err := httpClient.Do(request)
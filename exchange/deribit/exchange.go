@@ -250,6 +250,33 @@ func (e *Deribit) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainF
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.FundingRate:  {exchange.ContractWallet},
+		exchange.OptionChain:  {exchange.OptionWallet},
+		exchange.OptionTicker: {exchange.OptionWallet},
+		exchange.IndexPrice:   {exchange.ContractWallet, exchange.OptionWallet},
+		exchange.BookSummary:  {exchange.ContractWallet, exchange.OptionWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.GetPositions:    {exchange.ContractWallet, exchange.OptionWallet},
+		exchange.SetMarginType:   {exchange.ContractWallet},
+		exchange.SetPositionMode: {exchange.ContractWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.ContractWallet: {exchange.TRADE_LIMIT},
+		exchange.OptionWallet:   {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.ContractWallet: {exchange.GTC},
+		exchange.OptionWallet:   {exchange.GTC},
+	},
+}
+
+func (e *Deribit) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Deribit) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -9,10 +9,14 @@ import "encoding/json"
 type JsonResponse struct {
 	Jsonrpc string          `json:"jsonrpc"`
 	Data    json.RawMessage `json:"result"`
-	UsIn    int64           `json:"usIn"`
-	UsOut   int64           `json:"usOut"`
-	UsDiff  int             `json:"usDiff"`
-	Testnet bool            `json:"testnet"`
+	Error   *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+	UsIn    int64 `json:"usIn"`
+	UsOut   int64 `json:"usOut"`
+	UsDiff  int   `json:"usDiff"`
+	Testnet bool  `json:"testnet"`
 }
 
 /********** Public API Structure**********/
@@ -85,3 +89,84 @@ type PlaceOrder struct {
 	Status       string `json:"status"`
 	TimeInForce  string `json:"timeInForce"`
 }
+
+type Positions []struct {
+	InstrumentName            string  `json:"instrument_name"`
+	Kind                      string  `json:"kind"`
+	Direction                 string  `json:"direction"`
+	Size                      float64 `json:"size"`
+	SizeCurrency              float64 `json:"size_currency"`
+	AveragePrice              float64 `json:"average_price"`
+	MarkPrice                 float64 `json:"mark_price"`
+	IndexPrice                float64 `json:"index_price"`
+	EstimatedLiquidationPrice float64 `json:"estimated_liquidation_price"`
+	Leverage                  float64 `json:"leverage"`
+	InitialMargin             float64 `json:"initial_margin"`
+	MaintenanceMargin         float64 `json:"maintenance_margin"`
+	FloatingProfitLoss        float64 `json:"floating_profit_loss"`
+	RealizedProfitLoss        float64 `json:"realized_profit_loss"`
+	TotalProfitLoss           float64 `json:"total_profit_loss"`
+	Delta                     float64 `json:"delta"`
+	Gamma                     float64 `json:"gamma"`
+	Vega                      float64 `json:"vega"`
+	Theta                     float64 `json:"theta"`
+}
+
+type Ticker struct {
+	InstrumentName string  `json:"instrument_name"`
+	State          string  `json:"state"`
+	LastPrice      float64 `json:"last_price"`
+	MarkPrice      float64 `json:"mark_price"`
+	IndexPrice     float64 `json:"index_price"`
+	BestBidPrice   float64 `json:"best_bid_price"`
+	BestAskPrice   float64 `json:"best_ask_price"`
+	OpenInterest   float64 `json:"open_interest"`
+	CurrentFunding float64 `json:"current_funding"`
+	Funding8H      float64 `json:"funding_8h"`
+	Timestamp      int64   `json:"timestamp"`
+
+	// options only
+	MarkIv          float64 `json:"mark_iv"`
+	BidIv           float64 `json:"bid_iv"`
+	AskIv           float64 `json:"ask_iv"`
+	UnderlyingPrice float64 `json:"underlying_price"`
+	UnderlyingIndex string  `json:"underlying_index"`
+	Greeks          struct {
+		Delta float64 `json:"delta"`
+		Gamma float64 `json:"gamma"`
+		Vega  float64 `json:"vega"`
+		Theta float64 `json:"theta"`
+		Rho   float64 `json:"rho"`
+	} `json:"greeks"`
+}
+
+type IndexPrice struct {
+	IndexPrice             float64 `json:"index_price"`
+	EstimatedDeliveryPrice float64 `json:"estimated_delivery_price"`
+}
+
+type BookSummary []struct {
+	InstrumentName    string  `json:"instrument_name"`
+	BaseCurrency      string  `json:"base_currency"`
+	MarkPrice         float64 `json:"mark_price"`
+	MarkIv            float64 `json:"mark_iv"`
+	BidPrice          float64 `json:"bid_price"`
+	AskPrice          float64 `json:"ask_price"`
+	Last              float64 `json:"last"`
+	High              float64 `json:"high"`
+	Low               float64 `json:"low"`
+	UnderlyingPrice   float64 `json:"underlying_price"`
+	UnderlyingIndex   string  `json:"underlying_index"`
+	InterestRate      float64 `json:"interest_rate"`
+	Volume            float64 `json:"volume"`
+	OpenInterest      float64 `json:"open_interest"`
+	CreationTimestamp int64   `json:"creation_timestamp"`
+}
+
+type FundingRateHistory []struct {
+	Timestamp      int64   `json:"timestamp"`
+	Interest8H     float64 `json:"interest_8h"`
+	Interest1H     float64 `json:"interest_1h"`
+	IndexPrice     float64 `json:"index_price"`
+	PrevIndexPrice float64 `json:"prev_index_price"`
+}
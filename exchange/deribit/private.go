@@ -0,0 +1,158 @@
+package deribit
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Deribit) DoAccountOperation(operation *exchange.AccountOperation) error {
+	switch operation.Type {
+	case exchange.GetPositions:
+		if operation.Wallet == exchange.ContractWallet || operation.Wallet == exchange.OptionWallet {
+			return e.doGetPositions(operation)
+		}
+	case exchange.SetLeverage:
+		if operation.Wallet == exchange.ContractWallet {
+			return fmt.Errorf("%s SetLeverage: %w", e.GetName(), exchange.ErrNotSupported)
+		}
+	case exchange.SetMarginType:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetMarginType(operation)
+		}
+	case exchange.SetPositionMode:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetPositionMode(operation)
+		}
+	case exchange.AdjustIsolatedMargin:
+		if operation.Wallet == exchange.ContractWallet {
+			return fmt.Errorf("%s AdjustIsolatedMargin: %w", e.GetName(), exchange.ErrNotSupported)
+		}
+	}
+	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
+}
+
+// Deribit positions are queried per currency, all positions share the portfolio (cross) margin
+// ContractWallet returns the future positions, OptionWallet the option positions with their greeks
+func (e *Deribit) doGetPositions(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	currencies := []string{"BTC", "ETH"}
+	if operation.Pair != nil {
+		currencies = []string{strings.Split(e.GetSymbolByPair(operation.Pair), "-")[0]}
+	} else if operation.Coin != nil {
+		currencies = []string{e.GetSymbolByCoin(operation.Coin)}
+	}
+
+	operation.Positions = []*exchange.Position{}
+	for _, currency := range currencies {
+		jsonResponse := &JsonResponse{}
+		positions := Positions{}
+		strRequest := "/private/get_positions"
+
+		mapParams := make(map[string]string)
+		mapParams["currency"] = currency
+		if operation.Wallet == exchange.OptionWallet {
+			mapParams["kind"] = "option"
+		} else {
+			mapParams["kind"] = "future"
+		}
+
+		jsonPositionReturn := e.ApiKeyGet(strRequest, mapParams)
+		if operation.DebugMode {
+			operation.RequestURI = strRequest
+			operation.CallResponce = jsonPositionReturn
+		}
+
+		if err := json.Unmarshal([]byte(jsonPositionReturn), &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doGetPositions Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPositionReturn)
+			return operation.Error
+		} else if jsonResponse.Error != nil {
+			operation.Error = fmt.Errorf("%s doGetPositions Failed: %v", e.GetName(), jsonPositionReturn)
+			return operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &positions); err != nil {
+			operation.Error = fmt.Errorf("%s doGetPositions Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+			return operation.Error
+		}
+
+		for _, p := range positions {
+			if p.Size == 0 {
+				continue
+			}
+			if operation.Pair != nil && p.InstrumentName != e.GetSymbolByPair(operation.Pair) {
+				continue
+			}
+
+			position := &exchange.Position{
+				Pair:             e.GetPairBySymbol(p.InstrumentName),
+				Instrument:       p.InstrumentName,
+				Size:             math.Abs(p.Size),
+				EntryPrice:       p.AveragePrice,
+				MarkPrice:        p.MarkPrice,
+				LiquidationPrice: p.EstimatedLiquidationPrice,
+				Leverage:         p.Leverage,
+				MarginMode:       exchange.CrossMargin,
+				UnrealizedPnl:    p.FloatingProfitLoss,
+				RealizedPnl:      p.RealizedProfitLoss,
+				Timestamp:        jsonResponse.UsOut / 1000,
+			}
+			if p.Direction == "buy" {
+				position.Side = exchange.PositionLong
+			} else {
+				position.Side = exchange.PositionShort
+			}
+			if p.Kind == "option" {
+				position.Greeks = &exchange.Greeks{
+					Delta: p.Delta,
+					Gamma: p.Gamma,
+					Vega:  p.Vega,
+					Theta: p.Theta,
+				}
+			}
+
+			operation.Positions = append(operation.Positions, position)
+		}
+	}
+
+	return nil
+}
+
+// Deribit margins the whole portfolio, there is no isolated margin per position
+func (e *Deribit) doSetMarginType(operation *exchange.AccountOperation) error {
+	if operation.MarginMode != exchange.CrossMargin {
+		return fmt.Errorf("%s SetMarginType %v: %w", e.GetName(), operation.MarginMode, exchange.ErrNotSupported)
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		MarginMode: exchange.CrossMargin,
+	}
+	if operation.Pair != nil {
+		operation.LeverageSetting.Instrument = e.GetSymbolByPair(operation.Pair)
+	}
+
+	return nil
+}
+
+// Deribit positions are always one-way
+func (e *Deribit) doSetPositionMode(operation *exchange.AccountOperation) error {
+	if operation.PositionMode != exchange.OneWayMode {
+		return fmt.Errorf("%s SetPositionMode %v: %w", e.GetName(), operation.PositionMode, exchange.ErrNotSupported)
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		PositionMode: exchange.OneWayMode,
+	}
+
+	return nil
+}
@@ -0,0 +1,295 @@
+package deribit
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
+)
+
+/*************** PUBLIC  API ***************/
+func (e *Deribit) LoadPublicData(operation *exchange.PublicOperation) error {
+	switch operation.Type {
+	case exchange.FundingRate:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doFundingRate(operation)
+		}
+	case exchange.OptionChain:
+		return e.doOptionChain(operation)
+	case exchange.OptionTicker:
+		return e.doOptionTicker(operation)
+	case exchange.IndexPrice:
+		return e.doIndexPrice(operation)
+	case exchange.BookSummary:
+		return e.doBookSummary(operation)
+	}
+	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
+}
+
+// perpetuals are not loaded as pairs, the instrument is built from the pair, eg. USD|BTC -> BTC-PERPETUAL
+func getPerpetualName(operation *exchange.PublicOperation) string {
+	return operation.Pair.Target.Code + "-PERPETUAL"
+}
+
+// deribit funding is continuous, rates are reported as the 8 hour equivalent to compare with other exchanges
+func (e *Deribit) doFundingRate(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	ticker := Ticker{}
+	instrument := getPerpetualName(operation)
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/public/ticker?instrument_name=%s", API_URL, instrument),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if jsonResponse.Error != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Failed: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.FundingRate = &exchange.FundingRateDetail{
+		Pair:        operation.Pair,
+		Instrument:  instrument,
+		CurrentRate: ticker.Funding8H,
+		History:     []*exchange.FundingRateHistory{},
+	}
+
+	// both ends of the range are required, default to the last 24 hours
+	endTime := operation.FundingEndTime
+	if endTime == 0 {
+		endTime = time.Now().UnixNano() / int64(time.Millisecond)
+	}
+	startTime := operation.FundingStartTime
+	if startTime == 0 {
+		startTime = endTime - int64(24*time.Hour/time.Millisecond)
+	}
+
+	jsonResponse = &JsonResponse{}
+	fundingHistory := FundingRateHistory{}
+	get = &utils.HttpGet{
+		URI: fmt.Sprintf("%s/public/get_funding_rate_history?instrument_name=%s&start_timestamp=%d&end_timestamp=%d",
+			API_URL, instrument, startTime, endTime),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if jsonResponse.Error != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Failed: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &fundingHistory); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	for _, f := range fundingHistory {
+		operation.FundingRate.History = append(operation.FundingRate.History, &exchange.FundingRateHistory{
+			Rate:      f.Interest8H,
+			Timestamp: f.Timestamp,
+		})
+	}
+
+	return nil
+}
+
+// the underlying currency from Coin, or from the prefix of Instrument, eg. BTC-27DEC19-8000-C -> BTC
+func getCurrency(operation *exchange.PublicOperation) string {
+	if operation.Coin != nil {
+		return operation.Coin.Code
+	}
+	return strings.Split(operation.Instrument, "-")[0]
+}
+
+func (e *Deribit) doOptionChain(operation *exchange.PublicOperation) error {
+	if operation.Coin == nil {
+		return fmt.Errorf("%s doOptionChain Coin is empty", e.GetName())
+	}
+
+	contractsData := ContractsData{}
+	strRequest := fmt.Sprintf("/public/get_instruments?currency=%s&kind=option&expired=false", getCurrency(operation))
+	if err := e.publicRequest(operation, strRequest, &contractsData); err != nil {
+		return err
+	}
+
+	operation.OptionChain = []*exchange.OptionInstrument{}
+	for _, data := range contractsData {
+		if !data.IsActive {
+			continue
+		}
+
+		option := &exchange.OptionInstrument{
+			Instrument:     data.InstrumentName,
+			Underlying:     operation.Coin,
+			Strike:         data.Strike,
+			Expiry:         data.ExpirationTimestamp,
+			ContractSize:   data.ContractSize,
+			MinTradeAmount: data.MinTradeAmount,
+			TickSize:       data.TickSize,
+		}
+		if data.OptionType == "call" {
+			option.OptionType = exchange.CallOption
+		} else {
+			option.OptionType = exchange.PutOption
+		}
+		operation.OptionChain = append(operation.OptionChain, option)
+	}
+
+	return nil
+}
+
+func (e *Deribit) doOptionTicker(operation *exchange.PublicOperation) error {
+	if operation.Instrument == "" {
+		return fmt.Errorf("%s doOptionTicker Instrument is empty", e.GetName())
+	}
+
+	ticker := Ticker{}
+	strRequest := fmt.Sprintf("/public/ticker?instrument_name=%s", operation.Instrument)
+	if err := e.publicRequest(operation, strRequest, &ticker); err != nil {
+		return err
+	}
+
+	operation.OptionTicker = &exchange.OptionTickerDetail{
+		Instrument:      ticker.InstrumentName,
+		MarkPrice:       ticker.MarkPrice,
+		MarkIV:          ticker.MarkIv,
+		BidIV:           ticker.BidIv,
+		AskIV:           ticker.AskIv,
+		BestBidPrice:    ticker.BestBidPrice,
+		BestAskPrice:    ticker.BestAskPrice,
+		UnderlyingPrice: ticker.UnderlyingPrice,
+		IndexPrice:      ticker.IndexPrice,
+		OpenInterest:    ticker.OpenInterest,
+		Greeks: &exchange.Greeks{
+			Delta: ticker.Greeks.Delta,
+			Gamma: ticker.Greeks.Gamma,
+			Vega:  ticker.Greeks.Vega,
+			Theta: ticker.Greeks.Theta,
+			Rho:   ticker.Greeks.Rho,
+		},
+		Timestamp: ticker.Timestamp,
+	}
+
+	return nil
+}
+
+// deribit index names are the lower case currency against usd, eg. btc_usd
+func (e *Deribit) doIndexPrice(operation *exchange.PublicOperation) error {
+	if operation.Coin == nil && operation.Instrument == "" {
+		return fmt.Errorf("%s doIndexPrice Coin is empty", e.GetName())
+	}
+
+	indexPrice := IndexPrice{}
+	strRequest := fmt.Sprintf("/public/get_index_price?index_name=%s_usd", strings.ToLower(getCurrency(operation)))
+	if err := e.publicRequest(operation, strRequest, &indexPrice); err != nil {
+		return err
+	}
+
+	operation.IndexPrice = indexPrice.IndexPrice
+
+	return nil
+}
+
+// summary of Instrument if given, otherwise of all options of Coin, or all futures for ContractWallet
+func (e *Deribit) doBookSummary(operation *exchange.PublicOperation) error {
+	bookSummary := BookSummary{}
+	strRequest := ""
+	if operation.Instrument != "" {
+		strRequest = fmt.Sprintf("/public/get_book_summary_by_instrument?instrument_name=%s", operation.Instrument)
+	} else if operation.Coin != nil {
+		kind := "option"
+		if operation.Wallet == exchange.ContractWallet {
+			kind = "future"
+		}
+		strRequest = fmt.Sprintf("/public/get_book_summary_by_currency?currency=%s&kind=%s", getCurrency(operation), kind)
+	} else {
+		return fmt.Errorf("%s doBookSummary Instrument and Coin are empty", e.GetName())
+	}
+
+	if err := e.publicRequest(operation, strRequest, &bookSummary); err != nil {
+		return err
+	}
+
+	operation.BookSummary = []*exchange.BookSummaryDetail{}
+	for _, b := range bookSummary {
+		operation.BookSummary = append(operation.BookSummary, &exchange.BookSummaryDetail{
+			Instrument:      b.InstrumentName,
+			MarkPrice:       b.MarkPrice,
+			MarkIV:          b.MarkIv,
+			BidPrice:        b.BidPrice,
+			AskPrice:        b.AskPrice,
+			LastPrice:       b.Last,
+			UnderlyingPrice: b.UnderlyingPrice,
+			Volume:          b.Volume,
+			OpenInterest:    b.OpenInterest,
+			Timestamp:       b.CreationTimestamp,
+		})
+	}
+
+	return nil
+}
+
+// GET a public method and unmarshal its result into data
+func (e *Deribit) publicRequest(operation *exchange.PublicOperation, strRequest string, data interface{}) error {
+	jsonResponse := &JsonResponse{}
+
+	get := &utils.HttpGet{
+		URI:       API_URL + strRequest,
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v %v", e.GetName(), operation.Type, err, string(get.ResponseBody))
+		return operation.Error
+	} else if jsonResponse.Error != nil {
+		operation.Error = fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, data); err != nil {
+		operation.Error = fmt.Errorf("%s %s Result Unmarshal Err: %v %s", e.GetName(), operation.Type, err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	return nil
+}
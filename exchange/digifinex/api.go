@@ -19,11 +19,11 @@ import (
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
 
-	"strconv"
 )
 
-const (
-	API_URL string = "https://openapi.digifinex.com/v2"
+var (
+	API_URL    string = "https://openapi.digifinex.com/v2"
+	API_V3_URL string = "https://openapi.digifinex.com/v3"
 )
 
 var timeDiff int64
@@ -57,7 +57,7 @@ func (e *Digifinex) GetCoinsData() error {
 	jsonResponse := &JsonResponse{}
 	pairsData := PairsData{}
 
-	strUrl := "https://openapi.digifinex.com/v3/markets"
+	strUrl := API_V3_URL + "/markets"
 
 	jsonCurrencyReturn := exchange.HttpGetRequest(strUrl, nil)
 	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &jsonResponse); err != nil {
@@ -143,7 +143,7 @@ func (e *Digifinex) GetPairsData() error {
 	jsonResponse := &JsonResponse{}
 	pairsData := PairsData{}
 
-	strUrl := "https://openapi.digifinex.com/v3/markets"
+	strUrl := API_V3_URL + "/markets"
 
 	jsonSymbolsReturn := exchange.HttpGetRequest(strUrl, nil)
 	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &jsonResponse); err != nil {
@@ -203,7 +203,7 @@ func (e *Digifinex) OrderBook(pair *pair.Pair) (*exchange.Maker, error) {
 	orderBook := OrderBook{}
 	symbol := e.GetSymbolByPair(pair)
 
-	strUrl := "https://openapi.digifinex.com/v3/order_book"
+	strUrl := API_V3_URL + "/order_book"
 
 	mapParams := make(map[string]string)
 	mapParams["market"] = symbol
@@ -309,12 +309,14 @@ func (e *Digifinex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchang
 	placeOrder := PlaceOrder{}
 	strRequest := "/trade"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["type"] = "sell"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["sign"] = CreateSign(mapParams, e)
@@ -351,12 +353,14 @@ func (e *Digifinex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange
 	placeOrder := PlaceOrder{}
 	strRequest := "/trade"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["type"] = "buy"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	log.Printf("symbol: %v", e.GetSymbolByPair(pair))
@@ -248,6 +248,19 @@ func (e *Digifinex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrai
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Digifinex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Digifinex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
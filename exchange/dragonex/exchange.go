@@ -254,6 +254,19 @@ func (e *Dragonex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrain
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Dragonex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Dragonex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
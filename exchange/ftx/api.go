@@ -20,7 +20,7 @@ import (
 	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://ftx.com"
 )
 
@@ -293,6 +293,11 @@ func (e *Ftx) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	symbol := e.GetSymbolByPair(pair)
 	if symbol == "" {
 		symbol = pair.Symbol
@@ -300,8 +305,8 @@ func (e *Ftx) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 
 	mapParams := make(map[string]string)
 	mapParams["market"] = symbol // future "BTC-PERP", spot "ALTHEDGE/USD"
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["size"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "sell"
 	mapParams["type"] = "limit"
 	// mapParams["reduceOnly"] = false
@@ -338,6 +343,11 @@ func (e *Ftx) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	symbol := e.GetSymbolByPair(pair)
 	if symbol == "" {
 		symbol = pair.Symbol
@@ -347,8 +357,8 @@ func (e *Ftx) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order
 
 	mapParams := make(map[string]string)
 	mapParams["market"] = symbol // future "BTC-PERP", spot "ALTHEDGE/USD"
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["size"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "buy"
 	mapParams["type"] = "limit"
 	// mapParams["reduceOnly"] = false
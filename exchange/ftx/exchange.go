@@ -249,6 +249,40 @@ func (e *Ftx) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetch
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.KLine:          {exchange.SpotWallet},
+		exchange.GetTickerPrice: {exchange.SpotWallet},
+		exchange.GetFutureStats: {exchange.SpotWallet},
+		exchange.FundingRate:    {exchange.ContractWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.GetAccount:           {exchange.SpotWallet},
+		exchange.GetPositions:         {exchange.ContractWallet},
+		exchange.SetLeverage:          {exchange.ContractWallet},
+		exchange.SetMarginType:        {exchange.ContractWallet},
+		exchange.SetPositionMode:      {exchange.ContractWallet},
+		exchange.BalanceList:          {exchange.SpotWallet},
+		exchange.Balance:              {exchange.SpotWallet},
+		exchange.GetOpenOrder:         {exchange.SpotWallet},
+		exchange.GetOrderHistory:      {exchange.SpotWallet},
+		exchange.GetWithdrawalHistory: {exchange.SpotWallet},
+		exchange.GetDepositHistory:    {exchange.SpotWallet},
+		exchange.GetDepositAddress:    {exchange.SpotWallet},
+		exchange.GetTransferHistory:   {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Ftx) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Ftx) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -227,21 +227,21 @@ type Account struct {
 	Liquidating                  bool    `json:"liquidating"`
 	BackstopProvider             bool    `json:"backstopProvider"`
 	Positions                    []struct {
-		Future                       string      `json:"future"`
-		Size                         float64     `json:"size"`
-		Side                         string      `json:"side"`
-		NetSize                      float64     `json:"netSize"`
-		LongOrderSize                float64     `json:"longOrderSize"`
-		ShortOrderSize               float64     `json:"shortOrderSize"`
-		Cost                         float64     `json:"cost"`
-		EntryPrice                   interface{} `json:"entryPrice"`
-		UnrealizedPnl                float64     `json:"unrealizedPnl"`
-		RealizedPnl                  float64     `json:"realizedPnl"`
-		InitialMarginRequirement     float64     `json:"initialMarginRequirement"`
-		MaintenanceMarginRequirement float64     `json:"maintenanceMarginRequirement"`
-		OpenSize                     float64     `json:"openSize"`
-		CollateralUsed               float64     `json:"collateralUsed"`
-		EstimatedLiquidationPrice    interface{} `json:"estimatedLiquidationPrice"`
+		Future                       string  `json:"future"`
+		Size                         float64 `json:"size"`
+		Side                         string  `json:"side"`
+		NetSize                      float64 `json:"netSize"`
+		LongOrderSize                float64 `json:"longOrderSize"`
+		ShortOrderSize               float64 `json:"shortOrderSize"`
+		Cost                         float64 `json:"cost"`
+		EntryPrice                   float64 `json:"entryPrice"`
+		UnrealizedPnl                float64 `json:"unrealizedPnl"`
+		RealizedPnl                  float64 `json:"realizedPnl"`
+		InitialMarginRequirement     float64 `json:"initialMarginRequirement"`
+		MaintenanceMarginRequirement float64 `json:"maintenanceMarginRequirement"`
+		OpenSize                     float64 `json:"openSize"`
+		CollateralUsed               float64 `json:"collateralUsed"`
+		EstimatedLiquidationPrice    float64 `json:"estimatedLiquidationPrice"`
 	} `json:"positions"`
 	TakerFee                    float64     `json:"takerFee"`
 	MakerFee                    float64     `json:"makerFee"`
@@ -253,3 +253,25 @@ type Account struct {
 	SpotMarginEnabled           bool        `json:"spotMarginEnabled"`
 	SpotLendingEnabled          bool        `json:"spotLendingEnabled"`
 }
+
+type Futures []struct {
+	Name  string  `json:"name"`
+	Mark  float64 `json:"mark"`
+	Index float64 `json:"index"`
+}
+
+type FutureStats struct {
+	Volume                   float64 `json:"volume"`
+	NextFundingRate          float64 `json:"nextFundingRate"`
+	NextFundingTime          string  `json:"nextFundingTime"`
+	ExpirationPrice          float64 `json:"expirationPrice"`
+	PredictedExpirationPrice float64 `json:"predictedExpirationPrice"`
+	StrikePrice              float64 `json:"strikePrice"`
+	OpenInterest             float64 `json:"openInterest"`
+}
+
+type FundingRates []struct {
+	Future string  `json:"future"`
+	Rate   float64 `json:"rate"`
+	Time   string  `json:"time"`
+}
@@ -15,6 +15,14 @@ func (e *Ftx) DoAccountOperation(operation *exchange.AccountOperation) error {
 
 	case exchange.GetPositions:
 		return e.doGetPositions(operation)
+	case exchange.SetLeverage:
+		return e.doSetLeverage(operation)
+	case exchange.SetMarginType:
+		return e.doSetMarginType(operation)
+	case exchange.SetPositionMode:
+		return e.doSetPositionMode(operation)
+	case exchange.AdjustIsolatedMargin:
+		return fmt.Errorf("%s AdjustIsolatedMargin: %w", e.GetName(), exchange.ErrNotSupported)
 
 	case exchange.BalanceList:
 		if operation.Wallet == exchange.SpotWallet {
@@ -542,49 +550,159 @@ func (e *Ftx) getBalance(operation *exchange.AccountOperation) error {
 	return nil
 }
 
+// FTX only has cross margin, the leverage is set on the account
 func (e *Ftx) doGetPositions(operation *exchange.AccountOperation) error {
-	// var str string
-	var err error
-
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
 	}
 
 	jsonResponse := &JsonResponse{}
+	account := Account{}
 
-	strRequest := "/api/positions" // https://docs.ftx.com/#get-positions
-
-	mapParams := make(map[string]string)
-	mapParams["showAvgPrice"] = "true" // showAvgPrice 	boolean 	false 	optional
+	// the account endpoint returns the positions together with the account leverage
+	strRequest := "/api/account" // https://docs.ftx.com/#get-account-information
 
-	resp := e.ApiKeyRequest("GET", strRequest, mapParams)
+	resp := e.ApiKeyRequest("GET", strRequest, nil)
 	if operation.DebugMode {
 		operation.RequestURI = strRequest
 		operation.CallResponce = resp
 	}
 
-	// str = fmt.Sprintf("doGetPositions:: %s", resp)
-	// log.Print(str)
-
-	if err = json.Unmarshal([]byte(resp), &jsonResponse); err != nil {
+	if err := json.Unmarshal([]byte(resp), &jsonResponse); err != nil {
 		operation.Error = fmt.Errorf("%s doGetPositions Json Unmarshal Err: %v %v", e.GetName(), err, resp)
 		return operation.Error
 	} else if !jsonResponse.Success {
 		operation.Error = fmt.Errorf("%s doGetPositions Failed: %v", e.GetName(), resp)
 		return operation.Error
 	}
+	if err := json.Unmarshal(jsonResponse.Result, &account); err != nil {
+		operation.Error = fmt.Errorf("%s doGetPositions Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		return operation.Error
+	}
 
-	operation.OpenPositionList = exchange.OpenPositions{}
+	markPrices, err := e.getMarkPrices()
+	if err != nil {
+		operation.Error = err
+		return operation.Error
+	}
 
-	if err := json.Unmarshal(jsonResponse.Result, &operation.OpenPositionList); err != nil {
-		operation.Error = fmt.Errorf("%s doGetPositions Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+	operation.Positions = []*exchange.Position{}
+	for _, p := range account.Positions {
+		if p.Size == 0 {
+			continue
+		}
+
+		position := &exchange.Position{
+			Pair:             e.GetPairBySymbol(p.Future),
+			Instrument:       p.Future,
+			Size:             p.Size,
+			EntryPrice:       p.EntryPrice,
+			MarkPrice:        markPrices[p.Future],
+			LiquidationPrice: p.EstimatedLiquidationPrice,
+			Leverage:         account.Leverage,
+			MarginMode:       exchange.CrossMargin,
+			UnrealizedPnl:    p.UnrealizedPnl,
+			RealizedPnl:      p.RealizedPnl,
+		}
+		if p.Side == "buy" {
+			position.Side = exchange.PositionLong
+		} else {
+			position.Side = exchange.PositionShort
+		}
+
+		operation.Positions = append(operation.Positions, position)
+	}
+
+	return nil
+}
+
+// the positions have no mark price, it is the "mark" of the futures, key: future name
+func (e *Ftx) getMarkPrices() (map[string]float64, error) {
+	jsonResponse := &JsonResponse{}
+	futures := Futures{}
+
+	strUrl := API_URL + "/api/futures" // https://docs.ftx.com/#list-all-futures
+	jsonFuturesReturn := exchange.HttpGetRequest(strUrl, nil)
+	if err := json.Unmarshal([]byte(jsonFuturesReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("%s getMarkPrices Json Unmarshal Err: %v %v", e.GetName(), err, jsonFuturesReturn)
+	} else if !jsonResponse.Success {
+		return nil, fmt.Errorf("%s getMarkPrices Failed: %v", e.GetName(), jsonFuturesReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &futures); err != nil {
+		return nil, fmt.Errorf("%s getMarkPrices Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+	}
+
+	markPrices := make(map[string]float64)
+	for _, future := range futures {
+		markPrices[future.Name] = future.Mark
+	}
+	return markPrices, nil
+}
+
+// the leverage is account wide on FTX, Pair is ignored
+func (e *Ftx) doSetLeverage(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	if operation.Leverage < 1 || operation.Leverage > 20 {
+		return fmt.Errorf("%s SetLeverage is between 1 to 20: %d", e.GetName(), operation.Leverage)
+	}
+
+	jsonResponse := &JsonResponse{}
+	strRequest := "/api/account/leverage"
+
+	mapParams := make(map[string]string)
+	mapParams["leverage"] = fmt.Sprintf("%d", operation.Leverage)
+
+	jsonLeverageReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonLeverageReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonLeverageReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s SetLeverage Json Unmarshal Err: %v %v", e.GetName(), err, jsonLeverageReturn)
+		return operation.Error
+	} else if !jsonResponse.Success {
+		operation.Error = fmt.Errorf("%s SetLeverage Failed: %v", e.GetName(), jsonLeverageReturn)
 		return operation.Error
 	}
 
-	// str = fmt.Sprintf("doGetPositions:: %#v", openPositions)
-	// log.Print(str)
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Leverage:     float64(operation.Leverage),
+		MarginMode:   exchange.CrossMargin,
+		PositionMode: exchange.OneWayMode,
+	}
 
-	return err
+	return nil
+}
+
+// FTX only has cross margin
+func (e *Ftx) doSetMarginType(operation *exchange.AccountOperation) error {
+	if operation.MarginMode != exchange.CrossMargin {
+		return fmt.Errorf("%s SetMarginType %v: %w", e.GetName(), operation.MarginMode, exchange.ErrNotSupported)
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		MarginMode: exchange.CrossMargin,
+	}
+
+	return nil
+}
+
+// FTX positions are always one-way
+func (e *Ftx) doSetPositionMode(operation *exchange.AccountOperation) error {
+	if operation.PositionMode != exchange.OneWayMode {
+		return fmt.Errorf("%s SetPositionMode %v: %w", e.GetName(), operation.PositionMode, exchange.ErrNotSupported)
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		PositionMode: exchange.OneWayMode,
+	}
+
+	return nil
 }
 
 //!-- not done yet
@@ -619,11 +737,6 @@ func (e *Ftx) doGetAccount(operation *exchange.AccountOperation) error {
 
 	operation.Data = jsonResponse.Result
 
-	// if err := json.Unmarshal(jsonResponse.Result, &operation.OpenPositionList); err != nil {
-	// 	operation.Error = fmt.Errorf("%s doGetPositions Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
-	// 	return operation.Error
-	// }
-
 	// str = fmt.Sprintf("jsonResponse:: %#v", jsonResponse)
 	// log.Print(str)
 
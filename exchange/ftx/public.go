@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
 	"github.com/bitontop/gored/utils"
 )
 
@@ -28,6 +30,11 @@ func (e *Ftx) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet: //actually future works the same for FTX
 			return e.doGetFutureStats(operation)
 		}
+	case exchange.FundingRate:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doFundingRate(operation)
+		}
 
 	}
 
@@ -90,86 +97,82 @@ func (e *Ftx) doTickerPrice(operation *exchange.PublicOperation) error {
 	return nil
 }
 
-// interval options: 15s, 1min, 5min, 15min, 1hour, 4hour, 1day
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:  "60",
+	exchange.KLINE_5MIN:  "300",
+	exchange.KLINE_15MIN: "900",
+	exchange.KLINE_1HOUR: "3600",
+	exchange.KLINE_4HOUR: "14400",
+	exchange.KLINE_1DAY:  "86400",
+}
+
 func (e *Ftx) doSpotKline(operation *exchange.PublicOperation) error {
-	interval := "300"
-	if operation.KlineInterval != "" {
-		switch operation.KlineInterval {
-		case "15s":
-			interval = "15"
-		case "1min":
-			interval = "60"
-		case "5min":
-			interval = "300"
-		case "15min":
-			interval = "900"
-		case "1hour":
-			interval = "3600"
-		case "4hour":
-			interval = "14400"
-		case "1day":
-			interval = "86400"
-		}
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
 	}
 
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://ftx.com/api/markets/%v/candles?resolution=%v&limit=5000", // 1500478320000
-			e.GetSymbolByPair(operation.Pair), // BTC/USD
-			interval,
-		),
-		Proxy: operation.Proxy,
-	}
+	return exchange.FetchKline(operation, 5000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/api/markets/%v/candles?resolution=%v&limit=5000", // 1500478320000
+				API_URL,
+				e.GetSymbolByPair(operation.Pair), // BTC/USD
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
 
-	if operation.KlineStartTime != 0 {
-		get.URI += fmt.Sprintf("&start_time=%v", operation.KlineStartTime/1000)
-	}
-	if operation.KlineEndTime != 0 {
-		get.URI += fmt.Sprintf("&end_time=%v", operation.KlineEndTime/1000)
-	}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&start_time=%v", start/1000)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&end_time=%v", end/1000)
+		}
 
-	err := utils.HttpGetRequest(get)
+		err := utils.HttpGetRequest(get)
 
-	if err != nil {
-		log.Printf("%+v", err)
-		operation.Error = err
-		return err
+		if err != nil {
+			log.Printf("%+v", err)
+			return nil, err
 
-	}
+		}
 
-	if operation.DebugMode {
-		operation.RequestURI = get.URI
-		operation.CallResponce = string(get.ResponseBody)
-	}
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
 
-	jsonResponse := &JsonResponse{}
-	rawKline := RawKline{}
-	if err := json.Unmarshal([]byte(string(get.ResponseBody)), &jsonResponse); err != nil {
-		return fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
-	} else if !jsonResponse.Success {
-		return fmt.Errorf("%s doSpotKline Failed: %v", e.GetName(), string(get.ResponseBody))
-	}
-	if err := json.Unmarshal(jsonResponse.Result, &rawKline); err != nil {
-		return fmt.Errorf("%s doSpotKline Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
-	}
+		jsonResponse := &JsonResponse{}
+		rawKline := RawKline{}
+		if err := json.Unmarshal([]byte(string(get.ResponseBody)), &jsonResponse); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		} else if !jsonResponse.Success {
+			return nil, fmt.Errorf("%s doSpotKline Failed: %v", e.GetName(), string(get.ResponseBody))
+		}
+		if err := json.Unmarshal(jsonResponse.Result, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		}
 
-	operation.Kline = []*exchange.KlineDetail{}
-	for _, k := range rawKline {
+		details := []*exchange.KlineDetail{}
+		for _, k := range rawKline {
+
+			detail := &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: float64(k.StartTime.Unix()) * 1000,
+				Open:     k.Open,
+				High:     k.High,
+				Low:      k.Low,
+				Close:    k.Close,
+				Volume:   k.Volume,
+			}
 
-		detail := &exchange.KlineDetail{
-			Exchange: e.GetName(),
-			Pair:     operation.Pair.Name,
-			OpenTime: float64(k.StartTime.Unix()) * 1000,
-			Open:     k.Open,
-			High:     k.High,
-			Low:      k.Low,
-			Close:    k.Close,
-			Volume:   k.Volume,
+			details = append(details, detail)
 		}
 
-		operation.Kline = append(operation.Kline, detail)
-	}
-
-	return nil
+		return details, nil
+	})
 }
 
 func (e *Ftx) doGetFutureStats(operation *exchange.PublicOperation) error {
@@ -216,3 +219,100 @@ func (e *Ftx) doGetFutureStats(operation *exchange.PublicOperation) error {
 
 	return err
 }
+
+// futures are not loaded as pairs, use Pair.Symbol if set, otherwise the perpetual eg. BTC-PERP
+func getFutureName(p *pair.Pair) string {
+	if p.Symbol != "" {
+		return p.Symbol
+	}
+	return p.Target.Code + "-PERP"
+}
+
+// the funding is hourly, nextFundingRate is the estimate of the running hour
+func (e *Ftx) doFundingRate(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	futureStats := FutureStats{}
+	fundingRates := FundingRates{}
+	future := getFutureName(operation.Pair)
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/futures/%s/stats", API_URL, future),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if !jsonResponse.Success {
+		operation.Error = fmt.Errorf("%s doFundingRate Failed: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &futureStats); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		return operation.Error
+	}
+
+	operation.FundingRate = &exchange.FundingRateDetail{
+		Pair:        operation.Pair,
+		Instrument:  future,
+		CurrentRate: futureStats.NextFundingRate,
+		History:     []*exchange.FundingRateHistory{},
+	}
+	if nextFundingTime, err := time.Parse(time.RFC3339, futureStats.NextFundingTime); err == nil {
+		operation.FundingRate.NextFundingTime = nextFundingTime.UnixNano() / int64(time.Millisecond)
+	}
+
+	jsonResponse = &JsonResponse{}
+	get = &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/funding_rates?future=%s", API_URL, future),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if operation.FundingStartTime != 0 {
+		get.URI += fmt.Sprintf("&start_time=%v", operation.FundingStartTime/1000)
+	}
+	if operation.FundingEndTime != 0 {
+		get.URI += fmt.Sprintf("&end_time=%v", operation.FundingEndTime/1000)
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if !jsonResponse.Success {
+		operation.Error = fmt.Errorf("%s doFundingRate history Failed: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &fundingRates); err != nil {
+		operation.Error = fmt.Errorf("%s doFundingRate history Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		return operation.Error
+	}
+
+	// newest first
+	for i := len(fundingRates) - 1; i >= 0; i-- {
+		fundingTime, err := time.Parse(time.RFC3339, fundingRates[i].Time)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doFundingRate parse time Err: %v %v", e.GetName(), err, fundingRates[i].Time)
+			return operation.Error
+		}
+		operation.FundingRate.History = append(operation.FundingRate.History, &exchange.FundingRateHistory{
+			Rate:      fundingRates[i].Rate,
+			Timestamp: fundingTime.UnixNano() / int64(time.Millisecond),
+		})
+	}
+
+	return nil
+}
@@ -22,9 +22,10 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL     string = "https://data.gate.io"
 	Private_URL string = "https://api.gateio.io"
+	API_V4_URL  string = "https://api.gateio.ws"
 	// Private_URL string = "https://api.gateio.life"
 )
 
@@ -390,13 +391,18 @@ func (e *Gateio) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api2/1/private/sell"
 
 	mapParams := make(map[string]string)
 	mapParams["currencyPair"] = e.GetSymbolByPair(pair)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["orderType"] = "" //ioc: immediate order cancel
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
@@ -424,13 +430,18 @@ func (e *Gateio) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/api2/1/private/buy"
 
 	mapParams := make(map[string]string)
 	mapParams["currencyPair"] = e.GetSymbolByPair(pair)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 	mapParams["orderType"] = "" //ioc: immediate order cancel
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequest, mapParams)
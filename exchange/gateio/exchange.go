@@ -247,6 +247,28 @@ func (e *Gateio) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.Orderbook:    {exchange.SpotWallet},
+		exchange.KLine:        {exchange.SpotWallet},
+		exchange.Ticker:       {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Gateio) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Gateio) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
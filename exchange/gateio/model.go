@@ -155,3 +155,15 @@ type TradeHistory struct {
 		Type      string `json:"type"`
 	} `json:"data"`
 }
+
+type Ticker []struct {
+	CurrencyPair     string `json:"currency_pair"`
+	Last             string `json:"last"`
+	LowestAsk        string `json:"lowest_ask"`
+	HighestBid       string `json:"highest_bid"`
+	ChangePercentage string `json:"change_percentage"`
+	BaseVolume       string `json:"base_volume"`
+	QuoteVolume      string `json:"quote_volume"`
+	High24H          string `json:"high_24h"`
+	Low24H           string `json:"low_24h"`
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bitontop/gored/exchange"
@@ -19,6 +20,14 @@ func (e *Gateio) LoadPublicData(operation *exchange.PublicOperation) error {
 		if operation.Wallet == exchange.SpotWallet {
 			return e.doSpotOrderBook(operation)
 		}
+	case exchange.KLine:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doSpotKline(operation)
+		}
+	case exchange.Ticker:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doTicker(operation)
+		}
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
@@ -141,3 +150,143 @@ func (e *Gateio) doTradeHistory(operation *exchange.PublicOperation) error {
 
 	return nil
 }
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1m",
+	exchange.KLINE_5MIN:   "5m",
+	exchange.KLINE_15MIN:  "15m",
+	exchange.KLINE_30MIN:  "30m",
+	exchange.KLINE_1HOUR:  "1h",
+	exchange.KLINE_4HOUR:  "4h",
+	exchange.KLINE_8HOUR:  "8h",
+	exchange.KLINE_1DAY:   "1d",
+	exchange.KLINE_1WEEK:  "7d",
+	exchange.KLINE_1MONTH: "30d",
+}
+
+// candlesticks of the v4 api, at most 1000 points from the range
+func (e *Gateio) doTicker(operation *exchange.PublicOperation) error {
+	ticker := Ticker{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/v4/spot/tickers", API_V4_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	}
+
+	timestamp := time.Now().UnixNano() / 1e6
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range ticker {
+		p := e.GetPairBySymbol(strings.ToLower(t.CurrencyPair))
+		if p == nil {
+			continue
+		}
+
+		values, err := exchange.ParseFloats(t.Last, t.HighestBid, t.LowestAsk, t.High24H, t.Low24H,
+			t.BaseVolume, t.QuoteVolume, t.ChangePercentage)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), t.CurrencyPair, err)
+			return operation.Error
+		}
+
+		detail := &exchange.TickerDetail{
+			Pair:          p,
+			Last:          values[0],
+			BestBid:       values[1],
+			BestAsk:       values[2],
+			High:          values[3],
+			Low:           values[4],
+			Volume:        values[5],
+			QuoteVolume:   values[6],
+			ChangePercent: values[7],
+			Timestamp:     timestamp,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	return nil
+}
+
+func (e *Gateio) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	symbol := strings.ToUpper(e.GetSymbolByPair(operation.Pair))
+
+	return exchange.FetchKline(operation, 1000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/api/v4/spot/candlesticks?currency_pair=%s&interval=%s", API_V4_URL, symbol, interval),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		// limit can't be used with from and to
+		if start != 0 {
+			get.URI += fmt.Sprintf("&from=%v", start/1000)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&to=%v", end/1000)
+		}
+		if start == 0 && end == 0 {
+			get.URI += "&limit=1000"
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		// [time, quote volume, close, high, low, open, base volume]
+		rawKline := [][]string{}
+		if err := json.Unmarshal(get.ResponseBody, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, k := range rawKline {
+			if len(k) < 7 {
+				return nil, fmt.Errorf("%s doSpotKline invalid kline: %v", e.GetName(), k)
+			}
+			values := make([]float64, 7)
+			for j := range values {
+				value, err := strconv.ParseFloat(k[j], 64)
+				if err != nil {
+					return nil, fmt.Errorf("%s doSpotKline parse Err: %v %v", e.GetName(), err, k[j])
+				}
+				values[j] = value
+			}
+
+			details = append(details, &exchange.KlineDetail{
+				Exchange:         e.GetName(),
+				Pair:             operation.Pair.Name,
+				OpenTime:         values[0] * 1000,
+				Open:             values[5],
+				High:             values[3],
+				Low:              values[4],
+				Close:            values[2],
+				Volume:           values[6],
+				QuoteAssetVolume: values[1],
+			})
+		}
+		return details, nil
+	})
+}
@@ -25,7 +25,7 @@ import (
 	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://api.sandbox.gemini.com"
 )
 
@@ -312,6 +312,11 @@ func (e *Gemini) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) b
 }
 
 func (e *Gemini) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
@@ -321,8 +326,8 @@ func (e *Gemini) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	mapParams := make(map[string]interface{})
 	mapParams["request"] = strRequest
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "sell"
 	mapParams["type"] = "exchange limit"
 
@@ -345,6 +350,11 @@ func (e *Gemini) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 }
 
 func (e *Gemini) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
@@ -354,8 +364,8 @@ func (e *Gemini) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	mapParams := make(map[string]interface{})
 	mapParams["request"] = strRequest
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "buy"
 	mapParams["type"] = "exchange limit"
 
@@ -247,6 +247,19 @@ func (e *Gemini) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Gemini) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Gemini) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
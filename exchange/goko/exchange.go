@@ -249,6 +249,19 @@ func (e *Goko) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Goko) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Goko) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -0,0 +1,260 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+)
+
+const (
+	DEFAULT_HEALTH_INTERVAL = 5 * time.Minute
+)
+
+// Health is the last check of an exchange. Suspended are the coins which can't be deposited or withdrawn.
+// Errors are of the checks which failed, the status is Unknown if the system status failed.
+type Health struct {
+	Exchange     ExchangeName          `json:"exchange"`
+	Status       HealthStatus          `json:"status"`
+	Message      string                `json:"message"`
+	Maintenances []*MaintenanceDetail  `json:"maintenances"`
+	Coins        int                   `json:"coins"` // with a wallet status
+	Suspended    []*WalletStatusDetail `json:"suspended"`
+	Errors       []string              `json:"errors"`
+	Timestamp    int64                 `json:"timestamp"` // ms
+}
+
+func (h *Health) Healthy() bool {
+	return h.Status == HealthNormal
+}
+
+// HealthMonitor checks the system status and the wallet status of the exchanges which support both.
+// A check sets the Issue of the CoinConstraints and the PairConstraints, then the Health of the coins, read with coin.GetHealth:
+// a coin suspended on every exchange which has it is a problem of its chain.
+// The other exchanges are Unknown.
+//
+//	m := exchange.NewHealthMonitor(binance, huobi, kucoin)
+//	m.Start()
+//	for _, h := range m.Report() { ... }
+type HealthMonitor struct {
+	Exchanges []Exchange
+	Interval  time.Duration
+
+	mu     sync.Mutex
+	health map[ExchangeName]*Health
+	stop   chan struct{}
+}
+
+func NewHealthMonitor(exchanges ...Exchange) *HealthMonitor {
+	return &HealthMonitor{
+		Exchanges: exchanges,
+		Interval:  DEFAULT_HEALTH_INTERVAL,
+		health:    make(map[ExchangeName]*Health),
+	}
+}
+
+// Check checks one exchange and keeps its Health
+func (m *HealthMonitor) Check(e Exchange) *Health {
+	h := &Health{
+		Exchange:     e.GetName(),
+		Status:       HealthUnknown,
+		Maintenances: []*MaintenanceDetail{},
+		Suspended:    []*WalletStatusDetail{},
+		Errors:       []string{},
+		Timestamp:    time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if caps := e.GetCapabilities(); !caps.Supports(SystemStatus, SpotWallet) || !caps.Supports(WalletStatus, SpotWallet) {
+		h.Message = "no health api"
+		m.set(h)
+		return h
+	}
+
+	system := &PublicOperation{
+		Type:   SystemStatus,
+		EX:     e.GetName(),
+		Wallet: SpotWallet,
+	}
+	if err := e.LoadPublicData(system); err != nil {
+		h.Errors = append(h.Errors, err.Error())
+	} else if system.SystemStatus != nil {
+		h.Status = system.SystemStatus.Status
+		h.Message = system.SystemStatus.Message
+		h.Maintenances = system.SystemStatus.Maintenances
+	}
+
+	wallet := &PublicOperation{
+		Type:   WalletStatus,
+		EX:     e.GetName(),
+		Wallet: SpotWallet,
+	}
+	if err := e.LoadPublicData(wallet); err != nil {
+		h.Errors = append(h.Errors, err.Error())
+	} else {
+		h.Coins = len(wallet.WalletStatus)
+		for _, status := range wallet.WalletStatus {
+			if !status.Deposit || !status.Withdraw {
+				h.Suspended = append(h.Suspended, status)
+			}
+		}
+	}
+
+	setPairIssues(e, h)
+	m.set(h)
+	return h
+}
+
+// setPairIssues is the status of the exchange if it isn't Normal, then the issues of the coins of the pair
+func setPairIssues(e Exchange, h *Health) {
+	system := ""
+	if h.Status != HealthNormal && h.Status != HealthUnknown {
+		system = strings.TrimSpace(fmt.Sprintf("%s %s", h.Status, h.Message))
+	}
+
+	for _, p := range e.GetPairs() {
+		pairConstraint := e.GetPairConstraint(p)
+		if pairConstraint == nil {
+			continue
+		}
+		issues := []string{}
+		if system != "" {
+			issues = append(issues, system)
+		}
+		for _, c := range []*coin.Coin{p.Base, p.Target} {
+			if coinConstraint := e.GetCoinConstraint(c); coinConstraint != nil && coinConstraint.Issue != "" {
+				issues = append(issues, fmt.Sprintf("%s %s", c.Code, coinConstraint.Issue))
+			}
+		}
+		// a copy, the constraint may be read meanwhile
+		if issue := strings.Join(issues, "; "); issue != pairConstraint.Issue {
+			updated := *pairConstraint
+			updated.Issue = issue
+			e.SetPairConstraint(&updated)
+		}
+	}
+}
+
+func (m *HealthMonitor) set(h *Health) {
+	m.mu.Lock()
+	m.health[h.Exchange] = h
+	m.mu.Unlock()
+}
+
+// Poll checks the exchanges in parallel, then sets the Health of the coins
+func (m *HealthMonitor) Poll() {
+	var wg sync.WaitGroup
+	for _, e := range m.Exchanges {
+		wg.Add(1)
+		go func(e Exchange) {
+			defer wg.Done()
+			m.Check(e)
+		}(e)
+	}
+	wg.Wait()
+
+	m.setCoinHealth()
+}
+
+// setCoinHealth is the issues of a coin on every exchange if none of them can deposit and withdraw it, empty otherwise
+func (m *HealthMonitor) setCoinHealth() {
+	issues := make(map[int][]string)
+	working := make(map[int]bool)
+	coins := make(map[int]*coin.Coin)
+	for _, h := range m.Report() {
+		if h.Coins == 0 {
+			continue
+		}
+		suspended := make(map[int]*WalletStatusDetail)
+		for _, status := range h.Suspended {
+			suspended[status.Coin.ID] = status
+		}
+		for _, e := range m.Exchanges {
+			if e.GetName() != h.Exchange {
+				continue
+			}
+			for _, c := range e.GetCoins() {
+				coins[c.ID] = c
+				if status, ok := suspended[c.ID]; ok {
+					issues[c.ID] = append(issues[c.ID], fmt.Sprintf("%s %s", h.Exchange, status.Issue))
+				} else {
+					working[c.ID] = true
+				}
+			}
+		}
+	}
+
+	for id, c := range coins {
+		if working[id] {
+			coin.SetHealth(c, "")
+		} else {
+			coin.SetHealth(c, strings.Join(issues[id], "; "))
+		}
+	}
+}
+
+// Health is the last check of the exchange, nil if not checked
+func (m *HealthMonitor) Health(name ExchangeName) *Health {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.health[name]
+}
+
+// Report is the last check of every exchange checked, by name
+func (m *HealthMonitor) Report() []*Health {
+	m.mu.Lock()
+	report := []*Health{}
+	for _, h := range m.health {
+		report = append(report, h)
+	}
+	m.mu.Unlock()
+
+	sort.Slice(report, func(i, j int) bool {
+		return report[i].Exchange < report[j].Exchange
+	})
+	return report
+}
+
+// Start polls the exchanges every Interval until Stop, the first poll is right away
+func (m *HealthMonitor) Start() {
+	m.mu.Lock()
+	if m.stop != nil {
+		m.mu.Unlock()
+		return
+	}
+	m.stop = make(chan struct{})
+	stop := m.stop
+	m.mu.Unlock()
+
+	interval := m.Interval
+	if interval <= 0 {
+		interval = DEFAULT_HEALTH_INTERVAL
+	}
+	go func() {
+		m.Poll()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				m.Poll()
+			}
+		}
+	}()
+}
+
+func (m *HealthMonitor) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+}
@@ -249,6 +249,19 @@ func (e *Hibitex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainF
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Hibitex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Hibitex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
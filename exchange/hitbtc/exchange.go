@@ -247,6 +247,29 @@ func (e *Hitbtc) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.KLine:        {exchange.SpotWallet},
+		exchange.Ticker:       {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList: {exchange.SpotWallet},
+		exchange.Balance:     {exchange.SpotWallet},
+		exchange.Withdraw:    {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Hitbtc) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Hitbtc) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
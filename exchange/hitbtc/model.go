@@ -87,3 +87,26 @@ type TradeHistory []struct {
 	Fee           string    `json:"fee"`
 	Timestamp     time.Time `json:"timestamp"`
 }
+
+type Kline []struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Open        string    `json:"open"`
+	Close       string    `json:"close"`
+	Min         string    `json:"min"`
+	Max         string    `json:"max"`
+	Volume      string    `json:"volume"`
+	VolumeQuote string    `json:"volumeQuote"`
+}
+
+type Ticker []struct {
+	Symbol      string    `json:"symbol"`
+	Ask         string    `json:"ask"`
+	Bid         string    `json:"bid"`
+	Last        string    `json:"last"`
+	Open        string    `json:"open"`
+	Low         string    `json:"low"`
+	High        string    `json:"high"`
+	Volume      string    `json:"volume"`
+	VolumeQuote string    `json:"volumeQuote"`
+	Timestamp   time.Time `json:"timestamp"`
+}
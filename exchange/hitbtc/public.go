@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
 )
 
 func (e *Hitbtc) LoadPublicData(operation *exchange.PublicOperation) error {
@@ -13,6 +15,14 @@ func (e *Hitbtc) LoadPublicData(operation *exchange.PublicOperation) error {
 
 	case exchange.TradeHistory:
 		return e.doTradeHistory(operation)
+	case exchange.KLine:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doSpotKline(operation)
+		}
+	case exchange.Ticker:
+		if operation.Wallet == exchange.SpotWallet {
+			return e.doTicker(operation)
+		}
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
@@ -53,3 +63,142 @@ func (e *Hitbtc) doTradeHistory(operation *exchange.PublicOperation) error {
 
 	return nil
 }
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "M1",
+	exchange.KLINE_3MIN:   "M3",
+	exchange.KLINE_5MIN:   "M5",
+	exchange.KLINE_15MIN:  "M15",
+	exchange.KLINE_30MIN:  "M30",
+	exchange.KLINE_1HOUR:  "H1",
+	exchange.KLINE_4HOUR:  "H4",
+	exchange.KLINE_1DAY:   "D1",
+	exchange.KLINE_1WEEK:  "D7",
+	exchange.KLINE_1MONTH: "1M",
+}
+
+func (e *Hitbtc) doTicker(operation *exchange.PublicOperation) error {
+	ticker := Ticker{}
+	errResponse := ErrResponse{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/2/public/ticker", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &ticker); err != nil {
+		if json.Unmarshal(get.ResponseBody, &errResponse) == nil && errResponse.Error.Code != 0 {
+			operation.Error = fmt.Errorf("%s doTicker Failed: %v %v", e.GetName(), errResponse.Error.Code, errResponse.Error.Message)
+			return operation.Error
+		}
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	}
+
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range ticker {
+		p := e.GetPairBySymbol(t.Symbol)
+		if p == nil {
+			continue
+		}
+
+		values, err := exchange.ParseFloats(t.Last, t.Bid, t.Ask, t.Open, t.High, t.Low, t.Volume, t.VolumeQuote)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), t.Symbol, err)
+			return operation.Error
+		}
+
+		detail := &exchange.TickerDetail{
+			Pair:        p,
+			Last:        values[0],
+			BestBid:     values[1],
+			BestAsk:     values[2],
+			Open:        values[3],
+			High:        values[4],
+			Low:         values[5],
+			Volume:      values[6],
+			QuoteVolume: values[7],
+			Timestamp:   t.Timestamp.UnixNano() / 1e6,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	return nil
+}
+
+func (e *Hitbtc) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	return exchange.FetchKline(operation, 1000, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		kline := Kline{}
+		errResponse := ErrResponse{}
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/api/2/public/candles/%s?period=%s&limit=1000&sort=ASC", API_URL, symbol, interval),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&from=%v", time.Unix(0, start*1e6).UTC().Format(time.RFC3339))
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&till=%v", time.Unix(0, end*1e6).UTC().Format(time.RFC3339))
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &kline); err != nil {
+			if json.Unmarshal(get.ResponseBody, &errResponse) == nil && errResponse.Error.Code != 0 {
+				return nil, fmt.Errorf("%s doSpotKline Failed: %v %v", e.GetName(), errResponse.Error.Code, errResponse.Error.Message)
+			}
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, k := range kline {
+			values := make([]float64, 6)
+			for j, v := range []string{k.Open, k.Max, k.Min, k.Close, k.Volume, k.VolumeQuote} {
+				value, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%s doSpotKline parse Err: %v %v", e.GetName(), err, v)
+				}
+				values[j] = value
+			}
+
+			details = append(details, &exchange.KlineDetail{
+				Exchange:         e.GetName(),
+				Pair:             operation.Pair.Name,
+				OpenTime:         float64(k.Timestamp.UnixNano() / 1e6),
+				Open:             values[0],
+				High:             values[1],
+				Low:              values[2],
+				Close:            values[3],
+				Volume:           values[4],
+				QuoteAssetVolume: values[5],
+			})
+		}
+		return details, nil
+	})
+}
@@ -247,6 +247,27 @@ func (e *Homiex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList: {exchange.SpotWallet},
+		exchange.Balance:     {exchange.SpotWallet},
+		exchange.Withdraw:    {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Homiex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Homiex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -21,7 +21,7 @@ import (
 	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://api.hoo.co"
 )
 
@@ -470,13 +470,15 @@ func (e *Hoo) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 	placeOrder := PlaceOrder{}
 	strRequest := "/open/v1/orders/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "-1"
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
@@ -512,13 +514,15 @@ func (e *Hoo) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order
 	placeOrder := PlaceOrder{}
 	strRequest := "/open/v1/orders/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["side"] = "1"
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
@@ -247,6 +247,23 @@ func (e *Hoo) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetch
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList: {exchange.SpotWallet},
+		exchange.Balance:     {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Hoo) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Hoo) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
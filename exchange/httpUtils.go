@@ -223,10 +223,13 @@ func Map2UrlQueryInterface(mapParams map[string]interface{}) string {
 	return strParams
 }
 
+// EXTERNAL_IP_URL is a var like the API_URL of the adapters, the conformance tests serve it locally
+var EXTERNAL_IP_URL = "http://myexternalip.com/raw"
+
 func GetExternalIP() string {
 	httpClient := &http.Client{}
 
-	strRequestUrl := "http://myexternalip.com/raw"
+	strRequestUrl := EXTERNAL_IP_URL
 
 	request, err := http.NewRequest("GET", strRequestUrl, nil)
 	if nil != err {
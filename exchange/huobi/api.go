@@ -22,7 +22,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://api.huobi.pro"
 )
 
@@ -383,14 +383,16 @@ func (e *Huobi) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	placeOrder := ""
 	strRequest := "/v1/order/orders/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["account-id"] = e.Account_ID
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 	if rate != 0 {
-		mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+		mapParams["price"] = pairConstraint.FormatRate(rate)
 		mapParams["type"] = "sell-limit"
 	} else {
 		mapParams["type"] = "sell-market"
@@ -436,14 +438,16 @@ func (e *Huobi) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	placeOrder := ""
 	strRequest := "/v1/order/orders/place"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["account-id"] = e.Account_ID
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["amount"] = pairConstraint.FormatQuantity(quantity)
 	if rate != 0 {
-		mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+		mapParams["price"] = pairConstraint.FormatRate(rate)
 		mapParams["type"] = "buy-limit"
 	} else {
 		mapParams["type"] = "buy-market"
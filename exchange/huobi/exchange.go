@@ -251,6 +251,47 @@ func (e *Huobi) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory:   {exchange.SpotWallet},
+		exchange.CoinChainType:  {exchange.SpotWallet},
+		exchange.Orderbook:      {exchange.SpotWallet},
+		exchange.KLine:          {exchange.SpotWallet},
+		exchange.GetTickerPrice: {exchange.SpotWallet},
+		exchange.Ticker:         {exchange.SpotWallet},
+		exchange.SystemStatus:   {exchange.SpotWallet},
+		exchange.WalletStatus:   {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.SubAccountTransfer:   {exchange.SpotWallet},
+		exchange.BalanceList:          {exchange.SpotWallet},
+		exchange.Withdraw:             {exchange.SpotWallet},
+		exchange.GetOpenOrder:         {exchange.SpotWallet},
+		exchange.GetOrderHistory:      {exchange.SpotWallet},
+		exchange.GetDepositAddress:    {exchange.SpotWallet},
+		exchange.GetDepositHistory:    {exchange.SpotWallet},
+		exchange.GetWithdrawalHistory: {exchange.SpotWallet},
+		exchange.SubBalanceList:       {exchange.SpotWallet},
+		exchange.GetSubAccountList:    {exchange.SpotWallet},
+		exchange.SubAllBalanceList:    {exchange.SpotWallet},
+		exchange.GetTransferHistory:   {exchange.SpotWallet},
+		exchange.PlaceOrder:           {exchange.MarginWallet},
+		exchange.MarginOperation:      {exchange.MarginWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet:   {exchange.TRADE_LIMIT},
+		exchange.MarginWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet:   {exchange.GTC},
+		exchange.MarginWallet: {exchange.GTC},
+	},
+}
+
+func (e *Huobi) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Huobi) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -0,0 +1,486 @@
+package huobi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+func (e *Huobi) doMarginOperation(operation *exchange.AccountOperation) error {
+	if operation.Margin == nil {
+		return fmt.Errorf("%s MarginOperation empty Margin: %+v", e.GetName(), operation)
+	} else if operation.MarginMode == exchange.IsolatedMargin && operation.Margin.Pair == nil {
+		return fmt.Errorf("%s MarginOperation isolated margin without pair", e.GetName())
+	}
+
+	switch operation.Margin.Action {
+	case exchange.LOAN_REQUEST:
+		return e.doMarginLoan(operation)
+	case exchange.LOAN_REPAY:
+		return e.doMarginRepay(operation)
+	case exchange.TRANSFER_IN, exchange.TRANSFER_OUT:
+		return e.doMarginTransfer(operation)
+	case exchange.BALANCE:
+		if operation.MarginMode == exchange.IsolatedMargin {
+			return e.doIsolatedMarginBalance(operation)
+		}
+		return e.doCrossMarginBalance(operation)
+	case exchange.INTEREST_HISTORY:
+		return e.doMarginLoanOrders(operation)
+	}
+
+	return fmt.Errorf("%s MarginOperation action invalid: %v", e.GetName(), operation.Margin.Action)
+}
+
+// getMarginAccountID find the "super-margin" (cross) or the "margin" account of the pair (isolated)
+func (e *Huobi) getMarginAccountID(marginMode exchange.MarginMode, p *pair.Pair) (string, error) {
+	jsonResponse := &JsonResponse{}
+	accountsReturn := AccountsReturn{}
+	strRequest := "/v1/account/accounts"
+
+	jsonAccountsReturn := e.ApiKeyRequest("GET", make(map[string]string), strRequest)
+	if err := json.Unmarshal([]byte(jsonAccountsReturn), &jsonResponse); err != nil {
+		return "", fmt.Errorf("%s getMarginAccountID Json Unmarshal Err: %v %v", e.GetName(), err, jsonAccountsReturn)
+	} else if jsonResponse.Status != "ok" {
+		return "", fmt.Errorf("%s getMarginAccountID Failed: %v", e.GetName(), jsonAccountsReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &accountsReturn); err != nil {
+		return "", fmt.Errorf("%s getMarginAccountID Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	for _, account := range accountsReturn {
+		if marginMode == exchange.IsolatedMargin {
+			if account.Type == "margin" && account.SubType == e.GetSymbolByPair(p) {
+				return strconv.FormatInt(account.ID, 10), nil
+			}
+		} else if account.Type == "super-margin" {
+			return strconv.FormatInt(account.ID, 10), nil
+		}
+	}
+
+	return "", fmt.Errorf("%s getMarginAccountID %v margin account not found", e.GetName(), marginMode)
+}
+
+// the loan order id is returned in Margin.MarginOrder
+func (e *Huobi) doMarginLoan(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	var loanID int64
+	strRequest := "/v1/cross-margin/orders"
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["amount"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if operation.MarginMode == exchange.IsolatedMargin {
+		strRequest = "/v1/margin/orders"
+		mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+	}
+
+	jsonLoanReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonLoanReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonLoanReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginLoan Json Unmarshal Err: %v, %s", e.GetName(), err, jsonLoanReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doMarginLoan Failed: %v", e.GetName(), jsonLoanReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &loanID); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginLoan Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	margin.MarginOrder = &exchange.MarginOrder{
+		ID:         fmt.Sprintf("%d", loanID),
+		Currency:   margin.Currency,
+		Pair:       margin.Pair,
+		LoanAmount: margin.Quantity,
+	}
+
+	return nil
+}
+
+// general repayment of the margin account, oldest loans are repaid first
+func (e *Huobi) doMarginRepay(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	accountID, err := e.getMarginAccountID(operation.MarginMode, margin.Pair)
+	if err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	jsonResponse := &JsonResponse{}
+	repayment := MarginRepayment{}
+	strRequest := "/v2/account/repayment"
+
+	mapParams := make(map[string]string)
+	mapParams["accountId"] = accountID
+	mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["amount"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+
+	jsonRepayReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonRepayReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonRepayReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginRepay Json Unmarshal Err: %v, %s", e.GetName(), err, jsonRepayReturn)
+		return operation.Error
+	} else if jsonResponse.Code != 200 {
+		operation.Error = fmt.Errorf("%s doMarginRepay Failed: %v", e.GetName(), jsonRepayReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &repayment); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginRepay Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(repayment) == 0 {
+		operation.Error = fmt.Errorf("%s doMarginRepay Failed: %v", e.GetName(), jsonRepayReturn)
+		return operation.Error
+	}
+
+	margin.MarginOrder = &exchange.MarginOrder{
+		ID:        repayment[0].RepayID,
+		Currency:  margin.Currency,
+		Pair:      margin.Pair,
+		Timestamp: repayment[0].RepayTime,
+	}
+
+	return nil
+}
+
+// TRANSFER_IN: spot -> margin, TRANSFER_OUT: margin -> spot
+func (e *Huobi) doMarginTransfer(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	var transferID int64
+	strRequest := ""
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["amount"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if operation.MarginMode == exchange.IsolatedMargin {
+		mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+		if margin.Action == exchange.TRANSFER_IN {
+			strRequest = "/v1/dw/transfer-in/margin"
+		} else {
+			strRequest = "/v1/dw/transfer-out/margin"
+		}
+	} else if margin.Action == exchange.TRANSFER_IN {
+		strRequest = "/v1/cross-margin/transfer-in"
+	} else {
+		strRequest = "/v1/cross-margin/transfer-out"
+	}
+
+	jsonTransferReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonTransferReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonTransferReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Failed: %v", e.GetName(), jsonTransferReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &transferID); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	margin.TransferID = fmt.Sprintf("%d", transferID)
+
+	return nil
+}
+
+func (e *Huobi) doIsolatedMarginBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	accountBalance := MarginAccountBalance{}
+	strRequest := "/v1/margin/accounts/balance"
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+
+	jsonBalanceReturn := e.ApiKeyRequest("GET", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &accountBalance); err != nil {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(accountBalance) == 0 {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+
+	account := accountBalance[0]
+	balance := &exchange.MarginBalance{
+		Pair:       margin.Pair,
+		MarginMode: exchange.IsolatedMargin,
+	}
+	balance.RiskRate, _ = strconv.ParseFloat(account.RiskRate, 64)
+	balance.LiquidationPrice, _ = strconv.ParseFloat(account.FlPrice, 64)
+
+	assetMap := make(map[string]*exchange.MarginAsset)
+	for _, item := range account.List {
+		e.addMarginAsset(balance, assetMap, item.Currency, item.Type, item.Balance)
+	}
+
+	margin.MarginBalance = balance
+
+	return nil
+}
+
+func (e *Huobi) doCrossMarginBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	account := CrossMarginAccountBalance{}
+	strRequest := "/v1/cross-margin/accounts/balance"
+
+	jsonBalanceReturn := e.ApiKeyRequest("GET", make(map[string]string), strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doCrossMarginBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doCrossMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &account); err != nil {
+		operation.Error = fmt.Errorf("%s doCrossMarginBalance Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	balance := &exchange.MarginBalance{
+		MarginMode: exchange.CrossMargin,
+	}
+	balance.RiskRate, _ = strconv.ParseFloat(account.RiskRate, 64)
+
+	assetMap := make(map[string]*exchange.MarginAsset)
+	for _, item := range account.List {
+		e.addMarginAsset(balance, assetMap, item.Currency, item.Type, item.Balance)
+	}
+
+	operation.Margin.MarginBalance = balance
+
+	return nil
+}
+
+// huobi lists one balance per currency and type, loan and interest are negative
+func (e *Huobi) addMarginAsset(balance *exchange.MarginBalance, assetMap map[string]*exchange.MarginAsset, currency, balanceType, amount string) {
+	c := e.GetCoinBySymbol(currency)
+	if c == nil {
+		return
+	}
+
+	asset, ok := assetMap[currency]
+	if !ok {
+		asset = &exchange.MarginAsset{Coin: c}
+		assetMap[currency] = asset
+		balance.Assets = append(balance.Assets, asset)
+	}
+
+	value, _ := strconv.ParseFloat(amount, 64)
+	switch balanceType {
+	case "trade":
+		asset.Free = value
+	case "frozen":
+		asset.Locked = value
+	case "loan":
+		asset.Borrowed = math.Abs(value)
+	case "interest":
+		asset.Interest = math.Abs(value)
+	default:
+		return
+	}
+	asset.NetAsset = asset.Free + asset.Locked - asset.Borrowed - asset.Interest
+}
+
+// loan orders with their accrued interest, filter by Margin.Currency if set
+func (e *Huobi) doMarginLoanOrders(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	loanOrders := MarginLoanOrders{}
+	strRequest := "/v1/cross-margin/loan-orders"
+
+	mapParams := make(map[string]string)
+	mapParams["size"] = "100"
+	if margin.Currency != nil {
+		mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	}
+	if operation.MarginMode == exchange.IsolatedMargin {
+		strRequest = "/v1/margin/loan-orders"
+		mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+	}
+	if operation.StartTime != 0 {
+		mapParams["start-date"] = time.Unix(0, operation.StartTime*int64(time.Millisecond)).UTC().Format("2006-01-02")
+	}
+	if operation.EndTime != 0 {
+		mapParams["end-date"] = time.Unix(0, operation.EndTime*int64(time.Millisecond)).UTC().Format("2006-01-02")
+	}
+
+	jsonLoanReturn := e.ApiKeyRequest("GET", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonLoanReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonLoanReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginLoanOrders Json Unmarshal Err: %v, %s", e.GetName(), err, jsonLoanReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doMarginLoanOrders Failed: %v", e.GetName(), jsonLoanReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &loanOrders); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginLoanOrders Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	margin.History = []*exchange.MarginOrder{}
+	for _, loan := range loanOrders {
+		record := &exchange.MarginOrder{
+			ID:        fmt.Sprintf("%d", loan.ID),
+			Currency:  e.GetCoinBySymbol(loan.Currency),
+			State:     loan.State,
+			Timestamp: loan.CreatedAt,
+		}
+		if loan.Symbol != "" {
+			record.Pair = e.GetPairBySymbol(loan.Symbol)
+		}
+		record.LoanAmount, _ = strconv.ParseFloat(loan.LoanAmount, 64)
+		record.LoanBalance, _ = strconv.ParseFloat(loan.LoanBalance, 64)
+		record.InterestRate, _ = strconv.ParseFloat(loan.InterestRate, 64)
+		record.InterestAmount, _ = strconv.ParseFloat(loan.InterestAmount, 64)
+		record.InterestBalance, _ = strconv.ParseFloat(loan.InterestBalance, 64)
+		margin.History = append(margin.History, record)
+	}
+
+	return nil
+}
+
+// type: TRADE_LIMIT, TRADE_MARKET. Market buy Quantity is the amount of base coin
+func (e *Huobi) doMarginPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.OrderDirection == "" {
+		return fmt.Errorf("%s MarginPlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.TradeType == "" {
+		return fmt.Errorf("%s MarginPlaceOrder empty TradeType: %+v", e.GetName(), operation)
+	}
+
+	accountID, err := e.getMarginAccountID(operation.MarginMode, operation.Pair)
+	if err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	jsonResponse := &JsonResponse{}
+	placeOrder := ""
+	strRequest := "/v1/order/orders/place"
+
+	mapParams := make(map[string]string)
+	mapParams["account-id"] = accountID
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	pairConstraint := e.GetPairConstraint(operation.Pair)
+	mapParams["amount"] = pairConstraint.FormatQuantity(operation.Quantity)
+	orderType := "buy"
+	if operation.OrderDirection == exchange.Sell {
+		orderType = "sell"
+	}
+	if operation.TradeType == exchange.TRADE_MARKET {
+		mapParams["type"] = orderType + "-market"
+		if operation.OrderDirection == exchange.Buy {
+			// the amount of a market buy is in the quote coin, LotSize doesn't apply
+			mapParams["amount"] = exchange.FormatDecimal(operation.Quantity)
+		}
+	} else {
+		mapParams["type"] = orderType + "-limit"
+		mapParams["price"] = pairConstraint.FormatRate(operation.Rate)
+	}
+	if operation.MarginMode == exchange.IsolatedMargin {
+		mapParams["source"] = "margin-api"
+	} else {
+		mapParams["source"] = "super-margin-api"
+	}
+
+	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Order = &exchange.Order{
+		Pair:         operation.Pair,
+		OrderID:      placeOrder,
+		Rate:         operation.Rate,
+		Quantity:     operation.Quantity,
+		Direction:    operation.OrderDirection,
+		Status:       exchange.New,
+		JsonResponse: jsonPlaceReturn,
+	}
+
+	return nil
+}
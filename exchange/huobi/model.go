@@ -14,6 +14,7 @@ type JsonResponse struct {
 	Status  string          `json:"status"`
 	Data    json.RawMessage `json:"data"`
 	Tick    json.RawMessage `json:"tick"`
+	Ts      int64           `json:"ts"`
 	ErrCode string          `json:"err-code"`
 	ErrMsg  string          `json:"err-msg"`
 }
@@ -249,3 +250,60 @@ type SubTransfer struct {
 	ErrCode string `json:"err-code"`
 	ErrMsg  string `json:"err-msg"`
 }
+
+type MarginAccountBalance []struct {
+	ID       int    `json:"id"`
+	Type     string `json:"type"`
+	State    string `json:"state"`
+	Symbol   string `json:"symbol"`
+	FlPrice  string `json:"fl-price"`
+	FlType   string `json:"fl-type"`
+	RiskRate string `json:"risk-rate"`
+	List     []struct {
+		Currency string `json:"currency"`
+		Type     string `json:"type"`
+		Balance  string `json:"balance"`
+	} `json:"list"`
+}
+
+type CrossMarginAccountBalance struct {
+	ID             int    `json:"id"`
+	Type           string `json:"type"`
+	State          string `json:"state"`
+	RiskRate       string `json:"risk-rate"`
+	AcctBalanceSum string `json:"acct-balance-sum"`
+	DebtBalanceSum string `json:"debt-balance-sum"`
+	List           []struct {
+		Currency string `json:"currency"`
+		Type     string `json:"type"`
+		Balance  string `json:"balance"`
+	} `json:"list"`
+}
+
+type MarginLoanOrders []struct {
+	ID              int64  `json:"id"`
+	AccountID       int64  `json:"account-id"`
+	Symbol          string `json:"symbol"`
+	Currency        string `json:"currency"`
+	LoanAmount      string `json:"loan-amount"`
+	LoanBalance     string `json:"loan-balance"`
+	InterestRate    string `json:"interest-rate"`
+	InterestAmount  string `json:"interest-amount"`
+	InterestBalance string `json:"interest-balance"`
+	State           string `json:"state"`
+	CreatedAt       int64  `json:"created-at"`
+	AccruedAt       int64  `json:"accrued-at"`
+}
+
+type MarginRepayment []struct {
+	RepayID   string `json:"repayId"`
+	RepayTime int64  `json:"repayTime"`
+}
+
+type MarketStatus struct {
+	MarketStatus    int    `json:"marketStatus"`
+	HaltStartTime   int64  `json:"haltStartTime"`
+	HaltEndTime     int64  `json:"haltEndTime"`
+	HaltReason      int    `json:"haltReason"`
+	AffectedSymbols string `json:"affectedSymbols"`
+}
@@ -70,6 +70,14 @@ func (e *Huobi) DoAccountOperation(operation *exchange.AccountOperation) error {
 		if operation.Wallet == exchange.SpotWallet {
 			return e.doGetTransferHistory(operation)
 		}
+	case exchange.PlaceOrder:
+		if operation.Wallet == exchange.MarginWallet {
+			return e.doMarginPlaceOrder(operation)
+		}
+	case exchange.MarginOperation:
+		if operation.Wallet == exchange.MarginWallet {
+			return e.doMarginOperation(operation)
+		}
 	}
 	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
 }
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	exchange "github.com/bitontop/gored/exchange"
@@ -36,78 +37,95 @@ func (e *Huobi) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doTickerPrice(operation)
 		}
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
+	case exchange.SystemStatus:
+		return e.doSystemStatus(operation)
+	case exchange.WalletStatus:
+		return e.doWalletStatus(operation)
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
-// interval options: 1min, 5min, 15min, 30min, 1hour, 4hour, 1day, 1mon, 1week, 1year
-func (e *Huobi) doSpotKline(operation *exchange.PublicOperation) error {
-	interval := "5min"
-	if operation.KlineInterval != "" {
-		interval = operation.KlineInterval
-		if operation.KlineInterval == "1hour" {
-			interval = "60min"
-		}
-	}
-
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("%s/market/history/kline?symbol=%v&period=%v&size=2000", API_URL, // ETHBTC
-			e.GetSymbolByPair(operation.Pair), // BTCUSDT
-			interval,
-		),
-		Proxy: operation.Proxy,
-	}
-
-	err := utils.HttpGetRequest(get)
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1min",
+	exchange.KLINE_5MIN:   "5min",
+	exchange.KLINE_15MIN:  "15min",
+	exchange.KLINE_30MIN:  "30min",
+	exchange.KLINE_1HOUR:  "60min",
+	exchange.KLINE_4HOUR:  "4hour",
+	exchange.KLINE_1DAY:   "1day",
+	exchange.KLINE_1WEEK:  "1week",
+	exchange.KLINE_1MONTH: "1mon",
+}
 
+func (e *Huobi) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
 	if err != nil {
-		log.Printf("%+v", err)
 		operation.Error = err
 		return err
-
 	}
 
-	if operation.DebugMode {
-		operation.RequestURI = get.URI
-		operation.CallResponce = string(get.ResponseBody)
-	}
+	// no time range, the latest 2000 klines
+	return exchange.FetchKline(operation, 0, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/market/history/kline?symbol=%v&period=%v&size=2000", API_URL, // ETHBTC
+				e.GetSymbolByPair(operation.Pair), // BTCUSDT
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
 
-	jsonResponse := &JsonResponse{}
-	jsonKLine := get.ResponseBody
-	rawKline := KLines{}
+		err := utils.HttpGetRequest(get)
 
-	if err := json.Unmarshal([]byte(jsonKLine), &jsonResponse); err != nil {
-		operation.Error = fmt.Errorf("%s doSpotKline json Unmarshal error: %v %v", e.GetName(), err, string(jsonKLine))
-		return operation.Error
-	} else if jsonResponse.Status != "ok" {
-		operation.Error = fmt.Errorf("%s doSpotKline failed: %v %v", e.GetName(), err, string(jsonKLine))
-		return operation.Error
-	}
+		if err != nil {
+			log.Printf("%+v", err)
+			return nil, err
 
-	if err := json.Unmarshal(jsonResponse.Data, &rawKline); err != nil {
-		operation.Error = fmt.Errorf("%s doSpotKline Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
-		return operation.Error
-	}
+		}
 
-	operation.Kline = []*exchange.KlineDetail{}
-	for i := len(rawKline) - 1; i >= 0; i-- {
-		k := rawKline[i]
-		detail := &exchange.KlineDetail{
-			Exchange: e.GetName(),
-			Pair:     operation.Pair.Name,
-			OpenTime: float64(k.ID) * 1000,
-			Open:     k.Open,
-			High:     k.High,
-			Low:      k.Low,
-			Close:    k.Close,
-			Volume:   k.Vol,
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
 		}
 
-		operation.Kline = append(operation.Kline, detail)
-	}
+		jsonResponse := &JsonResponse{}
+		jsonKLine := get.ResponseBody
+		rawKline := KLines{}
 
-	return nil
+		if err := json.Unmarshal([]byte(jsonKLine), &jsonResponse); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline json Unmarshal error: %v %v", e.GetName(), err, string(jsonKLine))
+		} else if jsonResponse.Status != "ok" {
+			return nil, fmt.Errorf("%s doSpotKline failed: %v %v", e.GetName(), err, string(jsonKLine))
+		}
+
+		if err := json.Unmarshal(jsonResponse.Data, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for i := len(rawKline) - 1; i >= 0; i-- {
+			k := rawKline[i]
+			detail := &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: float64(k.ID) * 1000,
+				Open:     k.Open,
+				High:     k.High,
+				Low:      k.Low,
+				Close:    k.Close,
+				Volume:   k.Vol,
+			}
+
+			details = append(details, detail)
+		}
+
+		return details, nil
+	})
 }
 
 func (e *Huobi) doTickerPrice(operation *exchange.PublicOperation) error {
@@ -166,6 +184,67 @@ func (e *Huobi) doTickerPrice(operation *exchange.PublicOperation) error {
 	return nil
 }
 
+func (e *Huobi) doTicker(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	ticker := TickerPrice{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/market/tickers", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doTicker failed: %s", e.GetName(), get.ResponseBody)
+		return operation.Error
+	}
+
+	if err := json.Unmarshal(jsonResponse.Data, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range ticker {
+		p := e.GetPairBySymbol(t.Symbol)
+		if p == nil {
+			continue
+		}
+
+		// amount is in the base currency of huobi, the Target coin
+		detail := &exchange.TickerDetail{
+			Pair:            p,
+			Last:            t.Close,
+			BestBid:         t.Bid,
+			BestBidQuantity: t.BidSize,
+			BestAsk:         t.Ask,
+			BestAskQuantity: t.AskSize,
+			Open:            t.Open,
+			High:            t.High,
+			Low:             t.Low,
+			Volume:          t.Amount,
+			QuoteVolume:     t.Vol,
+			Timestamp:       jsonResponse.Ts,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	return nil
+}
+
 func (e *Huobi) doSpotOrderBook(op *exchange.PublicOperation) error {
 	jsonResponse := &JsonResponse{}
 	orderBook := OrderBook{}
@@ -231,12 +310,18 @@ func (e *Huobi) doSpotOrderBook(op *exchange.PublicOperation) error {
 	return nil
 }
 
+// huobi has no pages, with a range or TradeFromID the latest 2000 trades are filtered,
+// a range older than them fails with exchange.ErrTradeRange
 func (e *Huobi) doTradeHistory(operation *exchange.PublicOperation) error {
+	size := 1000 //TRADE_HISTORY_MAX_LIMIT,
+	if operation.IsTradePaged() {
+		size = 2000
+	}
 
 	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://api.huobi.pro/market/history/trade?symbol=%s&size=%d",
+		URI: fmt.Sprintf("%s/market/history/trade?symbol=%s&size=%d", API_URL,
 			e.GetSymbolByPair(operation.Pair),
-			1000, //TRADE_HISTORY_MAX_LIMIT,
+			size,
 		),
 		Proxy: operation.Proxy,
 	}
@@ -284,6 +369,9 @@ func (e *Huobi) doTradeHistory(operation *exchange.PublicOperation) error {
 		}
 	}
 
+	if operation.IsTradePaged() {
+		return exchange.LatestTrades(operation, operation.TradeHistory)
+	}
 	return nil
 
 }
@@ -335,3 +423,144 @@ func (e *Huobi) getCoinChainType(operation *exchange.PublicOperation) error {
 
 	return nil
 }
+
+func (e *Huobi) doSystemStatus(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	marketStatus := MarketStatus{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/v2/market-status", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doSystemStatus Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != 200 {
+		operation.Error = fmt.Errorf("%s doSystemStatus failed: %s", e.GetName(), get.ResponseBody)
+		return operation.Error
+	}
+
+	if err := json.Unmarshal(jsonResponse.Data, &marketStatus); err != nil {
+		operation.Error = fmt.Errorf("%s doSystemStatus Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	// marketStatus 1: normal, 2: halted, 3: cancel-only. haltReason 2: emergency maintenance, 3: scheduled maintenance
+	status := &exchange.SystemStatusDetail{
+		Status:       exchange.HealthNormal,
+		Maintenances: []*exchange.MaintenanceDetail{},
+		Timestamp:    time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	switch marketStatus.MarketStatus {
+	case 2:
+		status.Status = exchange.HealthMaintenance
+	case 3:
+		status.Status = exchange.HealthCancelOnly
+	}
+	if marketStatus.HaltStartTime > 0 || marketStatus.HaltEndTime > 0 {
+		title := "maintenance"
+		switch marketStatus.HaltReason {
+		case 2:
+			title = "emergency maintenance"
+		case 3:
+			title = "scheduled maintenance"
+		}
+		if marketStatus.AffectedSymbols != "" && marketStatus.AffectedSymbols != "all" {
+			title = fmt.Sprintf("%s of %s", title, marketStatus.AffectedSymbols)
+		}
+		status.Message = title
+		status.Maintenances = append(status.Maintenances, &exchange.MaintenanceDetail{
+			Title:     title,
+			Start:     marketStatus.HaltStartTime,
+			End:       marketStatus.HaltEndTime,
+			Timestamp: status.Timestamp,
+		})
+	}
+	operation.SystemStatus = status
+
+	return nil
+}
+
+// doWalletStatus is the status of the first chain of every currency, as in GetCoinsData
+func (e *Huobi) doWalletStatus(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	coinsData := CoinsData{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/v2/reference/currencies", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doWalletStatus Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != 200 {
+		operation.Error = fmt.Errorf("%s doWalletStatus failed: %s", e.GetName(), get.ResponseBody)
+		return operation.Error
+	}
+
+	if err := json.Unmarshal(jsonResponse.Data, &coinsData); err != nil {
+		operation.Error = fmt.Errorf("%s doWalletStatus Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.WalletStatus = []*exchange.WalletStatusDetail{}
+	for _, data := range coinsData {
+		c := e.GetCoinBySymbol(data.Currency)
+		if c == nil {
+			continue
+		}
+
+		status := &exchange.WalletStatusDetail{Coin: c}
+		issues := []string{}
+		if data.InstStatus != "" && data.InstStatus != "normal" {
+			issues = append(issues, data.InstStatus)
+		}
+		if len(data.Chains) > 0 {
+			status.Deposit = data.Chains[0].DepositStatus == "allowed"
+			status.Withdraw = data.Chains[0].WithdrawStatus == "allowed"
+			if !status.Deposit {
+				issues = append(issues, "deposit "+data.Chains[0].DepositStatus)
+			}
+			if !status.Withdraw {
+				issues = append(issues, "withdraw "+data.Chains[0].WithdrawStatus)
+			}
+		} else {
+			issues = append(issues, "no chain")
+		}
+		status.Issue = strings.Join(issues, "; ")
+
+		if coinConstraint := e.GetCoinConstraint(c); coinConstraint != nil {
+			// a copy, the constraint may be read meanwhile
+			updated := *coinConstraint
+			updated.Deposit = status.Deposit
+			updated.Withdraw = status.Withdraw
+			updated.Issue = status.Issue
+			e.SetCoinConstraint(&updated)
+		}
+		operation.WalletStatus = append(operation.WalletStatus, status)
+	}
+
+	return nil
+}
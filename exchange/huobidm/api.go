@@ -11,6 +11,8 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -21,8 +23,9 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
-	API_URL = "https://api.hbdm.com"
+var (
+	API_URL      = "https://api.hbdm.com"
+	SPOT_API_URL = "https://api.huobi.pro" // transfers between spot and contract accounts
 )
 
 /*API Base Knowledge
@@ -255,15 +258,7 @@ func (e *Huobidm) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
 	return maker, err
 }
 
-func (e *Huobidm) LoadPublicData(operation *exchange.PublicOperation) error {
-	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
-}
-
 /*************** Private API ***************/
-func (e *Huobidm) DoAccountOperation(operation *exchange.AccountOperation) error {
-	return nil
-}
-
 func (e *Huobidm) UpdateAllBalances() {
 	if e.API_KEY == "" || e.API_SECRET == "" {
 		log.Printf("%s API Key or Secret Key are nil.", e.GetName())
@@ -271,27 +266,28 @@ func (e *Huobidm) UpdateAllBalances() {
 	}
 
 	jsonResponse := &JsonResponse{}
-	accountBalance := AccountBalances{}
+	accounts := ContractAccounts{}
 
-	strRequestPath := "/API Path"
+	strRequestPath := "/api/v1/contract_account_info"
 
-	jsonBalanceReturn := e.ApiKeyGet(strRequestPath, make(map[string]string))
+	jsonBalanceReturn := e.ApiKeyRequest("POST", strRequestPath, make(map[string]string))
 	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
 		log.Printf("%s UpdateAllBalances Json Unmarshal Err: %v %v", e.GetName(), err, jsonBalanceReturn)
 		return
 	} else if jsonResponse.Status != "ok" {
-		log.Printf("%s UpdateAllBalances Failed: %v", e.GetName(), jsonResponse)
+		log.Printf("%s UpdateAllBalances Failed: %v", e.GetName(), jsonBalanceReturn)
 		return
 	}
-	if err := json.Unmarshal(jsonResponse.Data, &accountBalance); err != nil {
+	if err := json.Unmarshal(jsonResponse.Data, &accounts); err != nil {
 		log.Printf("%s UpdateAllBalances Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
 		return
 	}
 
-	for _, balance := range accountBalance {
-		c := e.GetCoinBySymbol(balance.Asset)
+	// the contract accounts are margined in the coin of the symbol
+	for _, account := range accounts {
+		c := coin.GetCoin(account.Symbol)
 		if c != nil {
-			balanceMap.Set(c.Code, balance.Available)
+			balanceMap.Set(c.Code, account.MarginAvailable)
 		}
 	}
 }
@@ -334,6 +330,11 @@ func (e *Huobidm) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -342,8 +343,8 @@ func (e *Huobidm) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "SELL"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -372,6 +373,11 @@ func (e *Huobidm) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/API Path"
@@ -380,8 +386,8 @@ func (e *Huobidm) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["side"] = "BUY"
 	mapParams["type"] = "LIMIT"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequestPath, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -493,17 +499,14 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Huobidm) ApiKeyGet(strRequestPath string, mapParams map[string]string) string {
-	mapParams["signature"] = exchange.ComputeHmac256NoDecode(exchange.Map2UrlQuery(mapParams), e.API_SECRET)
-
-	payload := exchange.Map2UrlQuery(mapParams)
-	strUrl := API_URL + strRequestPath + "?" + payload
+	e.addSignature("GET", API_URL, strRequestPath, mapParams)
+	strUrl := API_URL + strRequestPath + "?" + MapSortByKey(mapParams)
 
 	request, err := http.NewRequest("GET", strUrl, nil)
 	if nil != err {
 		return err.Error()
 	}
-	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 
 	httpClient := &http.Client{}
 	response, err := httpClient.Do(request)
@@ -524,9 +527,20 @@ func (e *Huobidm) ApiKeyGet(strRequestPath string, mapParams map[string]string)
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request*/
 func (e *Huobidm) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[string]string) string {
-	strUrl := API_URL + strRequestPath
+	return e.signedRequest(strMethod, API_URL, strRequestPath, mapParams)
+}
+
+/*Method: API Request to the Huobi spot host, same key and Signature as the contract API*/
+func (e *Huobidm) SpotApiKeyRequest(strMethod, strRequestPath string, mapParams map[string]string) string {
+	return e.signedRequest(strMethod, SPOT_API_URL, strRequestPath, mapParams)
+}
+
+func (e *Huobidm) signedRequest(strMethod, strHostUrl, strRequestPath string, mapParams map[string]string) string {
+	// only the authentication params are signed, the request params are sent as json body
+	authParams := make(map[string]string)
+	e.addSignature(strMethod, strHostUrl, strRequestPath, authParams)
+	strUrl := strHostUrl + strRequestPath + "?" + MapSortByKey(authParams)
 
-	mapParams["signature"] = exchange.ComputeHmac256NoDecode(exchange.Map2UrlQuery(mapParams), e.API_SECRET)
 	jsonParams := ""
 	if nil != mapParams {
 		bytesParams, _ := json.Marshal(mapParams)
@@ -537,8 +551,7 @@ func (e *Huobidm) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[
 	if nil != err {
 		return err.Error()
 	}
-	request.Header.Add("Content-Type", "application/json; charset=utf-8")
-	request.Header.Add("X-MBX-APIKEY", e.API_KEY)
+	request.Header.Add("Content-Type", "application/json")
 
 	httpClient := &http.Client{}
 	response, err := httpClient.Do(request)
@@ -555,6 +568,39 @@ func (e *Huobidm) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[
 	return string(body)
 }
 
+func (e *Huobidm) addSignature(strMethod, strHostUrl, strRequestPath string, mapParams map[string]string) {
+	mapParams["AccessKeyId"] = e.API_KEY
+	mapParams["SignatureMethod"] = "HmacSHA256"
+	mapParams["SignatureVersion"] = "2"
+	mapParams["Timestamp"] = time.Now().UTC().Format("2006-01-02T15:04:05")
+
+	hostName := strings.TrimPrefix(strHostUrl, "https://")
+	mapParams["Signature"] = CreateSign(mapParams, strMethod, hostName, strRequestPath, e.API_SECRET)
+}
+
+func CreateSign(mapParams map[string]string, strMethod, strHostUrl, strRequestPath, strSecretKey string) string {
+	sortedParams := MapSortByKey(mapParams)
+	strPayload := strMethod + "\n" + strHostUrl + "\n" + strRequestPath + "\n" + sortedParams
+
+	return exchange.ComputeHmac256Base64(strPayload, strSecretKey)
+}
+
+func MapSortByKey(mapValue map[string]string) string {
+	keys := make([]string, 0, len(mapValue))
+	for key := range mapValue {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	mapParams := ""
+	for _, key := range keys {
+		mapParams += (key + "=" + url.QueryEscape(mapValue[key]) + "&")
+	}
+	if len(mapParams) > 0 {
+		mapParams = mapParams[:len(mapParams)-1]
+	}
+	return mapParams
+}
+
 func GetContractName(code string) string {
 	if code == "this_week" {
 		return "CW"
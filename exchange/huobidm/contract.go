@@ -0,0 +1,524 @@
+package huobidm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// delivery contracts are loaded pairs, eg. USD|CQBTC -> BTC_quarter,
+// other pairs are coin-margined swaps, eg. USD|BTC -> BTC-USD
+func (e *Huobidm) contractParams(p *pair.Pair) (bool, map[string]string) {
+	mapParams := make(map[string]string)
+	if symbol := e.GetSymbolByPair(p); symbol != "" {
+		contract := strings.Split(symbol, "_")
+		mapParams["symbol"] = contract[0]
+		mapParams["contract_type"] = contract[1]
+		return false, mapParams
+	}
+
+	mapParams["contract_code"] = p.Target.Code + "-" + p.Base.Code
+	return true, mapParams
+}
+
+func (e *Huobidm) getContractPair(symbol, contractType, contractCode string) *pair.Pair {
+	if contractType != "" && contractType != "swap" {
+		return e.GetPairBySymbol(symbol + "_" + contractType)
+	}
+
+	codes := strings.Split(contractCode, "-")
+	if len(codes) != 2 {
+		return nil
+	}
+	base := coin.GetCoin(codes[1])
+	target := coin.GetCoin(codes[0])
+	if base == nil || target == nil {
+		return nil
+	}
+	return pair.GetPair(base, target)
+}
+
+// OrderType takes the Huobi order price types (LIMIT, OPTIMAL_5, BBO...) or GTC, IOC, FOK, GTX (post only)
+func getOrderPriceType(operation *exchange.AccountOperation) (string, error) {
+	switch operation.OrderType {
+	case exchange.LIMIT, exchange.OPTIMAL_5, exchange.OPTIMAL_5_FOK, exchange.OPTIMAL_10, exchange.OPTIMAL_20, exchange.OPTIMAL_20_FOK, exchange.BBO, exchange.BBO_FOK:
+		return string(operation.OrderType), nil
+	case exchange.GTC:
+		return "limit", nil
+	case exchange.IOC:
+		return "ioc", nil
+	case exchange.FOK:
+		return "fok", nil
+	case exchange.GTX:
+		return "post_only", nil
+	}
+
+	switch operation.TradeType {
+	case exchange.TRADE_LIMIT:
+		return "limit", nil
+	case exchange.TRADE_MARKET:
+		// there is no market order, opponent is a limit order at the best price of the other side
+		return string(exchange.BBO), nil
+	}
+	return "", fmt.Errorf("invalid TradeType: %v", operation.TradeType)
+}
+
+// Quantity is the number of contracts, Offset opens or closes a position with Leverage as lever_rate
+func (e *Huobidm) doContractPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Pair == nil {
+		return fmt.Errorf("%s ContractPlaceOrder Pair is empty", e.GetName())
+	} else if operation.OrderDirection == "" {
+		return fmt.Errorf("%s ContractPlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.Offset == "" {
+		return fmt.Errorf("%s ContractPlaceOrder empty Offset: %+v", e.GetName(), operation)
+	} else if operation.Leverage == 0 {
+		return fmt.Errorf("%s ContractPlaceOrder empty Leverage: %+v", e.GetName(), operation)
+	}
+
+	orderPriceType, err := getOrderPriceType(operation)
+	if err != nil {
+		return fmt.Errorf("%s ContractPlaceOrder %v", e.GetName(), err)
+	}
+
+	jsonResponse := &JsonResponse{}
+	placeOrder := ContractPlaceOrder{}
+	strRequest := "/api/v1/contract_order"
+
+	swap, mapParams := e.contractParams(operation.Pair)
+	if swap {
+		strRequest = "/swap-api/v1/swap_order"
+	}
+	mapParams["volume"] = fmt.Sprintf("%d", int64(operation.Quantity))
+	mapParams["offset"] = string(operation.Offset)
+	mapParams["lever_rate"] = fmt.Sprintf("%d", operation.Leverage)
+	mapParams["order_price_type"] = orderPriceType
+	if operation.Rate != 0 {
+		mapParams["price"] = e.GetPairConstraint(operation.Pair).FormatRate(operation.Rate)
+	}
+	if operation.OrderDirection == exchange.Buy {
+		mapParams["direction"] = "buy"
+	} else if operation.OrderDirection == exchange.Sell {
+		mapParams["direction"] = "sell"
+	}
+
+	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Order = &exchange.Order{
+		EX:           e.GetName(),
+		Pair:         operation.Pair,
+		OrderID:      placeOrder.OrderIDStr,
+		Rate:         operation.Rate,
+		Quantity:     operation.Quantity,
+		Direction:    operation.OrderDirection,
+		Status:       exchange.New,
+		Timestamp:    jsonResponse.Ts,
+		JsonResponse: jsonPlaceReturn,
+	}
+
+	return nil
+}
+
+func (e *Huobidm) doContractOrderStatus(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Order == nil || operation.Order.Pair == nil {
+		return fmt.Errorf("%s ContractOrderStatus Order or Order Pair is empty", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	orderInfo := []ContractOrder{}
+	strRequest := "/api/v1/contract_order_info"
+
+	swap, mapParams := e.contractParams(operation.Order.Pair)
+	if swap {
+		strRequest = "/swap-api/v1/swap_order_info"
+	} else {
+		delete(mapParams, "contract_type")
+	}
+	mapParams["order_id"] = operation.Order.OrderID
+
+	jsonOrderStatus := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonOrderStatus
+	}
+
+	if err := json.Unmarshal([]byte(jsonOrderStatus), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Json Unmarshal Err: %v, %s", e.GetName(), err, jsonOrderStatus)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Failed: %v", e.GetName(), jsonOrderStatus)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &orderInfo); err != nil {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(orderInfo) == 0 {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Could not find Order: %v", e.GetName(), operation.Order.OrderID)
+		return operation.Error
+	}
+
+	// order pointer from operation
+	order := operation.Order
+	status := e.toOrder(&orderInfo[0])
+	order.Status = status.Status
+	order.Direction = status.Direction
+	order.DealRate = status.DealRate
+	order.DealQuantity = status.DealQuantity
+
+	return nil
+}
+
+func (e *Huobidm) doContractCancelOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Order == nil || operation.Order.Pair == nil {
+		return fmt.Errorf("%s ContractCancelOrder Order or Order Pair is empty", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	cancelOrder := ContractCancelOrder{}
+	strRequest := "/api/v1/contract_cancel"
+
+	swap, mapParams := e.contractParams(operation.Order.Pair)
+	if swap {
+		strRequest = "/swap-api/v1/swap_cancel"
+	} else {
+		delete(mapParams, "contract_type")
+	}
+	mapParams["order_id"] = operation.Order.OrderID
+
+	jsonCancelOrder := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonCancelOrder
+	}
+
+	if err := json.Unmarshal([]byte(jsonCancelOrder), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonCancelOrder)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Failed: %v", e.GetName(), jsonCancelOrder)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &cancelOrder); err != nil {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(cancelOrder.Errors) > 0 {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Failed: %v %v", e.GetName(), cancelOrder.Errors[0].ErrCode, cancelOrder.Errors[0].ErrMsg)
+		return operation.Error
+	}
+
+	operation.Order.Status = exchange.Canceling
+	operation.Order.CancelStatus = jsonCancelOrder
+
+	return nil
+}
+
+func (e *Huobidm) doContractGetOpenOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Pair == nil {
+		return fmt.Errorf("%s ContractGetOpenOrder Pair is empty", e.GetName())
+	}
+
+	strRequest := "/api/v1/contract_openorders"
+	swap, mapParams := e.contractParams(operation.Pair)
+	if swap {
+		strRequest = "/swap-api/v1/swap_openorders"
+	} else {
+		delete(mapParams, "contract_type")
+	}
+
+	orders, err := e.getContractOrders(operation, strRequest, mapParams)
+	if err != nil {
+		return err
+	}
+
+	// delivery open orders are listed for every contract type of the symbol
+	operation.OpenOrders = []*exchange.Order{}
+	for _, order := range orders {
+		if order.Pair == operation.Pair {
+			operation.OpenOrders = append(operation.OpenOrders, order)
+		}
+	}
+
+	return nil
+}
+
+// closed orders of the last 7 days
+func (e *Huobidm) doContractGetOrderHistory(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Pair == nil {
+		return fmt.Errorf("%s ContractGetOrderHistory Pair is empty", e.GetName())
+	}
+
+	strRequest := "/api/v1/contract_hisorders"
+	swap, mapParams := e.contractParams(operation.Pair)
+	if swap {
+		strRequest = "/swap-api/v1/swap_hisorders"
+	} else {
+		delete(mapParams, "contract_type")
+	}
+	mapParams["trade_type"] = "0" // all
+	mapParams["type"] = "2"       // finished orders
+	mapParams["status"] = "0"     // all
+	mapParams["create_date"] = "7"
+
+	orders, err := e.getContractOrders(operation, strRequest, mapParams)
+	if err != nil {
+		return err
+	}
+
+	operation.OrderHistory = []*exchange.Order{}
+	for _, order := range orders {
+		if order.Pair == operation.Pair {
+			operation.OrderHistory = append(operation.OrderHistory, order)
+		}
+	}
+
+	return nil
+}
+
+// getContractOrders walks every page of the order list
+func (e *Huobidm) getContractOrders(operation *exchange.AccountOperation, strRequest string, mapParams map[string]string) ([]*exchange.Order, error) {
+	orders := []*exchange.Order{}
+	mapParams["page_size"] = "50"
+	for page := 1; ; page++ {
+		jsonResponse := &JsonResponse{}
+		contractOrders := ContractOrders{}
+		mapParams["page_index"] = fmt.Sprintf("%d", page)
+
+		jsonOrdersReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+		if operation.DebugMode {
+			operation.RequestURI = strRequest
+			operation.CallResponce = jsonOrdersReturn
+		}
+
+		if err := json.Unmarshal([]byte(jsonOrdersReturn), &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonOrdersReturn)
+			return nil, operation.Error
+		} else if jsonResponse.Status != "ok" {
+			operation.Error = fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, jsonOrdersReturn)
+			return nil, operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &contractOrders); err != nil {
+			operation.Error = fmt.Errorf("%s %s Result Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonResponse.Data)
+			return nil, operation.Error
+		}
+
+		for i := range contractOrders.Orders {
+			orders = append(orders, e.toOrder(&contractOrders.Orders[i]))
+		}
+
+		if contractOrders.CurrentPage >= contractOrders.TotalPage {
+			break
+		}
+	}
+
+	return orders, nil
+}
+
+func (e *Huobidm) toOrder(o *ContractOrder) *exchange.Order {
+	order := &exchange.Order{
+		EX:           e.GetName(),
+		Pair:         e.getContractPair(o.Symbol, o.ContractType, o.ContractCode),
+		OrderID:      o.OrderIDStr,
+		Rate:         o.Price,
+		Quantity:     o.Volume,
+		DealRate:     o.TradeAvgPrice,
+		DealQuantity: o.TradeVolume,
+		Timestamp:    o.CreatedAt,
+	}
+	if order.Timestamp == 0 {
+		order.Timestamp = o.CreateDate
+	}
+
+	switch o.Direction {
+	case "buy":
+		order.Direction = exchange.Buy
+	case "sell":
+		order.Direction = exchange.Sell
+	}
+
+	switch o.Status {
+	case 1, 2, 3:
+		order.Status = exchange.New
+	case 4:
+		order.Status = exchange.Partial
+	case 5, 7:
+		order.Status = exchange.Cancelled
+	case 6:
+		order.Status = exchange.Filled
+	case 11:
+		order.Status = exchange.Canceling
+	default:
+		order.Status = exchange.Other
+	}
+
+	return order
+}
+
+// delivery futures and swap accounts are summed per coin
+func (e *Huobidm) doContractAllBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	balances := make(map[string]*exchange.AssetBalance)
+	operation.BalanceList = []exchange.AssetBalance{}
+	for _, strRequest := range []string{"/api/v1/contract_account_info", "/swap-api/v1/swap_account_info"} {
+		jsonResponse := &JsonResponse{}
+		accounts := ContractAccounts{}
+
+		jsonBalanceReturn := e.ApiKeyRequest("POST", strRequest, make(map[string]string))
+		if operation.DebugMode {
+			operation.RequestURI = strRequest
+			operation.CallResponce = jsonBalanceReturn
+		}
+
+		if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s ContractAllBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+			return operation.Error
+		} else if jsonResponse.Status != "ok" {
+			operation.Error = fmt.Errorf("%s ContractAllBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+			return operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &accounts); err != nil {
+			operation.Error = fmt.Errorf("%s ContractAllBalance Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+			return operation.Error
+		}
+
+		for _, account := range accounts {
+			c := coin.GetCoin(account.Symbol)
+			if c == nil {
+				continue
+			}
+
+			balance, ok := balances[c.Code]
+			if !ok {
+				balance = &exchange.AssetBalance{Coin: c}
+				balances[c.Code] = balance
+			}
+			balance.Balance += account.MarginBalance
+			balance.BalanceAvailable += account.MarginAvailable
+			balance.BalanceFrozen += account.MarginFrozen + account.MarginPosition
+		}
+	}
+
+	for _, balance := range balances {
+		operation.BalanceList = append(operation.BalanceList, *balance)
+	}
+
+	return nil
+}
+
+// SpotWallet <-> ContractWallet. A swap Pair (eg. USD|BTC) moves Coin to the swap account,
+// otherwise to the delivery futures account
+func (e *Huobidm) doContractTransfer(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Coin == nil {
+		return fmt.Errorf("%s ContractTransfer Coin is empty", e.GetName())
+	}
+
+	var direction exchange.ContractTransDir
+	if operation.TransferFrom == exchange.SpotWallet && operation.TransferDestination == exchange.ContractWallet {
+		direction = exchange.SPOT_TO_FUTURE
+	} else if operation.TransferFrom == exchange.ContractWallet && operation.TransferDestination == exchange.SpotWallet {
+		direction = exchange.FUTURE_TO_SPOT
+	} else {
+		return fmt.Errorf("%s ContractTransfer invalid direction: %v -> %v", e.GetName(), operation.TransferFrom, operation.TransferDestination)
+	}
+
+	swap := false
+	if operation.Pair != nil {
+		swap, _ = e.contractParams(operation.Pair)
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = strings.ToLower(operation.Coin.Code)
+	mapParams["amount"] = operation.TransferAmount
+
+	if swap {
+		swapTransfer := SwapTransfer{}
+		strRequest := "/v2/account/transfer"
+		if direction == exchange.SPOT_TO_FUTURE {
+			mapParams["from"] = "spot"
+			mapParams["to"] = "swap"
+		} else {
+			mapParams["from"] = "swap"
+			mapParams["to"] = "spot"
+		}
+
+		jsonTransferReturn := e.SpotApiKeyRequest("POST", strRequest, mapParams)
+		if operation.DebugMode {
+			operation.RequestURI = strRequest
+			operation.CallResponce = jsonTransferReturn
+		}
+
+		if err := json.Unmarshal([]byte(jsonTransferReturn), &swapTransfer); err != nil {
+			operation.Error = fmt.Errorf("%s ContractTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+			return operation.Error
+		} else if !swapTransfer.Success {
+			operation.Error = fmt.Errorf("%s ContractTransfer Failed: %v", e.GetName(), jsonTransferReturn)
+			return operation.Error
+		}
+		return nil
+	}
+
+	futuresTransfer := FuturesTransfer{}
+	strRequest := "/v1/futures/transfer"
+	mapParams["type"] = string(direction)
+
+	jsonTransferReturn := e.SpotApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonTransferReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonTransferReturn), &futuresTransfer); err != nil {
+		operation.Error = fmt.Errorf("%s ContractTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+		return operation.Error
+	} else if futuresTransfer.Status != "ok" {
+		operation.Error = fmt.Errorf("%s ContractTransfer Failed: %v %v", e.GetName(), futuresTransfer.ErrCode, futuresTransfer.ErrMsg)
+		return operation.Error
+	}
+
+	return nil
+}
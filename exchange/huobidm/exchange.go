@@ -248,6 +248,36 @@ func (e *Huobidm) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainF
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.FundingRate: {exchange.ContractWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Transfer:             {exchange.ContractWallet},
+		exchange.BalanceList:          {exchange.ContractWallet},
+		exchange.PlaceOrder:           {exchange.ContractWallet},
+		exchange.GetOrderStatus:       {exchange.ContractWallet},
+		exchange.CancelOrder:          {exchange.ContractWallet},
+		exchange.GetOpenOrder:         {exchange.ContractWallet},
+		exchange.GetOrderHistory:      {exchange.ContractWallet},
+		exchange.GetPositions:         {exchange.ContractWallet},
+		exchange.SetLeverage:          {exchange.ContractWallet},
+		exchange.SetMarginType:        {exchange.ContractWallet},
+		exchange.SetPositionMode:      {exchange.ContractWallet},
+		exchange.AdjustIsolatedMargin: {exchange.ContractWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.ContractWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.ContractWallet: {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+	},
+}
+
+func (e *Huobidm) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Huobidm) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
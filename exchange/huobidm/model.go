@@ -73,3 +73,123 @@ type PlaceOrder struct {
 	Status       string `json:"status"`
 	TimeInForce  string `json:"timeInForce"`
 }
+
+type ContractPositions []struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	ContractType   string  `json:"contract_type"`
+	Volume         float64 `json:"volume"`
+	Available      float64 `json:"available"`
+	Frozen         float64 `json:"frozen"`
+	CostOpen       float64 `json:"cost_open"`
+	CostHold       float64 `json:"cost_hold"`
+	ProfitUnreal   float64 `json:"profit_unreal"`
+	ProfitRate     float64 `json:"profit_rate"`
+	Profit         float64 `json:"profit"`
+	PositionMargin float64 `json:"position_margin"`
+	LeverRate      float64 `json:"lever_rate"`
+	Direction      string  `json:"direction"`
+	LastPrice      float64 `json:"last_price"`
+}
+
+type ContractAccounts []struct {
+	Symbol            string  `json:"symbol"`
+	MarginBalance     float64 `json:"margin_balance"`
+	MarginPosition    float64 `json:"margin_position"`
+	MarginFrozen      float64 `json:"margin_frozen"`
+	MarginAvailable   float64 `json:"margin_available"`
+	ProfitReal        float64 `json:"profit_real"`
+	ProfitUnreal      float64 `json:"profit_unreal"`
+	RiskRate          float64 `json:"risk_rate"`
+	LiquidationPrice  float64 `json:"liquidation_price"`
+	WithdrawAvailable float64 `json:"withdraw_available"`
+	LeverRate         float64 `json:"lever_rate"`
+	AdjustFactor      float64 `json:"adjust_factor"`
+}
+
+type SwapFundingRate struct {
+	Symbol          string `json:"symbol"`
+	ContractCode    string `json:"contract_code"`
+	FeeAsset        string `json:"fee_asset"`
+	FundingTime     string `json:"funding_time"`
+	FundingRate     string `json:"funding_rate"`
+	EstimatedRate   string `json:"estimated_rate"`
+	NextFundingTime string `json:"next_funding_time"`
+}
+
+type SwapFundingHistory struct {
+	TotalPage   int `json:"total_page"`
+	CurrentPage int `json:"current_page"`
+	TotalSize   int `json:"total_size"`
+	Data        []struct {
+		Symbol       string `json:"symbol"`
+		ContractCode string `json:"contract_code"`
+		FeeAsset     string `json:"fee_asset"`
+		FundingTime  string `json:"funding_time"`
+		FundingRate  string `json:"funding_rate"`
+		RealizedRate string `json:"realized_rate"`
+	} `json:"data"`
+}
+
+type SwitchLeverRate struct {
+	Symbol    string  `json:"symbol"`
+	LeverRate float64 `json:"lever_rate"`
+}
+
+type ContractPlaceOrder struct {
+	OrderID       int64  `json:"order_id"`
+	OrderIDStr    string `json:"order_id_str"`
+	ClientOrderID int64  `json:"client_order_id"`
+}
+
+type ContractCancelOrder struct {
+	Errors []struct {
+		OrderID string `json:"order_id"`
+		ErrCode int    `json:"err_code"`
+		ErrMsg  string `json:"err_msg"`
+	} `json:"errors"`
+	Successes string `json:"successes"`
+}
+
+// status: 1,2 ready to submit, 3 submitted, 4 partially filled, 5 partially filled and cancelled,
+// 6 filled, 7 cancelled, 11 cancelling
+type ContractOrder struct {
+	Symbol         string  `json:"symbol"`
+	ContractCode   string  `json:"contract_code"`
+	ContractType   string  `json:"contract_type"`
+	Volume         float64 `json:"volume"`
+	Price          float64 `json:"price"`
+	OrderPriceType string  `json:"order_price_type"`
+	Direction      string  `json:"direction"`
+	Offset         string  `json:"offset"`
+	LeverRate      float64 `json:"lever_rate"`
+	OrderID        int64   `json:"order_id"`
+	OrderIDStr     string  `json:"order_id_str"`
+	CreatedAt      int64   `json:"created_at"`
+	CreateDate     int64   `json:"create_date"`
+	TradeVolume    float64 `json:"trade_volume"`
+	TradeAvgPrice  float64 `json:"trade_avg_price"`
+	Fee            float64 `json:"fee"`
+	Status         int     `json:"status"`
+}
+
+type ContractOrders struct {
+	Orders      []ContractOrder `json:"orders"`
+	TotalPage   int             `json:"total_page"`
+	CurrentPage int             `json:"current_page"`
+	TotalSize   int             `json:"total_size"`
+}
+
+type FuturesTransfer struct {
+	Status  string `json:"status"`
+	Data    int64  `json:"data"`
+	ErrCode string `json:"err-code"`
+	ErrMsg  string `json:"err-msg"`
+}
+
+type SwapTransfer struct {
+	Code    int    `json:"code"`
+	Data    int64  `json:"data"`
+	Message string `json:"message"`
+	Success bool   `json:"success"`
+}
@@ -0,0 +1,251 @@
+package huobidm
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Huobidm) DoAccountOperation(operation *exchange.AccountOperation) error {
+	switch operation.Type {
+	case exchange.Transfer:
+		return e.doContractTransfer(operation)
+	case exchange.BalanceList:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractAllBalance(operation)
+		}
+	case exchange.PlaceOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractPlaceOrder(operation)
+		}
+	case exchange.GetOrderStatus:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractOrderStatus(operation)
+		}
+	case exchange.CancelOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractCancelOrder(operation)
+		}
+	case exchange.GetOpenOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractGetOpenOrder(operation)
+		}
+	case exchange.GetOrderHistory:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractGetOrderHistory(operation)
+		}
+	case exchange.GetPositions:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetPositions(operation)
+		}
+	case exchange.SetLeverage:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetLeverage(operation)
+		}
+	case exchange.SetMarginType:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetMarginType(operation)
+		}
+	case exchange.SetPositionMode:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetPositionMode(operation)
+		}
+	case exchange.AdjustIsolatedMargin:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doAdjustIsolatedMargin(operation)
+		}
+	}
+	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
+}
+
+// Huobi DM positions are cross margin, the liquidation price is per symbol from the account info.
+// A swap Pair (eg. USD|BTC) queries the swap positions, no Pair returns both delivery and swap positions
+func (e *Huobidm) doGetPositions(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	operation.Positions = []*exchange.Position{}
+	if operation.Pair == nil {
+		if err := e.getPositions(operation, false, make(map[string]string)); err != nil {
+			return err
+		}
+		return e.getPositions(operation, true, make(map[string]string))
+	}
+
+	swap, mapParams := e.contractParams(operation.Pair)
+	// the delivery position is queried by symbol
+	delete(mapParams, "contract_type")
+	return e.getPositions(operation, swap, mapParams)
+}
+
+// the positions have the last price, not the mark price, MarkPrice is left 0
+func (e *Huobidm) getPositions(operation *exchange.AccountOperation, swap bool, mapParams map[string]string) error {
+	jsonResponse := &JsonResponse{}
+	positions := ContractPositions{}
+	strRequest := "/api/v1/contract_position_info"
+	strAccountRequest := "/api/v1/contract_account_info"
+	if swap {
+		strRequest = "/swap-api/v1/swap_position_info"
+		strAccountRequest = "/swap-api/v1/swap_account_info"
+	}
+
+	jsonPositionReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPositionReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPositionReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doGetPositions Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPositionReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doGetPositions Failed: %v", e.GetName(), jsonPositionReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &positions); err != nil {
+		operation.Error = fmt.Errorf("%s doGetPositions Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	liquidationPrice, err := e.getLiquidationPrice(strAccountRequest, mapParams)
+	if err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	for _, p := range positions {
+		if p.Volume == 0 {
+			continue
+		}
+
+		position := &exchange.Position{
+			Pair:             e.getContractPair(p.Symbol, p.ContractType, p.ContractCode),
+			Instrument:       p.ContractCode,
+			Size:             p.Volume,
+			EntryPrice:       p.CostOpen,
+			LiquidationPrice: liquidationPrice[p.Symbol],
+			Leverage:         p.LeverRate,
+			MarginMode:       exchange.CrossMargin,
+			UnrealizedPnl:    p.ProfitUnreal,
+			RealizedPnl:      p.Profit - p.ProfitUnreal,
+			Timestamp:        jsonResponse.Ts,
+		}
+		if p.Direction == "buy" {
+			position.Side = exchange.PositionLong
+		} else {
+			position.Side = exchange.PositionShort
+		}
+
+		operation.Positions = append(operation.Positions, position)
+	}
+
+	return nil
+}
+
+func (e *Huobidm) getLiquidationPrice(strRequest string, mapParams map[string]string) (map[string]float64, error) {
+	jsonResponse := &JsonResponse{}
+	accounts := ContractAccounts{}
+
+	jsonAccountReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if err := json.Unmarshal([]byte(jsonAccountReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("%s getLiquidationPrice Json Unmarshal Err: %v, %s", e.GetName(), err, jsonAccountReturn)
+	} else if jsonResponse.Status != "ok" {
+		return nil, fmt.Errorf("%s getLiquidationPrice Failed: %v", e.GetName(), jsonAccountReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &accounts); err != nil {
+		return nil, fmt.Errorf("%s getLiquidationPrice Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	liquidationPrice := make(map[string]float64)
+	for _, account := range accounts {
+		liquidationPrice[account.Symbol] = account.LiquidationPrice
+	}
+	return liquidationPrice, nil
+}
+
+// the lever rate is per symbol, shared by all contract types of the symbol
+func (e *Huobidm) doSetLeverage(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.Pair == nil {
+		return fmt.Errorf("%s SetLeverage Pair is empty", e.GetName())
+	} else if operation.Leverage < 1 || operation.Leverage > 125 {
+		return fmt.Errorf("%s SetLeverage is between 1 to 125: %d", e.GetName(), operation.Leverage)
+	}
+
+	jsonResponse := &JsonResponse{}
+	leverRate := SwitchLeverRate{}
+	strRequest := "/api/v1/contract_switch_lever_rate"
+
+	mapParams := make(map[string]string)
+	mapParams["symbol"] = strings.Split(e.GetSymbolByPair(operation.Pair), "_")[0]
+	mapParams["lever_rate"] = fmt.Sprintf("%d", operation.Leverage)
+
+	jsonLeverageReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonLeverageReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonLeverageReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s SetLeverage Json Unmarshal Err: %v, %s", e.GetName(), err, jsonLeverageReturn)
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s SetLeverage Failed: %v", e.GetName(), jsonLeverageReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &leverRate); err != nil {
+		operation.Error = fmt.Errorf("%s SetLeverage Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:         operation.Pair,
+		Instrument:   leverRate.Symbol,
+		Leverage:     leverRate.LeverRate,
+		MarginMode:   exchange.CrossMargin,
+		PositionMode: exchange.HedgeMode,
+	}
+
+	return nil
+}
+
+// Huobi DM delivery contracts are cross margin only
+func (e *Huobidm) doSetMarginType(operation *exchange.AccountOperation) error {
+	if operation.MarginMode != exchange.CrossMargin {
+		return fmt.Errorf("%s SetMarginType %v: %w", e.GetName(), operation.MarginMode, exchange.ErrNotSupported)
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		MarginMode: exchange.CrossMargin,
+	}
+
+	return nil
+}
+
+// long and short positions are always held separately
+func (e *Huobidm) doSetPositionMode(operation *exchange.AccountOperation) error {
+	if operation.PositionMode != exchange.HedgeMode {
+		return fmt.Errorf("%s SetPositionMode %v: %w", e.GetName(), operation.PositionMode, exchange.ErrNotSupported)
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		PositionMode: exchange.HedgeMode,
+	}
+
+	return nil
+}
+
+func (e *Huobidm) doAdjustIsolatedMargin(operation *exchange.AccountOperation) error {
+	return fmt.Errorf("%s AdjustIsolatedMargin: %w", e.GetName(), exchange.ErrNotSupported)
+}
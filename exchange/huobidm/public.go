@@ -0,0 +1,137 @@
+package huobidm
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
+)
+
+const FUNDING_HISTORY_MAX_PAGE = 20
+
+/*************** PUBLIC  API ***************/
+func (e *Huobidm) LoadPublicData(operation *exchange.PublicOperation) error {
+	switch operation.Type {
+	case exchange.FundingRate:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doSwapFundingRate(operation)
+		}
+	}
+	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
+}
+
+// swaps are not loaded as pairs, the contract code is built from the pair, eg. USD|BTC -> BTC-USD
+func getSwapCode(operation *exchange.PublicOperation) string {
+	return operation.Pair.Target.Code + "-" + operation.Pair.Base.Code
+}
+
+func (e *Huobidm) doSwapFundingRate(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	fundingRate := SwapFundingRate{}
+	contractCode := getSwapCode(operation)
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/swap-api/v1/swap_funding_rate?contract_code=%s", API_URL, contractCode),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doSwapFundingRate Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		return operation.Error
+	} else if jsonResponse.Status != "ok" {
+		operation.Error = fmt.Errorf("%s doSwapFundingRate Failed: %v", e.GetName(), string(get.ResponseBody))
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &fundingRate); err != nil {
+		operation.Error = fmt.Errorf("%s doSwapFundingRate Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	// funding_rate is settled at funding_time, estimated_rate is for the following period
+	operation.FundingRate = &exchange.FundingRateDetail{
+		Pair:       operation.Pair,
+		Instrument: contractCode,
+		History:    []*exchange.FundingRateHistory{},
+	}
+	operation.FundingRate.CurrentRate, _ = strconv.ParseFloat(fundingRate.FundingRate, 64)
+	operation.FundingRate.PredictedRate, _ = strconv.ParseFloat(fundingRate.EstimatedRate, 64)
+	operation.FundingRate.NextFundingTime, _ = strconv.ParseInt(fundingRate.FundingTime, 10, 64)
+
+	// the history is newest first without a time filter, page back until FundingStartTime
+	history := []*exchange.FundingRateHistory{}
+	for page := 1; page <= FUNDING_HISTORY_MAX_PAGE; page++ {
+		jsonResponse := &JsonResponse{}
+		fundingHistory := SwapFundingHistory{}
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/swap-api/v1/swap_historical_funding_rate?contract_code=%s&page_index=%d&page_size=50", API_URL, contractCode, page),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			operation.Error = err
+			return operation.Error
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doSwapFundingRate history Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+			return operation.Error
+		} else if jsonResponse.Status != "ok" {
+			operation.Error = fmt.Errorf("%s doSwapFundingRate history Failed: %v", e.GetName(), string(get.ResponseBody))
+			return operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &fundingHistory); err != nil {
+			operation.Error = fmt.Errorf("%s doSwapFundingRate history Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+			return operation.Error
+		}
+
+		reachStart := false
+		for _, f := range fundingHistory.Data {
+			timestamp, _ := strconv.ParseInt(f.FundingTime, 10, 64)
+			if operation.FundingEndTime != 0 && timestamp > operation.FundingEndTime {
+				continue
+			}
+			if timestamp < operation.FundingStartTime {
+				reachStart = true
+				break
+			}
+			rate, err := strconv.ParseFloat(f.RealizedRate, 64)
+			if err != nil {
+				operation.Error = fmt.Errorf("%s doSwapFundingRate parse Err: %v %v", e.GetName(), err, f.RealizedRate)
+				return operation.Error
+			}
+			history = append(history, &exchange.FundingRateHistory{
+				Rate:      rate,
+				Timestamp: timestamp,
+			})
+		}
+
+		// without a start time only the latest page is returned
+		if reachStart || operation.FundingStartTime == 0 || page >= fundingHistory.TotalPage {
+			break
+		}
+	}
+
+	for i := len(history) - 1; i >= 0; i-- {
+		operation.FundingRate.History = append(operation.FundingRate.History, history[i])
+	}
+
+	return nil
+}
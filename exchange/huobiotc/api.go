@@ -16,7 +16,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL = "https://otc-api.eiijo.cn/v1/data"
 )
 
@@ -250,6 +250,19 @@ func (e *HuobiOTC) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrain
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *HuobiOTC) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *HuobiOTC) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
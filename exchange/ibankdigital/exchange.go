@@ -253,6 +253,19 @@ func (e *Ibankdigital) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Const
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Ibankdigital) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Ibankdigital) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -22,10 +22,9 @@ import (
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
 
-	"strconv"
 )
 
-const (
+var (
 	API_URL_PUB string = "https://api.idcs.io:8323/api/v1/RealTimeQuote"
 	API_URL     string = "https://api.IDCM.cc:8323/api/v1"
 )
@@ -482,13 +481,15 @@ func (e *Idcm) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	placeOrder := PlaceOrder{}
 	strRequest := "/trade"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
-	mapParams["Size"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["Price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["Size"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["Price"] = pairConstraint.FormatRate(rate)
 	mapParams["Type"] = "1" // limit
 	mapParams["Side"] = "1" // 0 buy, 1 sell
 
@@ -524,13 +525,15 @@ func (e *Idcm) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 	placeOrder := PlaceOrder{}
 	strRequest := "/trade"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
-	mapParams["Size"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["Price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["Size"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["Price"] = pairConstraint.FormatRate(rate)
 	mapParams["Type"] = "1" // limit
 	mapParams["Side"] = "0" // 0 buy, 1 sell
 
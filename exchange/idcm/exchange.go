@@ -247,6 +247,24 @@ func (e *Idcm) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList: {exchange.SpotWallet},
+		exchange.Balance:     {exchange.SpotWallet},
+		exchange.Withdraw:    {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Idcm) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Idcm) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
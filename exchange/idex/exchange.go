@@ -249,6 +249,19 @@ func (e *Idex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Idex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Idex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
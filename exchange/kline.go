@@ -0,0 +1,151 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrKlineRange is a KLine range the exchange can't get, older than the latest klines it keeps
+var ErrKlineRange = errors.New("kline range not available")
+
+// KlineInterval is the exchange independent interval of PublicOperation.KlineInterval,
+// every adapter translates it with its KlineIntervals
+type KlineInterval string
+
+const (
+	KLINE_1MIN   KlineInterval = "1min"
+	KLINE_3MIN   KlineInterval = "3min"
+	KLINE_5MIN   KlineInterval = "5min"
+	KLINE_15MIN  KlineInterval = "15min"
+	KLINE_30MIN  KlineInterval = "30min"
+	KLINE_1HOUR  KlineInterval = "1hour"
+	KLINE_2HOUR  KlineInterval = "2hour"
+	KLINE_4HOUR  KlineInterval = "4hour"
+	KLINE_6HOUR  KlineInterval = "6hour"
+	KLINE_8HOUR  KlineInterval = "8hour"
+	KLINE_12HOUR KlineInterval = "12hour"
+	KLINE_1DAY   KlineInterval = "1day"
+	KLINE_3DAY   KlineInterval = "3day"
+	KLINE_1WEEK  KlineInterval = "1week"
+	KLINE_1MONTH KlineInterval = "1month"
+
+	DEFAULT_KLINE_INTERVAL = KLINE_5MIN
+)
+
+var klineDurations = map[KlineInterval]time.Duration{
+	KLINE_1MIN:   time.Minute,
+	KLINE_3MIN:   3 * time.Minute,
+	KLINE_5MIN:   5 * time.Minute,
+	KLINE_15MIN:  15 * time.Minute,
+	KLINE_30MIN:  30 * time.Minute,
+	KLINE_1HOUR:  time.Hour,
+	KLINE_2HOUR:  2 * time.Hour,
+	KLINE_4HOUR:  4 * time.Hour,
+	KLINE_6HOUR:  6 * time.Hour,
+	KLINE_8HOUR:  8 * time.Hour,
+	KLINE_12HOUR: 12 * time.Hour,
+	KLINE_1DAY:   24 * time.Hour,
+	KLINE_3DAY:   3 * 24 * time.Hour,
+	KLINE_1WEEK:  7 * 24 * time.Hour,
+	KLINE_1MONTH: 31 * 24 * time.Hour, // the longest month, a page of months never has more than the limit
+}
+
+// Duration is 0 for an unknown interval
+func (i KlineInterval) Duration() time.Duration {
+	return klineDurations[i]
+}
+
+func (i KlineInterval) Valid() bool {
+	_, ok := klineDurations[i]
+	return ok
+}
+
+// KlineIntervals translates the intervals to the values of an exchange, eg. KLINE_1MIN: "1m"
+type KlineIntervals map[KlineInterval]string
+
+// Get is the value of the interval on the exchange, of DEFAULT_KLINE_INTERVAL if empty.
+// The error of an interval the exchange doesn't have wraps ErrNotSupported.
+func (m KlineIntervals) Get(ex ExchangeName, interval KlineInterval) (string, error) {
+	if interval == "" {
+		interval = DEFAULT_KLINE_INTERVAL
+	}
+	if value, ok := m[interval]; ok {
+		return value, nil
+	} else if !interval.Valid() {
+		return "", fmt.Errorf("%s invalid kline interval: %v", ex, interval)
+	}
+	return "", fmt.Errorf("%s kline interval %v: %w", ex, interval, ErrNotSupported)
+}
+
+// Normalize is the interval of a value of the exchange, eg. "1m" is KLINE_1MIN, for the callers which
+// still pass the values of the exchange. Any other interval is returned as is.
+func (m KlineIntervals) Normalize(interval KlineInterval) KlineInterval {
+	if interval.Valid() {
+		return interval
+	}
+	for i, value := range m {
+		if string(interval) == value {
+			return i
+		}
+	}
+	return interval
+}
+
+// FetchKline sets operation.Kline from the pages of [KlineStartTime, KlineEndTime], each at most limit klines
+// of the interval. fetch gets the start and end of a page in ms, 0 if not set, and returns its klines
+// with OpenTime in ms. The klines are sorted by OpenTime, without duplicates and outside the range.
+// Without KlineStartTime, or with limit 0 for an exchange without a time range, fetch is called once.
+func FetchKline(operation *PublicOperation, limit int, fetch func(start, end int64) ([]*KlineDetail, error)) error {
+	interval := operation.KlineInterval
+	if interval == "" {
+		interval = DEFAULT_KLINE_INTERVAL
+	}
+	start, end := operation.KlineStartTime, operation.KlineEndTime
+
+	pages := [][2]int64{{start, end}}
+	if start != 0 && limit > 0 && interval.Valid() {
+		if end == 0 {
+			end = time.Now().UnixNano() / 1e6
+		}
+		step := int64(interval.Duration()/time.Millisecond) * int64(limit)
+		pages = [][2]int64{}
+		for from := start; from <= end; from += step {
+			to := from + step - 1
+			if to > end {
+				to = end
+			}
+			pages = append(pages, [2]int64{from, to})
+		}
+	}
+
+	seen := make(map[float64]bool)
+	klines := []*KlineDetail{}
+	for _, page := range pages {
+		details, err := fetch(page[0], page[1])
+		if err != nil {
+			operation.Error = err
+			return err
+		}
+		for _, detail := range details {
+			if seen[detail.OpenTime] ||
+				(start != 0 && detail.OpenTime < float64(start)) ||
+				(end != 0 && detail.OpenTime > float64(end)) {
+				continue
+			}
+			seen[detail.OpenTime] = true
+			klines = append(klines, detail)
+		}
+	}
+	sort.SliceStable(klines, func(i, j int) bool {
+		return klines[i].OpenTime < klines[j].OpenTime
+	})
+
+	operation.Kline = klines
+	return nil
+}
@@ -26,7 +26,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://api.kraken.com"
 )
 
@@ -379,15 +379,17 @@ func (e *Kraken) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/0/private/AddOrder"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	params := url.Values{
 		"pair":      {e.GetSymbolByPair(pair)},
 		"type":      {"sell"},
 		"ordertype": {"limit"},
-		"price":     {strconv.FormatFloat(rate, 'f', priceFilter, 64)},
-		"volume":    {strconv.FormatFloat(quantity, 'f', lotSize, 64)},
+		"price":     {pairConstraint.FormatRate(rate)},
+		"volume":    {pairConstraint.FormatQuantity(quantity)},
 	}
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequestPath, params, &PlaceOrder{})
@@ -421,15 +423,17 @@ func (e *Kraken) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	placeOrder := PlaceOrder{}
 	strRequestPath := "/0/private/AddOrder"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	params := url.Values{
 		"pair":      {e.GetSymbolByPair(pair)},
 		"type":      {"buy"},
 		"ordertype": {"limit"},
-		"price":     {strconv.FormatFloat(rate, 'f', priceFilter, 64)},
-		"volume":    {strconv.FormatFloat(quantity, 'f', lotSize, 64)},
+		"price":     {pairConstraint.FormatRate(rate)},
+		"volume":    {pairConstraint.FormatQuantity(quantity)},
 	}
 
 	jsonPlaceReturn := e.ApiKeyPost(strRequestPath, params, &PlaceOrder{})
@@ -137,3 +137,15 @@ type Trade struct {
 	ZKRW float64 `json:"ZKRW,string"`
 	ZUSD float64 `json:"ZUSD,string"`
 } */
+
+// a and b are [price, whole lot volume, lot volume], c is [price, lot volume], the others [today, last 24 hours]
+type Ticker map[string]struct {
+	A []string `json:"a"`
+	B []string `json:"b"`
+	C []string `json:"c"`
+	V []string `json:"v"`
+	P []string `json:"p"`
+	L []string `json:"l"`
+	H []string `json:"h"`
+	O string   `json:"o"`
+}
@@ -24,74 +24,181 @@ func (e *Kraken) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doSpotOrderBook(operation)
 		}
+	case exchange.KLine:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doSpotKline(operation)
+		}
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
-// no tradeID
+// [price, volume, time, buy/sell, market/limit, miscellaneous, trade_id], the pages are followed by "since", in ns.
+// TradeFromID is not supported, "since" is a time.
 func (e *Kraken) doTradeHistory(operation *exchange.PublicOperation) error {
-	// symbol := e.GetSymbolByPair(operation.Pair)
-	// strRequestUrl := fmt.Sprintf("/spot/trades?symbol=%v", symbol)
-	// strUrl := API_URL + strRequestUrl
-
-	// get := &utils.HttpGet{
-	// 	URI: strUrl,
-	// }
-
-	// err := utils.HttpGetRequest(get)
-
-	// if err != nil {
-	// 	log.Printf("%+v", err)
-	// 	operation.Error = err
-	// 	return err
-
-	// } else {
-	// 	// log.Printf("%+v  ERR:%+v", string(get.ResponseBody), err) // ======================
-	// 	if operation.DebugMode {
-	// 		operation.RequestURI = get.URI
-	// 		operation.CallResponce = string(get.ResponseBody)
-	// 	}
-
-	// 	tradeHistory := [][]Trade{} //TradeHistory{}
-	// 	if err := json.Unmarshal(get.ResponseBody, &tradeHistory); err != nil {
-	// 		operation.Error = err
-	// 		return err
-	// 	} else if len(tradeHistory) == 0 {
-	// 		operation.Error = fmt.Errorf("Got Empty Trade History")
-	// 		return fmt.Errorf("Got Empty Trade History")
-	// 		// log.Printf("%+v ", tradeHistory)
-	// 	}
-
-	// 	operation.TradeHistory = []*exchange.TradeDetail{}
-	// 	for _, trade := range tradeHistory {
-	// 		price, err := strconv.ParseFloat(trade[0].Price, 64)
-	// 		if err != nil {
-	// 			log.Printf("%s price parse Err: %v %v", e.GetName(), err, trade[0].Price)
-	// 			operation.Error = err
-	// 			return err
-	// 		}
-	// 		amount, err := strconv.ParseFloat(trade[0].Volume, 64)
-	// 		if err != nil {
-	// 			log.Printf("%s amount parse Err: %v %v", e.GetName(), err, trade[0].Volume)
-	// 			operation.Error = err
-	// 			return err
-	// 		}
-
-	// 		td := &exchange.TradeDetail{
-	// 			ID:        trade.V,
-	// 			Quantity:  amount,
-	// 			TimeStamp: trade.TimeStamp.UnixNano() / 1e6,
-	// 			Rate:      price,
-	// 		}
-	// 		if trade.S == "buy" {
-	// 			td.Direction = exchange.Buy
-	// 		} else if trade.S == "sell" {
-	// 			td.Direction = exchange.Sell
-	// 		}
-
-	// 		operation.TradeHistory = append(operation.TradeHistory, td)
-	// 	}
-	// }
+	if operation.TradeFromID != "" {
+		operation.Error = fmt.Errorf("%s doTradeHistory TradeFromID: %w", e.GetName(), exchange.ErrNotSupported)
+		return operation.Error
+	}
+
+	return exchange.FetchTrades(operation, false, func(cursor string) ([]*exchange.TradeDetail, string, error) {
+		jsonResponse := &JsonResponse{}
+		result := make(map[string]json.RawMessage)
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/0/public/Trades?pair=%s", API_URL, e.GetSymbolByPair(operation.Pair)),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if cursor != "" {
+			get.URI += "&since=" + cursor
+		} else if operation.TradeStartTime != 0 {
+			get.URI += fmt.Sprintf("&since=%d", operation.TradeStartTime*int64(time.Millisecond))
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, "", err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+			return nil, "", fmt.Errorf("%s doTradeHistory Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		} else if len(jsonResponse.Error) != 0 {
+			return nil, "", fmt.Errorf("%s doTradeHistory Failed: %v", e.GetName(), jsonResponse.Error)
+		}
+		if err := json.Unmarshal(jsonResponse.Result, &result); err != nil {
+			return nil, "", fmt.Errorf("%s doTradeHistory Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		}
+
+		next := ""
+		trades := []*exchange.TradeDetail{}
+		for key, data := range result {
+			if key == "last" {
+				if err := json.Unmarshal(data, &next); err != nil {
+					return nil, "", fmt.Errorf("%s doTradeHistory last Unmarshal Err: %v %s", e.GetName(), err, data)
+				}
+				continue
+			}
+			rawTrades := [][]interface{}{}
+			if err := json.Unmarshal(data, &rawTrades); err != nil {
+				return nil, "", fmt.Errorf("%s doTradeHistory Trade Unmarshal Err: %v %s", e.GetName(), err, data)
+			}
+
+			for _, t := range rawTrades {
+				if len(t) < 4 {
+					return nil, "", fmt.Errorf("%s doTradeHistory invalid trade: %v", e.GetName(), t)
+				}
+				price, err := strconv.ParseFloat(fmt.Sprint(t[0]), 64)
+				if err != nil {
+					return nil, "", fmt.Errorf("%s price parse Err: %v %v", e.GetName(), err, t[0])
+				}
+				amount, err := strconv.ParseFloat(fmt.Sprint(t[1]), 64)
+				if err != nil {
+					return nil, "", fmt.Errorf("%s amount parse Err: %v %v", e.GetName(), err, t[1])
+				}
+				timestamp, _ := t[2].(float64)
+
+				td := &exchange.TradeDetail{
+					Quantity:  amount,
+					TimeStamp: int64(timestamp * 1000),
+					Rate:      price,
+				}
+				if len(t) > 6 {
+					if id, ok := t[6].(float64); ok {
+						td.ID = fmt.Sprintf("%.0f", id)
+					}
+				}
+				if t[3] == "b" {
+					td.Direction = exchange.Buy
+				} else if t[3] == "s" {
+					td.Direction = exchange.Sell
+				}
+				trades = append(trades, td)
+			}
+		}
+
+		// a page has at most 1000 trades, a shorter one is the last
+		if len(trades) < 1000 {
+			next = ""
+		}
+		return trades, next, nil
+	})
+}
+
+func (e *Kraken) doTicker(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	ticker := Ticker{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/0/public/Ticker", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if len(jsonResponse.Error) != 0 {
+		operation.Error = fmt.Errorf("%s doTicker Failed: %v", e.GetName(), jsonResponse.Error)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Result, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		return operation.Error
+	}
+
+	timestamp := time.Now().UnixNano() / 1e6
+	operation.Ticker = []*exchange.TickerDetail{}
+	for key, t := range ticker {
+		p := e.GetPairBySymbol(key)
+		if p == nil {
+			continue
+		} else if len(t.A) < 3 || len(t.B) < 3 || len(t.C) < 1 || len(t.V) < 2 || len(t.P) < 2 || len(t.L) < 2 || len(t.H) < 2 {
+			operation.Error = fmt.Errorf("%s doTicker invalid ticker: %v %+v", e.GetName(), key, t)
+			return operation.Error
+		}
+
+		values, err := exchange.ParseFloats(t.C[0], t.B[0], t.B[2], t.A[0], t.A[2], t.O, t.H[1], t.L[1], t.V[1], t.P[1])
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), key, err)
+			return operation.Error
+		}
+
+		// o is the opening price of today, the quote volume is the volume at the vwap
+		detail := &exchange.TickerDetail{
+			Pair:            p,
+			Last:            values[0],
+			BestBid:         values[1],
+			BestBidQuantity: values[2],
+			BestAsk:         values[3],
+			BestAskQuantity: values[4],
+			Open:            values[5],
+			High:            values[6],
+			Low:             values[7],
+			Volume:          values[8],
+			QuoteVolume:     values[8] * values[9],
+			Timestamp:       timestamp,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
 
 	return nil
 }
@@ -166,3 +273,118 @@ func (e *Kraken) doSpotOrderBook(op *exchange.PublicOperation) error {
 	op.Maker = maker
 	return nil
 }
+
+// interval in minutes
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:  "1",
+	exchange.KLINE_5MIN:  "5",
+	exchange.KLINE_15MIN: "15",
+	exchange.KLINE_30MIN: "30",
+	exchange.KLINE_1HOUR: "60",
+	exchange.KLINE_4HOUR: "240",
+	exchange.KLINE_1DAY:  "1440",
+	exchange.KLINE_1WEEK: "10080",
+}
+
+// kraken only has since, and returns at most the latest 720 klines: one request, a KlineStartTime
+// before them fails with ErrKlineRange
+func (e *Kraken) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	step := operation.KlineInterval
+	if step == "" {
+		step = exchange.DEFAULT_KLINE_INTERVAL
+	}
+
+	return exchange.FetchKline(operation, 0, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		jsonResponse := &JsonResponse{}
+		result := make(map[string]json.RawMessage)
+
+		get := &utils.HttpGet{
+			URI:       fmt.Sprintf("%s/0/public/OHLC?pair=%s&interval=%s", API_URL, e.GetSymbolByPair(operation.Pair), interval),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
+		}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&since=%v", start/1000-1)
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		} else if len(jsonResponse.Error) != 0 {
+			return nil, fmt.Errorf("%s doSpotKline Failed: %v", e.GetName(), jsonResponse.Error)
+		}
+		if err := json.Unmarshal(jsonResponse.Result, &result); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Result)
+		}
+
+		// [time, open, high, low, close, vwap, volume, count], result has the pair and "last"
+		details := []*exchange.KlineDetail{}
+		for key, data := range result {
+			if key == "last" {
+				continue
+			}
+			rawKline := [][]interface{}{}
+			if err := json.Unmarshal(data, &rawKline); err != nil {
+				return nil, fmt.Errorf("%s doSpotKline Kline Unmarshal Err: %v %s", e.GetName(), err, data)
+			}
+
+			for _, k := range rawKline {
+				if len(k) < 8 {
+					return nil, fmt.Errorf("%s doSpotKline invalid kline: %v", e.GetName(), k)
+				}
+				openTime, ok := k[0].(float64)
+				if !ok {
+					return nil, fmt.Errorf("%s doSpotKline invalid time: %v", e.GetName(), k[0])
+				}
+				values := make([]float64, 6)
+				for j := range values {
+					value, err := strconv.ParseFloat(fmt.Sprint(k[j+1]), 64)
+					if err != nil {
+						return nil, fmt.Errorf("%s doSpotKline parse Err: %v %v", e.GetName(), err, k[j+1])
+					}
+					values[j] = value
+				}
+				count, _ := k[7].(float64)
+
+				details = append(details, &exchange.KlineDetail{
+					Exchange:         e.GetName(),
+					Pair:             operation.Pair.Name,
+					OpenTime:         openTime * 1000,
+					Open:             values[0],
+					High:             values[1],
+					Low:              values[2],
+					Close:            values[3],
+					Volume:           values[5],
+					QuoteAssetVolume: values[4] * values[5],
+					TradesCount:      count,
+				})
+			}
+		}
+
+		// the kline of start is the first one returned if kraken still has it
+		if start != 0 && len(details) > 0 {
+			first := details[0].OpenTime
+			for _, detail := range details {
+				if detail.OpenTime < first {
+					first = detail.OpenTime
+				}
+			}
+			if first >= float64(start+int64(step.Duration()/time.Millisecond)) {
+				return nil, fmt.Errorf("%w: %s has only its latest %d klines", exchange.ErrKlineRange, e.GetName(), len(details))
+			}
+		}
+		return details, nil
+	})
+}
@@ -21,7 +21,7 @@ import (
 )
 
 /*The Base Endpoint URL*/
-const (
+var (
 	API_URL         = "https://openapi-v2.kucoin.com"
 	SANDBOX_API_URL = "https://openapi-sandbox.kucoin.com"
 )
@@ -344,16 +344,18 @@ func (e *Kucoin) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	placeOrder := OrderDetail{}
 	strRequest := "/api/v1/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["clientOid"] = fmt.Sprintf("%v", time.Now().UnixNano()) //Unique order id selected by you to identify your order
 	mapParams["side"] = "sell"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["type"] = "limit"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["size"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams, false)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -387,16 +389,18 @@ func (e *Kucoin) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	placeOrder := OrderDetail{}
 	strRequest := "/api/v1/orders"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["clientOid"] = fmt.Sprintf("%v", time.Now().UnixNano()) //Unique order id selected by you to identify your order
 	mapParams["side"] = "buy"
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
 	mapParams["type"] = "limit"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
+	mapParams["size"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams, false)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
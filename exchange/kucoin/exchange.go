@@ -261,6 +261,43 @@ func (e *Kucoin) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory:   {exchange.SpotWallet},
+		exchange.Orderbook:      {exchange.SpotWallet},
+		exchange.GetTickerPrice: {exchange.SpotWallet},
+		exchange.KLine:          {exchange.SpotWallet},
+		exchange.Ticker:         {exchange.SpotWallet},
+		exchange.SystemStatus:   {exchange.SpotWallet},
+		exchange.WalletStatus:   {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Transfer:          {exchange.SpotWallet},
+		exchange.BalanceList:       {exchange.AssetWallet, exchange.SpotWallet, exchange.MarginWallet},
+		exchange.Balance:           {exchange.SpotWallet},
+		exchange.Withdraw:          {exchange.SpotWallet},
+		exchange.GetOpenOrder:      {exchange.SpotWallet},
+		exchange.GetOrderHistory:   {exchange.SpotWallet},
+		exchange.PlaceOrder:        {exchange.MarginWallet},
+		exchange.MarginOperation:   {exchange.MarginWallet},
+		exchange.SubBalanceList:    {exchange.SpotWallet},
+		exchange.GetSubAccountList: {exchange.SpotWallet},
+		exchange.SubAllBalanceList: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet:   {exchange.TRADE_LIMIT},
+		exchange.MarginWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet:   {exchange.GTC},
+		exchange.MarginWallet: {exchange.GTC},
+	},
+}
+
+func (e *Kucoin) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Kucoin) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
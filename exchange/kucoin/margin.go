@@ -0,0 +1,505 @@
+package kucoin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Kucoin) doMarginOperation(operation *exchange.AccountOperation) error {
+	if operation.Margin == nil {
+		return fmt.Errorf("%s MarginOperation empty Margin: %+v", e.GetName(), operation)
+	} else if operation.MarginMode == exchange.IsolatedMargin && operation.Margin.Pair == nil {
+		return fmt.Errorf("%s MarginOperation isolated margin without pair", e.GetName())
+	}
+
+	switch operation.Margin.Action {
+	case exchange.LOAN_REQUEST:
+		return e.doMarginBorrow(operation)
+	case exchange.LOAN_REPAY:
+		return e.doMarginRepay(operation)
+	case exchange.TRANSFER_IN, exchange.TRANSFER_OUT:
+		return e.doMarginTransfer(operation)
+	case exchange.BALANCE:
+		if operation.MarginMode == exchange.IsolatedMargin {
+			return e.doIsolatedMarginBalance(operation)
+		}
+		return e.doCrossMarginBalance(operation)
+	case exchange.INTEREST_HISTORY:
+		if operation.MarginMode == exchange.IsolatedMargin {
+			return e.doIsolatedOutstanding(operation)
+		}
+		return e.doMarginOutstanding(operation)
+	}
+
+	return fmt.Errorf("%s MarginOperation action invalid: %v", e.GetName(), operation.Margin.Action)
+}
+
+// the borrow order id is returned in Margin.MarginOrder
+func (e *Kucoin) doMarginBorrow(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	borrow := OrderDetail{}
+	strRequest := "/api/v1/margin/borrow"
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["size"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if operation.MarginMode == exchange.IsolatedMargin {
+		strRequest = "/api/v1/isolated/borrow"
+		mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+		mapParams["borrowStrategy"] = "FOK"
+	} else {
+		mapParams["type"] = "FOK"
+	}
+
+	jsonBorrowReturn := e.ApiKeyRequest("POST", strRequest, mapParams, operation.Sandbox)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBorrowReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBorrowReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginBorrow Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBorrowReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doMarginBorrow Failed: %v", e.GetName(), jsonBorrowReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &borrow); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginBorrow Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	margin.MarginOrder = &exchange.MarginOrder{
+		ID:         borrow.OrderID,
+		Currency:   margin.Currency,
+		Pair:       margin.Pair,
+		LoanAmount: margin.Quantity,
+	}
+
+	return nil
+}
+
+// repay the loans which expire first, kucoin returns no repayment id
+func (e *Kucoin) doMarginRepay(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	strRequest := "/api/v1/margin/repay/all"
+
+	mapParams := make(map[string]string)
+	mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["size"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if operation.MarginMode == exchange.IsolatedMargin {
+		strRequest = "/api/v1/isolated/repay/all"
+		mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+		mapParams["seqStrategy"] = "RECENTLY_EXPIRE_FIRST"
+	} else {
+		mapParams["sequence"] = "RECENTLY_EXPIRE_FIRST"
+	}
+
+	jsonRepayReturn := e.ApiKeyRequest("POST", strRequest, mapParams, operation.Sandbox)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonRepayReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonRepayReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginRepay Json Unmarshal Err: %v, %s", e.GetName(), err, jsonRepayReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doMarginRepay Failed: %v", e.GetName(), jsonRepayReturn)
+		return operation.Error
+	}
+
+	margin.MarginOrder = &exchange.MarginOrder{
+		Currency:  margin.Currency,
+		Pair:      margin.Pair,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+
+	return nil
+}
+
+// TRANSFER_IN: trade -> margin/isolated, TRANSFER_OUT: margin/isolated -> trade
+func (e *Kucoin) doMarginTransfer(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	transfer := OrderDetail{}
+	strRequest := "/api/v2/accounts/inner-transfer"
+
+	marginAccount := "margin"
+	if operation.MarginMode == exchange.IsolatedMargin {
+		marginAccount = "isolated"
+	}
+
+	mapParams := make(map[string]string)
+	mapParams["clientOid"] = fmt.Sprintf("%v", time.Now().UnixNano())
+	mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["amount"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if margin.Action == exchange.TRANSFER_IN {
+		mapParams["from"] = "trade"
+		mapParams["to"] = marginAccount
+		if operation.MarginMode == exchange.IsolatedMargin {
+			mapParams["toTag"] = e.GetSymbolByPair(margin.Pair)
+		}
+	} else {
+		mapParams["from"] = marginAccount
+		mapParams["to"] = "trade"
+		if operation.MarginMode == exchange.IsolatedMargin {
+			mapParams["fromTag"] = e.GetSymbolByPair(margin.Pair)
+		}
+	}
+
+	jsonTransferReturn := e.ApiKeyRequest("POST", strRequest, mapParams, operation.Sandbox)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonTransferReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonTransferReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Failed: %v", e.GetName(), jsonTransferReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &transfer); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	margin.TransferID = transfer.OrderID
+
+	return nil
+}
+
+func (e *Kucoin) doCrossMarginBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	account := MarginAccount{}
+	strRequest := "/api/v1/margin/account"
+
+	jsonBalanceReturn := e.ApiKeyRequest("GET", strRequest, nil, operation.Sandbox)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doCrossMarginBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doCrossMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &account); err != nil {
+		operation.Error = fmt.Errorf("%s doCrossMarginBalance Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	balance := &exchange.MarginBalance{
+		MarginMode: exchange.CrossMargin,
+		RiskRate:   riskRate(account.DebtRatio),
+	}
+	for _, item := range account.Accounts {
+		c := e.GetCoinBySymbol(item.Currency)
+		if c == nil {
+			continue
+		}
+
+		asset := &exchange.MarginAsset{
+			Coin: c,
+		}
+		asset.Free, _ = strconv.ParseFloat(item.AvailableBalance, 64)
+		asset.Locked, _ = strconv.ParseFloat(item.HoldBalance, 64)
+		asset.Borrowed, _ = strconv.ParseFloat(item.Liability, 64)
+		total, _ := strconv.ParseFloat(item.TotalBalance, 64)
+		asset.NetAsset = total - asset.Borrowed
+		balance.Assets = append(balance.Assets, asset)
+	}
+
+	operation.Margin.MarginBalance = balance
+
+	return nil
+}
+
+func (e *Kucoin) doIsolatedMarginBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	account := IsolatedAccount{}
+	strRequest := "/api/v1/isolated/account/" + e.GetSymbolByPair(margin.Pair)
+
+	jsonBalanceReturn := e.ApiKeyRequest("GET", strRequest, nil, operation.Sandbox)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &account); err != nil {
+		operation.Error = fmt.Errorf("%s doIsolatedMarginBalance Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	balance := &exchange.MarginBalance{
+		Pair:       margin.Pair,
+		MarginMode: exchange.IsolatedMargin,
+		RiskRate:   riskRate(account.DebtRatio),
+	}
+	for _, item := range []IsolatedAsset{account.BaseAsset, account.QuoteAsset} {
+		asset := &exchange.MarginAsset{
+			Coin: e.GetCoinBySymbol(item.Currency),
+		}
+		asset.Free, _ = strconv.ParseFloat(item.AvailableBalance, 64)
+		asset.Locked, _ = strconv.ParseFloat(item.HoldBalance, 64)
+		asset.Borrowed, _ = strconv.ParseFloat(item.Liability, 64)
+		asset.Interest, _ = strconv.ParseFloat(item.Interest, 64)
+		total, _ := strconv.ParseFloat(item.TotalBalance, 64)
+		asset.NetAsset = total - asset.Borrowed - asset.Interest
+		balance.Assets = append(balance.Assets, asset)
+	}
+
+	margin.MarginBalance = balance
+
+	return nil
+}
+
+// kucoin reports liability / asset, RiskRate is the other way round
+func riskRate(debtRatio string) float64 {
+	ratio, _ := strconv.ParseFloat(debtRatio, 64)
+	if ratio == 0 {
+		return 0
+	}
+	return 1 / ratio
+}
+
+// outstanding loans with the accrued interest, filter by Margin.Currency if set
+func (e *Kucoin) doMarginOutstanding(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	strRequest := "/api/v1/margin/borrow/outstanding"
+	margin.History = []*exchange.MarginOrder{}
+
+	mapParams := make(map[string]string)
+	mapParams["pageSize"] = "50"
+	if margin.Currency != nil {
+		mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	}
+
+	for page := 1; ; page++ {
+		jsonResponse := &JsonResponse{}
+		outstanding := MarginOutstanding{}
+		mapParams["currentPage"] = fmt.Sprintf("%v", page)
+
+		jsonOutstandingReturn := e.ApiKeyRequest("GET", strRequest, mapParams, operation.Sandbox)
+		if operation.DebugMode {
+			operation.RequestURI = strRequest
+			operation.CallResponce = jsonOutstandingReturn
+		}
+
+		if err := json.Unmarshal([]byte(jsonOutstandingReturn), &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doMarginOutstanding Json Unmarshal Err: %v, %s", e.GetName(), err, jsonOutstandingReturn)
+			return operation.Error
+		} else if jsonResponse.Code != "200000" {
+			operation.Error = fmt.Errorf("%s doMarginOutstanding Failed: %v", e.GetName(), jsonOutstandingReturn)
+			return operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &outstanding); err != nil {
+			operation.Error = fmt.Errorf("%s doMarginOutstanding Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+			return operation.Error
+		}
+
+		for _, item := range outstanding.Items {
+			loan := &exchange.MarginOrder{
+				ID:        item.TradeID,
+				Currency:  e.GetCoinBySymbol(item.Currency),
+				State:     "accrual",
+				Timestamp: item.CreatedAt,
+			}
+			loan.LoanAmount, _ = strconv.ParseFloat(item.Principal, 64)
+			repaid, _ := strconv.ParseFloat(item.RepaidSize, 64)
+			loan.LoanBalance = loan.LoanAmount - repaid
+			loan.InterestRate, _ = strconv.ParseFloat(item.DailyIntRate, 64)
+			loan.InterestBalance, _ = strconv.ParseFloat(item.AccruedInterest, 64)
+			loan.InterestAmount = loan.InterestBalance
+			margin.History = append(margin.History, loan)
+		}
+
+		if page >= outstanding.TotalPage {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (e *Kucoin) doIsolatedOutstanding(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	margin := operation.Margin
+	strRequest := "/api/v1/isolated/borrow/outstanding"
+	margin.History = []*exchange.MarginOrder{}
+
+	mapParams := make(map[string]string)
+	mapParams["pageSize"] = "50"
+	mapParams["symbol"] = e.GetSymbolByPair(margin.Pair)
+	if margin.Currency != nil {
+		mapParams["currency"] = e.GetSymbolByCoin(margin.Currency)
+	}
+
+	for page := 1; ; page++ {
+		jsonResponse := &JsonResponse{}
+		outstanding := IsolatedOutstanding{}
+		mapParams["currentPage"] = fmt.Sprintf("%v", page)
+
+		jsonOutstandingReturn := e.ApiKeyRequest("GET", strRequest, mapParams, operation.Sandbox)
+		if operation.DebugMode {
+			operation.RequestURI = strRequest
+			operation.CallResponce = jsonOutstandingReturn
+		}
+
+		if err := json.Unmarshal([]byte(jsonOutstandingReturn), &jsonResponse); err != nil {
+			operation.Error = fmt.Errorf("%s doIsolatedOutstanding Json Unmarshal Err: %v, %s", e.GetName(), err, jsonOutstandingReturn)
+			return operation.Error
+		} else if jsonResponse.Code != "200000" {
+			operation.Error = fmt.Errorf("%s doIsolatedOutstanding Failed: %v", e.GetName(), jsonOutstandingReturn)
+			return operation.Error
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &outstanding); err != nil {
+			operation.Error = fmt.Errorf("%s doIsolatedOutstanding Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+			return operation.Error
+		}
+
+		for _, item := range outstanding.Items {
+			loan := &exchange.MarginOrder{
+				ID:        item.LoanID,
+				Currency:  e.GetCoinBySymbol(item.Currency),
+				Pair:      e.GetPairBySymbol(item.Symbol),
+				State:     "accrual",
+				Timestamp: item.CreatedAt,
+			}
+			loan.LoanAmount, _ = strconv.ParseFloat(item.PrincipalTotal, 64)
+			repaid, _ := strconv.ParseFloat(item.RepaidSize, 64)
+			loan.LoanBalance = loan.LoanAmount - repaid
+			loan.InterestRate, _ = strconv.ParseFloat(item.DailyInterestRate, 64)
+			loan.InterestBalance, _ = strconv.ParseFloat(item.InterestBalance, 64)
+			loan.InterestAmount = loan.InterestBalance
+			margin.History = append(margin.History, loan)
+		}
+
+		if page >= outstanding.TotalPage {
+			break
+		}
+	}
+
+	return nil
+}
+
+// type: TRADE_LIMIT, TRADE_MARKET. Market buy Quantity is the amount of base coin
+func (e *Kucoin) doMarginPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+	}
+
+	if operation.OrderDirection == "" {
+		return fmt.Errorf("%s MarginPlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.TradeType == "" {
+		return fmt.Errorf("%s MarginPlaceOrder empty TradeType: %+v", e.GetName(), operation)
+	}
+
+	jsonResponse := &JsonResponse{}
+	placeOrder := OrderDetail{}
+	strRequest := "/api/v1/margin/order"
+
+	mapParams := make(map[string]string)
+	mapParams["clientOid"] = fmt.Sprintf("%v", time.Now().UnixNano())
+	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
+	if operation.OrderDirection == exchange.Buy {
+		mapParams["side"] = "buy"
+	} else {
+		mapParams["side"] = "sell"
+	}
+	pairConstraint := e.GetPairConstraint(operation.Pair)
+	if operation.TradeType == exchange.TRADE_MARKET {
+		mapParams["type"] = "market"
+		if operation.OrderDirection == exchange.Buy {
+			// funds are in the quote coin, LotSize doesn't apply
+			mapParams["funds"] = exchange.FormatDecimal(operation.Quantity)
+		} else {
+			mapParams["size"] = pairConstraint.FormatQuantity(operation.Quantity)
+		}
+	} else {
+		mapParams["type"] = "limit"
+		mapParams["price"] = pairConstraint.FormatRate(operation.Rate)
+		mapParams["size"] = pairConstraint.FormatQuantity(operation.Quantity)
+	}
+	if operation.MarginMode == exchange.IsolatedMargin {
+		mapParams["marginModel"] = "isolated"
+	} else {
+		mapParams["marginModel"] = "cross"
+	}
+
+	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams, operation.Sandbox)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s MarginPlaceOrder Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Order = &exchange.Order{
+		Pair:         operation.Pair,
+		OrderID:      placeOrder.OrderID,
+		Rate:         operation.Rate,
+		Quantity:     operation.Quantity,
+		Direction:    operation.OrderDirection,
+		Status:       exchange.New,
+		JsonResponse: jsonPlaceReturn,
+	}
+
+	return nil
+}
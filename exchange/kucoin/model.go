@@ -53,7 +53,9 @@ type TickerPrice struct {
 		Symbol       string `json:"symbol"`
 		SymbolName   string `json:"symbolName"`
 		Buy          string `json:"buy"`
+		BestBidSize  string `json:"bestBidSize"`
 		Sell         string `json:"sell"`
+		BestAskSize  string `json:"bestAskSize"`
 		ChangeRate   string `json:"changeRate"`
 		ChangePrice  string `json:"changePrice"`
 		High         string `json:"high"`
@@ -262,3 +264,93 @@ type OpenOrders struct {
 		TradeType     string      `json:"tradeType"`
 	} `json:"items"`
 }
+
+type MarginAccount struct {
+	DebtRatio string `json:"debtRatio"`
+	Accounts  []struct {
+		Currency         string `json:"currency"`
+		TotalBalance     string `json:"totalBalance"`
+		AvailableBalance string `json:"availableBalance"`
+		HoldBalance      string `json:"holdBalance"`
+		Liability        string `json:"liability"`
+		MaxBorrowSize    string `json:"maxBorrowSize"`
+	} `json:"accounts"`
+}
+
+type IsolatedAsset struct {
+	Currency         string `json:"currency"`
+	TotalBalance     string `json:"totalBalance"`
+	HoldBalance      string `json:"holdBalance"`
+	AvailableBalance string `json:"availableBalance"`
+	Liability        string `json:"liability"`
+	Interest         string `json:"interest"`
+	BorrowableAmount string `json:"borrowableAmount"`
+}
+
+type IsolatedAccount struct {
+	Symbol     string        `json:"symbol"`
+	Status     string        `json:"status"`
+	DebtRatio  string        `json:"debtRatio"`
+	BaseAsset  IsolatedAsset `json:"baseAsset"`
+	QuoteAsset IsolatedAsset `json:"quoteAsset"`
+}
+
+type MarginOutstanding struct {
+	CurrentPage int `json:"currentPage"`
+	PageSize    int `json:"pageSize"`
+	TotalNum    int `json:"totalNum"`
+	TotalPage   int `json:"totalPage"`
+	Items       []struct {
+		TradeID         string `json:"tradeId"`
+		Currency        string `json:"currency"`
+		Liability       string `json:"liability"`
+		Principal       string `json:"principal"`
+		AccruedInterest string `json:"accruedInterest"`
+		CreatedAt       int64  `json:"createdAt"`
+		MaturityTime    int64  `json:"maturityTime"`
+		Term            int    `json:"term"`
+		RepaidSize      string `json:"repaidSize"`
+		DailyIntRate    string `json:"dailyIntRate"`
+	} `json:"items"`
+}
+
+type IsolatedOutstanding struct {
+	CurrentPage int `json:"currentPage"`
+	PageSize    int `json:"pageSize"`
+	TotalNum    int `json:"totalNum"`
+	TotalPage   int `json:"totalPage"`
+	Items       []struct {
+		LoanID            string `json:"loanId"`
+		Symbol            string `json:"symbol"`
+		Currency          string `json:"currency"`
+		LiabilityBalance  string `json:"liabilityBalance"`
+		PrincipalTotal    string `json:"principalTotal"`
+		InterestBalance   string `json:"interestBalance"`
+		CreatedAt         int64  `json:"createdAt"`
+		MaturityTime      int64  `json:"maturityTime"`
+		Period            int    `json:"period"`
+		RepaidSize        string `json:"repaidSize"`
+		DailyInterestRate string `json:"dailyInterestRate"`
+	} `json:"items"`
+}
+
+type SystemStatus struct {
+	Status string `json:"status"`
+	Msg    string `json:"msg"`
+}
+
+type Announcements struct {
+	TotalNum int `json:"totalNum"`
+	Items    []struct {
+		AnnID    int64    `json:"annId"`
+		AnnTitle string   `json:"annTitle"`
+		AnnType  []string `json:"annType"`
+		AnnDesc  string   `json:"annDesc"`
+		CTime    int64    `json:"cTime"`
+		Language string   `json:"language"`
+		AnnURL   string   `json:"annUrl"`
+	} `json:"items"`
+	CurrentPage int `json:"currentPage"`
+	PageSize    int `json:"pageSize"`
+	TotalPage   int `json:"totalPage"`
+}
@@ -32,6 +32,14 @@ func (e *Kucoin) DoAccountOperation(operation *exchange.AccountOperation) error
 		if operation.Wallet == exchange.SpotWallet {
 			return e.doGetOrderHistory(operation)
 		}
+	case exchange.PlaceOrder:
+		if operation.Wallet == exchange.MarginWallet {
+			return e.doMarginPlaceOrder(operation)
+		}
+	case exchange.MarginOperation:
+		if operation.Wallet == exchange.MarginWallet {
+			return e.doMarginOperation(operation)
+		}
 
 	case exchange.SubBalanceList:
 		if operation.Wallet == exchange.SpotWallet {
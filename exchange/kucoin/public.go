@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/bitontop/gored/exchange"
@@ -30,144 +31,129 @@ func (e *Kucoin) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doSpotKline(operation)
 		}
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
+	case exchange.SystemStatus:
+		return e.doSystemStatus(operation)
+	case exchange.WalletStatus:
+		return e.doWalletStatus(operation)
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
-// interval options: 1min, 3min, 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 8hour, 12hour, 1day, 1week
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1min",
+	exchange.KLINE_3MIN:   "3min",
+	exchange.KLINE_5MIN:   "5min",
+	exchange.KLINE_15MIN:  "15min",
+	exchange.KLINE_30MIN:  "30min",
+	exchange.KLINE_1HOUR:  "1hour",
+	exchange.KLINE_2HOUR:  "2hour",
+	exchange.KLINE_4HOUR:  "4hour",
+	exchange.KLINE_6HOUR:  "6hour",
+	exchange.KLINE_8HOUR:  "8hour",
+	exchange.KLINE_12HOUR: "12hour",
+	exchange.KLINE_1DAY:   "1day",
+	exchange.KLINE_1WEEK:  "1week",
+}
+
 func (e *Kucoin) doSpotKline(operation *exchange.PublicOperation) error {
-	interval := "5min"
-	if operation.KlineInterval != "" {
-		switch operation.KlineInterval {
-		case "1min":
-			interval = "1min"
-		case "3min":
-			interval = "3min"
-		case "5min":
-			interval = "5min"
-		case "15min":
-			interval = "15min"
-		case "30min":
-			interval = "30min"
-		case "1hour":
-			interval = "1hour"
-		case "2hour":
-			interval = "2hour"
-		case "4hour":
-			interval = "4hour"
-		case "6hour":
-			interval = "6hour"
-		case "8hour":
-			interval = "8hour"
-		case "12hour":
-			interval = "12hour"
-		case "1day":
-			interval = "1day"
-		case "1week":
-			interval = "1week"
-		}
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
 	}
 
 	baseURL := API_URL
 	if e.isSandBox() {
 		baseURL = SANDBOX_API_URL
 	}
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("%s/api/v1/market/candles?symbol=%v&type=%v", baseURL,
-			e.GetSymbolByPair(operation.Pair), // ETH-BTC
-			interval,
-		),
-		Proxy:     operation.Proxy,
-		DebugMode: operation.DebugMode,
-	}
-	if operation.KlineStartTime != 0 {
-		startTime := operation.KlineStartTime
-		for startTime > 9999999999 {
-			startTime = startTime / 10
+	return exchange.FetchKline(operation, 1500, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/api/v1/market/candles?symbol=%v&type=%v", baseURL,
+				e.GetSymbolByPair(operation.Pair), // ETH-BTC
+				interval,
+			),
+			Proxy:     operation.Proxy,
+			DebugMode: operation.DebugMode,
 		}
-		get.URI += fmt.Sprintf("&startAt=%v", startTime)
-	}
-	if operation.KlineEndTime != 0 {
-		endTime := operation.KlineEndTime
-		for endTime > 9999999999 {
-			endTime = endTime / 10
+		if start != 0 {
+			get.URI += fmt.Sprintf("&startAt=%v", start/1000)
 		}
-		get.URI += fmt.Sprintf("&endAt=%v", endTime)
-	}
-
-	if err := utils.HttpGetRequest(get); err != nil {
-		operation.Error = err
-		return operation.Error
-	}
-	if operation.DebugMode {
-		operation.RequestURI = get.URI
-		operation.CallResponce = string(get.ResponseBody)
-	}
-
-	rawKline := KLine{}
-
-	jsonKline := get.ResponseBody
-	if err := json.Unmarshal([]byte(jsonKline), &rawKline); err != nil {
-		return fmt.Errorf("%s Get doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, jsonKline)
-	} else if rawKline.Code != "200000" {
-		return fmt.Errorf("%s Get doSpotKline Failed: %v", e.GetName(), jsonKline)
-	}
-
-	operation.Kline = []*exchange.KlineDetail{}
-	for _, k := range rawKline.Data {
-		openTime, err := strconv.ParseFloat(k[0], 64)
-		if err != nil {
-			log.Printf("%s openTime parse Err: %v %v", e.GetName(), err, k[0])
-			operation.Error = err
-			return err
-		}
-		open, err := strconv.ParseFloat(k[1], 64)
-		if err != nil {
-			log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
-			operation.Error = err
-			return err
-		}
-		close, err := strconv.ParseFloat(k[2], 64)
-		if err != nil {
-			log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[2])
-			operation.Error = err
-			return err
+		if end != 0 {
+			get.URI += fmt.Sprintf("&endAt=%v", end/1000)
 		}
-		high, err := strconv.ParseFloat(k[3], 64)
-		if err != nil {
-			log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[3])
-			operation.Error = err
-			return err
+
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, err
 		}
-		low, err := strconv.ParseFloat(k[4], 64)
-		if err != nil {
-			log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[4])
-			operation.Error = err
-			return err
-		}
-		volume, err := strconv.ParseFloat(k[6], 64) // k[5] Transaction amount, k[6] Transaction volume.
-		if err != nil {
-			log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[6])
-			operation.Error = err
-			return err
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
 		}
 
-		detail := &exchange.KlineDetail{
-			Exchange: e.GetName(),
-			Pair:     operation.Pair.Name,
-			OpenTime: openTime,
-			Open:     open,
-			High:     high,
-			Low:      low,
-			Close:    close,
-			Volume:   volume,
+		rawKline := KLine{}
+
+		jsonKline := get.ResponseBody
+		if err := json.Unmarshal([]byte(jsonKline), &rawKline); err != nil {
+			return nil, fmt.Errorf("%s Get doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, jsonKline)
+		} else if rawKline.Code != "200000" {
+			return nil, fmt.Errorf("%s Get doSpotKline Failed: %v", e.GetName(), jsonKline)
 		}
 
-		operation.Kline = append(operation.Kline, detail)
-	}
+		details := []*exchange.KlineDetail{}
+		for _, k := range rawKline.Data {
+			openTime, err := strconv.ParseFloat(k[0], 64)
+			if err != nil {
+				log.Printf("%s openTime parse Err: %v %v", e.GetName(), err, k[0])
+				return nil, err
+			}
+			open, err := strconv.ParseFloat(k[1], 64)
+			if err != nil {
+				log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
+				return nil, err
+			}
+			close, err := strconv.ParseFloat(k[2], 64)
+			if err != nil {
+				log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[2])
+				return nil, err
+			}
+			high, err := strconv.ParseFloat(k[3], 64)
+			if err != nil {
+				log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[3])
+				return nil, err
+			}
+			low, err := strconv.ParseFloat(k[4], 64)
+			if err != nil {
+				log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[4])
+				return nil, err
+			}
+			volume, err := strconv.ParseFloat(k[6], 64) // k[5] Transaction amount, k[6] Transaction volume.
+			if err != nil {
+				log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[6])
+				return nil, err
+			}
 
-	return nil
+			detail := &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: openTime * 1000,
+				Open:     open,
+				High:     high,
+				Low:      low,
+				Close:    close,
+				Volume:   volume,
+			}
+
+			details = append(details, detail)
+		}
+
+		return details, nil
+	})
 }
 
 func (e *Kucoin) doTickerPrice(operation *exchange.PublicOperation) error {
@@ -233,6 +219,79 @@ func (e *Kucoin) doTickerPrice(operation *exchange.PublicOperation) error {
 	return nil
 }
 
+// kucoin has no pages, with a range or TradeFromID the latest 100 trades are filtered,
+// a range older than them fails with exchange.ErrTradeRange
+func (e *Kucoin) doTicker(operation *exchange.PublicOperation) error {
+	jsonResponse := JsonResponse{}
+	ticker := TickerPrice{}
+
+	baseURL := API_URL
+	if e.isSandBox() {
+		baseURL = SANDBOX_API_URL
+	}
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/v1/market/allTickers", baseURL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doTicker Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range ticker.Ticker {
+		p := e.GetPairBySymbol(t.Symbol)
+		if p == nil {
+			continue
+		}
+
+		// vol is in the base currency of kucoin, the Target coin, changeRate 0.05 is +5%
+		values, err := exchange.ParseFloats(t.Last, t.Buy, t.BestBidSize, t.Sell, t.BestAskSize,
+			t.High, t.Low, t.Vol, t.VolValue, t.ChangePrice, t.ChangeRate)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), t.Symbol, err)
+			return operation.Error
+		}
+
+		detail := &exchange.TickerDetail{
+			Pair:            p,
+			Last:            values[0],
+			BestBid:         values[1],
+			BestBidQuantity: values[2],
+			BestAsk:         values[3],
+			BestAskQuantity: values[4],
+			High:            values[5],
+			Low:             values[6],
+			Volume:          values[7],
+			QuoteVolume:     values[8],
+			Change:          values[9],
+			ChangePercent:   values[10] * 100,
+			Timestamp:       ticker.Time,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	return nil
+}
+
 func (e *Kucoin) doTradeHistory(operation *exchange.PublicOperation) error {
 	symbol := e.GetSymbolByPair(operation.Pair)
 
@@ -280,6 +339,9 @@ func (e *Kucoin) doTradeHistory(operation *exchange.PublicOperation) error {
 		}
 	}
 
+	if operation.IsTradePaged() {
+		return exchange.LatestTrades(operation, operation.TradeHistory)
+	}
 	return nil
 }
 
@@ -354,3 +416,164 @@ func (e *Kucoin) doSpotOrderBook(op *exchange.PublicOperation) error {
 	op.Maker = maker
 	return nil
 }
+
+func (e *Kucoin) doSystemStatus(operation *exchange.PublicOperation) error {
+	jsonResponse := JsonResponse{}
+	systemStatus := SystemStatus{}
+
+	baseURL := API_URL
+	if e.isSandBox() {
+		baseURL = SANDBOX_API_URL
+	}
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/v1/status", baseURL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doSystemStatus Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doSystemStatus Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &systemStatus); err != nil {
+		operation.Error = fmt.Errorf("%s doSystemStatus Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	// open, close, cancelonly
+	status := &exchange.SystemStatusDetail{
+		Status:    exchange.HealthNormal,
+		Message:   systemStatus.Msg,
+		Timestamp: time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	switch systemStatus.Status {
+	case "close":
+		status.Status = exchange.HealthMaintenance
+	case "cancelonly":
+		status.Status = exchange.HealthCancelOnly
+	}
+
+	// the announcements don't change the status, the status is kept without them
+	maintenances, err := e.getMaintenances(baseURL, operation)
+	if err != nil {
+		log.Printf("%v", err)
+	}
+	status.Maintenances = maintenances
+	operation.SystemStatus = status
+
+	return nil
+}
+
+// getMaintenances is the first page of the maintenance announcements, they have no window
+func (e *Kucoin) getMaintenances(baseURL string, operation *exchange.PublicOperation) ([]*exchange.MaintenanceDetail, error) {
+	jsonResponse := JsonResponse{}
+	announcements := Announcements{}
+	maintenances := []*exchange.MaintenanceDetail{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/v3/announcements?annType=maintenance-updates&lang=en_US", baseURL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		return maintenances, err
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		return maintenances, fmt.Errorf("%s getMaintenances Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+	} else if jsonResponse.Code != "200000" {
+		return maintenances, fmt.Errorf("%s getMaintenances Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &announcements); err != nil {
+		return maintenances, fmt.Errorf("%s getMaintenances Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	}
+
+	for _, item := range announcements.Items {
+		maintenances = append(maintenances, &exchange.MaintenanceDetail{
+			Title:     item.AnnTitle,
+			URL:       item.AnnURL,
+			Timestamp: item.CTime,
+		})
+	}
+	return maintenances, nil
+}
+
+func (e *Kucoin) doWalletStatus(operation *exchange.PublicOperation) error {
+	jsonResponse := JsonResponse{}
+	coinsData := CoinsData{}
+
+	baseURL := API_URL
+	if e.isSandBox() {
+		baseURL = SANDBOX_API_URL
+	}
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/v1/currencies", baseURL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doWalletStatus Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != "200000" {
+		operation.Error = fmt.Errorf("%s doWalletStatus Failed: %s %v", e.GetName(), jsonResponse.Code, jsonResponse.Msg)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &coinsData); err != nil {
+		operation.Error = fmt.Errorf("%s doWalletStatus Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.WalletStatus = []*exchange.WalletStatusDetail{}
+	for _, data := range coinsData {
+		c := e.GetCoinBySymbol(data.Currency)
+		if c == nil {
+			continue
+		}
+
+		status := &exchange.WalletStatusDetail{
+			Coin:     c,
+			Deposit:  data.IsDepositEnabled,
+			Withdraw: data.IsWithdrawEnabled,
+		}
+		issues := []string{}
+		if !status.Deposit {
+			issues = append(issues, "deposit disabled")
+		}
+		if !status.Withdraw {
+			issues = append(issues, "withdraw disabled")
+		}
+		status.Issue = strings.Join(issues, "; ")
+
+		if coinConstraint := e.GetCoinConstraint(c); coinConstraint != nil {
+			// a copy, the constraint may be read meanwhile
+			updated := *coinConstraint
+			updated.Deposit = status.Deposit
+			updated.Withdraw = status.Withdraw
+			updated.Issue = status.Issue
+			e.SetCoinConstraint(&updated)
+		}
+		operation.WalletStatus = append(operation.WalletStatus, status)
+	}
+
+	return nil
+}
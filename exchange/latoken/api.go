@@ -18,10 +18,9 @@ import (
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
 
-	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://api.latoken.com"
 )
 
@@ -313,14 +312,16 @@ func (e *Latoken) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 	placeOrder := PlaceOrder{}
 	strRequest := "/api/v1/Order/new"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
 	mapParams["Side"] = "sell"
-	mapParams["Amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["Price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["Amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["Price"] = pairConstraint.FormatRate(rate)
 	mapParams["OrderType"] = "limit"
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
@@ -350,14 +351,16 @@ func (e *Latoken) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 	strRequest := "/api/v1/Order/new"
 	// strRequest := "/api/v1/Order/test-order" // test buy api
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["Symbol"] = e.GetSymbolByPair(pair)
 	mapParams["Side"] = "buy"
-	mapParams["Amount"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["Price"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["Amount"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["Price"] = pairConstraint.FormatRate(rate)
 	mapParams["OrderType"] = "limit"
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
@@ -65,6 +65,7 @@ type Exchange interface {
 
 	/***** Exchange Constraint *****/
 	GetConstraintFetchMethod(pair *pair.Pair) *ConstrainFetchMethod
+	GetCapabilities() *Capabilities
 	UpdateConstraint()
 	/***** Coin Constraint *****/
 	GetTxFee(coin *coin.Coin) float64
@@ -81,6 +82,12 @@ type Exchange interface {
 	DoAccountOperation(operation *AccountOperation) error
 }
 
+// BookFeed is where the orderbooks come from, every Exchange is a BookFeed.
+// Papertrade matches its orders against one, a websocket book cache is one returning its latest book.
+type BookFeed interface {
+	OrderBook(pair *pair.Pair) (*Maker, error)
+}
+
 type ExchangeManager struct {
 }
 
@@ -195,6 +202,43 @@ func (e *ExchangeManager) SubsetPairs(e1, e2 Exchange) []*pair.Pair {
 	return pairs
 }
 
+// Supporting is the exchanges which do op on wallet, SpotWallet if empty, by ID
+func (e *ExchangeManager) Supporting(op OperationType, wallet WalletType) []Exchange {
+	exchanges := []Exchange{}
+	for _, ex := range e.GetExchanges() {
+		if ex.GetCapabilities().Supports(op, wallet) {
+			exchanges = append(exchanges, ex)
+		}
+	}
+	return exchanges
+}
+
+// Route is the first of the exchanges named which does op on wallet, all of them if none named.
+// The error wraps ErrNotSupported if none does.
+func (e *ExchangeManager) Route(op OperationType, wallet WalletType, names ...ExchangeName) (Exchange, error) {
+	exchanges := []Exchange{}
+	if len(names) == 0 {
+		exchanges = e.GetExchanges()
+	}
+	for _, name := range names {
+		if ex := e.Get(name); ex != nil {
+			exchanges = append(exchanges, ex)
+		}
+	}
+
+	for _, ex := range exchanges {
+		if ex.GetCapabilities().Supports(op, wallet) {
+			return ex, nil
+		}
+	}
+	return nil, fmt.Errorf("%v %s: %w", op, wallet, ErrNotSupported)
+}
+
+// Coverage is the capabilities of the exchanges added side by side
+func (e *ExchangeManager) Coverage() *Coverage {
+	return NewCoverage(e.GetExchanges()...)
+}
+
 func (e *ExchangeManager) UpdateExData(conf *Update) {
 	switch conf.Method {
 	case API_TIGGER:
@@ -268,7 +312,7 @@ func (e *ExchangeManager) initExchangeNames() {
 	supportList = append(supportList, LATOKEN)   // ID = 49
 	supportList = append(supportList, VIRGOCX)   // ID = 50
 	supportList = append(supportList, ABCC)      // ID = 51
-	// supportList = append(supportList, BYBIT)     // ID = 52 no orderbook API
+	supportList = append(supportList, BYBIT)      // ID = 52
 	supportList = append(supportList, ZEBITEX)    // ID = 53
 	supportList = append(supportList, BITHUMB)    // ID = 54
 	supportList = append(supportList, SWITCHEO)   // ID = 55
@@ -290,4 +334,5 @@ func (e *ExchangeManager) initExchangeNames() {
 	// supportList = append(supportList, NICEHASH) // ID = 72
 	// supportList = append(supportList, BITBNS) // ID = 73
 // 	supportList = append(supportList, OKSIM) // ID = 74
+	// supportList = append(supportList, PAPERTRADE) // ID = 75, in process, see papertrade.CreatePapertrade
 }
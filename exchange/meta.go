@@ -45,6 +45,8 @@ const (
 	MARKET_BUY   MarginAction = "MARKET_BUY"
 	MARKET_SELL  MarginAction = "MARKET_SELL"
 
+	INTEREST_HISTORY MarginAction = "INTEREST_HISTORY"
+
 	// ************ from goredmergin ************
 	CONTRACT_MARKET_BUY   ContractAction = "CONTRACT_MARKET_BUY"
 	CONTRACT_MARKET_SELL  ContractAction = "CONTRACT_MARKET_SELL"
@@ -162,6 +164,7 @@ const (
 	OKSIM        ExchangeName = "OKSIM"
 	OTCBTC       ExchangeName = "OTCBTC"
 	P2PB2B       ExchangeName = "P2PB2B"
+	PAPERTRADE   ExchangeName = "PAPERTRADE"
 	POLONIEX     ExchangeName = "POLONIEX"
 	PROBIT       ExchangeName = "PROBIT"
 	RIGHTBTC     ExchangeName = "RIGHTBTC"
@@ -201,4 +204,5 @@ const (
 	Trade_TAKE_PROFIT          OrderTradeType = "TAKE_PROFIT"
 	Trade_TAKE_PROFIT_MARKET   OrderTradeType = "TAKE_PROFIT_MARKET"
 	Trade_TRAILING_STOP_MARKET OrderTradeType = "TRAILING_STOP_MARKET"
+	Trade_PEGGED               OrderTradeType = "PEGGED" // limit order pegged to the best price of its side, offset by PegOffset
 )
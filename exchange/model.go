@@ -6,6 +6,7 @@ package exchange
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/bitontop/gored/coin"
@@ -63,9 +64,9 @@ type CoinConstraint struct {
 type ConstrainFetchMethod struct {
 	PublicAPI   bool
 	PrivateAPI  bool
-	HealthAPI   bool // get exchange health status from exchange's API directly
-	HasWithdraw bool // has withdraw method implemented
-	HasTransfer bool // has transfer method
+	HealthAPI   bool // get exchange health status from exchange's API directly, Deprecated: GetCapabilities SystemStatus
+	HasWithdraw bool // has withdraw method implemented, Deprecated: GetCapabilities Withdraw
+	HasTransfer bool // has transfer method, Deprecated: GetCapabilities Transfer
 
 	Fee             bool // true only when get Fee from API directly
 	LotSize         bool
@@ -112,6 +113,21 @@ type Order struct {
 	Error        error
 }
 
+// Execution is one fill of an order
+type Execution struct {
+	ID         string         `json:"id"`
+	OrderID    string         `json:"order_id"`
+	Pair       *pair.Pair     `json:"pair"`
+	Instrument string         `json:"instrument"`
+	Direction  TradeDirection `json:"direction"`
+	Rate       float64        `json:"rate"`
+	Quantity   float64        `json:"quantity"`
+	Fee        float64        `json:"fee"` // negative for rebates
+	FeeCoin    *coin.Coin     `json:"fee_coin"`
+	Maker      bool           `json:"maker"`
+	Timestamp  int64          `json:"timestamp"` // ms
+}
+
 type Maker struct {
 	WorkerIP        string     `bson:"workerip"`
 	WorkerDeadTS    float64    `bson:"workerdeadts"`
@@ -125,41 +141,54 @@ type Maker struct {
 	Asks            []Order    `json:"asks"`
 }
 
+// Margin carries the input and the result of a MarginOperation, Action selects what to do:
+// LOAN_REQUEST/LOAN_REPAY borrow or repay Quantity of Currency, TRANSFER_IN/TRANSFER_OUT move funds
+// between spot and margin account, BALANCE fills MarginBalance, INTEREST_HISTORY fills History.
+// Pair is required for isolated margin (AccountOperation.MarginMode).
 type Margin struct {
 	Action        MarginAction
 	Pair          *pair.Pair
 	Currency      *coin.Coin
 	Rate          float64
 	Quantity      float64
-	TransferID    int
+	TransferID    string
 	Order         *Order
 	MarginOrder   *MarginOrder
 	MarginBalance *MarginBalance
+	History       []*MarginOrder
 }
 
+// MarginOrder is a loan (or a single interest record of a loan) on the margin account
 type MarginOrder struct {
-	ID              int
+	ID              string
+	Currency        *coin.Coin
+	Pair            *pair.Pair // isolated margin only
 	LoanAmount      float64
-	LoanBalance     float64
+	LoanBalance     float64 // loan not repaid yet
 	InterestRate    float64
 	InterestAmount  float64
-	InterestBalance float64
+	InterestBalance float64 // interest not repaid yet
 	State           string
+	Timestamp       int64
 }
 
+// MarginBalance is one margin account, the cross account or an isolated pair.
+// RiskRate is the margin level, total asset / total liability, 0 if nothing is borrowed.
 type MarginBalance struct {
-	ID       int    `json:"id"`
-	Type     string `json:"type"`
-	State    string `json:"state"`
-	Symbol   string `json:"symbol"`
-	FlPrice  string `json:"fl-price"`
-	FlType   string `json:"fl-type"`
-	RiskRate string `json:"risk-rate"`
-	List     []struct {
-		Currency string `json:"currency"`
-		Type     string `json:"type"`
-		Balance  string `json:"balance"`
-	} `json:"list"`
+	Pair             *pair.Pair // isolated margin only
+	MarginMode       MarginMode
+	RiskRate         float64
+	LiquidationPrice float64
+	Assets           []*MarginAsset
+}
+
+type MarginAsset struct {
+	Coin     *coin.Coin
+	Free     float64
+	Locked   float64
+	Borrowed float64
+	Interest float64
+	NetAsset float64
 }
 
 //Account Operation Data Modeling
@@ -179,6 +208,7 @@ const (
 	GetPositions       OperationType = "GetPositions"       // open positions' list for the contract 3
 	GetFutureStats     OperationType = "GetFutureStats"
 	GetAccount         OperationType = "GetAccount"
+	MarginOperation    OperationType = "MarginOperation" // borrow, repay, transfer, balance of margin account, see Margin
 
 	//Public Query
 	GetCoin OperationType = "GetCoin"
@@ -189,23 +219,37 @@ const (
 	CoinChainType  OperationType = "CoinChainType"
 	KLine          OperationType = "KLine"
 	GetTickerPrice OperationType = "GetTickerPrice"
+	Ticker         OperationType = "Ticker" // 24h statistics and best bid/ask of every pair, see TickerDetail
+	FundingRate    OperationType = "FundingRate"
+	OptionChain    OperationType = "OptionChain"  // all option instruments of the Coin underlying
+	OptionTicker   OperationType = "OptionTicker" // mark price, implied volatility and greeks of Instrument
+	IndexPrice     OperationType = "IndexPrice"   // index price of the Coin underlying
+	BookSummary    OperationType = "BookSummary"  // book summary of Instrument, or of every option of the Coin underlying
+	SystemStatus   OperationType = "SystemStatus" // status and announced maintenances of the exchange, see SystemStatusDetail
+	WalletStatus   OperationType = "WalletStatus" // deposit and withdraw status of every coin, the CoinConstraints are updated
 
 	//Trade (Private Action)
 	PlaceOrder     OperationType = "PlaceOrder"
 	CancelOrder    OperationType = "CancelOrder"
 	GetOrderStatus OperationType = "GetOrderStatus"
+	CancelAllAfter OperationType = "CancelAllAfter" // dead man's switch, cancel all orders CancelTimeout ms after the last call, 0 disarms
 
 	//User (Private Action)
-	GetOpenOrder         OperationType = "GetOpenOrder"    // New and Partial Orders
-	GetOrderHistory      OperationType = "GetOrderHistory" // All Orders other than open orders
+	GetOpenOrder         OperationType = "GetOpenOrder"        // New and Partial Orders
+	GetOrderHistory      OperationType = "GetOrderHistory"     // All Orders other than open orders
+	GetExecutionHistory  OperationType = "GetExecutionHistory" // Fills of the account
 	GetDepositHistory    OperationType = "GetDepositHistory"
 	GetWithdrawalHistory OperationType = "GetWithdrawalHistory"
 	GetTransferHistory   OperationType = "GetTransferHistory"
 	GetDepositAddress    OperationType = "GetDepositAddress" // Get address for one coin
 
 	// Contract
-	GetFutureBalance  OperationType = "GetFutureBalance"
-	SetFutureLeverage OperationType = "SetFutureLeverage"
+	GetFutureBalance     OperationType = "GetFutureBalance"
+	SetFutureLeverage    OperationType = "SetFutureLeverage"
+	SetLeverage          OperationType = "SetLeverage"          // Leverage of Pair, account wide if the exchange has no per contract leverage
+	SetMarginType        OperationType = "SetMarginType"        // MarginMode of Pair
+	SetPositionMode      OperationType = "SetPositionMode"      // PositionMode, one-way or hedge
+	AdjustIsolatedMargin OperationType = "AdjustIsolatedMargin" // add (positive) or reduce (negative) MarginAmount of an isolated position
 )
 
 type WalletType string
@@ -217,6 +261,7 @@ const (
 	MarginWallet  WalletType = "MarginWallet"
 	// ##### New - Contract
 	ContractWallet WalletType = "ContractWallet"
+	OptionWallet   WalletType = "OptionWallet"
 )
 
 type AccountOperation struct {
@@ -266,9 +311,10 @@ type AccountOperation struct {
 	//Coin = nil
 	BalanceList []AssetBalance `json:"balance_list"`
 
-	// #OpenOrder, OrderHistory
-	OpenOrders   []*Order
-	OrderHistory []*Order
+	// #OpenOrder, OrderHistory, ExecutionHistory
+	OpenOrders       []*Order
+	OrderHistory     []*Order
+	ExecutionHistory []*Execution
 
 	// #GetWithdrawal/DepositHistory
 	WithdrawalHistory []*WDHistory
@@ -305,11 +351,22 @@ type AccountOperation struct {
 	TradeType      OrderTradeType // eg. TRADE_LIMIT
 	OrderDirection TradeDirection //TradeDirection
 	Leverage       int
+	Offset         OffSetType // open or close a position, Huobi DM contract orders
+	PegOffset      float64    // for Trade_PEGGED, Trade_TRAILING_STOP_MARKET
+	CancelTimeout  int64      // ms, for CancelAllAfter
 
-	//### new start from FTX
-	OpenPositionList OpenPositions
-	//for binance
-	BinanceOpenPositionList BinancePositions
+	// #GetPositions, GetPositionInfo
+	Positions []*Position
+
+	// #MarginOperation, PlaceOrder with MarginWallet. Cross margin if MarginMode empty
+	MarginMode MarginMode
+	Margin     *Margin
+
+	// #SetLeverage, SetMarginType, SetPositionMode, AdjustIsolatedMargin
+	PositionMode    PositionMode
+	PositionSide    PositionSide // the side of a hedge mode position
+	MarginAmount    float64      // in settle currency
+	LeverageSetting *LeverageSetting
 
 	Data json.RawMessage //semi-processed data
 }
@@ -321,27 +378,115 @@ type FutureStats struct {
 	OpenInterest    float64   `json:"openInterest"`
 }
 
-type OpenPositions []struct {
-	Future                       string  `json:"future"`
-	Size                         float64 `json:"size"`
-	Side                         string  `json:"side"`
-	NetSize                      float64 `json:"netSize"`
-	LongOrderSize                float64 `json:"longOrderSize"`
-	ShortOrderSize               float64 `json:"shortOrderSize"`
-	Cost                         float64 `json:"cost"`
-	EntryPrice                   float64 `json:"entryPrice"`
-	UnrealizedPnl                float64 `json:"unrealizedPnl"`
-	RealizedPnl                  float64 `json:"realizedPnl"`
-	InitialMarginRequirement     float64 `json:"initialMarginRequirement"`
-	MaintenanceMarginRequirement float64 `json:"maintenanceMarginRequirement"`
-	OpenSize                     float64 `json:"openSize"`
-	CollateralUsed               float64 `json:"collateralUsed"`
-	EstimatedLiquidationPrice    float64 `json:"estimatedLiquidationPrice"`
-	RecentAverageOpenPrice       float64 `json:"recentAverageOpenPrice"`
-	RecentPnl                    float64 `json:"recentPnl"`
-	RecentBreakEvenPrice         float64 `json:"recentBreakEvenPrice"`
-	CumulativeBuySize            float64 `json:"cumulativeBuySize"`
-	CumulativeSellSize           float64 `json:"cumulativeSellSize"`
+type PositionSide string
+
+const (
+	PositionLong  PositionSide = "Long"
+	PositionShort PositionSide = "Short"
+	PositionBoth  PositionSide = "Both" // one-way mode position, direction from the sign of the exchange's size
+)
+
+type MarginMode string
+
+const (
+	CrossMargin    MarginMode = "cross"
+	IsolatedMargin MarginMode = "isolated"
+)
+
+type PositionMode string
+
+const (
+	OneWayMode PositionMode = "one_way"
+	HedgeMode  PositionMode = "hedge"
+)
+
+// ErrNotSupported is wrapped in the error of an operation the exchange can't do, eg. hedge mode on bitmex
+var ErrNotSupported = errors.New("not supported by exchange")
+
+// LeverageSetting is the account configuration after SetLeverage, SetMarginType, SetPositionMode or AdjustIsolatedMargin.
+// Fields the exchange doesn't return are left empty.
+type LeverageSetting struct {
+	Pair           *pair.Pair   `json:"pair"`
+	Instrument     string       `json:"instrument"`
+	Leverage       float64      `json:"leverage"`
+	MarginMode     MarginMode   `json:"margin_mode"`
+	PositionMode   PositionMode `json:"position_mode"`
+	IsolatedMargin float64      `json:"isolated_margin"`
+}
+
+// Position is the exchange independent view of an open contract position.
+// Size is always positive and counted in the exchange's contract unit, Side carries the direction.
+type Position struct {
+	Pair             *pair.Pair   `json:"pair"`       // nil if the instrument is not a loaded pair
+	Instrument       string       `json:"instrument"` // symbol on exchange, eg. BTCUSDT, XBTUSD, BTC-PERPETUAL
+	Side             PositionSide `json:"side"`
+	Size             float64      `json:"size"`
+	EntryPrice       float64      `json:"entry_price"`
+	MarkPrice        float64      `json:"mark_price"` // 0 if the exchange doesn't give it
+	LiquidationPrice float64      `json:"liquidation_price"`
+	Leverage         float64      `json:"leverage"`
+	MarginMode       MarginMode   `json:"margin_mode"`
+	UnrealizedPnl    float64      `json:"unrealized_pnl"`
+	RealizedPnl      float64      `json:"realized_pnl"`
+	Greeks           *Greeks      `json:"greeks"` // options only, for the whole position, nil for futures and swaps
+	Timestamp        int64        `json:"timestamp"`
+}
+
+type OptionType string
+
+const (
+	CallOption OptionType = "call"
+	PutOption  OptionType = "put"
+)
+
+// options are not loaded as pairs, they are identified by the instrument name on exchange
+type OptionInstrument struct {
+	Instrument     string     `json:"instrument"` // eg. BTC-27DEC19-8000-C
+	Underlying     *coin.Coin `json:"underlying"`
+	OptionType     OptionType `json:"option_type"`
+	Strike         float64    `json:"strike"`
+	Expiry         int64      `json:"expiry"` // ms
+	ContractSize   float64    `json:"contract_size"`
+	MinTradeAmount float64    `json:"min_trade_amount"`
+	TickSize       float64    `json:"tick_size"`
+}
+
+// greeks per contract, Vega and Theta are in the quote currency of the instrument
+type Greeks struct {
+	Delta float64 `json:"delta"`
+	Gamma float64 `json:"gamma"`
+	Vega  float64 `json:"vega"`
+	Theta float64 `json:"theta"`
+	Rho   float64 `json:"rho"`
+}
+
+// implied volatilities are in percent, eg. 65.3
+type OptionTickerDetail struct {
+	Instrument      string  `json:"instrument"`
+	MarkPrice       float64 `json:"mark_price"`
+	MarkIV          float64 `json:"mark_iv"`
+	BidIV           float64 `json:"bid_iv"`
+	AskIV           float64 `json:"ask_iv"`
+	BestBidPrice    float64 `json:"best_bid_price"`
+	BestAskPrice    float64 `json:"best_ask_price"`
+	UnderlyingPrice float64 `json:"underlying_price"`
+	IndexPrice      float64 `json:"index_price"`
+	OpenInterest    float64 `json:"open_interest"`
+	Greeks          *Greeks `json:"greeks"`
+	Timestamp       int64   `json:"timestamp"` // ms
+}
+
+type BookSummaryDetail struct {
+	Instrument      string  `json:"instrument"`
+	MarkPrice       float64 `json:"mark_price"`
+	MarkIV          float64 `json:"mark_iv"` // 0 for futures
+	BidPrice        float64 `json:"bid_price"`
+	AskPrice        float64 `json:"ask_price"`
+	LastPrice       float64 `json:"last_price"`
+	UnderlyingPrice float64 `json:"underlying_price"`
+	Volume          float64 `json:"volume"` // 24h, in contracts
+	OpenInterest    float64 `json:"open_interest"`
+	Timestamp       int64   `json:"timestamp"` // ms
 }
 
 type PublicOperation struct {
@@ -351,19 +496,39 @@ type PublicOperation struct {
 	EX       ExchangeName  `json:"exchange_name"`
 	TestMode bool          `json:"test_mode"`
 
-	Coin           *coin.Coin           `json:"op_coin"` //BOT standard symbol, not the symbol on exchange
-	Pair           *pair.Pair           `json:"op_pair"`
-	Maker          *Maker               `json:"maker"`
-	TradeHistory   []*TradeDetail       `json:"history"`
-	CoinChainType  []ChainType          `json:"chain_type"`
-	KlineInterval  string               `json:"kline_interval"`
-	KlineStartTime int64                `json:"kline_start_time"`
-	KlineEndTime   int64                `json:"kline_end_time"`
-	Kline          []*KlineDetail       `json:"kline"`
-	TickerPrice    []*TickerPriceDetail `json:"ticker_price"`
+	Coin           *coin.Coin            `json:"op_coin"` //BOT standard symbol, not the symbol on exchange
+	Pair           *pair.Pair            `json:"op_pair"`
+	Maker          *Maker                `json:"maker"`
+	TradeHistory   []*TradeDetail        `json:"history"`
+	CoinChainType  []ChainType           `json:"chain_type"`
+	KlineInterval  KlineInterval         `json:"kline_interval"`
+	KlineStartTime int64                 `json:"kline_start_time"` // ms
+	KlineEndTime   int64                 `json:"kline_end_time"`   // ms
+	Kline          []*KlineDetail        `json:"kline"`
+	TickerPrice    []*TickerPriceDetail  `json:"ticker_price"`
+	Ticker         []*TickerDetail       `json:"ticker"`
+	SystemStatus   *SystemStatusDetail   `json:"system_status"`
+	WalletStatus   []*WalletStatusDetail `json:"wallet_status"`
+
+	// #TradeHistory, range in ms, TradeFromID is the id of the last trade already known, it is not returned again
+	TradeStartTime int64  `json:"trade_start_time"`
+	TradeEndTime   int64  `json:"trade_end_time"`
+	TradeFromID    string `json:"trade_from_id"`
 
 	FutureStats *FutureStats
 
+	// #FundingRate, history range in ms
+	FundingStartTime int64              `json:"funding_start_time"`
+	FundingEndTime   int64              `json:"funding_end_time"`
+	FundingRate      *FundingRateDetail `json:"funding_rate"`
+
+	// #Options, Coin is the underlying, Instrument the option symbol on exchange
+	Instrument   string               `json:"instrument"`
+	OptionChain  []*OptionInstrument  `json:"option_chain"`
+	OptionTicker *OptionTickerDetail  `json:"option_ticker"`
+	IndexPrice   float64              `json:"index_price"`
+	BookSummary  []*BookSummaryDetail `json:"book_summary"`
+
 	//#Debug
 	DebugMode    bool   `json:"debug mode"`
 	RequestURI   string `json:"request_uri"`
@@ -434,7 +599,7 @@ type KlineDetail struct {
 	ID                  int          `json:"id" gorm:"primary_key;AUTO_INCREMENT"`
 	Exchange            ExchangeName `json:"exchange"`
 	Pair                string       `json:"pair"`
-	OpenTime            float64      `json:"open_time"`
+	OpenTime            float64      `json:"open_time"` // ms
 	Open                float64      `json:"open"`
 	High                float64      `json:"high"`
 	Low                 float64      `json:"low"`
@@ -447,11 +612,78 @@ type KlineDetail struct {
 	TakerBuyQuoteVolume float64      `json:"taker_buy_quote_volume"`
 }
 
+type FundingRateDetail struct {
+	Pair            *pair.Pair            `json:"pair"`
+	Instrument      string                `json:"instrument"`
+	CurrentRate     float64               `json:"current_rate"`      // rate of the running period, settled at NextFundingTime
+	PredictedRate   float64               `json:"predicted_rate"`    // estimate for the period after NextFundingTime, 0 if not published
+	NextFundingTime int64                 `json:"next_funding_time"` // ms, 0 for continuous funding
+	History         []*FundingRateHistory `json:"history"`
+}
+
+type FundingRateHistory struct {
+	Rate      float64 `json:"rate"`
+	Timestamp int64   `json:"timestamp"` // ms, settlement time
+}
+
 type TickerPriceDetail struct {
 	Pair  *pair.Pair `json:"pair"`
 	Price float64    `json:"price"`
 }
 
+// TickerDetail is the ticker of the last 24h, a value the exchange doesn't have is 0.
+// Volume is in the Target coin of the pair, QuoteVolume in the Base coin.
+type TickerDetail struct {
+	Pair            *pair.Pair `json:"pair"`
+	Last            float64    `json:"last"`
+	BestBid         float64    `json:"best_bid"`
+	BestBidQuantity float64    `json:"best_bid_quantity"`
+	BestAsk         float64    `json:"best_ask"`
+	BestAskQuantity float64    `json:"best_ask_quantity"`
+	Open            float64    `json:"open"`
+	High            float64    `json:"high"`
+	Low             float64    `json:"low"`
+	Volume          float64    `json:"volume"`
+	QuoteVolume     float64    `json:"quote_volume"`
+	Change          float64    `json:"change"`         // Last - Open
+	ChangePercent   float64    `json:"change_percent"` // 5 is +5%
+	Timestamp       int64      `json:"timestamp"`      // ms
+}
+
+type HealthStatus string
+
+const (
+	HealthNormal      HealthStatus = "Normal"
+	HealthMaintenance HealthStatus = "Maintenance" // no trading
+	HealthCancelOnly  HealthStatus = "CancelOnly"  // orders can be cancelled, not placed
+	HealthUnknown     HealthStatus = "Unknown"
+)
+
+// SystemStatusDetail is the status of the exchange and the maintenances it announced
+type SystemStatusDetail struct {
+	Status       HealthStatus         `json:"status"`
+	Message      string               `json:"message"`
+	Maintenances []*MaintenanceDetail `json:"maintenances"`
+	Timestamp    int64                `json:"timestamp"` // ms
+}
+
+// MaintenanceDetail is an announced maintenance, Start and End are 0 if the announcement has no window
+type MaintenanceDetail struct {
+	Title     string `json:"title"`
+	Start     int64  `json:"start"` // ms
+	End       int64  `json:"end"`   // ms
+	URL       string `json:"url"`
+	Timestamp int64  `json:"timestamp"` // ms, announced
+}
+
+// WalletStatusDetail is the deposit and withdraw status of a coin, Issue is why one of them is suspended
+type WalletStatusDetail struct {
+	Coin     *coin.Coin `json:"coin"`
+	Deposit  bool       `json:"deposit"`
+	Withdraw bool       `json:"withdraw"`
+	Issue    string     `json:"issue"`
+}
+
 type TradeDetail struct {
 	ID        string         `json:"id"`
 	Quantity  float64        `json:"quantity"`  //amount 	/ Qty
@@ -468,21 +700,3 @@ type ChainTypeRequest struct {
 	ChainType []string `json:"chain_type, omitempty"`
 	CTSource  string   `json:"ct_source, omitempty"`
 }
-
-type BinancePositions []struct {
-	Symbol           string `json:"symbol"`
-	PositionAmt      string `json:"positionAmt"`
-	EntryPrice       string `json:"entryPrice"`
-	MarkPrice        string `json:"markPrice"`
-	UnRealizedProfit string `json:"unRealizedProfit"`
-	LiquidationPrice string `json:"liquidationPrice"`
-	Leverage         string `json:"leverage"`
-	MaxNotionalValue string `json:"maxNotionalValue"`
-	MarginType       string `json:"marginType"`
-	IsolatedMargin   string `json:"isolatedMargin"`
-	IsAutoAddMargin  string `json:"isAutoAddMargin"`
-	PositionSide     string `json:"positionSide"`
-	Notional         string `json:"notional"`
-	IsolatedWallet   string `json:"isolatedWallet"`
-	UpdateTime       int64  `json:"updateTime"`
-}
@@ -247,6 +247,27 @@ func (e *Mxc) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetch
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory: {exchange.SpotWallet},
+		exchange.Ticker:       {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList: {exchange.SpotWallet},
+		exchange.Balance:     {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Mxc) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Mxc) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
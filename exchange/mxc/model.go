@@ -71,3 +71,16 @@ type TradeHistory []struct {
 	TradeQuantity string `json:"tradeQuantity"`
 	TradeType     string `json:"tradeType"`
 }
+
+type Ticker []struct {
+	Symbol     string `json:"symbol"`
+	Volume     string `json:"volume"`
+	High       string `json:"high"`
+	Low        string `json:"low"`
+	Bid        string `json:"bid"`
+	Ask        string `json:"ask"`
+	Open       string `json:"open"`
+	Last       string `json:"last"`
+	Time       int64  `json:"time"`
+	ChangeRate string `json:"change_rate"`
+}
@@ -1,20 +1,96 @@
 package mxc
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
 )
 
 func (e *Mxc) LoadPublicData(operation *exchange.PublicOperation) error {
 	switch operation.Type {
 	case exchange.TradeHistory:
 		return e.doTradeHistory(operation)
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
+// tickers of the v2 api, the v1 api has no ticker of all the pairs
+func (e *Mxc) doTicker(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	ticker := Ticker{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/open/api/v2/market/ticker", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != 200 {
+		operation.Error = fmt.Errorf("%s doTicker Failed: %v", e.GetName(), jsonResponse.Msg)
+		return operation.Error
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range ticker {
+		p := e.GetPairBySymbol(t.Symbol)
+		if p == nil {
+			p = e.GetPairBySymbol(strings.ToLower(t.Symbol))
+		}
+		if p == nil {
+			continue
+		}
+
+		// change_rate 0.05 is +5%
+		values, err := exchange.ParseFloats(t.Last, t.Bid, t.Ask, t.Open, t.High, t.Low, t.Volume, t.ChangeRate)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), t.Symbol, err)
+			return operation.Error
+		}
+
+		detail := &exchange.TickerDetail{
+			Pair:          p,
+			Last:          values[0],
+			BestBid:       values[1],
+			BestAsk:       values[2],
+			Open:          values[3],
+			High:          values[4],
+			Low:           values[5],
+			Volume:        values[6],
+			ChangePercent: values[7] * 100,
+			Timestamp:     t.Time,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	return nil
+}
+
 // timeStamp parse TODO
 func (e *Mxc) doTradeHistory(operation *exchange.PublicOperation) error {
 	// symbol := e.GetSymbolByPair(operation.Pair)
@@ -19,8 +19,8 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
-	API_URL string = "https://www.okex.com"
+var (
+	API_URL string = "https://www.okx.com"
 )
 
 /*API Base Knowledge
@@ -48,97 +48,101 @@ Get - Method
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
+// the coins are taken from the spot instruments, deposit, withdraw and fee need the api key
 func (e *Okex) GetCoinsData() error {
-	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
-		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	pairsData, err := e.getInstruments()
+	if err != nil {
+		return fmt.Errorf("%s Get Coins %v", e.GetName(), err)
 	}
 
-	coinsData := CoinsData{}
-
-	strRequestUrl := "/api/account/v3/currencies"
-	jsonCurrencyReturn := e.ApiKeyRequest("GET", nil, strRequestUrl)
-	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &coinsData); err != nil {
-		return fmt.Errorf("%s Get Coins Result Unmarshal Err: %v %s", e.GetName(), err, []byte(jsonCurrencyReturn))
-	}
-
-	for _, data := range coinsData {
-		c := &coin.Coin{}
-		switch e.Source {
-		case exchange.EXCHANGE_API:
-			c = coin.GetCoin(data.Currency) //data.Currency)
-			if c == nil {
-				c = &coin.Coin{}
-				c.Code = data.Currency //data.Currency
-				c.Name = data.Name
-				coin.AddCoin(c)
-			}
-
-		case exchange.JSON_FILE:
-			c = e.GetCoinBySymbol(data.Currency) //data.Currency)
-		}
-
-		if c != nil {
-			coinConstraint := e.GetCoinConstraint(c)
-			if coinConstraint == nil {
-				coinConstraint = &exchange.CoinConstraint{
-					CoinID:       c.ID,
-					Coin:         c,
-					ExSymbol:     data.Currency,
-					ChainType:    exchange.MAINNET,
-					Confirmation: DEFAULT_CONFIRMATION,
+	for _, data := range pairsData {
+		for _, symbol := range []string{data.BaseCcy, data.QuoteCcy} {
+			c := &coin.Coin{}
+			switch e.Source {
+			case exchange.EXCHANGE_API:
+				c = coin.GetCoin(symbol)
+				if c == nil {
+					c = &coin.Coin{}
+					c.Code = symbol
+					coin.AddCoin(c)
 				}
-			} else {
-				coinConstraint.ExSymbol = data.Currency
+			case exchange.JSON_FILE:
+				c = e.GetCoinBySymbol(symbol)
 			}
 
-			if data.CanDeposit == "1" {
-				coinConstraint.Deposit = true
-			} else {
-				coinConstraint.Deposit = false
-			}
-
-			if data.CanWithdraw == "1" {
-				coinConstraint.Withdraw = true
-			} else {
-				coinConstraint.Withdraw = false
+			if c != nil {
+				coinConstraint := e.GetCoinConstraint(c)
+				if coinConstraint == nil {
+					coinConstraint = &exchange.CoinConstraint{
+						CoinID:       c.ID,
+						Coin:         c,
+						ExSymbol:     symbol,
+						ChainType:    exchange.MAINNET,
+						Confirmation: DEFAULT_CONFIRMATION,
+						Listed:       DEFAULT_LISTED,
+					}
+				} else {
+					coinConstraint.ExSymbol = symbol
+				}
+				e.SetCoinConstraint(coinConstraint)
 			}
-
-			e.SetCoinConstraint(coinConstraint)
 		}
 	}
+
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return nil
+	}
 	return e.WithdrawFee()
 }
 
+// WithdrawFee sets deposit, withdraw and the lowest withdraw fee of every chain of the coin
 func (e *Okex) WithdrawFee() error {
 	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
 		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	withdrawFee := WithdrawFee{}
-	strRequest := "/api/account/v3/withdrawal/fee"
+	jsonResponse := &JsonResponse{}
+	coinsData := CoinsData{}
+	strRequest := "/api/v5/asset/currencies"
 
-	jsonWithdrawFee := e.ApiKeyRequest("GET", nil, strRequest)
-	if err := json.Unmarshal([]byte(jsonWithdrawFee), &withdrawFee); err != nil {
-		return fmt.Errorf("%s WithdrawFee Unmarshal Err: %v %v", e.GetName(), err, jsonWithdrawFee)
+	jsonCurrencyReturn := e.ApiKeyRequest("GET", nil, strRequest)
+	if err := json.Unmarshal([]byte(jsonCurrencyReturn), &jsonResponse); err != nil {
+		return fmt.Errorf("%s WithdrawFee Json Unmarshal Err: %v %v", e.GetName(), err, jsonCurrencyReturn)
+	} else if jsonResponse.Code != "0" {
+		return fmt.Errorf("%s WithdrawFee Failed: %v", e.GetName(), jsonCurrencyReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &coinsData); err != nil {
+		return fmt.Errorf("%s WithdrawFee Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
 	}
 
-	for _, data := range withdrawFee {
-		c := e.GetCoinBySymbol(data.Currency)
-		if c != nil {
-			coinConstraint := e.GetCoinConstraint(c)
-			if data.MinFee != "" {
-				minFee, err := strconv.ParseFloat(data.MinFee, 64)
-				if err != nil {
-					return fmt.Errorf("%s minFee conver to float64 err: %v %+v", e.GetName(), err, data)
-				} else {
-					coinConstraint.TxFee = minFee
-					coinConstraint.Listed = true
-				}
-			} else {
-				coinConstraint.Listed = false
-			}
+	// one record per chain
+	updated := make(map[string]bool)
+	for _, data := range coinsData {
+		c := e.GetCoinBySymbol(data.Ccy)
+		if c == nil {
+			continue
+		}
+		coinConstraint := e.GetCoinConstraint(c)
+		minFee, err := strconv.ParseFloat(data.MinFee, 64)
+		if err != nil {
+			minFee = 0
+		}
+
+		if !updated[data.Ccy] {
+			updated[data.Ccy] = true
+			coinConstraint.Deposit = data.CanDep
+			coinConstraint.Withdraw = data.CanWd
+			coinConstraint.TxFee = minFee
+			coinConstraint.Listed = data.CanWd
+			continue
 		}
 
+		coinConstraint.Deposit = coinConstraint.Deposit || data.CanDep
+		if data.CanWd && (!coinConstraint.Withdraw || minFee < coinConstraint.TxFee) {
+			coinConstraint.TxFee = minFee
+		}
+		coinConstraint.Withdraw = coinConstraint.Withdraw || data.CanWd
+		coinConstraint.Listed = coinConstraint.Withdraw
 	}
 	return nil
 }
@@ -148,41 +152,35 @@ Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
 Step 3: Modify API Path(strRequestUrl)*/
 func (e *Okex) GetPairsData() error {
-	//jsonResponse := &JsonResponse{}
-	pairsData := PairsData{}
-
-	strRequestUrl := "/api/spot/v3/instruments"
-	strUrl := API_URL + strRequestUrl
-
-	jsonSymbolsReturn := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &pairsData); err != nil {
-		return fmt.Errorf("%s Get Pairs Json Unmarshal Err: %v %v", e.GetName(), err, jsonSymbolsReturn)
+	pairsData, err := e.getInstruments()
+	if err != nil {
+		return fmt.Errorf("%s Get Pairs %v", e.GetName(), err)
 	}
 
 	for _, data := range pairsData {
 		p := &pair.Pair{}
 		switch e.Source {
 		case exchange.EXCHANGE_API:
-			base := coin.GetCoin(data.QuoteCurrency)
-			target := coin.GetCoin(data.BaseCurrency)
+			base := coin.GetCoin(data.QuoteCcy)
+			target := coin.GetCoin(data.BaseCcy)
 			if base != nil && target != nil {
 				p = pair.GetPair(base, target)
 			}
 		case exchange.JSON_FILE:
-			p = e.GetPairBySymbol(data.InstrumentID)
+			p = e.GetPairBySymbol(data.InstID)
 		}
 
-		lotSize, err := strconv.ParseFloat(data.SizeIncrement, 64)
+		lotSize, err := strconv.ParseFloat(data.LotSz, 64)
 		if err != nil {
-			return fmt.Errorf("%s Convert lotSize to Float64 Err: %v %v", e.GetName(), err, data.SizeIncrement)
+			return fmt.Errorf("%s Convert lotSize to Float64 Err: %v %v", e.GetName(), err, data.LotSz)
 		}
-		priceFilter, err := strconv.ParseFloat(data.TickSize, 64)
+		priceFilter, err := strconv.ParseFloat(data.TickSz, 64)
 		if err != nil {
-			return fmt.Errorf("%s Convert lotSize to Float64 Err: %v %v", e.GetName(), err, data.TickSize)
+			return fmt.Errorf("%s Convert priceFilter to Float64 Err: %v %v", e.GetName(), err, data.TickSz)
 		}
-		minTrade, err := strconv.ParseFloat(data.MinSize, 64)
+		minTrade, err := strconv.ParseFloat(data.MinSz, 64)
 		if err != nil {
-			return fmt.Errorf("%s Convert minTrade to Float64 Err: %v %v", e.GetName(), err, data.MinSize)
+			return fmt.Errorf("%s Convert minTrade to Float64 Err: %v %v", e.GetName(), err, data.MinSz)
 		}
 
 		if p != nil {
@@ -191,7 +189,7 @@ func (e *Okex) GetPairsData() error {
 				pairConstraint = &exchange.PairConstraint{ // no minBaseQuantity
 					PairID:           p.ID,
 					Pair:             p,
-					ExSymbol:         data.InstrumentID,
+					ExSymbol:         data.InstID,
 					MakerFee:         DEFAULT_MAKER_FEE,
 					TakerFee:         DEFAULT_TAKER_FEE,
 					LotSize:          lotSize,
@@ -200,7 +198,7 @@ func (e *Okex) GetPairsData() error {
 					Listed:           DEFAULT_LISTED,
 				}
 			} else {
-				pairConstraint.ExSymbol = data.InstrumentID
+				pairConstraint.ExSymbol = data.InstID
 				pairConstraint.LotSize = lotSize
 				pairConstraint.PriceFilter = priceFilter
 				pairConstraint.MinTradeQuantity = minTrade
@@ -211,6 +209,36 @@ func (e *Okex) GetPairsData() error {
 	return nil
 }
 
+// getInstruments returns the live spot instruments
+func (e *Okex) getInstruments() (PairsData, error) {
+	jsonResponse := &JsonResponse{}
+	pairsData := PairsData{}
+
+	strRequestUrl := "/api/v5/public/instruments"
+	strUrl := API_URL + strRequestUrl
+
+	mapParams := make(map[string]string)
+	mapParams["instType"] = "SPOT"
+
+	jsonSymbolsReturn := exchange.HttpGetRequest(strUrl, mapParams)
+	if err := json.Unmarshal([]byte(jsonSymbolsReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("Json Unmarshal Err: %v %v", err, jsonSymbolsReturn)
+	} else if jsonResponse.Code != "0" {
+		return nil, fmt.Errorf("Failed: %v", jsonSymbolsReturn)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &pairsData); err != nil {
+		return nil, fmt.Errorf("Result Unmarshal Err: %v %s", err, jsonResponse.Data)
+	}
+
+	live := PairsData{}
+	for _, data := range pairsData {
+		if data.State == "live" {
+			live = append(live, data)
+		}
+	}
+	return live, nil
+}
+
 /*Get Pair Market Depth
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Add Model of API Response
@@ -219,10 +247,14 @@ Step 4: Modify API Path(strRequestUrl)
 Step 5: Add Params - Depend on API request
 Step 6: Convert the response to Standard Maker struct*/
 func (e *Okex) OrderBook(pair *pair.Pair) (*exchange.Maker, error) {
+	jsonResponse := &JsonResponse{}
 	orderBook := OrderBook{}
-	symbol := e.GetSymbolByPair(pair)
 
-	strRequestUrl := fmt.Sprintf("/api/spot/v3/instruments/%s/book", symbol)
+	mapParams := make(map[string]string)
+	mapParams["instId"] = e.GetSymbolByPair(pair)
+	mapParams["sz"] = "400"
+
+	strRequestUrl := "/api/v5/market/books"
 	strUrl := API_URL + strRequestUrl
 
 	maker := &exchange.Maker{
@@ -231,20 +263,27 @@ func (e *Okex) OrderBook(pair *pair.Pair) (*exchange.Maker, error) {
 		BeforeTimestamp: float64(time.Now().UnixNano() / 1e6),
 	}
 
-	jsonOrderbook := exchange.HttpGetRequest(strUrl, nil)
-	if err := json.Unmarshal([]byte(jsonOrderbook), &orderBook); err != nil {
+	jsonOrderbook := exchange.HttpGetRequest(strUrl, mapParams)
+	if err := json.Unmarshal([]byte(jsonOrderbook), &jsonResponse); err != nil {
 		return nil, fmt.Errorf("%s Get Orderbook Json Unmarshal Err: %v %v", e.GetName(), err, jsonOrderbook)
+	} else if jsonResponse.Code != "0" {
+		return nil, fmt.Errorf("%s Get Orderbook Failed: %v", e.GetName(), jsonOrderbook)
+	}
+	if err := json.Unmarshal(jsonResponse.Data, &orderBook); err != nil {
+		return nil, fmt.Errorf("%s Get Orderbook Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+	} else if len(orderBook) == 0 {
+		return nil, fmt.Errorf("%s Get Orderbook got empty return: %v", e.GetName(), jsonOrderbook)
 	}
 
 	maker.AfterTimestamp = float64(time.Now().UnixNano() / 1e6)
-	for _, bid := range orderBook.Bids {
+	for _, bid := range orderBook[0].Bids {
 		var buydata exchange.Order
 		buydata.Rate, _ = strconv.ParseFloat(bid[0], 64)
 		buydata.Quantity, _ = strconv.ParseFloat(bid[1], 64)
 
 		maker.Bids = append(maker.Bids, buydata)
 	}
-	for _, ask := range orderBook.Asks {
+	for _, ask := range orderBook[0].Asks {
 		var selldata exchange.Order
 		selldata.Rate, _ = strconv.ParseFloat(ask[0], 64)
 		selldata.Quantity, _ = strconv.ParseFloat(ask[1], 64)
@@ -256,39 +295,41 @@ func (e *Okex) OrderBook(pair *pair.Pair) (*exchange.Maker, error) {
 
 /*************** Private API ***************/
 
+// available balance of the trading account
 func (e *Okex) UpdateAllBalances() {
 	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
 		log.Printf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 		return
 	}
 
-	accountBalance := AccountBalances{}
-	strRequest := "/api/spot/v3/accounts"
+	jsonResponse := &JsonResponse{}
+	accountBalance := AccountBalance{}
+	strRequest := "/api/v5/account/balance"
 
 	jsonBalanceReturn := e.ApiKeyRequest("GET", nil, strRequest)
-	// log.Printf("jsonBalanceReturn: %v", jsonBalanceReturn)
-	if err := json.Unmarshal([]byte(jsonBalanceReturn), &accountBalance); err != nil {
-		errorJson := ErrorMsg{}
-		if err := json.Unmarshal([]byte(jsonBalanceReturn), &errorJson); err != nil {
-			log.Printf("%s UpdateAllBalances Err: Code: %v Msg: %v", e.GetName(), errorJson.Code, errorJson.Msg)
-		} else {
-			log.Printf("%s UpdateAllBalances Json Unmarshal Err: %v %v", e.GetName(), err, jsonBalanceReturn)
-		}
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		log.Printf("%s UpdateAllBalances Json Unmarshal Err: %v %v", e.GetName(), err, jsonBalanceReturn)
 		return
-	} else if len(accountBalance) == 0 {
+	} else if jsonResponse.Code != "0" {
 		log.Printf("%s UpdateAllBalances Failed: %v", e.GetName(), jsonBalanceReturn)
 		return
 	}
+	if err := json.Unmarshal(jsonResponse.Data, &accountBalance); err != nil {
+		log.Printf("%s UpdateAllBalances Result Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return
+	}
 
-	for _, v := range accountBalance {
-		c := e.GetCoinBySymbol(v.Currency)
-		if c != nil {
-			balanceAvailable, err := strconv.ParseFloat(v.Available, 64)
-			if err != nil {
-				log.Printf("%s available balance conver to float64 err : %v", e.GetName, err)
-				balanceAvailable = 0.0
+	for _, account := range accountBalance {
+		for _, v := range account.Details {
+			c := e.GetCoinBySymbol(v.Ccy)
+			if c != nil {
+				balanceAvailable, err := strconv.ParseFloat(v.AvailBal, 64)
+				if err != nil {
+					log.Printf("%s available balance conver to float64 err : %v", e.GetName(), err)
+					balanceAvailable = 0.0
+				}
+				balanceMap.Set(c.Code, balanceAvailable)
 			}
-			balanceMap.Set(c.Code, balanceAvailable)
 		}
 	}
 }
@@ -299,23 +340,16 @@ func (e *Okex) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) boo
 		return false
 	}
 
-	withdrawResponse := WithdrawResponse{}
-	strRequest := "/api/account/v3/withdrawal"
-
-	mapParams := make(map[string]interface{})
-	mapParams["currency"] = e.GetSymbolByCoin(coin)
-	mapParams["amount"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["destination"] = "4"
-	mapParams["to_address"] = addr
-	mapParams["trade_pwd"] = e.TradePassword
-	mapParams["fee"] = e.GetTxFee(coin)
-
-	jsonSubmitWithdraw := e.ApiKeyRequest("POST", mapParams, strRequest)
-	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &withdrawResponse); err != nil {
-		log.Printf("%s Withdraw Json Unmarshal Err: %v %v", e.GetName(), err, jsonSubmitWithdraw)
-		return false
-	} else if !withdrawResponse.Result {
-		log.Printf("%s Withdraw Failed: %v %v", e.GetName(), withdrawResponse.Code, withdrawResponse.Message)
+	operation := &exchange.AccountOperation{
+		Type:            exchange.Withdraw,
+		Wallet:          exchange.SpotWallet,
+		Coin:            coin,
+		WithdrawAmount:  strconv.FormatFloat(quantity, 'f', -1, 64),
+		WithdrawAddress: addr,
+		WithdrawTag:     tag,
+	}
+	if err := e.doWithdraw(operation); err != nil {
+		log.Printf("%v", err)
 		return false
 	}
 
@@ -328,25 +362,6 @@ func (e *Okex) Transfer(coin *coin.Coin, quantity float64, from, to int) bool {
 		return false
 	}
 
-	// transfer := Transfer{}
-	// strRequest := "/api/account/v3/transfer"
-
-	// mapParams := make(map[string]interface{})
-	// mapParams["currency"] = e.GetSymbolByCoin(coin)
-	// mapParams["amount"] = quantity
-	// mapParams["from"] = from
-	// mapParams["to"] = to
-
-	// jsonTransfer := e.ApiKeyRequest("POST", mapParams, strRequest)
-
-	// if err := json.Unmarshal([]byte(jsonTransfer), &transfer); err != nil {
-	// 	log.Printf("%s Transfer Unmarshal Err: %v %v", e.GetName, err, jsonTransfer)
-	// 	return false
-	// } else if !transfer.Result {
-	// 	log.Printf("%s Transfer Failed: %v %v", e.GetName, transfer.Code, transfer.Message)
-	// 	return false
-	// }
-
 	return true
 }
 
@@ -355,34 +370,7 @@ func (e *Okex) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	placeOrder := PlaceOrder{}
-	strRequest := "/api/spot/v3/orders"
-
-	mapParams := make(map[string]interface{})
-	mapParams["side"] = "sell"
-	mapParams["instrument_id"] = e.GetSymbolByPair(pair)
-	mapParams["type"] = "limit"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-
-	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
-	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
-		return nil, fmt.Errorf("%s LimitSell Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
-	} else if !placeOrder.Result {
-		return nil, fmt.Errorf("%s LimitSell Failed: %v", e.GetName(), jsonPlaceReturn)
-	}
-
-	order := &exchange.Order{
-		Pair:         pair,
-		OrderID:      placeOrder.OrderID,
-		Rate:         rate,
-		Quantity:     quantity,
-		Direction:    exchange.Sell,
-		Status:       exchange.New,
-		JsonResponse: jsonPlaceReturn,
-	}
-
-	return order, nil
+	return e.limitOrder(pair, exchange.Sell, quantity, rate)
 }
 
 func (e *Okex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
@@ -390,34 +378,28 @@ func (e *Okex) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Orde
 		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	placeOrder := PlaceOrder{}
-	strRequest := "/api/spot/v3/orders"
-
-	mapParams := make(map[string]interface{})
-	mapParams["side"] = "buy"
-	mapParams["instrument_id"] = e.GetSymbolByPair(pair)
-	mapParams["type"] = "limit"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["size"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-
-	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
-	if err := json.Unmarshal([]byte(jsonPlaceReturn), &placeOrder); err != nil {
-		return nil, fmt.Errorf("%s LimitBuy Json Unmarshal Err: %v %v", e.GetName(), err, jsonPlaceReturn)
-	} else if !placeOrder.Result {
-		return nil, fmt.Errorf("%s LimitBuy Failed: %v", e.GetName(), jsonPlaceReturn)
-	}
-
-	order := &exchange.Order{
-		Pair:         pair,
-		OrderID:      placeOrder.OrderID,
-		Rate:         rate,
-		Quantity:     quantity,
-		Direction:    exchange.Buy,
-		Status:       exchange.New,
-		JsonResponse: jsonPlaceReturn,
-	}
+	return e.limitOrder(pair, exchange.Buy, quantity, rate)
+}
 
-	return order, nil
+func (e *Okex) limitOrder(pair *pair.Pair, direction exchange.TradeDirection, quantity, rate float64) (*exchange.Order, error) {
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
+	operation := &exchange.AccountOperation{
+		Type:           exchange.PlaceOrder,
+		Wallet:         exchange.SpotWallet,
+		Pair:           pair,
+		OrderDirection: direction,
+		TradeType:      exchange.TRADE_LIMIT,
+		Quantity:       quantity,
+		Rate:           rate,
+	}
+	if err := e.doPlaceOrder(operation); err != nil {
+		return nil, err
+	}
+	return operation.Order, nil
 }
 
 func (e *Okex) OrderStatus(order *exchange.Order) error {
@@ -425,37 +407,11 @@ func (e *Okex) OrderStatus(order *exchange.Order) error {
 		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	orderStatus := OrderStatus{}
-	strRequest := fmt.Sprintf("/api/spot/v3/orders/%s", order.OrderID)
-
-	mapParams := make(map[string]string)
-	mapParams["instrument_id"] = e.GetSymbolByPair(order.Pair)
-
-	strRequest += fmt.Sprintf("?%s", exchange.Map2UrlQuery(mapParams))
-
-	jsonOrderStatus := e.ApiKeyRequest("GET", nil, strRequest)
-	if err := json.Unmarshal([]byte(jsonOrderStatus), &orderStatus); err != nil {
-		return fmt.Errorf("%s OrderStatus Json Unmarshal Err: %v %v", e.GetName(), err, jsonOrderStatus)
-	}
-
-	order.StatusMessage = jsonOrderStatus
-	if orderStatus.Status == "open" {
-		order.Status = exchange.New
-	} else if orderStatus.Status == "part_filled" {
-		order.Status = exchange.Partial
-	} else if orderStatus.Status == "filled" {
-		order.Status = exchange.Filled
-	} else if orderStatus.Status == "canceling" {
-		order.Status = exchange.Canceling
-	} else if orderStatus.Status == "cancelled" {
-		order.Status = exchange.Cancelled
-	} else if orderStatus.Status == "failure" {
-		order.Status = exchange.Rejected
-	} else {
-		order.Status = exchange.Other
-	}
-
-	return nil
+	return e.doOrderStatus(&exchange.AccountOperation{
+		Type:   exchange.GetOrderStatus,
+		Wallet: exchange.SpotWallet,
+		Order:  order,
+	})
 }
 
 func (e *Okex) ListOrders() ([]*exchange.Order, error) {
@@ -467,23 +423,11 @@ func (e *Okex) CancelOrder(order *exchange.Order) error {
 		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	cancelOrder := PlaceOrder{}
-	strRequest := fmt.Sprintf("/api/spot/v3/cancel_orders/%s", order.OrderID)
-
-	mapParams := make(map[string]interface{})
-	mapParams["instrument_id"] = e.GetSymbolByPair(order.Pair)
-
-	jsonCancelOrder := e.ApiKeyRequest("POST", mapParams, strRequest)
-	if err := json.Unmarshal([]byte(jsonCancelOrder), &cancelOrder); err != nil {
-		return fmt.Errorf("%s CancelOrder Json Unmarshal Err: %v %v", e.GetName(), err, jsonCancelOrder)
-	} else if !cancelOrder.Result {
-		return fmt.Errorf("%s CancelOrder Failed: %v", e.GetName(), jsonCancelOrder)
-	}
-
-	order.Status = exchange.Canceling
-	order.CancelStatus = jsonCancelOrder
-
-	return nil
+	return e.doCancelOrder(&exchange.AccountOperation{
+		Type:   exchange.CancelOrder,
+		Wallet: exchange.SpotWallet,
+		Order:  order,
+	})
 }
 
 func (e *Okex) CancelAllOrder() error {
@@ -495,31 +439,22 @@ func (e *Okex) CancelAllOrder() error {
 Step 1: Change Instance Name    (e *<exchange Instance Name>)
 Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
+// v5 signature: timestamp + method + request path (with query for GET) + json body (POST)
 func (e *Okex) ApiKeyRequest(method string, mapParams map[string]interface{}, strRequestPath string) string {
 	TimeStamp := IsoTime()
 
 	jsonParams := ""
 	var bytesParams []byte
-	if len(mapParams) != 0 {
-		bytesParams, _ = json.Marshal(mapParams)
-		jsonParams = string(bytesParams)
-	}
-
-	var strMessage string
 	if method == "GET" {
 		if len(mapParams) > 0 {
 			strRequestPath += "?" + exchange.Map2UrlQueryInterface(mapParams)
 		}
-		strMessage = TimeStamp + method + strRequestPath
-		if len(mapParams) > 0 {
-			strMessage += jsonParams
-		}
-	} else {
-		strMessage = TimeStamp + method + strRequestPath + jsonParams
+	} else if len(mapParams) != 0 {
+		bytesParams, _ = json.Marshal(mapParams)
+		jsonParams = string(bytesParams)
 	}
 
-	// log.Printf("===================strMessage: %v", strMessage)
-
+	strMessage := TimeStamp + method + strRequestPath + jsonParams
 	signature := exchange.ComputeHmac256Base64(strMessage, e.API_SECRET)
 	strUrl := API_URL + strRequestPath
 
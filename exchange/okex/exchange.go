@@ -9,6 +9,7 @@ import (
 	"log"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	cmap "github.com/orcaman/concurrent-map"
@@ -46,7 +47,7 @@ func CreateOkex(config *exchange.Config) *Okex {
 		instance = &Okex{
 			ID:      DEFAULT_ID,
 			Name:    "Okex",
-			Website: "https://www.Okex.com/",
+			Website: "https://www.okx.com/",
 
 			API_KEY:       config.API_KEY,
 			API_SECRET:    config.API_SECRET,
@@ -114,7 +115,7 @@ func (e *Okex) GetBalance(coin *coin.Coin) float64 {
 }
 
 func (e *Okex) GetTradingWebURL(pair *pair.Pair) string {
-	return fmt.Sprintf("https://www.okex.com/spot/full#product=%s_%s", e.GetSymbolByCoin(pair.Target), e.GetSymbolByCoin(pair.Base))
+	return fmt.Sprintf("https://www.okx.com/trade-spot/%s", strings.ToLower(e.GetSymbolByPair(pair)))
 }
 
 /*************** Coins on the Exchanges ***************/
@@ -251,6 +252,42 @@ func (e *Okex) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.TradeHistory:   {exchange.SpotWallet},
+		exchange.Orderbook:      {exchange.SpotWallet},
+		exchange.KLine:          {exchange.SpotWallet},
+		exchange.GetTickerPrice: {exchange.SpotWallet},
+		exchange.Ticker:         {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.SubAccountTransfer: {exchange.SpotWallet},
+		exchange.Transfer:           {exchange.SpotWallet},
+		exchange.BalanceList:        {exchange.AssetWallet, exchange.SpotWallet},
+		exchange.Balance:            {exchange.SpotWallet},
+		exchange.Withdraw:           {exchange.SpotWallet},
+		exchange.GetTransferHistory: {exchange.SpotWallet},
+		exchange.GetOpenOrder:       {exchange.SpotWallet, exchange.MarginWallet},
+		exchange.GetOrderHistory:    {exchange.SpotWallet, exchange.MarginWallet},
+		exchange.PlaceOrder:         {exchange.SpotWallet, exchange.MarginWallet},
+		exchange.GetOrderStatus:     {exchange.SpotWallet, exchange.MarginWallet},
+		exchange.CancelOrder:        {exchange.SpotWallet, exchange.MarginWallet},
+		exchange.MarginOperation:    {exchange.MarginWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet:   {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+		exchange.MarginWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet:   {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+		exchange.MarginWallet: {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+	},
+}
+
+func (e *Okex) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Okex) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
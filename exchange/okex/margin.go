@@ -0,0 +1,260 @@
+package okex
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// v5 unified account, the margin loans are in the trading account.
+// Cross margin if MarginMode empty, isolated margin needs the Pair
+func (e *Okex) doMarginOperation(operation *exchange.AccountOperation) error {
+	if operation.Margin == nil {
+		return fmt.Errorf("%s MarginOperation empty Margin: %+v", e.GetName(), operation)
+	} else if operation.MarginMode == exchange.IsolatedMargin && operation.Margin.Pair == nil {
+		return fmt.Errorf("%s MarginOperation isolated margin without pair", e.GetName())
+	}
+
+	switch operation.Margin.Action {
+	case exchange.LOAN_REQUEST:
+		return e.doMarginLoan(operation, "borrow")
+	case exchange.LOAN_REPAY:
+		return e.doMarginLoan(operation, "repay")
+	case exchange.TRANSFER_IN, exchange.TRANSFER_OUT:
+		return e.doMarginTransfer(operation)
+	case exchange.BALANCE:
+		return e.doMarginBalance(operation)
+	case exchange.INTEREST_HISTORY:
+		return e.doMarginInterest(operation)
+	}
+
+	return fmt.Errorf("%s MarginOperation action invalid: %v", e.GetName(), operation.Margin.Action)
+}
+
+// manual borrow or repay in the spot mode of the unified account, side: borrow, repay
+func (e *Okex) doMarginLoan(operation *exchange.AccountOperation, side string) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	loan := MarginBorrowRepay{}
+	strRequest := "/api/v5/account/spot-manual-borrow-repay"
+
+	mapParams := make(map[string]interface{})
+	mapParams["ccy"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["side"] = side
+	mapParams["amt"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+
+	jsonLoanReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonLoanReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonLoanReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginLoan Json Unmarshal Err: %v, %s", e.GetName(), err, jsonLoanReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doMarginLoan Failed: %v", e.GetName(), jsonLoanReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &loan); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginLoan Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	// no loan id in v5
+	margin.MarginOrder = &exchange.MarginOrder{
+		Currency: margin.Currency,
+		Pair:     margin.Pair,
+	}
+	if margin.Action == exchange.LOAN_REQUEST {
+		margin.MarginOrder.LoanAmount = margin.Quantity
+	}
+
+	return nil
+}
+
+// TRANSFER_IN: funding -> trading, TRANSFER_OUT: trading -> funding. 6 funding, 18 trading
+func (e *Okex) doMarginTransfer(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	trans := Transfer{}
+	strRequest := "/api/v5/asset/transfer"
+
+	mapParams := make(map[string]interface{})
+	mapParams["ccy"] = e.GetSymbolByCoin(margin.Currency)
+	mapParams["amt"] = strconv.FormatFloat(margin.Quantity, 'f', -1, 64)
+	if margin.Action == exchange.TRANSFER_IN {
+		mapParams["from"] = "6"
+		mapParams["to"] = "18"
+	} else {
+		mapParams["from"] = "18"
+		mapParams["to"] = "6"
+	}
+
+	jsonTransferReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonTransferReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonTransferReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Failed: %v", e.GetName(), jsonTransferReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &trans); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(trans) == 0 {
+		operation.Error = fmt.Errorf("%s doMarginTransfer Failed: %v", e.GetName(), jsonTransferReturn)
+		return operation.Error
+	}
+
+	margin.TransferID = trans[0].TransID
+
+	return nil
+}
+
+// the trading account, only the coins of the Pair if set
+func (e *Okex) doMarginBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	account := AccountBalance{}
+	strRequest := "/api/v5/account/balance"
+
+	mapParams := make(map[string]interface{})
+	if margin.Pair != nil {
+		mapParams["ccy"] = e.GetSymbolByCoin(margin.Pair.Target) + "," + e.GetSymbolByCoin(margin.Pair.Base)
+	}
+
+	jsonBalanceReturn := e.ApiKeyRequest("GET", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &account); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginBalance Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(account) == 0 {
+		operation.Error = fmt.Errorf("%s doMarginBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	}
+
+	balance := &exchange.MarginBalance{
+		Pair:       margin.Pair,
+		MarginMode: exchange.CrossMargin,
+	}
+	if operation.MarginMode == exchange.IsolatedMargin {
+		balance.MarginMode = exchange.IsolatedMargin
+	}
+	balance.RiskRate, _ = strconv.ParseFloat(account[0].MgnRatio, 64)
+
+	for _, detail := range account[0].Details {
+		c := e.GetCoinBySymbol(detail.Ccy)
+		if c == nil {
+			continue
+		}
+
+		asset := &exchange.MarginAsset{
+			Coin: c,
+		}
+		asset.Free, _ = strconv.ParseFloat(detail.AvailBal, 64)
+		asset.Locked, _ = strconv.ParseFloat(detail.FrozenBal, 64)
+		asset.Borrowed, _ = strconv.ParseFloat(detail.Liab, 64)
+		asset.Interest, _ = strconv.ParseFloat(detail.Interest, 64)
+		asset.NetAsset, _ = strconv.ParseFloat(detail.Eq, 64)
+		balance.Assets = append(balance.Assets, asset)
+
+		if liqPx, err := strconv.ParseFloat(detail.LiqPx, 64); err == nil && liqPx > 0 {
+			balance.LiquidationPrice = liqPx
+		}
+	}
+
+	margin.MarginBalance = balance
+
+	return nil
+}
+
+// hourly interest records, filter by Margin.Currency if set
+func (e *Okex) doMarginInterest(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	margin := operation.Margin
+	jsonResponse := &JsonResponse{}
+	interest := InterestAccrued{}
+	strRequest := "/api/v5/account/interest-accrued"
+
+	mapParams := make(map[string]interface{})
+	if operation.MarginMode == exchange.IsolatedMargin {
+		mapParams["mgnMode"] = "isolated"
+		mapParams["instId"] = e.GetSymbolByPair(margin.Pair)
+	} else {
+		mapParams["mgnMode"] = "cross"
+	}
+	if margin.Currency != nil {
+		mapParams["ccy"] = e.GetSymbolByCoin(margin.Currency)
+	}
+
+	jsonInterestReturn := e.ApiKeyRequest("GET", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonInterestReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonInterestReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginInterest Json Unmarshal Err: %v, %s", e.GetName(), err, jsonInterestReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doMarginInterest Failed: %v", e.GetName(), jsonInterestReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &interest); err != nil {
+		operation.Error = fmt.Errorf("%s doMarginInterest Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	margin.History = []*exchange.MarginOrder{}
+	for _, record := range interest {
+		c := e.GetCoinBySymbol(record.Ccy)
+		if margin.Currency != nil && c != margin.Currency {
+			continue
+		}
+
+		loan := &exchange.MarginOrder{
+			Currency: c,
+			State:    "accrual",
+		}
+		if record.InstID != "" {
+			loan.Pair = e.GetPairBySymbol(record.InstID)
+		}
+		loan.Timestamp, _ = strconv.ParseInt(record.Ts, 10, 64)
+		loan.LoanBalance, _ = strconv.ParseFloat(record.Liab, 64)
+		loan.InterestRate, _ = strconv.ParseFloat(record.InterestRate, 64)
+		loan.InterestAmount, _ = strconv.ParseFloat(record.Interest, 64)
+		margin.History = append(margin.History, loan)
+	}
+
+	return nil
+}
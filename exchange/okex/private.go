@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"math"
 	"strconv"
 
 	"github.com/bitontop/gored/exchange"
@@ -36,36 +37,57 @@ func (e *Okex) DoAccountOperation(operation *exchange.AccountOperation) error {
 			return e.doGetTransferHistory(operation)
 		}
 	case exchange.GetOpenOrder:
-		if operation.Wallet == exchange.SpotWallet {
+		if operation.Wallet == exchange.SpotWallet || operation.Wallet == exchange.MarginWallet {
 			return e.getOpenOrder(operation)
 		}
+	case exchange.GetOrderHistory:
+		if operation.Wallet == exchange.SpotWallet || operation.Wallet == exchange.MarginWallet {
+			return e.doGetOrderHistory(operation)
+		}
+	case exchange.PlaceOrder:
+		if operation.Wallet == exchange.SpotWallet || operation.Wallet == exchange.MarginWallet {
+			return e.doPlaceOrder(operation)
+		}
+	case exchange.GetOrderStatus:
+		if operation.Wallet == exchange.SpotWallet || operation.Wallet == exchange.MarginWallet {
+			return e.doOrderStatus(operation)
+		}
+	case exchange.CancelOrder:
+		if operation.Wallet == exchange.SpotWallet || operation.Wallet == exchange.MarginWallet {
+			return e.doCancelOrder(operation)
+		}
+	case exchange.MarginOperation:
+		if operation.Wallet == exchange.MarginWallet {
+			return e.doMarginOperation(operation)
+		}
 
 	}
 
 	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
 }
 
-// all types of transfer in same api, all types of wallets. Only support spot now.
+// master and sub account transfer between the funding accounts.
 // put subAccount login name into 'SubTransferFrom' or 'SubTransferTo'
 func (e *Okex) subTransfer(operation *exchange.AccountOperation) error {
 	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
 		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
+	jsonResponse := &JsonResponse{}
 	trans := Transfer{}
-	strRequest := "/api/account/v3/transfer"
+	strRequest := "/api/v5/asset/transfer"
 
 	mapParams := make(map[string]interface{})
-	mapParams["currency"] = e.GetSymbolByCoin(operation.Coin)
-	mapParams["amount"] = operation.SubTransferAmount
-	mapParams["from"] = "1" // 1 spot, 6 asset
-	mapParams["to"] = "1"
+	mapParams["ccy"] = e.GetSymbolByCoin(operation.Coin)
+	mapParams["amt"] = operation.SubTransferAmount
+	mapParams["from"] = "6" // 6 funding, 18 trading
+	mapParams["to"] = "6"
 	if operation.SubTransferFrom != "" {
 		mapParams["type"] = "2"
-		mapParams["sub_account"] = operation.SubTransferFrom
+		mapParams["subAcct"] = operation.SubTransferFrom
 	} else if operation.SubTransferTo != "" {
 		mapParams["type"] = "1"
-		mapParams["sub_account"] = operation.SubTransferTo
+		mapParams["subAcct"] = operation.SubTransferTo
 	} else {
 		return fmt.Errorf("%s doSubTransfer failed, missing subAccount param", e.GetName())
 	}
@@ -76,113 +98,325 @@ func (e *Okex) subTransfer(operation *exchange.AccountOperation) error {
 		operation.CallResponce = jsonTransferReturn
 	}
 
-	if err := json.Unmarshal([]byte(jsonTransferReturn), &trans); err != nil {
-		errorJson := ErrorMsg{}
-		if err := json.Unmarshal([]byte(jsonTransferReturn), &errorJson); err != nil {
-			operation.Error = fmt.Errorf("%s doSubTransfer Err: %v", e.GetName(), jsonTransferReturn)
-			return operation.Error
-		} else {
-			operation.Error = fmt.Errorf("%s doSubTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
-			return operation.Error
-		}
-	} else if !trans.Result {
+	if err := json.Unmarshal([]byte(jsonTransferReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doSubTransfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
 		operation.Error = fmt.Errorf("%s doSubTransfer failed: %v", e.GetName(), jsonTransferReturn)
 		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &trans); err != nil {
+		operation.Error = fmt.Errorf("%s doSubTransfer Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	return nil
+}
+
+// tdMode of the order, cash for spot. Cross margin if MarginMode empty
+func tradeMode(operation *exchange.AccountOperation) string {
+	if operation.Wallet != exchange.MarginWallet {
+		return "cash"
+	} else if operation.MarginMode == exchange.IsolatedMargin {
+		return "isolated"
+	}
+	return "cross"
+}
+
+// type: TRADE_LIMIT, TRADE_MARKET. Market buy Quantity is the amount of quote coin
+func (e *Okex) doPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	if operation.OrderDirection == "" {
+		return fmt.Errorf("%s PlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.TradeType == "" {
+		return fmt.Errorf("%s PlaceOrder empty TradeType: %+v", e.GetName(), operation)
+	}
+
+	jsonResponse := &JsonResponse{}
+	placeOrder := PlaceOrder{}
+	strRequest := "/api/v5/trade/order"
+
+	mapParams := make(map[string]interface{})
+	mapParams["instId"] = e.GetSymbolByPair(operation.Pair)
+	mapParams["tdMode"] = tradeMode(operation)
+	if operation.OrderDirection == exchange.Buy {
+		mapParams["side"] = "buy"
+	} else {
+		mapParams["side"] = "sell"
+	}
+	mapParams["sz"] = e.GetPairConstraint(operation.Pair).FormatQuantity(operation.Quantity)
+	if operation.TradeType == exchange.TRADE_MARKET {
+		mapParams["ordType"] = "market"
+	} else {
+		switch operation.OrderType {
+		case exchange.FOK:
+			mapParams["ordType"] = "fok"
+		case exchange.IOC:
+			mapParams["ordType"] = "ioc"
+		case exchange.GTX:
+			mapParams["ordType"] = "post_only"
+		default:
+			mapParams["ordType"] = "limit"
+		}
+		mapParams["px"] = e.GetPairConstraint(operation.Pair).FormatRate(operation.Rate)
+	}
+
+	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s PlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s PlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s PlaceOrder Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(placeOrder) == 0 || placeOrder[0].OrdID == "" {
+		operation.Error = fmt.Errorf("%s PlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
 	}
 
-	// log.Printf("SubTransfer response %v", jsonTransferReturn)
+	operation.Order = &exchange.Order{
+		Pair:         operation.Pair,
+		OrderID:      placeOrder[0].OrdID,
+		Rate:         operation.Rate,
+		Quantity:     operation.Quantity,
+		Direction:    operation.OrderDirection,
+		Status:       exchange.New,
+		JsonResponse: jsonPlaceReturn,
+	}
+	if operation.OrderDirection == exchange.Buy {
+		operation.Order.Side = "Buy"
+	} else {
+		operation.Order.Side = "Sell"
+	}
 
 	return nil
 }
 
-func (e *Okex) getOpenOrder(op *exchange.AccountOperation) error {
-	if e.API_KEY == "" || e.API_SECRET == "" {
-		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+func (e *Okex) doOrderStatus(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
+
+	order := operation.Order
+	jsonResponse := &JsonResponse{}
+	orderStatus := []OrderDetail{}
+	strRequest := "/api/v5/trade/order"
+
+	mapParams := make(map[string]interface{})
+	mapParams["instId"] = e.GetSymbolByPair(order.Pair)
+	mapParams["ordId"] = order.OrderID
+
+	jsonOrderStatus := e.ApiKeyRequest("GET", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonOrderStatus
+	}
+
+	if err := json.Unmarshal([]byte(jsonOrderStatus), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s OrderStatus Json Unmarshal Err: %v, %s", e.GetName(), err, jsonOrderStatus)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s OrderStatus Failed: %v", e.GetName(), jsonOrderStatus)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &orderStatus); err != nil {
+		operation.Error = fmt.Errorf("%s OrderStatus Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(orderStatus) == 0 {
+		operation.Error = fmt.Errorf("%s OrderStatus got empty return: %v", e.GetName(), jsonOrderStatus)
+		return operation.Error
+	}
+
+	result := e.toOrder(orderStatus[0])
+	order.Status = result.Status
+	order.DealRate = result.DealRate
+	order.DealQuantity = result.DealQuantity
+	order.JsonResponse = jsonOrderStatus
+
+	return nil
+}
+
+func (e *Okex) doCancelOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	openOrders := OpenOrders{}
-	strRequest := "/api/spot/v3/orders_pending"
+	order := operation.Order
+	jsonResponse := &JsonResponse{}
+	cancelOrder := PlaceOrder{}
+	strRequest := "/api/v5/trade/cancel-order"
 
 	mapParams := make(map[string]interface{})
-	if op.Pair != nil {
-		mapParams["instrument_id"] = e.GetSymbolByPair(op.Pair)
+	mapParams["instId"] = e.GetSymbolByPair(order.Pair)
+	mapParams["ordId"] = order.OrderID
+
+	jsonCancelOrder := e.ApiKeyRequest("POST", mapParams, strRequest)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonCancelOrder
 	}
 
-	jsonOrders := e.ApiKeyRequest("GET", mapParams, strRequest)
-	if op.DebugMode {
-		op.RequestURI = strRequest
-		op.CallResponce = jsonOrders
+	if err := json.Unmarshal([]byte(jsonCancelOrder), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s CancelOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonCancelOrder)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s CancelOrder Failed: %v", e.GetName(), jsonCancelOrder)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &cancelOrder); err != nil {
+		operation.Error = fmt.Errorf("%s CancelOrder Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	order.Status = exchange.Canceling
+	order.CancelStatus = jsonCancelOrder
+
+	return nil
+}
+
+// open orders of all the pairs if Pair is nil
+func (e *Okex) getOpenOrder(op *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	if err := json.Unmarshal([]byte(jsonOrders), &openOrders); err != nil {
-		op.Error = fmt.Errorf("%s Get OpenOrders Json Unmarshal Err: %v, %s", e.GetName(), err, jsonOrders)
+	orders, err := e.getOrders(op, "/api/v5/trade/orders-pending")
+	if err != nil {
+		op.Error = fmt.Errorf("%s Get OpenOrders %v", e.GetName(), err)
 		return op.Error
 	}
+	op.OpenOrders = orders
 
-	result := []*exchange.Order{}
-	for _, data := range openOrders {
-		order := &exchange.Order{
-			Pair:      e.GetPairBySymbol(data.InstrumentID),
-			OrderID:   data.OrderID,
-			Timestamp: data.Timestamp.UnixNano(),
-		}
+	return nil
+}
 
-		switch data.Side {
-		case "buy":
-			order.Direction = exchange.Buy
-		case "sell":
-			order.Direction = exchange.Sell
-		}
+// completed orders of the last 7 days
+func (e *Okex) doGetOrderHistory(op *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
+	}
 
-		order.Quantity, _ = strconv.ParseFloat(data.Size, 64)
-		order.Rate, _ = strconv.ParseFloat(data.Price, 64)
+	orders, err := e.getOrders(op, "/api/v5/trade/orders-history")
+	if err != nil {
+		op.Error = fmt.Errorf("%s Get OrderHistory %v", e.GetName(), err)
+		return op.Error
+	}
+	op.OrderHistory = orders
 
-		order.DealRate = order.Rate
-		order.DealQuantity = 0.0
+	return nil
+}
+
+// spot or margin orders, the list is paged by the last ordId
+func (e *Okex) getOrders(op *exchange.AccountOperation, strRequest string) ([]*exchange.Order, error) {
+	instType := "SPOT"
+	if op.Wallet == exchange.MarginWallet {
+		instType = "MARGIN"
+	}
+
+	result := []*exchange.Order{}
+	after := ""
+	for {
+		jsonResponse := &JsonResponse{}
+		orders := []OrderDetail{}
+
+		mapParams := make(map[string]interface{})
+		mapParams["instType"] = instType
+		if op.Pair != nil {
+			mapParams["instId"] = e.GetSymbolByPair(op.Pair)
+		}
+		if after != "" {
+			mapParams["after"] = after
+		}
 
-		dealQ, _ := strconv.ParseFloat(data.FilledSize, 64)
-		dealTotal, _ := strconv.ParseFloat(data.FilledNotional, 64)
-		if dealQ > 0 {
-			order.DealQuantity = dealQ
+		jsonOrders := e.ApiKeyRequest("GET", mapParams, strRequest)
+		if op.DebugMode {
+			op.RequestURI = strRequest
+			op.CallResponce = jsonOrders
 		}
-		if dealTotal > 0 && dealQ > 0 {
-			order.DealRate = dealTotal / dealQ
+
+		if err := json.Unmarshal([]byte(jsonOrders), &jsonResponse); err != nil {
+			return nil, fmt.Errorf("Json Unmarshal Err: %v, %s", err, jsonOrders)
+		} else if jsonResponse.Code != "0" {
+			return nil, fmt.Errorf("Failed: %v", jsonOrders)
+		} else if err := json.Unmarshal(jsonResponse.Data, &orders); err != nil {
+			return nil, fmt.Errorf("Result Unmarshal Err: %v, %s", err, jsonResponse.Data)
 		}
 
-		if data.State == "0" && order.DealQuantity == 0 {
-			order.Status = exchange.New
-		} else if data.State == "0" && order.DealQuantity < order.Quantity {
-			order.Status = exchange.Partial
-		} else {
-			order.Status = exchange.Other
+		for _, data := range orders {
+			result = append(result, e.toOrder(data))
 		}
 
-		result = append(result, order)
+		// 100 orders per page
+		if len(orders) < 100 {
+			break
+		}
+		after = orders[len(orders)-1].OrdID
 	}
-	op.OpenOrders = result
 
-	return nil
+	return result, nil
 }
 
-// only 1 month data
+func (e *Okex) toOrder(data OrderDetail) *exchange.Order {
+	order := &exchange.Order{
+		Pair:    e.GetPairBySymbol(data.InstID),
+		OrderID: data.OrdID,
+	}
+	order.Timestamp, _ = strconv.ParseInt(data.CTime, 10, 64)
+
+	switch data.Side {
+	case "buy":
+		order.Direction = exchange.Buy
+		order.Side = "Buy"
+	case "sell":
+		order.Direction = exchange.Sell
+		order.Side = "Sell"
+	}
+
+	order.Quantity, _ = strconv.ParseFloat(data.Sz, 64)
+	order.Rate, _ = strconv.ParseFloat(data.Px, 64)
+	order.DealQuantity, _ = strconv.ParseFloat(data.AccFillSz, 64)
+	order.DealRate, _ = strconv.ParseFloat(data.AvgPx, 64)
+
+	switch data.State {
+	case "live":
+		order.Status = exchange.New
+	case "partially_filled":
+		order.Status = exchange.Partial
+	case "filled":
+		order.Status = exchange.Filled
+	case "canceled", "mmp_canceled":
+		order.Status = exchange.Cancelled
+	default:
+		order.Status = exchange.Other
+	}
+
+	return order
+}
+
+// bills of the funding account, only 1 month data.
+// type 20: transfer to sub account, 21: transfer from sub account
 func (e *Okex) doGetTransferHistory(operation *exchange.AccountOperation) error {
-	if e.API_KEY == "" || e.API_SECRET == "" {
-		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
+	jsonResponse := &JsonResponse{}
 	transfer := TransferHistory{}
-	strRequest := "/api/account/v3/ledger"
-
-	// 	//============
-	// strRequest = "/api/account/v3/deposit/history"
-	// 	// ==============
+	strRequest := "/api/v5/asset/bills"
 
 	mapParams := make(map[string]interface{})
 	if operation.TransferStartTime != 0 {
-		mapParams["after"] = operation.TransferStartTime
+		mapParams["begin"] = operation.TransferStartTime
 	}
 	if operation.TransferEndTime != 0 {
-		mapParams["before"] = operation.TransferEndTime
+		mapParams["end"] = operation.TransferEndTime
 	}
 
 	jsonTransferOutHistory := e.ApiKeyRequest("GET", mapParams, strRequest)
@@ -191,36 +425,42 @@ func (e *Okex) doGetTransferHistory(operation *exchange.AccountOperation) error
 		operation.CallResponce = jsonTransferOutHistory
 	}
 
-	if err := json.Unmarshal([]byte(jsonTransferOutHistory), &transfer); err != nil {
+	if err := json.Unmarshal([]byte(jsonTransferOutHistory), &jsonResponse); err != nil {
 		operation.Error = fmt.Errorf("%s doGetTransferHistory Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferOutHistory)
 		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doGetTransferHistory Failed: %v", e.GetName(), jsonTransferOutHistory)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &transfer); err != nil {
+		operation.Error = fmt.Errorf("%s doGetTransferHistory Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
 	}
 
 	// store info into orders
 	operation.TransferOutHistory = []*exchange.TransferHistory{}
 	operation.TransferInHistory = []*exchange.TransferHistory{}
 	for _, tx := range transfer {
-		c := e.GetCoinBySymbol(tx.Currency)
-		quantity, err := strconv.ParseFloat(tx.Amount, 64)
+		c := e.GetCoinBySymbol(tx.Ccy)
+		quantity, err := strconv.ParseFloat(tx.BalChg, 64)
 		if err != nil {
-			operation.Error = fmt.Errorf("%s doGetTransferHistory parse quantity Err: %v, %v", e.GetName(), err, tx.Amount)
+			operation.Error = fmt.Errorf("%s doGetTransferHistory parse quantity Err: %v, %v", e.GetName(), err, tx.BalChg)
 			return operation.Error
 		}
+		timestamp, _ := strconv.ParseInt(tx.Ts, 10, 64)
 
 		record := &exchange.TransferHistory{
-			ID:        tx.LedgerID,
+			ID:        tx.BillID,
 			Coin:      c,
-			Quantity:  quantity,
-			TimeStamp: tx.Timestamp.UnixNano(),
+			Quantity:  math.Abs(quantity),
+			TimeStamp: timestamp * 1e6,
 		}
 
-		switch tx.Typename {
-		case "To: subaccount":
+		switch tx.Type {
+		case "20":
 			record.Type = exchange.TransferIn
 			operation.TransferInHistory = append(operation.TransferInHistory, record)
-		case "From: subaccount":
+		case "21":
 			record.Type = exchange.TransferOut
-			record.Quantity *= -1
 			operation.TransferOutHistory = append(operation.TransferOutHistory, record)
 		default:
 			continue
@@ -240,16 +480,16 @@ func (e *Okex) doWithdraw(operation *exchange.AccountOperation) error {
 		return operation.Error
 	}
 
+	jsonResponse := &JsonResponse{}
 	withdrawResponse := WithdrawResponse{}
-	strRequest := "/api/account/v3/withdrawal"
+	strRequest := "/api/v5/asset/withdrawal"
 
 	mapParams := make(map[string]interface{})
-	mapParams["currency"] = e.GetSymbolByCoin(operation.Coin)
-	mapParams["amount"] = operation.WithdrawAmount
-	mapParams["destination"] = "4"
-	mapParams["to_address"] = operation.WithdrawAddress
-	mapParams["trade_pwd"] = e.TradePassword
-	mapParams["fee"] = e.GetTxFee(operation.Coin)
+	mapParams["ccy"] = e.GetSymbolByCoin(operation.Coin)
+	mapParams["amt"] = operation.WithdrawAmount
+	mapParams["dest"] = "4" // on chain
+	mapParams["toAddr"] = operation.WithdrawAddress
+	mapParams["fee"] = strconv.FormatFloat(e.GetTxFee(operation.Coin), 'f', -1, 64)
 
 	jsonSubmitWithdraw := e.ApiKeyRequest("POST", mapParams, strRequest)
 	if operation.DebugMode {
@@ -257,45 +497,47 @@ func (e *Okex) doWithdraw(operation *exchange.AccountOperation) error {
 		operation.CallResponce = jsonSubmitWithdraw
 	}
 
-	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &withdrawResponse); err != nil {
+	if err := json.Unmarshal([]byte(jsonSubmitWithdraw), &jsonResponse); err != nil {
 		operation.Error = fmt.Errorf("%s Withdraw Json Unmarshal Err: %v, %s", e.GetName(), err, jsonSubmitWithdraw)
 		return operation.Error
-	} else if !withdrawResponse.Result {
+	} else if jsonResponse.Code != "0" {
 		operation.Error = fmt.Errorf("%s Withdraw Failed: %v", e.GetName(), jsonSubmitWithdraw)
 		return operation.Error
-	} else if withdrawResponse.WithdrawalID == "" {
+	} else if err := json.Unmarshal(jsonResponse.Data, &withdrawResponse); err != nil {
+		operation.Error = fmt.Errorf("%s Withdraw Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(withdrawResponse) == 0 || withdrawResponse[0].WdID == "" {
 		operation.Error = fmt.Errorf("%s Withdraw Failed: %v", e.GetName(), jsonSubmitWithdraw)
 		return operation.Error
 	}
 
-	operation.WithdrawID = withdrawResponse.WithdrawalID
+	operation.WithdrawID = withdrawResponse[0].WdID
 
 	return nil
 }
 
+// AssetWallet is the funding account, SpotWallet the trading account of the unified account
+func accountType(wallet exchange.WalletType) string {
+	if wallet == exchange.AssetWallet {
+		return "6"
+	}
+	return "18"
+}
+
 func (e *Okex) transfer(operation *exchange.AccountOperation) error {
 	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
 		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
+	jsonResponse := &JsonResponse{}
 	trans := Transfer{}
-	strRequest := "/api/account/v3/transfer"
+	strRequest := "/api/v5/asset/transfer"
 
 	mapParams := make(map[string]interface{})
-	mapParams["currency"] = e.GetSymbolByCoin(operation.Coin)
-	mapParams["amount"] = operation.TransferAmount
-	switch operation.TransferFrom {
-	case exchange.AssetWallet:
-		mapParams["from"] = "6"
-	case exchange.SpotWallet:
-		mapParams["from"] = "1"
-	}
-	switch operation.TransferDestination {
-	case exchange.AssetWallet:
-		mapParams["to"] = "6"
-	case exchange.SpotWallet:
-		mapParams["to"] = "1"
-	}
+	mapParams["ccy"] = e.GetSymbolByCoin(operation.Coin)
+	mapParams["amt"] = operation.TransferAmount
+	mapParams["from"] = accountType(operation.TransferFrom)
+	mapParams["to"] = accountType(operation.TransferDestination)
 
 	jsonTransferReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 	if operation.DebugMode {
@@ -303,18 +545,15 @@ func (e *Okex) transfer(operation *exchange.AccountOperation) error {
 		operation.CallResponce = jsonTransferReturn
 	}
 
-	if err := json.Unmarshal([]byte(jsonTransferReturn), &trans); err != nil {
-		errorJson := ErrorMsg{}
-		if err := json.Unmarshal([]byte(jsonTransferReturn), &errorJson); err != nil {
-			operation.Error = fmt.Errorf("%s Transfer Err: %v", e.GetName(), jsonTransferReturn)
-			return operation.Error
-		} else {
-			operation.Error = fmt.Errorf("%s Transfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
-			return operation.Error
-		}
-	} else if !trans.Result {
+	if err := json.Unmarshal([]byte(jsonTransferReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s Transfer Json Unmarshal Err: %v, %s", e.GetName(), err, jsonTransferReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
 		operation.Error = fmt.Errorf("%s Transfer failed: %v", e.GetName(), jsonTransferReturn)
 		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &trans); err != nil {
+		operation.Error = fmt.Errorf("%s Transfer Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
 	}
 	log.Printf("%s Transfer return: %+v", e.GetName(), jsonTransferReturn)
 
@@ -323,113 +562,106 @@ func (e *Okex) transfer(operation *exchange.AccountOperation) error {
 
 func (e *Okex) getAllBalance(operation *exchange.AccountOperation) error {
 	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
-		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	balance := AssetBalance{}
-	operation.BalanceList = []exchange.AssetBalance{}
-
-	strRequest := ""
-	switch operation.Wallet {
-	case exchange.AssetWallet:
-		strRequest = "/api/account/v3/wallet" // asset api
-	case exchange.SpotWallet:
-		strRequest = "/api/spot/v3/accounts" // coin api
+	balances, err := e.getAccountBalance(operation, "")
+	if err != nil {
+		operation.Error = fmt.Errorf("%s getAllBalance %v", e.GetName(), err)
+		return operation.Error
 	}
 
-	jsonAllBalanceReturn := e.ApiKeyRequest("GET", nil, strRequest)
-	if operation.DebugMode {
-		operation.RequestURI = strRequest
-		operation.CallResponce = jsonAllBalanceReturn
+	operation.BalanceList = []exchange.AssetBalance{}
+	for _, b := range balances {
+		if b.Coin == nil {
+			continue
+		}
+		operation.BalanceList = append(operation.BalanceList, b)
 	}
 
-	// log.Printf("jsonAllBalanceReturn: %v", jsonAllBalanceReturn) //====================
-	if jsonAllBalanceReturn == "[]" {
-		// log.Printf("getAllBalance empty return: %v", jsonAllBalanceReturn)
-		for _, c := range e.GetCoins() { // set all coin balance to 0
-			b := exchange.AssetBalance{
-				Coin: c,
-			}
-			operation.BalanceList = append(operation.BalanceList, b)
-		}
-		return nil
-	} else if err := json.Unmarshal([]byte(jsonAllBalanceReturn), &balance); err != nil {
-		errorJson := ErrorMsg{}
-		if err := json.Unmarshal([]byte(jsonAllBalanceReturn), &errorJson); err != nil {
-			operation.Error = fmt.Errorf("%s getAllBalance Err: %v", e.GetName(), jsonAllBalanceReturn)
-			return operation.Error
-		} else {
-			operation.Error = fmt.Errorf("%s getAllBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonAllBalanceReturn)
-			return operation.Error
-		}
+	return nil
+}
+
+func (e *Okex) getBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
-	for _, account := range balance {
-		// if account.Balance == "0" {
-		// 	continue
-		// }
-		frozen, err := strconv.ParseFloat(account.Hold, 64)
-		available, err := strconv.ParseFloat(account.Available, 64)
-		if err != nil {
-			return fmt.Errorf("%s balance parse fail: %v %+v", e.GetName(), err, balance)
-		}
+	symbol := e.GetSymbolByCoin(operation.Coin)
+	balances, err := e.getAccountBalance(operation, symbol)
+	if err != nil {
+		operation.Error = fmt.Errorf("%s getBalance %v", e.GetName(), err)
+		return operation.Error
+	}
 
-		b := exchange.AssetBalance{
-			Coin:             e.GetCoinBySymbol(account.Currency),
-			BalanceAvailable: available,
-			BalanceFrozen:    frozen,
+	// coins without balance are not returned
+	operation.BalanceFrozen = 0
+	operation.BalanceAvailable = 0
+	for _, b := range balances {
+		if b.Coin == operation.Coin {
+			operation.BalanceFrozen = b.BalanceFrozen
+			operation.BalanceAvailable = b.BalanceAvailable
 		}
-		operation.BalanceList = append(operation.BalanceList, b)
 	}
 
 	return nil
-	// return fmt.Errorf("%s getBalance get %v account balance fail: %v", e.GetName(), symbol, jsonBalanceReturn)
 }
 
-func (e *Okex) getBalance(operation *exchange.AccountOperation) error {
-	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
-		return fmt.Errorf("%s API Key or Secret Key are nil.", e.GetName())
+// balances of the funding account (AssetWallet) or the trading account, filtered by ccy if not empty
+func (e *Okex) getAccountBalance(operation *exchange.AccountOperation, ccy string) ([]exchange.AssetBalance, error) {
+	jsonResponse := &JsonResponse{}
+	strRequest := "/api/v5/account/balance"
+	if operation.Wallet == exchange.AssetWallet {
+		strRequest = "/api/v5/asset/balances"
 	}
 
-	symbol := e.GetSymbolByCoin(operation.Coin)
-	balance := AssetBalance{}
-	strRequest := fmt.Sprintf("/api/account/v3/wallet/%s", e.GetSymbolByCoin(operation.Coin))
+	mapParams := make(map[string]interface{})
+	if ccy != "" {
+		mapParams["ccy"] = ccy
+	}
 
-	jsonBalanceReturn := e.ApiKeyRequest("GET", nil, strRequest)
+	jsonBalanceReturn := e.ApiKeyRequest("GET", mapParams, strRequest)
 	if operation.DebugMode {
 		operation.RequestURI = strRequest
 		operation.CallResponce = jsonBalanceReturn
 	}
 
-	if jsonBalanceReturn == "[]" {
-		log.Printf("getBalance empty return: %v", jsonBalanceReturn)
-		operation.BalanceFrozen = 0
-		operation.BalanceAvailable = 0
-		return nil
-	} else if err := json.Unmarshal([]byte(jsonBalanceReturn), &balance); err != nil {
-		errorJson := ErrorMsg{}
-		if err := json.Unmarshal([]byte(jsonBalanceReturn), &errorJson); err != nil {
-			operation.Error = fmt.Errorf("%s getBalance Err: %v", e.GetName(), jsonBalanceReturn)
-			return operation.Error
-		} else {
-			operation.Error = fmt.Errorf("%s getBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
-			return operation.Error
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		return nil, fmt.Errorf("Json Unmarshal Err: %v, %s", err, jsonBalanceReturn)
+	} else if jsonResponse.Code != "0" {
+		return nil, fmt.Errorf("Failed: %v", jsonBalanceReturn)
+	}
+
+	result := []exchange.AssetBalance{}
+	if operation.Wallet == exchange.AssetWallet {
+		balance := AssetBalance{}
+		if err := json.Unmarshal(jsonResponse.Data, &balance); err != nil {
+			return nil, fmt.Errorf("Result Unmarshal Err: %v, %s", err, jsonResponse.Data)
+		}
+		for _, account := range balance {
+			b := exchange.AssetBalance{
+				Coin: e.GetCoinBySymbol(account.Ccy),
+			}
+			b.BalanceAvailable, _ = strconv.ParseFloat(account.AvailBal, 64)
+			b.BalanceFrozen, _ = strconv.ParseFloat(account.FrozenBal, 64)
+			result = append(result, b)
 		}
+		return result, nil
 	}
 
+	balance := AccountBalance{}
+	if err := json.Unmarshal(jsonResponse.Data, &balance); err != nil {
+		return nil, fmt.Errorf("Result Unmarshal Err: %v, %s", err, jsonResponse.Data)
+	}
 	for _, account := range balance {
-		if account.Currency == symbol {
-			frozen, err := strconv.ParseFloat(account.Hold, 64)
-			available, err := strconv.ParseFloat(account.Available, 64)
-			if err != nil {
-				return fmt.Errorf("%s balance parse fail: %v %+v", e.GetName(), err, balance)
+		for _, detail := range account.Details {
+			b := exchange.AssetBalance{
+				Coin: e.GetCoinBySymbol(detail.Ccy),
 			}
-			operation.BalanceFrozen = frozen
-			operation.BalanceAvailable = available
-			return nil
+			b.BalanceAvailable, _ = strconv.ParseFloat(detail.AvailBal, 64)
+			b.BalanceFrozen, _ = strconv.ParseFloat(detail.FrozenBal, 64)
+			result = append(result, b)
 		}
 	}
-
-	operation.Error = fmt.Errorf("%s getBalance get %v account balance fail: %v", e.GetName(), symbol, jsonBalanceReturn)
-	return operation.Error
+	return result, nil
 }
@@ -30,145 +30,144 @@ func (e *Okex) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doTickerPrice(operation)
 		}
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
 
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
-// interval options: 1min, 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 12hour, 1day, 1week
-func (e *Okex) doSpotKline(operation *exchange.PublicOperation) error {
-	interval := "300"
-	if operation.KlineInterval != "" {
-		switch operation.KlineInterval {
-		case "1min":
-			interval = "60"
-		case "3min":
-			interval = "180"
-		case "5min":
-			interval = "300"
-		case "15min":
-			interval = "900"
-		case "30min":
-			interval = "1800"
-		case "1hour":
-			interval = "3600"
-		case "2hour":
-			interval = "7200"
-		case "4hour":
-			interval = "14400"
-		case "6hour":
-			interval = "21600"
-		case "12hour":
-			interval = "43200"
-		case "1day":
-			interval = "86400"
-		case "1week":
-			interval = "604800"
-		}
-	}
-
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://www.okex.com/api/spot/v3/instruments/%v/candles?granularity=%v", // ETHBTC
-			e.GetSymbolByPair(operation.Pair), // BTCUSDT
-			interval,
-		),
-		Proxy: operation.Proxy,
-	}
-
-	startTime := time.Unix(operation.KlineStartTime/1000, 0)
-	endTime := time.Unix(operation.KlineEndTime/1000, 0)
-	start := startTime.UTC().Format("2006-01-02T15:04:05.000Z")
-	end := endTime.UTC().Format("2006-01-02T15:04:05.000Z")
-
-	if operation.KlineStartTime != 0 {
-		get.URI += fmt.Sprintf("&start=%v", start)
-	}
-	if operation.KlineEndTime != 0 {
-		get.URI += fmt.Sprintf("&end=%v", end)
-	}
-
-	// log.Printf("url: %v", get.URI) // ***********************
-	err := utils.HttpGetRequest(get)
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1m",
+	exchange.KLINE_3MIN:   "3m",
+	exchange.KLINE_5MIN:   "5m",
+	exchange.KLINE_15MIN:  "15m",
+	exchange.KLINE_30MIN:  "30m",
+	exchange.KLINE_1HOUR:  "1H",
+	exchange.KLINE_2HOUR:  "2H",
+	exchange.KLINE_4HOUR:  "4H",
+	exchange.KLINE_6HOUR:  "6H",
+	exchange.KLINE_12HOUR: "12H",
+	exchange.KLINE_1DAY:   "1D",
+	exchange.KLINE_1WEEK:  "1W",
+}
 
+func (e *Okex) doSpotKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
 	if err != nil {
-		log.Printf("%+v", err)
 		operation.Error = err
 		return err
-
 	}
 
-	if operation.DebugMode {
-		operation.RequestURI = get.URI
-		operation.CallResponce = string(get.ResponseBody)
-	}
+	return exchange.FetchKline(operation, 300, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/api/v5/market/candles?instId=%v&bar=%v&limit=300", // ETH-BTC
+				API_URL,
+				e.GetSymbolByPair(operation.Pair),
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
 
-	jsonKLine := get.ResponseBody
-	var rawKline [][]interface{}
+		// after: records earlier than the ts, before: records newer than the ts, both exclusive
+		if start != 0 {
+			get.URI += fmt.Sprintf("&before=%v", start-1)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&after=%v", end+1)
+		}
 
-	if err := json.Unmarshal([]byte(jsonKLine), &rawKline); err != nil {
-		return fmt.Errorf("%s doSpotKline Json Unmarshal Err: %s %s", e.GetName(), err, jsonKLine)
-	}
+		err := utils.HttpGetRequest(get)
 
-	operation.Kline = []*exchange.KlineDetail{}
-	for i := len(rawKline) - 1; i >= 0; i-- {
-		k := rawKline[i]
-		open, err := strconv.ParseFloat(k[1].(string), 64)
-		if err != nil {
-			log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
-			operation.Error = err
-			return err
-		}
-		high, err := strconv.ParseFloat(k[2].(string), 64)
 		if err != nil {
-			log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[2])
-			operation.Error = err
-			return err
+			log.Printf("%+v", err)
+			return nil, err
+
 		}
-		low, err := strconv.ParseFloat(k[3].(string), 64)
-		if err != nil {
-			log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[3])
-			operation.Error = err
-			return err
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
 		}
-		close, err := strconv.ParseFloat(k[4].(string), 64)
-		if err != nil {
-			log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[4])
-			operation.Error = err
-			return err
+
+		jsonKLine := get.ResponseBody
+		jsonResponse := &JsonResponse{}
+		var rawKline [][]string
+
+		if err := json.Unmarshal([]byte(jsonKLine), &jsonResponse); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %s %s", e.GetName(), err, jsonKLine)
+		} else if jsonResponse.Code != "0" {
+			return nil, fmt.Errorf("%s doSpotKline Failed: %s", e.GetName(), jsonKLine)
 		}
-		volume, err := strconv.ParseFloat(k[5].(string), 64)
-		if err != nil {
-			log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[5])
-			operation.Error = err
-			return err
+		if err := json.Unmarshal(jsonResponse.Data, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Result Unmarshal Err: %s %s", e.GetName(), err, jsonResponse.Data)
 		}
 
-		strTime := k[0].(string)
-		openTime, err := time.Parse("2006-01-02T15:04:05.000Z", strTime) //
-		openTS := float64(openTime.Unix()) * 1000
-
-		detail := &exchange.KlineDetail{
-			Exchange: e.GetName(),
-			Pair:     operation.Pair.Name,
-			OpenTime: openTS,
-			Open:     open,
-			High:     high,
-			Low:      low,
-			Close:    close,
-			Volume:   volume,
-		}
+		// [ts, open, high, low, close, vol, volCcy, ...], newest first
+		details := []*exchange.KlineDetail{}
+		for i := len(rawKline) - 1; i >= 0; i-- {
+			k := rawKline[i]
+			if len(k) < 6 {
+				continue
+			}
+			openTS, err := strconv.ParseFloat(k[0], 64)
+			if err != nil {
+				log.Printf("%s open time parse Err: %v %v", e.GetName(), err, k[0])
+				return nil, err
+			}
+			open, err := strconv.ParseFloat(k[1], 64)
+			if err != nil {
+				log.Printf("%s open parse Err: %v %v", e.GetName(), err, k[1])
+				return nil, err
+			}
+			high, err := strconv.ParseFloat(k[2], 64)
+			if err != nil {
+				log.Printf("%s high parse Err: %v %v", e.GetName(), err, k[2])
+				return nil, err
+			}
+			low, err := strconv.ParseFloat(k[3], 64)
+			if err != nil {
+				log.Printf("%s low parse Err: %v %v", e.GetName(), err, k[3])
+				return nil, err
+			}
+			close, err := strconv.ParseFloat(k[4], 64)
+			if err != nil {
+				log.Printf("%s close parse Err: %v %v", e.GetName(), err, k[4])
+				return nil, err
+			}
+			volume, err := strconv.ParseFloat(k[5], 64)
+			if err != nil {
+				log.Printf("%s volume parse Err: %v %v", e.GetName(), err, k[5])
+				return nil, err
+			}
 
-		operation.Kline = append(operation.Kline, detail)
-	}
+			detail := &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: openTS,
+				Open:     open,
+				High:     high,
+				Low:      low,
+				Close:    close,
+				Volume:   volume,
+			}
 
-	return nil
+			details = append(details, detail)
+		}
+
+		return details, nil
+	})
 }
 
 func (e *Okex) doTickerPrice(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
 	tickerPrice := TickerPrice{}
 
 	get := &utils.HttpGet{
-		URI:       fmt.Sprintf("%s/api/spot/v3/instruments/ticker", API_URL),
+		URI:       fmt.Sprintf("%s/api/v5/market/tickers?instType=SPOT", API_URL),
 		Proxy:     operation.Proxy,
 		DebugMode: operation.DebugMode,
 	}
@@ -183,9 +182,15 @@ func (e *Okex) doTickerPrice(operation *exchange.PublicOperation) error {
 	}
 
 	jsonTickerPrice := get.ResponseBody
-	if err := json.Unmarshal([]byte(jsonTickerPrice), &tickerPrice); err != nil {
+	if err := json.Unmarshal([]byte(jsonTickerPrice), &jsonResponse); err != nil {
 		operation.Error = fmt.Errorf("%s doTickerPrice json Unmarshal error: %v %v", e.GetName(), err, string(jsonTickerPrice))
 		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doTickerPrice failed: %v", e.GetName(), string(jsonTickerPrice))
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &tickerPrice); err != nil {
+		operation.Error = fmt.Errorf("%s doTickerPrice result Unmarshal error: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
 	} else if len(tickerPrice) == 0 {
 		operation.Error = fmt.Errorf("%s doTickerPrice failed, got empty ticker: %v", e.GetName(), string(jsonTickerPrice))
 		return operation.Error
@@ -193,25 +198,25 @@ func (e *Okex) doTickerPrice(operation *exchange.PublicOperation) error {
 
 	operation.TickerPrice = []*exchange.TickerPriceDetail{}
 	for _, tp := range tickerPrice {
-		p := e.GetPairBySymbol(tp.InstrumentID)
+		p := e.GetPairBySymbol(tp.InstID)
 		if p == nil {
 			if operation.DebugMode {
-				log.Printf("doTickerPrice got nil pair for symbol: %v", tp.InstrumentID)
+				log.Printf("doTickerPrice got nil pair for symbol: %v", tp.InstID)
 			}
 			continue
 		} else if p.Name == "" {
 			continue
 		}
 
-		bid, err := strconv.ParseFloat(tp.BestBid, 64)
+		bid, err := strconv.ParseFloat(tp.BidPx, 64)
 		if err != nil {
-			log.Printf("%s doTickerPrice parse Err: %v %v", e.GetName(), err, tp.BestBid)
+			log.Printf("%s doTickerPrice parse Err: %v %v", e.GetName(), err, tp.BidPx)
 			operation.Error = err
 			return err
 		}
-		ask, err := strconv.ParseFloat(tp.BestAsk, 64)
+		ask, err := strconv.ParseFloat(tp.AskPx, 64)
 		if err != nil {
-			log.Printf("%s doTickerPrice parse Err: %v %v", e.GetName(), err, tp.BestAsk)
+			log.Printf("%s doTickerPrice parse Err: %v %v", e.GetName(), err, tp.AskPx)
 			operation.Error = err
 			return err
 		}
@@ -227,7 +232,74 @@ func (e *Okex) doTickerPrice(operation *exchange.PublicOperation) error {
 	return nil
 }
 
+func (e *Okex) doTicker(operation *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
+	ticker := Ticker{}
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/api/v5/market/tickers?instType=SPOT", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doTicker failed: %s", e.GetName(), get.ResponseBody)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Ticker = []*exchange.TickerDetail{}
+	for _, t := range ticker {
+		p := e.GetPairBySymbol(t.InstID)
+		if p == nil {
+			continue
+		}
+
+		// vol24h is in the base currency of okex, the Target coin
+		values, err := exchange.ParseFloats(t.Last, t.BidPx, t.BidSz, t.AskPx, t.AskSz, t.Open24h,
+			t.High24h, t.Low24h, t.Vol24h, t.VolCcy24h, t.Ts)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), t.InstID, err)
+			return operation.Error
+		}
+
+		detail := &exchange.TickerDetail{
+			Pair:            p,
+			Last:            values[0],
+			BestBid:         values[1],
+			BestBidQuantity: values[2],
+			BestAsk:         values[3],
+			BestAskQuantity: values[4],
+			Open:            values[5],
+			High:            values[6],
+			Low:             values[7],
+			Volume:          values[8],
+			QuoteVolume:     values[9],
+			Timestamp:       int64(values[10]),
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
+	}
+
+	return nil
+}
+
 func (e *Okex) doSpotOrderBook(op *exchange.PublicOperation) error {
+	jsonResponse := &JsonResponse{}
 	orderBook := OrderBook{}
 	symbol := e.GetSymbolByPair(op.Pair)
 
@@ -237,14 +309,8 @@ func (e *Okex) doSpotOrderBook(op *exchange.PublicOperation) error {
 		BeforeTimestamp: float64(time.Now().UnixNano() / 1e6),
 	}
 
-	/******
-		// strRequestUrl := fmt.Sprintf("/public/orderbook/%s", e.GetIDByPair(pair))
-		// strUrl := API3_URL + strRequestUrl
-		// jsonOrderbook := exchange.HttpGetRequest(strUrl, nil)
-	********/
-	//!------
 	get := &utils.HttpGet{
-		URI:       fmt.Sprintf("%s/api/spot/v3/instruments/%s/book", API_URL, symbol),
+		URI:       fmt.Sprintf("%s/api/v5/market/books?instId=%s&sz=400", API_URL, symbol),
 		Proxy:     op.Proxy,
 		DebugMode: op.DebugMode,
 	}
@@ -254,21 +320,30 @@ func (e *Okex) doSpotOrderBook(op *exchange.PublicOperation) error {
 	}
 
 	jsonOrderbook := get.ResponseBody
-	//! ###
+	if op.DebugMode {
+		op.RequestURI = get.URI
+		op.CallResponce = string(jsonOrderbook)
+	}
 
-	if err := json.Unmarshal([]byte(jsonOrderbook), &orderBook); err != nil {
+	if err := json.Unmarshal([]byte(jsonOrderbook), &jsonResponse); err != nil {
 		return fmt.Errorf("%s Get Orderbook Json Unmarshal Err: %s %s", e.GetName(), err, jsonOrderbook)
+	} else if jsonResponse.Code != "0" {
+		return fmt.Errorf("%s Get Orderbook Failed: %s", e.GetName(), jsonOrderbook)
+	} else if err := json.Unmarshal(jsonResponse.Data, &orderBook); err != nil {
+		return fmt.Errorf("%s Get Orderbook Result Unmarshal Err: %s %s", e.GetName(), err, jsonResponse.Data)
+	} else if len(orderBook) == 0 {
+		return fmt.Errorf("%s Get Orderbook got empty return: %s", e.GetName(), jsonOrderbook)
 	}
 
 	maker.AfterTimestamp = float64(time.Now().UnixNano() / 1e6)
-	for _, bid := range orderBook.Bids {
+	for _, bid := range orderBook[0].Bids {
 		var buydata exchange.Order
 		buydata.Rate, _ = strconv.ParseFloat(bid[0], 64)
 		buydata.Quantity, _ = strconv.ParseFloat(bid[1], 64)
 
 		maker.Bids = append(maker.Bids, buydata)
 	}
-	for _, ask := range orderBook.Asks {
+	for _, ask := range orderBook[0].Asks {
 		var selldata exchange.Order
 		selldata.Rate, _ = strconv.ParseFloat(ask[0], 64)
 		selldata.Quantity, _ = strconv.ParseFloat(ask[1], 64)
@@ -281,10 +356,14 @@ func (e *Okex) doSpotOrderBook(op *exchange.PublicOperation) error {
 }
 
 func (e *Okex) doTradeHistory(operation *exchange.PublicOperation) error {
+	if operation.IsTradePaged() {
+		return e.doTradePages(operation)
+	}
 	symbol := e.GetSymbolByPair(operation.Pair)
 
 	get := &utils.HttpGet{
-		URI: fmt.Sprintf("%s/api/spot/v3/instruments/%s/trades", API_URL, symbol),
+		URI:   fmt.Sprintf("%s/api/v5/market/trades?instId=%s&limit=500", API_URL, symbol),
+		Proxy: operation.Proxy,
 	}
 
 	err := utils.HttpGetRequest(get)
@@ -293,14 +372,19 @@ func (e *Okex) doTradeHistory(operation *exchange.PublicOperation) error {
 		return err
 
 	} else {
+		jsonResponse := &JsonResponse{}
 		tradeHistory := TradeHistory{}
-		if err := json.Unmarshal(get.ResponseBody, &tradeHistory); err != nil {
+		if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+			return err
+		} else if jsonResponse.Code != "0" {
+			return fmt.Errorf("%s doTradeHistory Failed: %s", e.GetName(), get.ResponseBody)
+		} else if err := json.Unmarshal(jsonResponse.Data, &tradeHistory); err != nil {
 			return err
 		}
 
+		// newest first
 		operation.TradeHistory = []*exchange.TradeDetail{}
-		for i := len(tradeHistory) - 1; i > 0; i-- {
-			// for _, d := range *tradeHistory {
+		for i := len(tradeHistory) - 1; i >= 0; i-- {
 			d := tradeHistory[i]
 			td := &exchange.TradeDetail{}
 
@@ -311,10 +395,9 @@ func (e *Okex) doTradeHistory(operation *exchange.PublicOperation) error {
 				td.Direction = exchange.Sell
 			}
 
-			td.Quantity, err = strconv.ParseFloat(d.Size, 64)
-			td.Rate, err = strconv.ParseFloat(d.Price, 64)
-
-			td.TimeStamp = d.Timestamp.UnixNano() / 1e6
+			td.Quantity, err = strconv.ParseFloat(d.Sz, 64)
+			td.Rate, err = strconv.ParseFloat(d.Px, 64)
+			td.TimeStamp, err = strconv.ParseInt(d.Ts, 10, 64)
 
 			operation.TradeHistory = append(operation.TradeHistory, td)
 		}
@@ -322,3 +405,69 @@ func (e *Okex) doTradeHistory(operation *exchange.PublicOperation) error {
 
 	return nil
 }
+
+// history-trades goes back from the newest trade or from a time with type=2, then by the smallest tradeId of a page with type=1
+func (e *Okex) doTradePages(operation *exchange.PublicOperation) error {
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	return exchange.FetchTrades(operation, true, func(cursor string) ([]*exchange.TradeDetail, string, error) {
+		jsonResponse := &JsonResponse{}
+		tradeHistory := TradeHistory{}
+
+		get := &utils.HttpGet{
+			URI:   fmt.Sprintf("%s/api/v5/market/history-trades?instId=%s&limit=100", API_URL, symbol),
+			Proxy: operation.Proxy,
+		}
+		if cursor != "" {
+			get.URI += fmt.Sprintf("&type=1&after=%s", cursor)
+		} else if operation.TradeEndTime != 0 {
+			get.URI += fmt.Sprintf("&type=2&after=%d", operation.TradeEndTime+1)
+		}
+		if err := utils.HttpGetRequest(get); err != nil {
+			return nil, "", err
+		}
+
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+			return nil, "", fmt.Errorf("%s doTradePages Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
+		} else if jsonResponse.Code != "0" {
+			return nil, "", fmt.Errorf("%s doTradePages Failed: %s", e.GetName(), get.ResponseBody)
+		} else if err := json.Unmarshal(jsonResponse.Data, &tradeHistory); err != nil {
+			return nil, "", fmt.Errorf("%s doTradePages Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		}
+
+		next := ""
+		minID := int64(0)
+		trades := []*exchange.TradeDetail{}
+		for _, d := range tradeHistory {
+			td := &exchange.TradeDetail{
+				ID: d.TradeID,
+			}
+			if d.Side == "buy" {
+				td.Direction = exchange.Buy
+			} else if d.Side == "sell" {
+				td.Direction = exchange.Sell
+			}
+
+			var err error
+			if td.Quantity, err = strconv.ParseFloat(d.Sz, 64); err != nil {
+				return nil, "", fmt.Errorf("%s amount parse Err: %v %v", e.GetName(), err, d.Sz)
+			} else if td.Rate, err = strconv.ParseFloat(d.Px, 64); err != nil {
+				return nil, "", fmt.Errorf("%s price parse Err: %v %v", e.GetName(), err, d.Px)
+			} else if td.TimeStamp, err = strconv.ParseInt(d.Ts, 10, 64); err != nil {
+				return nil, "", fmt.Errorf("%s ts parse Err: %v %v", e.GetName(), err, d.Ts)
+			}
+			trades = append(trades, td)
+
+			if id, err := strconv.ParseInt(d.TradeID, 10, 64); err == nil && (minID == 0 || id < minID) {
+				minID = id
+				next = d.TradeID
+			}
+		}
+		return trades, next, nil
+	})
+}
@@ -0,0 +1,377 @@
+package okexdm
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+// BTC-USD-SWAP -> SWAP, BTC-USD-240329 -> FUTURES
+func getInstType(instID string) string {
+	if strings.HasSuffix(instID, "-SWAP") {
+		return "SWAP"
+	}
+	return "FUTURES"
+}
+
+// margin mode of the order, cross if MarginMode empty
+func getTradeMode(operation *exchange.AccountOperation) string {
+	if operation.MarginMode == exchange.IsolatedMargin {
+		return "isolated"
+	}
+	return "cross"
+}
+
+// posSide is required in long_short_mode, from PositionSide or from the Offset and the direction.
+// Empty in net_mode
+func getPosSide(operation *exchange.AccountOperation) string {
+	switch operation.PositionSide {
+	case exchange.PositionLong:
+		return "long"
+	case exchange.PositionShort:
+		return "short"
+	}
+
+	buy := operation.OrderDirection == exchange.Buy
+	switch operation.Offset {
+	case exchange.OPEN:
+		if buy {
+			return "long"
+		}
+		return "short"
+	case exchange.CLOSE:
+		if buy {
+			return "short"
+		}
+		return "long"
+	}
+	return ""
+}
+
+// Quantity is the number of contracts. OrderType: GTC, IOC, FOK, GTX (post only)
+func (e *Okexdm) doContractPlaceOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	if operation.Pair == nil {
+		return fmt.Errorf("%s ContractPlaceOrder Pair is empty", e.GetName())
+	} else if operation.OrderDirection == "" {
+		return fmt.Errorf("%s ContractPlaceOrder empty OrderDirection: %+v", e.GetName(), operation)
+	} else if operation.TradeType == "" {
+		return fmt.Errorf("%s ContractPlaceOrder empty TradeType: %+v", e.GetName(), operation)
+	}
+
+	jsonResponse := &JsonResponse{}
+	placeOrder := PlaceOrder{}
+	strRequest := "/api/v5/trade/order"
+
+	mapParams := make(map[string]string)
+	mapParams["instId"] = e.GetSymbolByPair(operation.Pair)
+	mapParams["tdMode"] = getTradeMode(operation)
+	mapParams["sz"] = e.GetPairConstraint(operation.Pair).FormatQuantity(operation.Quantity)
+	if posSide := getPosSide(operation); posSide != "" {
+		mapParams["posSide"] = posSide
+	}
+	if operation.OrderDirection == exchange.Buy {
+		mapParams["side"] = "buy"
+	} else {
+		mapParams["side"] = "sell"
+	}
+	if operation.TradeType == exchange.TRADE_MARKET {
+		mapParams["ordType"] = "market"
+	} else {
+		switch operation.OrderType {
+		case exchange.IOC:
+			mapParams["ordType"] = "ioc"
+		case exchange.FOK:
+			mapParams["ordType"] = "fok"
+		case exchange.GTX:
+			mapParams["ordType"] = "post_only"
+		default:
+			mapParams["ordType"] = "limit"
+		}
+		mapParams["px"] = e.GetPairConstraint(operation.Pair).FormatRate(operation.Rate)
+	}
+
+	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPlaceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPlaceReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &placeOrder); err != nil {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(placeOrder) == 0 || placeOrder[0].OrdID == "" {
+		operation.Error = fmt.Errorf("%s ContractPlaceOrder Failed: %v", e.GetName(), jsonPlaceReturn)
+		return operation.Error
+	}
+
+	operation.Order = &exchange.Order{
+		Pair:         operation.Pair,
+		OrderID:      placeOrder[0].OrdID,
+		Rate:         operation.Rate,
+		Quantity:     operation.Quantity,
+		Direction:    operation.OrderDirection,
+		Status:       exchange.New,
+		JsonResponse: jsonPlaceReturn,
+	}
+
+	return nil
+}
+
+func (e *Okexdm) doContractOrderStatus(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	order := operation.Order
+	jsonResponse := &JsonResponse{}
+	orderStatus := []OrderDetail{}
+	strRequest := "/api/v5/trade/order"
+
+	mapParams := make(map[string]string)
+	mapParams["instId"] = e.GetSymbolByPair(order.Pair)
+	mapParams["ordId"] = order.OrderID
+
+	jsonOrderStatus := e.ApiKeyGet(strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonOrderStatus
+	}
+
+	if err := json.Unmarshal([]byte(jsonOrderStatus), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Json Unmarshal Err: %v, %s", e.GetName(), err, jsonOrderStatus)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Failed: %v", e.GetName(), jsonOrderStatus)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &orderStatus); err != nil {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	} else if len(orderStatus) == 0 {
+		operation.Error = fmt.Errorf("%s ContractOrderStatus got empty return: %v", e.GetName(), jsonOrderStatus)
+		return operation.Error
+	}
+
+	result := e.toOrder(orderStatus[0])
+	order.Status = result.Status
+	order.DealRate = result.DealRate
+	order.DealQuantity = result.DealQuantity
+	order.JsonResponse = jsonOrderStatus
+
+	return nil
+}
+
+func (e *Okexdm) doContractCancelOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	order := operation.Order
+	jsonResponse := &JsonResponse{}
+	cancelOrder := PlaceOrder{}
+	strRequest := "/api/v5/trade/cancel-order"
+
+	mapParams := make(map[string]string)
+	mapParams["instId"] = e.GetSymbolByPair(order.Pair)
+	mapParams["ordId"] = order.OrderID
+
+	jsonCancelOrder := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonCancelOrder
+	}
+
+	if err := json.Unmarshal([]byte(jsonCancelOrder), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Json Unmarshal Err: %v, %s", e.GetName(), err, jsonCancelOrder)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Failed: %v", e.GetName(), jsonCancelOrder)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &cancelOrder); err != nil {
+		operation.Error = fmt.Errorf("%s ContractCancelOrder Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	order.Status = exchange.Canceling
+	order.CancelStatus = jsonCancelOrder
+
+	return nil
+}
+
+// open orders of all the futures and swaps if Pair is nil
+func (e *Okexdm) doContractGetOpenOrder(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	orders, err := e.getOrders(operation, "/api/v5/trade/orders-pending")
+	if err != nil {
+		operation.Error = fmt.Errorf("%s ContractGetOpenOrder %v", e.GetName(), err)
+		return operation.Error
+	}
+	operation.OpenOrders = orders
+
+	return nil
+}
+
+// completed orders of the last 7 days
+func (e *Okexdm) doContractGetOrderHistory(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	orders, err := e.getOrders(operation, "/api/v5/trade/orders-history")
+	if err != nil {
+		operation.Error = fmt.Errorf("%s ContractGetOrderHistory %v", e.GetName(), err)
+		return operation.Error
+	}
+	operation.OrderHistory = orders
+
+	return nil
+}
+
+// the list is paged by the last ordId, 100 orders per page
+func (e *Okexdm) getOrders(operation *exchange.AccountOperation, strRequest string) ([]*exchange.Order, error) {
+	instTypes := []string{"FUTURES", "SWAP"}
+	if operation.Pair != nil {
+		instTypes = []string{getInstType(e.GetSymbolByPair(operation.Pair))}
+	}
+
+	result := []*exchange.Order{}
+	for _, instType := range instTypes {
+		after := ""
+		for {
+			jsonResponse := &JsonResponse{}
+			orders := []OrderDetail{}
+
+			mapParams := make(map[string]string)
+			mapParams["instType"] = instType
+			if operation.Pair != nil {
+				mapParams["instId"] = e.GetSymbolByPair(operation.Pair)
+			}
+			if after != "" {
+				mapParams["after"] = after
+			}
+
+			jsonOrders := e.ApiKeyGet(strRequest, mapParams)
+			if operation.DebugMode {
+				operation.RequestURI = strRequest
+				operation.CallResponce = jsonOrders
+			}
+
+			if err := json.Unmarshal([]byte(jsonOrders), &jsonResponse); err != nil {
+				return nil, fmt.Errorf("Json Unmarshal Err: %v, %s", err, jsonOrders)
+			} else if jsonResponse.Code != "0" {
+				return nil, fmt.Errorf("Failed: %v", jsonOrders)
+			} else if err := json.Unmarshal(jsonResponse.Data, &orders); err != nil {
+				return nil, fmt.Errorf("Result Unmarshal Err: %v, %s", err, jsonResponse.Data)
+			}
+
+			for _, data := range orders {
+				result = append(result, e.toOrder(data))
+			}
+
+			if len(orders) < 100 {
+				break
+			}
+			after = orders[len(orders)-1].OrdID
+		}
+	}
+
+	return result, nil
+}
+
+func (e *Okexdm) toOrder(data OrderDetail) *exchange.Order {
+	order := &exchange.Order{
+		Pair:    e.GetPairBySymbol(data.InstID),
+		OrderID: data.OrdID,
+	}
+	order.Timestamp, _ = strconv.ParseInt(data.CTime, 10, 64)
+
+	switch data.Side {
+	case "buy":
+		order.Direction = exchange.Buy
+	case "sell":
+		order.Direction = exchange.Sell
+	}
+
+	order.Quantity, _ = strconv.ParseFloat(data.Sz, 64)
+	order.Rate, _ = strconv.ParseFloat(data.Px, 64)
+	order.DealQuantity, _ = strconv.ParseFloat(data.AccFillSz, 64)
+	order.DealRate, _ = strconv.ParseFloat(data.AvgPx, 64)
+
+	switch data.State {
+	case "live":
+		order.Status = exchange.New
+	case "partially_filled":
+		order.Status = exchange.Partial
+	case "filled":
+		order.Status = exchange.Filled
+	case "canceled", "mmp_canceled":
+		order.Status = exchange.Cancelled
+	default:
+		order.Status = exchange.Other
+	}
+
+	return order
+}
+
+// the trading account of the unified account is shared by spot, margin, futures and swaps
+func (e *Okexdm) doContractAllBalance(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	accountBalance := AccountBalance{}
+	strRequest := "/api/v5/account/balance"
+
+	jsonBalanceReturn := e.ApiKeyGet(strRequest, nil)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonBalanceReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonBalanceReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s ContractAllBalance Json Unmarshal Err: %v, %s", e.GetName(), err, jsonBalanceReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s ContractAllBalance Failed: %v", e.GetName(), jsonBalanceReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &accountBalance); err != nil {
+		operation.Error = fmt.Errorf("%s ContractAllBalance Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.BalanceList = []exchange.AssetBalance{}
+	for _, account := range accountBalance {
+		for _, detail := range account.Details {
+			c := e.GetCoinBySymbol(detail.Ccy)
+			if c == nil {
+				continue
+			}
+
+			b := exchange.AssetBalance{
+				Coin: c,
+			}
+			b.Balance, _ = strconv.ParseFloat(detail.Eq, 64)
+			b.BalanceAvailable, _ = strconv.ParseFloat(detail.AvailEq, 64)
+			b.BalanceFrozen, _ = strconv.ParseFloat(detail.FrozenBal, 64)
+			operation.BalanceList = append(operation.BalanceList, b)
+		}
+	}
+
+	return nil
+}
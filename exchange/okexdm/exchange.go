@@ -9,6 +9,7 @@ import (
 	"log"
 	"sort"
 	"strconv"
+	"strings"
 	"sync"
 
 	cmap "github.com/orcaman/concurrent-map"
@@ -26,6 +27,7 @@ type Okexdm struct {
 
 	API_KEY    string
 	API_SECRET string
+	Passphrase string
 
 	Source    exchange.DataSource // / exchange API / microservicve api 1 / PSQL
 	SourceURI string
@@ -44,10 +46,11 @@ func CreateOkexdm(config *exchange.Config) *Okexdm {
 		instance = &Okexdm{
 			ID:      DEFAULT_ID,
 			Name:    "Okexdm",
-			Website: "https://www.okex.com",
+			Website: "https://www.okx.com",
 
 			API_KEY:    config.API_KEY,
 			API_SECRET: config.API_SECRET,
+			Passphrase: config.Passphrase,
 			Source:     config.Source,
 			SourceURI:  config.SourceURI,
 		}
@@ -102,7 +105,11 @@ func (e *Okexdm) GetName() exchange.ExchangeName {
 }
 
 func (e *Okexdm) GetTradingWebURL(pair *pair.Pair) string {
-	return fmt.Sprintf("https://www.okex.com/future/trade" /* , e.GetSymbolByCoin(pair.Target), e.GetSymbolByCoin(pair.Base) */)
+	symbol := e.GetSymbolByPair(pair)
+	if getInstType(symbol) == "SWAP" {
+		return fmt.Sprintf("https://www.okx.com/trade-swap/%s", strings.ToLower(symbol))
+	}
+	return fmt.Sprintf("https://www.okx.com/trade-futures/%s", strings.ToLower(symbol))
 }
 
 func (e *Okexdm) GetBalance(coin *coin.Coin) float64 {
@@ -233,7 +240,7 @@ func (e *Okexdm) DeletePair(pair *pair.Pair) {
 func (e *Okexdm) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetchMethod {
 	constrainFetchMethod := &exchange.ConstrainFetchMethod{}
 	constrainFetchMethod.PublicAPI = true
-	constrainFetchMethod.PrivateAPI = false
+	constrainFetchMethod.PrivateAPI = true
 	constrainFetchMethod.HealthAPI = false
 	constrainFetchMethod.HasWithdraw = false
 	constrainFetchMethod.HasTransfer = false
@@ -249,6 +256,38 @@ func (e *Okexdm) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.FundingRate:  {exchange.ContractWallet},
+		exchange.Orderbook:    {exchange.ContractWallet},
+		exchange.KLine:        {exchange.ContractWallet},
+		exchange.TradeHistory: {exchange.ContractWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.BalanceList:          {exchange.ContractWallet},
+		exchange.PlaceOrder:           {exchange.ContractWallet},
+		exchange.GetOrderStatus:       {exchange.ContractWallet},
+		exchange.CancelOrder:          {exchange.ContractWallet},
+		exchange.GetOpenOrder:         {exchange.ContractWallet},
+		exchange.GetOrderHistory:      {exchange.ContractWallet},
+		exchange.GetPositions:         {exchange.ContractWallet},
+		exchange.SetLeverage:          {exchange.ContractWallet},
+		exchange.SetMarginType:        {exchange.ContractWallet},
+		exchange.SetPositionMode:      {exchange.ContractWallet},
+		exchange.AdjustIsolatedMargin: {exchange.ContractWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.ContractWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.ContractWallet: {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+	},
+}
+
+func (e *Okexdm) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Okexdm) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -2,71 +2,127 @@ package okexdm
 
 import (
 	"encoding/json"
-	"time"
 )
 
 // Copyright (c) 2015-2019 Bitontop Technologies Inc.
 // Distributed under the MIT software license, see the accompanying
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
+// v5 response wrapper, code "0" is success
 type JsonResponse struct {
-	Success bool            `json:"success"`
-	Message string          `json:"message"`
-	Data    json.RawMessage `json:"data"`
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
 }
 
 /********** Public API Structure**********/
-type ContractsData []struct {
-	InstrumentID string    `json:"instrument_id"`
-	Last         string    `json:"last"`
-	BestBid      string    `json:"best_bid"`
-	BestAsk      string    `json:"best_ask"`
-	High24H      string    `json:"high_24h"`
-	Low24H       string    `json:"low_24h"`
-	Volume24H    string    `json:"volume_24h"`
-	Timestamp    time.Time `json:"timestamp"`
+// futures and swaps, alias of futures: this_week, next_week, quarter, next_quarter
+type InstrumentsData []struct {
+	InstID    string `json:"instId"`
+	InstType  string `json:"instType"`
+	Uly       string `json:"uly"`
+	SettleCcy string `json:"settleCcy"`
+	CtVal     string `json:"ctVal"`
+	CtValCcy  string `json:"ctValCcy"`
+	CtType    string `json:"ctType"`
+	Alias     string `json:"alias"`
+	LotSz     string `json:"lotSz"`
+	TickSz    string `json:"tickSz"`
+	MinSz     string `json:"minSz"`
+	State     string `json:"state"`
+	ExpTime   string `json:"expTime"`
 }
 
-type PairsData []struct {
-	Symbol      string  `json:"symbol"`
-	Status      string  `json:"status"`
-	BaseAsset   string  `json:"baseAsset"`
-	QuoteAsset  string  `json:"quoteAsset"`
-	MakerFee    float64 `json:"makerFee"`
-	TakerFee    float64 `json:"takerFee"`
-	PriceFilter float64 `json:"priceFilter"`
-	LotSize     float64 `json:"lotSize"`
+// asks and bids are [price, size, deprecated, number of orders]
+type OrderBook []struct {
+	Asks [][]string `json:"asks"`
+	Bids [][]string `json:"bids"`
+	Ts   string     `json:"ts"`
 }
 
-type OrderBook struct {
-	Bids      [][]string `json:"bids"`
-	Asks      [][]string `json:"asks"`
-	Timestamp time.Time  `json:"timestamp"`
+type FundingRate []struct {
+	InstID          string `json:"instId"`
+	FundingRate     string `json:"fundingRate"`
+	NextFundingRate string `json:"nextFundingRate"`
+	FundingTime     string `json:"fundingTime"`
+	NextFundingTime string `json:"nextFundingTime"`
+}
+
+type FundingHistory []struct {
+	InstID       string `json:"instId"`
+	FundingRate  string `json:"fundingRate"`
+	RealizedRate string `json:"realizedRate"`
+	FundingTime  string `json:"fundingTime"`
+}
+
+type TradeHistory []struct {
+	InstID  string `json:"instId"`
+	TradeID string `json:"tradeId"`
+	Px      string `json:"px"`
+	Sz      string `json:"sz"`
+	Side    string `json:"side"`
+	Ts      string `json:"ts"`
 }
 
 /********** Private API Structure**********/
-type AccountBalances []struct {
-	Asset     string  `json:"asset"`
-	Total     float64 `json:"total"`
-	Available float64 `json:"available"`
-	Locked    float64 `json:"locked"`
+// trading account of the unified account
+type AccountBalance []struct {
+	TotalEq string `json:"totalEq"`
+	Details []struct {
+		Ccy       string `json:"ccy"`
+		Eq        string `json:"eq"`
+		AvailBal  string `json:"availBal"`
+		AvailEq   string `json:"availEq"`
+		FrozenBal string `json:"frozenBal"`
+	} `json:"details"`
+}
+
+// posSide: long, short, or net in net_mode where the sign of pos is the direction
+type Positions []struct {
+	InstID      string `json:"instId"`
+	InstType    string `json:"instType"`
+	MgnMode     string `json:"mgnMode"`
+	PosSide     string `json:"posSide"`
+	Pos         string `json:"pos"`
+	AvgPx       string `json:"avgPx"`
+	MarkPx      string `json:"markPx"`
+	LiqPx       string `json:"liqPx"`
+	Lever       string `json:"lever"`
+	Upl         string `json:"upl"`
+	RealizedPnl string `json:"realizedPnl"`
+	UTime       string `json:"uTime"`
+}
+
+type PlaceOrder []struct {
+	OrdID   string `json:"ordId"`
+	ClOrdID string `json:"clOrdId"`
+	SCode   string `json:"sCode"`
+	SMsg    string `json:"sMsg"`
 }
 
-type WithdrawResponse struct {
-	Msg     string `json:"msg"`
-	Success bool   `json:"success"`
-	ID      string `json:"id"`
+type OrderDetail struct {
+	InstID    string `json:"instId"`
+	OrdID     string `json:"ordId"`
+	Px        string `json:"px"`
+	Sz        string `json:"sz"`
+	Side      string `json:"side"`
+	PosSide   string `json:"posSide"`
+	OrdType   string `json:"ordType"`
+	TdMode    string `json:"tdMode"`
+	State     string `json:"state"`
+	AvgPx     string `json:"avgPx"`
+	AccFillSz string `json:"accFillSz"`
+	CTime     string `json:"cTime"`
+	UTime     string `json:"uTime"`
 }
 
-type PlaceOrder struct {
-	Symbol       string `json:"symbol"`
-	OrderID      string `json:"orderId"`
-	Side         string `json:"side"`
-	Type         string `json:"type"`
-	Price        string `json:"price"`
-	AveragePrice string `json:"executedQty"`
-	OrigQty      string `json:"origQty"`
-	ExecutedQty  string `json:"executedQty"`
-	Status       string `json:"status"`
-	TimeInForce  string `json:"timeInForce"`
+// response of the leverage, position mode and position margin settings
+type AccountSetting []struct {
+	InstID  string `json:"instId"`
+	Lever   string `json:"lever"`
+	MgnMode string `json:"mgnMode"`
+	PosSide string `json:"posSide"`
+	PosMode string `json:"posMode"`
+	Amt     string `json:"amt"`
+	Type    string `json:"type"`
 }
@@ -0,0 +1,285 @@
+package okexdm
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Okexdm) DoAccountOperation(operation *exchange.AccountOperation) error {
+	switch operation.Type {
+	case exchange.BalanceList:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractAllBalance(operation)
+		}
+	case exchange.PlaceOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractPlaceOrder(operation)
+		}
+	case exchange.GetOrderStatus:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractOrderStatus(operation)
+		}
+	case exchange.CancelOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractCancelOrder(operation)
+		}
+	case exchange.GetOpenOrder:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractGetOpenOrder(operation)
+		}
+	case exchange.GetOrderHistory:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doContractGetOrderHistory(operation)
+		}
+	case exchange.GetPositions:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doGetPositions(operation)
+		}
+	case exchange.SetLeverage:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetLeverage(operation)
+		}
+	case exchange.SetMarginType:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetMarginType(operation)
+		}
+	case exchange.SetPositionMode:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doSetPositionMode(operation)
+		}
+	case exchange.AdjustIsolatedMargin:
+		if operation.Wallet == exchange.ContractWallet {
+			return e.doAdjustIsolatedMargin(operation)
+		}
+	}
+	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
+}
+
+// futures and swap positions, only the Pair if set
+func (e *Okexdm) doGetPositions(operation *exchange.AccountOperation) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	positions := Positions{}
+	strRequest := "/api/v5/account/positions"
+
+	mapParams := make(map[string]string)
+	if operation.Pair != nil {
+		mapParams["instId"] = e.GetSymbolByPair(operation.Pair)
+	}
+
+	jsonPositionReturn := e.ApiKeyGet(strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonPositionReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonPositionReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s doGetPositions Json Unmarshal Err: %v, %s", e.GetName(), err, jsonPositionReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s doGetPositions Failed: %v", e.GetName(), jsonPositionReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &positions); err != nil {
+		operation.Error = fmt.Errorf("%s doGetPositions Result Unmarshal Err: %v, %s", e.GetName(), err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	operation.Positions = []*exchange.Position{}
+	for _, p := range positions {
+		if p.InstType != "FUTURES" && p.InstType != "SWAP" {
+			continue
+		}
+		size, _ := strconv.ParseFloat(p.Pos, 64)
+		if size == 0 {
+			continue
+		}
+
+		position := &exchange.Position{
+			Pair:       e.GetPairBySymbol(p.InstID),
+			Instrument: p.InstID,
+			Size:       math.Abs(size),
+		}
+		position.Timestamp, _ = strconv.ParseInt(p.UTime, 10, 64)
+		position.EntryPrice, _ = strconv.ParseFloat(p.AvgPx, 64)
+		position.MarkPrice, _ = strconv.ParseFloat(p.MarkPx, 64)
+		position.LiquidationPrice, _ = strconv.ParseFloat(p.LiqPx, 64)
+		position.Leverage, _ = strconv.ParseFloat(p.Lever, 64)
+		position.UnrealizedPnl, _ = strconv.ParseFloat(p.Upl, 64)
+		position.RealizedPnl, _ = strconv.ParseFloat(p.RealizedPnl, 64)
+
+		switch p.PosSide {
+		case "long":
+			position.Side = exchange.PositionLong
+		case "short":
+			position.Side = exchange.PositionShort
+		default: // net
+			if size > 0 {
+				position.Side = exchange.PositionLong
+			} else {
+				position.Side = exchange.PositionShort
+			}
+		}
+
+		if p.MgnMode == "isolated" {
+			position.MarginMode = exchange.IsolatedMargin
+		} else {
+			position.MarginMode = exchange.CrossMargin
+		}
+
+		operation.Positions = append(operation.Positions, position)
+	}
+
+	return nil
+}
+
+// cross: leverage of the instrument, isolated (MarginMode): leverage of the instrument,
+// PositionSide is needed in long_short_mode
+func (e *Okexdm) doSetLeverage(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s SetLeverage Pair is empty", e.GetName())
+	} else if operation.Leverage < 1 || operation.Leverage > 125 {
+		return fmt.Errorf("%s SetLeverage is between 1 to 125: %d", e.GetName(), operation.Leverage)
+	}
+
+	instrumentID := e.GetSymbolByPair(operation.Pair)
+	strRequest := "/api/v5/account/set-leverage"
+
+	mapParams := make(map[string]string)
+	mapParams["instId"] = instrumentID
+	mapParams["lever"] = fmt.Sprintf("%d", operation.Leverage)
+	mapParams["mgnMode"] = getTradeMode(operation)
+	if operation.MarginMode == exchange.IsolatedMargin {
+		switch operation.PositionSide {
+		case exchange.PositionLong:
+			mapParams["posSide"] = "long"
+		case exchange.PositionShort:
+			mapParams["posSide"] = "short"
+		}
+	}
+
+	if err := e.accountSetting(operation, strRequest, mapParams); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: instrumentID,
+		Leverage:   float64(operation.Leverage),
+		MarginMode: exchange.CrossMargin,
+	}
+	if operation.MarginMode == exchange.IsolatedMargin {
+		operation.LeverageSetting.MarginMode = exchange.IsolatedMargin
+	}
+
+	return nil
+}
+
+// the margin mode is chosen per order with tdMode (AccountOperation.MarginMode), there is no account setting
+func (e *Okexdm) doSetMarginType(operation *exchange.AccountOperation) error {
+	return fmt.Errorf("%s SetMarginType %v: %w", e.GetName(), operation.MarginMode, exchange.ErrNotSupported)
+}
+
+// HedgeMode: long_short_mode, OneWayMode: net_mode. For the whole account
+func (e *Okexdm) doSetPositionMode(operation *exchange.AccountOperation) error {
+	strRequest := "/api/v5/account/set-position-mode"
+
+	mapParams := make(map[string]string)
+	switch operation.PositionMode {
+	case exchange.HedgeMode:
+		mapParams["posMode"] = "long_short_mode"
+	case exchange.OneWayMode:
+		mapParams["posMode"] = "net_mode"
+	default:
+		return fmt.Errorf("%s SetPositionMode invalid PositionMode: %v", e.GetName(), operation.PositionMode)
+	}
+
+	if err := e.accountSetting(operation, strRequest, mapParams); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		PositionMode: operation.PositionMode,
+	}
+
+	return nil
+}
+
+// MarginAmount in the settle coin of the isolated position, PositionSide: Long or Short in long_short_mode, empty in net_mode
+func (e *Okexdm) doAdjustIsolatedMargin(operation *exchange.AccountOperation) error {
+	if operation.Pair == nil {
+		return fmt.Errorf("%s AdjustIsolatedMargin Pair is empty", e.GetName())
+	} else if operation.MarginAmount == 0 {
+		return fmt.Errorf("%s AdjustIsolatedMargin MarginAmount is 0", e.GetName())
+	}
+
+	instrumentID := e.GetSymbolByPair(operation.Pair)
+	strRequest := "/api/v5/account/position/margin-balance"
+
+	mapParams := make(map[string]string)
+	mapParams["instId"] = instrumentID
+	mapParams["amt"] = strconv.FormatFloat(math.Abs(operation.MarginAmount), 'f', -1, 64)
+	switch operation.PositionSide {
+	case exchange.PositionLong:
+		mapParams["posSide"] = "long"
+	case exchange.PositionShort:
+		mapParams["posSide"] = "short"
+	default:
+		mapParams["posSide"] = "net"
+	}
+	if operation.MarginAmount > 0 {
+		mapParams["type"] = "add"
+	} else {
+		mapParams["type"] = "reduce"
+	}
+
+	if err := e.accountSetting(operation, strRequest, mapParams); err != nil {
+		return err
+	}
+
+	operation.LeverageSetting = &exchange.LeverageSetting{
+		Pair:       operation.Pair,
+		Instrument: instrumentID,
+		MarginMode: exchange.IsolatedMargin,
+	}
+
+	return nil
+}
+
+func (e *Okexdm) accountSetting(operation *exchange.AccountOperation, strRequest string, mapParams map[string]string) error {
+	if e.API_KEY == "" || e.API_SECRET == "" || e.Passphrase == "" {
+		return fmt.Errorf("%s API Key or Secret Key or passphrase are nil.", e.GetName())
+	}
+
+	jsonResponse := &JsonResponse{}
+	setting := AccountSetting{}
+
+	jsonSettingReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
+	if operation.DebugMode {
+		operation.RequestURI = strRequest
+		operation.CallResponce = jsonSettingReturn
+	}
+
+	if err := json.Unmarshal([]byte(jsonSettingReturn), &jsonResponse); err != nil {
+		operation.Error = fmt.Errorf("%s %s Json Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonSettingReturn)
+		return operation.Error
+	} else if jsonResponse.Code != "0" {
+		operation.Error = fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, jsonSettingReturn)
+		return operation.Error
+	} else if err := json.Unmarshal(jsonResponse.Data, &setting); err != nil {
+		operation.Error = fmt.Errorf("%s %s Result Unmarshal Err: %v, %s", e.GetName(), operation.Type, err, jsonResponse.Data)
+		return operation.Error
+	}
+
+	return nil
+}
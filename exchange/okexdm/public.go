@@ -0,0 +1,273 @@
+package okexdm
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/utils"
+)
+
+/*************** PUBLIC  API ***************/
+func (e *Okexdm) LoadPublicData(operation *exchange.PublicOperation) error {
+	switch operation.Type {
+	case exchange.FundingRate:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doSwapFundingRate(operation)
+		}
+	case exchange.Orderbook:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doContractOrderBook(operation)
+		}
+	case exchange.KLine:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doContractKline(operation)
+		}
+	case exchange.TradeHistory:
+		switch operation.Wallet {
+		case exchange.ContractWallet:
+			return e.doContractTradeHistory(operation)
+		}
+	}
+	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
+}
+
+// the swap of the pair, eg. USD|BTC -> BTC-USD-SWAP, also for a pair not loaded
+func (e *Okexdm) getSwapInstrument(operation *exchange.PublicOperation) string {
+	if symbol := e.GetSymbolByPair(operation.Pair); getInstType(symbol) == "SWAP" {
+		return symbol
+	}
+	return operation.Pair.Target.Code + "-" + operation.Pair.Base.Code + "-SWAP"
+}
+
+// getPublicData gets a v5 public api and unmarshals the data into result
+func (e *Okexdm) getPublicData(operation *exchange.PublicOperation, uri string, result interface{}) error {
+	jsonResponse := &JsonResponse{}
+
+	get := &utils.HttpGet{
+		URI:       uri,
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		return err
+	}
+
+	if operation.DebugMode {
+		operation.RequestURI = get.URI
+		operation.CallResponce = string(get.ResponseBody)
+	}
+
+	if err := json.Unmarshal(get.ResponseBody, &jsonResponse); err != nil {
+		return fmt.Errorf("%s %s Json Unmarshal Err: %v %v", e.GetName(), operation.Type, err, string(get.ResponseBody))
+	} else if jsonResponse.Code != "0" {
+		return fmt.Errorf("%s %s Failed: %v", e.GetName(), operation.Type, string(get.ResponseBody))
+	} else if err := json.Unmarshal(jsonResponse.Data, result); err != nil {
+		return fmt.Errorf("%s %s Result Unmarshal Err: %v %s", e.GetName(), operation.Type, err, jsonResponse.Data)
+	}
+	return nil
+}
+
+func (e *Okexdm) doSwapFundingRate(operation *exchange.PublicOperation) error {
+	fundingRate := FundingRate{}
+	fundingHistory := FundingHistory{}
+	instrumentID := e.getSwapInstrument(operation)
+
+	uri := fmt.Sprintf("%s/api/v5/public/funding-rate?instId=%s", API_URL, instrumentID)
+	if err := e.getPublicData(operation, uri, &fundingRate); err != nil {
+		operation.Error = err
+		return operation.Error
+	} else if len(fundingRate) == 0 {
+		operation.Error = fmt.Errorf("%s doSwapFundingRate got empty return: %v", e.GetName(), instrumentID)
+		return operation.Error
+	}
+
+	operation.FundingRate = &exchange.FundingRateDetail{
+		Pair:       operation.Pair,
+		Instrument: instrumentID,
+		History:    []*exchange.FundingRateHistory{},
+	}
+	operation.FundingRate.NextFundingTime, _ = strconv.ParseInt(fundingRate[0].FundingTime, 10, 64)
+	operation.FundingRate.CurrentRate, _ = strconv.ParseFloat(fundingRate[0].FundingRate, 64)
+	operation.FundingRate.PredictedRate, _ = strconv.ParseFloat(fundingRate[0].NextFundingRate, 64)
+
+	// 100 settlements per request, newest first
+	uri = fmt.Sprintf("%s/api/v5/public/funding-rate-history?instId=%s&limit=100", API_URL, instrumentID)
+	if operation.FundingStartTime != 0 {
+		uri += fmt.Sprintf("&before=%d", operation.FundingStartTime-1)
+	}
+	if operation.FundingEndTime != 0 {
+		uri += fmt.Sprintf("&after=%d", operation.FundingEndTime+1)
+	}
+	if err := e.getPublicData(operation, uri, &fundingHistory); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	for i := len(fundingHistory) - 1; i >= 0; i-- {
+		f := fundingHistory[i]
+		timestamp, _ := strconv.ParseInt(f.FundingTime, 10, 64)
+		if timestamp < operation.FundingStartTime || (operation.FundingEndTime != 0 && timestamp > operation.FundingEndTime) {
+			continue
+		}
+		rate, err := strconv.ParseFloat(f.RealizedRate, 64)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doSwapFundingRate parse Err: %v %v", e.GetName(), err, f.RealizedRate)
+			return operation.Error
+		}
+		operation.FundingRate.History = append(operation.FundingRate.History, &exchange.FundingRateHistory{
+			Rate:      rate,
+			Timestamp: timestamp,
+		})
+	}
+
+	return nil
+}
+
+func (e *Okexdm) doContractOrderBook(operation *exchange.PublicOperation) error {
+	orderBook := OrderBook{}
+	symbol := e.GetSymbolByPair(operation.Pair)
+
+	maker := &exchange.Maker{
+		WorkerIP:        utils.GetExternalIP(),
+		Source:          exchange.EXCHANGE_API,
+		BeforeTimestamp: float64(time.Now().UnixNano() / 1e6),
+	}
+
+	uri := fmt.Sprintf("%s/api/v5/market/books?instId=%s&sz=400", API_URL, symbol)
+	if err := e.getPublicData(operation, uri, &orderBook); err != nil {
+		operation.Error = err
+		return operation.Error
+	} else if len(orderBook) == 0 {
+		operation.Error = fmt.Errorf("%s doContractOrderBook got empty return: %v", e.GetName(), symbol)
+		return operation.Error
+	}
+
+	maker.AfterTimestamp = float64(time.Now().UnixNano() / 1e6)
+	for _, bid := range orderBook[0].Bids {
+		buydata := exchange.Order{}
+		buydata.Quantity, _ = strconv.ParseFloat(bid[1], 64)
+		buydata.Rate, _ = strconv.ParseFloat(bid[0], 64)
+		maker.Bids = append(maker.Bids, buydata)
+	}
+	for _, ask := range orderBook[0].Asks {
+		selldata := exchange.Order{}
+		selldata.Quantity, _ = strconv.ParseFloat(ask[1], 64)
+		selldata.Rate, _ = strconv.ParseFloat(ask[0], 64)
+		maker.Asks = append(maker.Asks, selldata)
+	}
+
+	operation.Maker = maker
+	return nil
+}
+
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1m",
+	exchange.KLINE_3MIN:   "3m",
+	exchange.KLINE_5MIN:   "5m",
+	exchange.KLINE_15MIN:  "15m",
+	exchange.KLINE_30MIN:  "30m",
+	exchange.KLINE_1HOUR:  "1H",
+	exchange.KLINE_2HOUR:  "2H",
+	exchange.KLINE_4HOUR:  "4H",
+	exchange.KLINE_6HOUR:  "6H",
+	exchange.KLINE_12HOUR: "12H",
+	exchange.KLINE_1DAY:   "1D",
+	exchange.KLINE_1WEEK:  "1W",
+}
+
+func (e *Okexdm) doContractKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+
+	return exchange.FetchKline(operation, 300, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		// after: records earlier than the ts, before: records newer than the ts, both exclusive
+		uri := fmt.Sprintf("%s/api/v5/market/candles?instId=%s&bar=%s&limit=300", API_URL, e.GetSymbolByPair(operation.Pair), interval)
+		if start != 0 {
+			uri += fmt.Sprintf("&before=%v", start-1)
+		}
+		if end != 0 {
+			uri += fmt.Sprintf("&after=%v", end+1)
+		}
+
+		rawKline := [][]string{}
+		if err := e.getPublicData(operation, uri, &rawKline); err != nil {
+			return nil, err
+		}
+
+		// [ts, open, high, low, close, vol, volCcy, ...], newest first
+		details := []*exchange.KlineDetail{}
+		for _, k := range rawKline {
+			if len(k) < 6 {
+				continue
+			}
+
+			values := make([]float64, 6)
+			for j := range values {
+				value, err := strconv.ParseFloat(k[j], 64)
+				if err != nil {
+					log.Printf("%s doContractKline parse Err: %v %v", e.GetName(), err, k[j])
+					return nil, err
+				}
+				values[j] = value
+			}
+
+			details = append(details, &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: values[0],
+				Open:     values[1],
+				High:     values[2],
+				Low:      values[3],
+				Close:    values[4],
+				Volume:   values[5],
+			})
+		}
+		return details, nil
+	})
+}
+
+// Quantity is the number of contracts
+func (e *Okexdm) doContractTradeHistory(operation *exchange.PublicOperation) error {
+	tradeHistory := TradeHistory{}
+
+	uri := fmt.Sprintf("%s/api/v5/market/trades?instId=%s&limit=500", API_URL, e.GetSymbolByPair(operation.Pair))
+	if err := e.getPublicData(operation, uri, &tradeHistory); err != nil {
+		operation.Error = err
+		return operation.Error
+	}
+
+	// newest first
+	operation.TradeHistory = []*exchange.TradeDetail{}
+	for i := len(tradeHistory) - 1; i >= 0; i-- {
+		d := tradeHistory[i]
+		td := &exchange.TradeDetail{
+			ID: d.TradeID,
+		}
+		if d.Side == "buy" {
+			td.Direction = exchange.Buy
+		} else if d.Side == "sell" {
+			td.Direction = exchange.Sell
+		}
+		td.Quantity, _ = strconv.ParseFloat(d.Sz, 64)
+		td.Rate, _ = strconv.ParseFloat(d.Px, 64)
+		td.TimeStamp, _ = strconv.ParseInt(d.Ts, 10, 64)
+
+		operation.TradeHistory = append(operation.TradeHistory, td)
+	}
+
+	return nil
+}
@@ -19,7 +19,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://www.okex.com"
 )
 
@@ -313,6 +313,11 @@ func (e *Oksim) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	strRequest := "/api/v5/trade/order"
 
@@ -321,8 +326,8 @@ func (e *Oksim) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	mapParams["tdMode"] = "cash"
 	mapParams["side"] = "sell"
 	mapParams["ordType"] = "limit"
-	mapParams["px"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["sz"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["px"] = pairConstraint.FormatRate(rate)
+	mapParams["sz"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 	log.Printf("%s", jsonPlaceReturn)
@@ -357,6 +362,11 @@ func (e *Oksim) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 		return nil, fmt.Errorf("%s API Key, Secret Key or Passphrase are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	jsonResponse := &JsonResponse{}
 	strRequest := "/api/v5/trade/order"
 
@@ -365,8 +375,8 @@ func (e *Oksim) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	mapParams["tdMode"] = "cash"
 	mapParams["side"] = "buy"
 	mapParams["ordType"] = "limit"
-	mapParams["px"] = strconv.FormatFloat(rate, 'f', -1, 64)
-	mapParams["sz"] = strconv.FormatFloat(quantity, 'f', -1, 64)
+	mapParams["px"] = pairConstraint.FormatRate(rate)
+	mapParams["sz"] = pairConstraint.FormatQuantity(quantity)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", mapParams, strRequest)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -247,6 +247,19 @@ func (e *Otcbtc) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Otcbtc) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Otcbtc) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -0,0 +1,170 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+/*************** Public API ***************/
+// GetCoinsData copies the coin constraints of the Source
+func (e *Papertrade) GetCoinsData() error {
+	for _, c := range e.Source.GetCoins() {
+		if coinConstraint := e.Source.GetCoinConstraint(c); coinConstraint != nil {
+			copied := *coinConstraint
+			e.SetCoinConstraint(&copied)
+		}
+	}
+	return nil
+}
+
+// GetPairsData copies the pair constraints of the Source, the fees of a pair without fees are the default fees
+func (e *Papertrade) GetPairsData() error {
+	for _, p := range e.Source.GetPairs() {
+		if pairConstraint := e.Source.GetPairConstraint(p); pairConstraint != nil {
+			copied := *pairConstraint
+			if copied.MakerFee == 0 && copied.TakerFee == 0 {
+				copied.MakerFee = DEFAULT_MAKER_FEE
+				copied.TakerFee = DEFAULT_TAKER_FEE
+			}
+			e.SetPairConstraint(&copied)
+		}
+	}
+	if len(e.GetPairs()) == 0 {
+		return fmt.Errorf("%s Get Pairs Failed: no pairs on %s", e.GetName(), e.Source.GetName())
+	}
+	return nil
+}
+
+// OrderBook is the book of the Feed, the open orders of the pair are matched against it
+func (e *Papertrade) OrderBook(pair *pair.Pair) (*exchange.Maker, error) {
+	return e.Match(pair)
+}
+
+/*************** Private API ***************/
+// UpdateAllBalances does nothing, the balances are updated by the fills
+func (e *Papertrade) UpdateAllBalances() {
+}
+
+// Withdraw takes the quantity from the available balance
+func (e *Papertrade) Withdraw(coin *coin.Coin, quantity float64, addr, tag string) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	b := e.balance(coin.Code)
+	if quantity <= 0 || b.available < quantity {
+		log.Printf("%s Withdraw Failed: %v %s, %v available", e.GetName(), quantity, coin.Code, b.available)
+		return false
+	}
+	b.available -= quantity
+	return true
+}
+
+func (e *Papertrade) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return e.placeOrder(pair, exchange.Sell, quantity, rate, exchange.TRADE_LIMIT, exchange.GTC)
+}
+
+func (e *Papertrade) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	return e.placeOrder(pair, exchange.Buy, quantity, rate, exchange.TRADE_LIMIT, exchange.GTC)
+}
+
+// OrderStatus matches the order against the current book first
+func (e *Papertrade) OrderStatus(order *exchange.Order) error {
+	e.mutex.Lock()
+	po, err := e.findOrder(order)
+	e.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if po.open() {
+		if _, err := e.Match(po.order.Pair); err != nil {
+			return err
+		}
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	order.Status = po.order.Status
+	order.DealRate = po.order.DealRate
+	order.DealQuantity = po.order.DealQuantity
+	return nil
+}
+
+// ListOrders returns the open orders, after matching their pairs
+func (e *Papertrade) ListOrders() ([]*exchange.Order, error) {
+	for _, p := range e.openPairs() {
+		if _, err := e.Match(p); err != nil {
+			return nil, err
+		}
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	orders := []*exchange.Order{}
+	for _, po := range e.orders {
+		if po.open() {
+			order := po.order
+			orders = append(orders, &order)
+		}
+	}
+	return orders, nil
+}
+
+func (e *Papertrade) CancelOrder(order *exchange.Order) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	po, err := e.findOrder(order)
+	if err != nil {
+		return err
+	} else if !po.open() {
+		return fmt.Errorf("%s CancelOrder failed: order %s is %s", e.GetName(), order.OrderID, po.order.Status)
+	}
+
+	po.order.Status = exchange.Cancelled
+	po.order.Canceled = true
+	e.release(po)
+
+	order.Status = exchange.Cancelled
+	order.Canceled = true
+	order.DealRate = po.order.DealRate
+	order.DealQuantity = po.order.DealQuantity
+	return nil
+}
+
+func (e *Papertrade) CancelAllOrder() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, po := range e.orders {
+		if po.open() {
+			po.order.Status = exchange.Cancelled
+			po.order.Canceled = true
+			e.release(po)
+		}
+	}
+	return nil
+}
+
+func (e *Papertrade) openPairs() []*pair.Pair {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	pairs := []*pair.Pair{}
+	seen := make(map[int]bool)
+	for _, po := range e.orders {
+		if po.open() && !seen[po.order.Pair.ID] {
+			seen[po.order.Pair.ID] = true
+			pairs = append(pairs, po.order.Pair)
+		}
+	}
+	return pairs
+}
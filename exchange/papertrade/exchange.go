@@ -0,0 +1,347 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	cmap "github.com/orcaman/concurrent-map"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Papertrade is an exchange in process. The orders are matched against the orderbooks of a Feed,
+// another adapter or recorded books, and the balances are simulated, so a strategy written
+// against exchange.Exchange runs unchanged before it goes live.
+//
+// Coins, pairs and constraints are copied from the Source exchange. The fees of the fills are the
+// MakerFee and TakerFee of the PairConstraint, change them with SetPairConstraint.
+type Papertrade struct {
+	ID      int
+	Name    string `bson:"name"`
+	Website string `bson:"website"`
+
+	Source      exchange.Exchange
+	Feed        exchange.BookFeed
+	Latency     time.Duration // an order is matched from the first book fetched after the latency
+	PartialFill float64       // share of the quantity of a book level an order can take per book, 0 is all
+
+	pairConstraintMap cmap.ConcurrentMap
+	coinConstraintMap cmap.ConcurrentMap
+
+	mutex      sync.Mutex
+	balances   map[string]*balance // key: coin code
+	orders     []*paperOrder
+	executions []*exchange.Execution
+	nextID     int
+}
+
+type Config struct {
+	Source      exchange.Exchange  // coins, pairs and constraints
+	Feed        exchange.BookFeed  // orderbooks, the Source if nil
+	Balances    map[string]float64 // initial balances, key: coin code
+	Latency     time.Duration
+	PartialFill float64
+}
+
+/***************************************************/
+// CreatePapertrade is not a singleton like the other adapters, every simulation has its own orders and balances
+func CreatePapertrade(config *Config) *Papertrade {
+	e := &Papertrade{
+		ID:      DEFAULT_ID,
+		Name:    "Papertrade",
+		Website: "",
+
+		Source:      config.Source,
+		Feed:        config.Feed,
+		Latency:     config.Latency,
+		PartialFill: config.PartialFill,
+
+		pairConstraintMap: cmap.New(),
+		coinConstraintMap: cmap.New(),
+		balances:          make(map[string]*balance),
+	}
+	if e.Feed == nil && config.Source != nil {
+		e.Feed = config.Source
+	}
+	for code, amount := range config.Balances {
+		e.balances[code] = &balance{available: amount}
+	}
+
+	if err := e.InitData(); err != nil {
+		log.Printf("%v", err)
+		return nil
+	}
+	return e
+}
+
+func (e *Papertrade) InitData() error {
+	if e.Source == nil {
+		return fmt.Errorf("%s Initial Data Error: no source exchange.", e.GetName())
+	}
+	if err := e.GetCoinsData(); err != nil {
+		return err
+	}
+	if err := e.GetPairsData(); err != nil {
+		return err
+	}
+	return nil
+}
+
+/**************** Exchange Information ****************/
+func (e *Papertrade) GetID() int {
+	return e.ID
+}
+
+func (e *Papertrade) GetName() exchange.ExchangeName {
+	return exchange.PAPERTRADE
+}
+
+func (e *Papertrade) GetBalance(coin *coin.Coin) float64 {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if b, ok := e.balances[coin.Code]; ok {
+		return b.available
+	}
+	return 0.0
+}
+
+func (e *Papertrade) GetTradingWebURL(pair *pair.Pair) string {
+	return e.Source.GetTradingWebURL(pair)
+}
+
+/*************** Coins on the Exchanges ***************/
+func (e *Papertrade) GetCoinConstraint(coin *coin.Coin) *exchange.CoinConstraint {
+	if tmp, ok := e.coinConstraintMap.Get(fmt.Sprintf("%d", coin.ID)); ok {
+		return tmp.(*exchange.CoinConstraint)
+	}
+	return nil
+}
+
+func (e *Papertrade) SetCoinConstraint(coinConstraint *exchange.CoinConstraint) {
+	e.coinConstraintMap.Set(fmt.Sprintf("%d", coinConstraint.CoinID), coinConstraint)
+}
+
+func (e *Papertrade) GetCoins() []*coin.Coin {
+	coinList := []*coin.Coin{}
+	keySort := []int{}
+	for _, key := range e.coinConstraintMap.Keys() {
+		id, _ := strconv.Atoi(key)
+		keySort = append(keySort, id)
+	}
+	sort.Ints(keySort)
+	for _, key := range keySort {
+		c := coin.GetCoinByID(key)
+		if c != nil {
+			coinList = append(coinList, c)
+		}
+	}
+	return coinList
+}
+
+func (e *Papertrade) GetSymbolByCoin(coin *coin.Coin) string {
+	key := fmt.Sprintf("%d", coin.ID)
+	if tmp, ok := e.coinConstraintMap.Get(key); ok {
+		cc := tmp.(*exchange.CoinConstraint)
+		return cc.ExSymbol
+	}
+	return ""
+}
+
+func (e *Papertrade) GetCoinBySymbol(symbol string) *coin.Coin {
+	for _, id := range e.coinConstraintMap.Keys() {
+		if tmp, ok := e.coinConstraintMap.Get(id); ok {
+			cc := tmp.(*exchange.CoinConstraint)
+			if cc.ExSymbol == symbol {
+				return cc.Coin
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Papertrade) DeleteCoin(coin *coin.Coin) {
+	e.coinConstraintMap.Remove(fmt.Sprintf("%d", coin.ID))
+}
+
+/*************** Pairs on the Exchanges ***************/
+func (e *Papertrade) GetPairConstraint(pair *pair.Pair) *exchange.PairConstraint {
+	if pair == nil {
+		return nil
+	}
+	if tmp, ok := e.pairConstraintMap.Get(fmt.Sprintf("%d", pair.ID)); ok {
+		return tmp.(*exchange.PairConstraint)
+	}
+	return nil
+}
+
+func (e *Papertrade) SetPairConstraint(pairConstraint *exchange.PairConstraint) {
+	e.pairConstraintMap.Set(fmt.Sprintf("%d", pairConstraint.PairID), pairConstraint)
+}
+
+func (e *Papertrade) GetPairs() []*pair.Pair {
+	pairList := []*pair.Pair{}
+	keySort := []int{}
+	for _, key := range e.pairConstraintMap.Keys() {
+		id, _ := strconv.Atoi(key)
+		keySort = append(keySort, id)
+	}
+	sort.Ints(keySort)
+	for _, key := range keySort {
+		p := pair.GetPairByID(key)
+		if p != nil {
+			pairList = append(pairList, p)
+		}
+	}
+	return pairList
+}
+
+func (e *Papertrade) GetPairBySymbol(symbol string) *pair.Pair {
+	for _, id := range e.pairConstraintMap.Keys() {
+		if tmp, ok := e.pairConstraintMap.Get(id); ok {
+			pc := tmp.(*exchange.PairConstraint)
+			if pc.ExSymbol == symbol {
+				return pc.Pair
+			}
+		}
+	}
+	return nil
+}
+
+func (e *Papertrade) GetSymbolByPair(pair *pair.Pair) string {
+	pairConstraint := e.GetPairConstraint(pair)
+	if pairConstraint != nil {
+		return pairConstraint.ExSymbol
+	}
+	return ""
+}
+
+func (e *Papertrade) HasPair(pair *pair.Pair) bool {
+	return e.pairConstraintMap.Has(fmt.Sprintf("%d", pair.ID))
+}
+
+func (e *Papertrade) DeletePair(pair *pair.Pair) {
+	e.pairConstraintMap.Remove(fmt.Sprintf("%d", pair.ID))
+}
+
+/**************** Exchange Constraint ****************/
+func (e *Papertrade) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetchMethod {
+	constrainFetchMethod := &exchange.ConstrainFetchMethod{}
+	constrainFetchMethod.PublicAPI = true
+	constrainFetchMethod.PrivateAPI = true
+	constrainFetchMethod.HealthAPI = false
+	constrainFetchMethod.HasWithdraw = true
+	constrainFetchMethod.HasTransfer = false
+	constrainFetchMethod.Fee = true
+	constrainFetchMethod.LotSize = true
+	constrainFetchMethod.PriceFilter = true
+	constrainFetchMethod.TxFee = true
+	constrainFetchMethod.Withdraw = true
+	constrainFetchMethod.Deposit = true
+	constrainFetchMethod.Confirmation = true
+	constrainFetchMethod.ConstrainSource = 1
+	constrainFetchMethod.ApiRestrictIP = false
+	return constrainFetchMethod
+}
+
+// the orders are matched on spot, the other public data is of the Source
+var capabilities = &exchange.Capabilities{
+	Public: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Orderbook: {exchange.SpotWallet},
+	},
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.PlaceOrder:          {exchange.SpotWallet},
+		exchange.CancelOrder:         {exchange.SpotWallet},
+		exchange.GetOrderStatus:      {exchange.SpotWallet},
+		exchange.GetOpenOrder:        {exchange.SpotWallet},
+		exchange.GetOrderHistory:     {exchange.SpotWallet},
+		exchange.GetExecutionHistory: {exchange.SpotWallet},
+		exchange.Balance:             {exchange.SpotWallet},
+		exchange.BalanceList:         {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT, exchange.TRADE_MARKET},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX},
+	},
+}
+
+func (e *Papertrade) GetCapabilities() *exchange.Capabilities {
+	if e.Source == nil || e.Source.GetCapabilities() == nil {
+		return capabilities
+	}
+	return capabilities.Merge(&exchange.Capabilities{Public: e.Source.GetCapabilities().Public})
+}
+
+func (e *Papertrade) UpdateConstraint() {
+	e.GetCoinsData()
+	e.GetPairsData()
+}
+
+/**************** Coin Constraint ****************/
+func (e *Papertrade) GetTxFee(coin *coin.Coin) float64 {
+	coinConstraint := e.GetCoinConstraint(coin)
+	if coinConstraint == nil {
+		return 0.0
+	}
+	return coinConstraint.TxFee
+}
+
+func (e *Papertrade) CanWithdraw(coin *coin.Coin) bool {
+	coinConstraint := e.GetCoinConstraint(coin)
+	if coinConstraint == nil {
+		return false
+	}
+	return coinConstraint.Withdraw
+}
+
+func (e *Papertrade) CanDeposit(coin *coin.Coin) bool {
+	coinConstraint := e.GetCoinConstraint(coin)
+	if coinConstraint == nil {
+		return false
+	}
+	return coinConstraint.Deposit
+}
+
+func (e *Papertrade) GetConfirmation(coin *coin.Coin) int {
+	coinConstraint := e.GetCoinConstraint(coin)
+	if coinConstraint == nil {
+		return 0
+	}
+	return coinConstraint.Confirmation
+}
+
+/**************** Pair Constraint ****************/
+func (e *Papertrade) GetFee(pair *pair.Pair) float64 {
+	pairConstraint := e.GetPairConstraint(pair)
+	if pairConstraint == nil {
+		return 0.0
+	}
+	return pairConstraint.TakerFee
+}
+
+func (e *Papertrade) GetLotSize(pair *pair.Pair) float64 {
+	pairConstraint := e.GetPairConstraint(pair)
+	if pairConstraint == nil {
+		return 0.0
+	}
+	return pairConstraint.LotSize
+}
+
+func (e *Papertrade) GetPriceFilter(pair *pair.Pair) float64 {
+	pairConstraint := e.GetPairConstraint(pair)
+	if pairConstraint == nil {
+		return 0.0
+	}
+	return pairConstraint.PriceFilter
+}
@@ -0,0 +1,117 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// RecordedBook is a line of a recorded book stream, one json object per line
+type RecordedBook struct {
+	Pair  string          `json:"pair"` // pair name, eg. USDT|BTC
+	Maker *exchange.Maker `json:"maker"`
+}
+
+// RecordedBooks replays a recorded book stream. Every call of OrderBook returns the next book
+// of the pair, the last one is repeated.
+type RecordedBooks struct {
+	mutex  sync.Mutex
+	books  map[int][]*exchange.Maker // key: pair id
+	played map[int]int
+}
+
+func NewRecordedBooks() *RecordedBooks {
+	return &RecordedBooks{
+		books:  make(map[int][]*exchange.Maker),
+		played: make(map[int]int),
+	}
+}
+
+// LoadRecordedBooks reads a file written by a Recorder
+func LoadRecordedBooks(path string) (*RecordedBooks, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	books := NewRecordedBooks()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		recorded := &RecordedBook{}
+		if err := json.Unmarshal(scanner.Bytes(), recorded); err != nil {
+			return nil, fmt.Errorf("%s line %d Unmarshal Err: %v", path, line, err)
+		}
+		p := pair.GetPairByKey(recorded.Pair)
+		if p == nil {
+			return nil, fmt.Errorf("%s line %d: pair %s not loaded", path, line, recorded.Pair)
+		}
+		books.Add(p, recorded.Maker)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return books, nil
+}
+
+func (r *RecordedBooks) Add(p *pair.Pair, maker *exchange.Maker) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.books[p.ID] = append(r.books[p.ID], maker)
+}
+
+func (r *RecordedBooks) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	books := r.books[p.ID]
+	if len(books) == 0 {
+		return nil, fmt.Errorf("%s no recorded orderbook of %s", exchange.PAPERTRADE, p.Name)
+	}
+	i := r.played[p.ID]
+	if i >= len(books) {
+		i = len(books) - 1
+	}
+	r.played[p.ID] = i + 1
+	return books[i], nil
+}
+
+// Recorder is a Feed writing every book of another Feed to a stream, LoadRecordedBooks replays it
+type Recorder struct {
+	Feed   exchange.BookFeed
+	Writer io.Writer
+
+	mutex sync.Mutex
+}
+
+func (r *Recorder) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
+	maker, err := r.Feed.OrderBook(p)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(&RecordedBook{Pair: p.Name, Maker: maker})
+	if err != nil {
+		return nil, fmt.Errorf("%s %s Record Marshal Err: %v", exchange.PAPERTRADE, p.Name, err)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if _, err := r.Writer.Write(append(data, '\n')); err != nil {
+		return nil, err
+	}
+	return maker, nil
+}
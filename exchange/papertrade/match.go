@@ -0,0 +1,327 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+type balance struct {
+	available float64
+	frozen    float64
+}
+
+type paperOrder struct {
+	order     exchange.Order // the state, copied to the order of the caller
+	market    bool
+	priceType exchange.OrderPriceType
+	activeAt  time.Time
+	resting   bool    // matched against a book already, the next fills are maker fills at the order rate
+	frozen    float64 // base for a limit buy, target for a sell
+}
+
+// level is the quantity of a book level left to the orders matched against the same book
+type level struct {
+	rate     float64
+	quantity float64
+}
+
+func (po *paperOrder) open() bool {
+	return po.order.Status == exchange.New || po.order.Status == exchange.Partial
+}
+
+func (po *paperOrder) remaining() float64 {
+	return decimal.NewFromFloat(po.order.Quantity).Sub(decimal.NewFromFloat(po.order.DealQuantity)).Float64()
+}
+
+func (e *Papertrade) balance(code string) *balance {
+	b, ok := e.balances[code]
+	if !ok {
+		b = &balance{}
+		e.balances[code] = b
+	}
+	return b
+}
+
+// placeOrder freezes the funds of the order, it is matched from the first book after the Latency.
+// Without Latency the book is fetched right away.
+func (e *Papertrade) placeOrder(p *pair.Pair, direction exchange.TradeDirection, quantity, rate float64, tradeType exchange.OrderTradeType, priceType exchange.OrderPriceType) (*exchange.Order, error) {
+	market := false
+	switch tradeType {
+	case exchange.TRADE_LIMIT, "":
+		if rate <= 0 {
+			return nil, fmt.Errorf("%s %s limit order without rate", e.GetName(), p.Name)
+		}
+	case exchange.TRADE_MARKET:
+		market = true
+		rate = 0
+	default:
+		return nil, fmt.Errorf("%s %s: %w", e.GetName(), tradeType, exchange.ErrNotSupported)
+	}
+	switch priceType {
+	case "", exchange.GTC, exchange.IOC, exchange.FOK, exchange.GTX:
+	default:
+		return nil, fmt.Errorf("%s %s: %w", e.GetName(), priceType, exchange.ErrNotSupported)
+	}
+
+	quantity, rate, err := exchange.ValidateOrder(e, p, direction, quantity, rate, true)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mutex.Lock()
+	code, frozen := p.Target.Code, quantity
+	if direction == exchange.Buy {
+		code, frozen = p.Base.Code, decimal.NewFromFloat(quantity).Mul(decimal.NewFromFloat(rate)).Float64()
+	}
+	b := e.balance(code)
+	if b.available < frozen || (market && b.available <= 0) {
+		e.mutex.Unlock()
+		return nil, fmt.Errorf("%s %s insufficient %s balance: %v available, %v required", e.GetName(), p.Name, code, b.available, frozen)
+	}
+	b.available -= frozen
+	b.frozen += frozen
+
+	e.nextID++
+	po := &paperOrder{
+		order: exchange.Order{
+			EX:        e.GetName(),
+			Pair:      p,
+			OrderID:   strconv.Itoa(e.nextID),
+			Rate:      rate,
+			Quantity:  quantity,
+			Direction: direction,
+			Status:    exchange.New,
+			Timestamp: time.Now().UnixNano() / 1e6,
+		},
+		market:    market,
+		priceType: priceType,
+		activeAt:  time.Now().Add(e.Latency),
+		frozen:    frozen,
+	}
+	e.orders = append(e.orders, po)
+	e.mutex.Unlock()
+
+	if e.Latency == 0 {
+		if _, err := e.Match(p); err != nil {
+			log.Printf("%s %s order %s not matched: %v", e.GetName(), p.Name, po.order.OrderID, err)
+		}
+	}
+
+	e.mutex.Lock()
+	order := po.order
+	e.mutex.Unlock()
+	return &order, nil
+}
+
+// Match fetches the orderbook of the pair from the Feed and matches the open orders against it.
+// OrderBook, OrderStatus and ListOrders match too, a strategy polling them needs no extra call.
+func (e *Papertrade) Match(p *pair.Pair) (*exchange.Maker, error) {
+	maker, err := e.Feed.OrderBook(p)
+	if err != nil {
+		return nil, err
+	} else if maker == nil {
+		return nil, fmt.Errorf("%s %s no orderbook from feed", e.GetName(), p.Name)
+	}
+
+	e.mutex.Lock()
+	e.match(p, maker)
+	e.mutex.Unlock()
+	return maker, nil
+}
+
+// match takes the book for the first match of an order, up to the order rate at the book rates.
+// The rest of a limit order rests and is filled at its own rate by the books crossing it.
+// Market, IOC and FOK orders don't rest, GTX orders crossing the book are rejected.
+func (e *Papertrade) match(p *pair.Pair, maker *exchange.Maker) {
+	now := time.Now()
+	bids := e.levels(maker.Bids, true)
+	asks := e.levels(maker.Asks, false)
+
+	for _, po := range e.orders {
+		if po.order.Pair.ID != p.ID || !po.open() || now.Before(po.activeAt) {
+			continue
+		}
+
+		levels := asks
+		if po.order.Direction == exchange.Sell {
+			levels = bids
+		}
+		if !po.resting {
+			switch {
+			case po.priceType == exchange.GTX && po.crossing(levels) > 0:
+				po.order.Status = exchange.Rejected
+				e.release(po)
+				continue
+			case po.priceType == exchange.FOK && po.crossing(levels) < po.remaining():
+				po.order.Status = exchange.Cancelled
+				e.release(po)
+				continue
+			}
+		}
+
+		for _, l := range levels {
+			remaining := po.remaining()
+			if remaining <= 0 {
+				break
+			} else if !po.crosses(l.rate) {
+				break
+			} else if l.quantity <= 0 {
+				continue
+			}
+
+			rate := l.rate
+			if po.resting {
+				rate = po.order.Rate
+			}
+			filled := e.fill(po, math.Min(remaining, l.quantity), rate, po.resting)
+			if filled <= 0 {
+				break
+			}
+			l.quantity = decimal.NewFromFloat(l.quantity).Sub(decimal.NewFromFloat(filled)).Float64()
+		}
+
+		if !po.resting && po.open() && (po.market || po.priceType == exchange.IOC || po.priceType == exchange.FOK) {
+			po.order.Status = exchange.Cancelled
+			e.release(po)
+		}
+		po.resting = true
+	}
+}
+
+func (e *Papertrade) levels(orders []exchange.Order, descending bool) []*level {
+	ratio := e.PartialFill
+	if ratio <= 0 || ratio > 1 {
+		ratio = 1
+	}
+	levels := []*level{}
+	for _, order := range orders {
+		levels = append(levels, &level{rate: order.Rate, quantity: order.Quantity * ratio})
+	}
+	sort.SliceStable(levels, func(i, j int) bool {
+		if descending {
+			return levels[i].rate > levels[j].rate
+		}
+		return levels[i].rate < levels[j].rate
+	})
+	return levels
+}
+
+func (po *paperOrder) crosses(rate float64) bool {
+	if po.market {
+		return true
+	} else if po.order.Direction == exchange.Buy {
+		return rate <= po.order.Rate
+	}
+	return rate >= po.order.Rate
+}
+
+// crossing is the quantity of the levels the order crosses
+func (po *paperOrder) crossing(levels []*level) float64 {
+	quantity := 0.0
+	for _, l := range levels {
+		if !po.crosses(l.rate) {
+			break
+		}
+		quantity += l.quantity
+	}
+	return quantity
+}
+
+// fill moves the funds of a fill and records the execution, the fee is paid in the coin received.
+// It returns the quantity filled, less than the quantity if a market buy runs out of balance.
+func (e *Papertrade) fill(po *paperOrder, quantity, rate float64, maker bool) float64 {
+	p := po.order.Pair
+	constraint := e.GetPairConstraint(p)
+	feeRate := constraint.TakerFee
+	if maker {
+		feeRate = constraint.MakerFee
+	}
+	base, target := e.balance(p.Base.Code), e.balance(p.Target.Code)
+
+	execution := &exchange.Execution{
+		OrderID:   po.order.OrderID,
+		Pair:      p,
+		Direction: po.order.Direction,
+		Rate:      rate,
+		Maker:     maker,
+		Timestamp: time.Now().UnixNano() / 1e6,
+	}
+	if po.order.Direction == exchange.Buy {
+		if po.market {
+			if affordable := constraint.QuantityDecimal(base.available / rate).Float64(); affordable < quantity {
+				quantity = affordable
+			}
+			if quantity <= 0 {
+				return 0
+			}
+			base.available -= quantity * rate
+		} else {
+			// frozen at the order rate, the difference of a better rate is returned
+			locked := math.Min(quantity*po.order.Rate, po.frozen)
+			po.frozen -= locked
+			base.frozen -= locked
+			base.available += locked - quantity*rate
+		}
+		execution.Fee = quantity * feeRate
+		execution.FeeCoin = p.Target
+		target.available += quantity - execution.Fee
+	} else {
+		locked := math.Min(quantity, po.frozen)
+		po.frozen -= locked
+		target.frozen -= locked
+		execution.Fee = quantity * rate * feeRate
+		execution.FeeCoin = p.Base
+		base.available += quantity*rate - execution.Fee
+	}
+
+	dealQuantity := decimal.NewFromFloat(po.order.DealQuantity).Add(decimal.NewFromFloat(quantity))
+	po.order.DealRate = (po.order.DealRate*po.order.DealQuantity + rate*quantity) / dealQuantity.Float64()
+	po.order.DealQuantity = dealQuantity.Float64()
+	if po.remaining() <= 0 {
+		po.order.Status = exchange.Filled
+		e.release(po)
+	} else {
+		po.order.Status = exchange.Partial
+	}
+
+	execution.ID = fmt.Sprintf("%s-%d", po.order.OrderID, len(e.executions)+1)
+	execution.Quantity = quantity
+	e.executions = append(e.executions, execution)
+	return quantity
+}
+
+// release returns the funds still frozen by a closed order
+func (e *Papertrade) release(po *paperOrder) {
+	code := po.order.Pair.Target.Code
+	if po.order.Direction == exchange.Buy {
+		code = po.order.Pair.Base.Code
+	}
+	b := e.balance(code)
+	b.frozen -= po.frozen
+	b.available += po.frozen
+	po.frozen = 0
+}
+
+func (e *Papertrade) findOrder(order *exchange.Order) (*paperOrder, error) {
+	if order == nil {
+		return nil, fmt.Errorf("%s order is nil", e.GetName())
+	}
+	for _, po := range e.orders {
+		if po.order.OrderID == order.OrderID {
+			return po, nil
+		}
+	}
+	return nil, fmt.Errorf("%s order %s not found", e.GetName(), order.OrderID)
+}
@@ -0,0 +1,14 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+const (
+	DEFAULT_ID           = 75
+	DEFAULT_TAKER_FEE    = 0.001
+	DEFAULT_MAKER_FEE    = 0.001
+	DEFAULT_LOT_SIZE     = 0.00000001
+	DEFAULT_PRICE_FILTER = 0.00000001
+	DEFAULT_CONFIRMATION = 2
+)
@@ -0,0 +1,144 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+
+	"github.com/bitontop/gored/exchange"
+)
+
+func (e *Papertrade) DoAccountOperation(operation *exchange.AccountOperation) error {
+	if operation.Wallet != "" && operation.Wallet != exchange.SpotWallet {
+		return fmt.Errorf("%s %s: %w", e.GetName(), operation.Wallet, exchange.ErrNotSupported)
+	}
+
+	switch operation.Type {
+	case exchange.PlaceOrder:
+		return e.doPlaceOrder(operation)
+	case exchange.CancelOrder:
+		return e.doCancelOrder(operation)
+	case exchange.GetOrderStatus:
+		return e.doOrderStatus(operation)
+	case exchange.GetOpenOrder:
+		return e.doOpenOrder(operation)
+	case exchange.GetOrderHistory:
+		return e.doOrderHistory(operation)
+	case exchange.GetExecutionHistory:
+		return e.doExecutionHistory(operation)
+	case exchange.Balance:
+		return e.doBalance(operation)
+	case exchange.BalanceList:
+		return e.doBalanceList(operation)
+	}
+	return fmt.Errorf("%s Operation type invalid: %s %v", operation.Ex, operation.Wallet, operation.Type)
+}
+
+func (e *Papertrade) doPlaceOrder(operation *exchange.AccountOperation) error {
+	order, err := e.placeOrder(operation.Pair, operation.OrderDirection, operation.Quantity, operation.Rate, operation.TradeType, operation.OrderType)
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+	operation.Order = order
+	return nil
+}
+
+func (e *Papertrade) doCancelOrder(operation *exchange.AccountOperation) error {
+	if err := e.CancelOrder(operation.Order); err != nil {
+		operation.Error = err
+		return err
+	}
+	return nil
+}
+
+func (e *Papertrade) doOrderStatus(operation *exchange.AccountOperation) error {
+	if err := e.OrderStatus(operation.Order); err != nil {
+		operation.Error = err
+		return err
+	}
+	return nil
+}
+
+func (e *Papertrade) doOpenOrder(operation *exchange.AccountOperation) error {
+	orders, err := e.ListOrders()
+	if err != nil {
+		operation.Error = err
+		return err
+	}
+
+	operation.OpenOrders = []*exchange.Order{}
+	for _, order := range orders {
+		if operation.Pair == nil || order.Pair.ID == operation.Pair.ID {
+			operation.OpenOrders = append(operation.OpenOrders, order)
+		}
+	}
+	return nil
+}
+
+// doOrderHistory returns the closed orders, of operation.Pair if not nil
+func (e *Papertrade) doOrderHistory(operation *exchange.AccountOperation) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	operation.OrderHistory = []*exchange.Order{}
+	for _, po := range e.orders {
+		if po.open() || (operation.Pair != nil && po.order.Pair.ID != operation.Pair.ID) {
+			continue
+		}
+		order := po.order
+		operation.OrderHistory = append(operation.OrderHistory, &order)
+	}
+	return nil
+}
+
+func (e *Papertrade) doExecutionHistory(operation *exchange.AccountOperation) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	operation.ExecutionHistory = []*exchange.Execution{}
+	for _, execution := range e.executions {
+		if operation.Pair != nil && execution.Pair.ID != operation.Pair.ID {
+			continue
+		}
+		copied := *execution
+		operation.ExecutionHistory = append(operation.ExecutionHistory, &copied)
+	}
+	return nil
+}
+
+func (e *Papertrade) doBalance(operation *exchange.AccountOperation) error {
+	if operation.Coin == nil {
+		operation.Error = fmt.Errorf("%s Balance: coin is nil", e.GetName())
+		return operation.Error
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	b := e.balance(operation.Coin.Code)
+	operation.BalanceAvailable = b.available
+	operation.BalanceFrozen = b.frozen
+	return nil
+}
+
+func (e *Papertrade) doBalanceList(operation *exchange.AccountOperation) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	operation.BalanceList = []exchange.AssetBalance{}
+	for _, c := range e.GetCoins() {
+		b, ok := e.balances[c.Code]
+		if !ok {
+			continue
+		}
+		operation.BalanceList = append(operation.BalanceList, exchange.AssetBalance{
+			Coin:             c,
+			Balance:          b.available + b.frozen,
+			BalanceAvailable: b.available,
+			BalanceFrozen:    b.frozen,
+		})
+	}
+	return nil
+}
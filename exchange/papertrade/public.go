@@ -0,0 +1,24 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"github.com/bitontop/gored/exchange"
+)
+
+/*************** PUBLIC  API ***************/
+// LoadPublicData takes the orderbook from the Feed, the other operations from the Source
+func (e *Papertrade) LoadPublicData(operation *exchange.PublicOperation) error {
+	switch operation.Type {
+	case exchange.Orderbook:
+		maker, err := e.Match(operation.Pair)
+		if err != nil {
+			return err
+		}
+		operation.Maker = maker
+		return nil
+	}
+	return e.Source.LoadPublicData(operation)
+}
@@ -30,52 +30,101 @@ func (e *Poloniex) LoadPublicData(operation *exchange.PublicOperation) error {
 		case exchange.SpotWallet:
 			return e.doSpotOrderBook(operation)
 		}
+	case exchange.Ticker:
+		switch operation.Wallet {
+		case exchange.SpotWallet:
+			return e.doTicker(operation)
+		}
 	}
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
-// interval options: 5min, 15min, 30min, 2hour, 4hour, 1day
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_5MIN:  "300",
+	exchange.KLINE_15MIN: "900",
+	exchange.KLINE_30MIN: "1800",
+	exchange.KLINE_2HOUR: "7200",
+	exchange.KLINE_4HOUR: "14400",
+	exchange.KLINE_1DAY:  "86400",
+}
+
 func (e *Poloniex) doSpotKline(operation *exchange.PublicOperation) error {
-	interval := "300"
-	if operation.KlineInterval != "" {
-		switch operation.KlineInterval {
-		case "5min":
-			interval = "300"
-		case "15min":
-			interval = "900"
-		case "30min":
-			interval = "1800"
-		case "2hour":
-			interval = "7200"
-		case "4hour":
-			interval = "14400"
-		case "1day":
-			interval = "86400"
-		}
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
+	if err != nil {
+		operation.Error = err
+		return err
 	}
 
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://poloniex.com/public?command=returnChartData&currencyPair=%v&period=%v", // 1500478320000
-			e.GetSymbolByPair(operation.Pair), // BTCUSDT
-			interval,
-		),
-		Proxy: operation.Proxy,
-	}
+	// the whole range in one call
+	return exchange.FetchKline(operation, 0, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/public?command=returnChartData&currencyPair=%v&period=%v", // 1500478320000
+				API_URL,
+				e.GetSymbolByPair(operation.Pair), // BTCUSDT
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
 
-	if operation.KlineStartTime != 0 {
-		get.URI += fmt.Sprintf("&start=%v", operation.KlineStartTime/1000)
-	}
-	if operation.KlineEndTime != 0 {
-		get.URI += fmt.Sprintf("&end=%v", operation.KlineEndTime/1000)
-	}
+		if start != 0 {
+			get.URI += fmt.Sprintf("&start=%v", start/1000)
+		}
+		if end != 0 {
+			get.URI += fmt.Sprintf("&end=%v", end/1000)
+		}
 
-	err := utils.HttpGetRequest(get)
+		err := utils.HttpGetRequest(get)
 
-	if err != nil {
-		log.Printf("%+v", err)
-		operation.Error = err
-		return err
+		if err != nil {
+			log.Printf("%+v", err)
+			return nil, err
+
+		}
 
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		kLine := Kline{}
+		if err := json.Unmarshal(get.ResponseBody, &kLine); err != nil {
+			return nil, fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, k := range kLine {
+
+			detail := &exchange.KlineDetail{
+				Exchange: e.GetName(),
+				Pair:     operation.Pair.Name,
+				OpenTime: float64(k.Date) * 1000,
+				Open:     k.Open,
+				High:     k.High,
+				Low:      k.Low,
+				Close:    k.Close,
+				Volume:   k.Volume,
+				// QuoteAssetVolume:    k.QuoteVolume,
+			}
+
+			details = append(details, detail)
+		}
+
+		return details, nil
+	})
+}
+
+// timestamp 10 digit precision
+func (e *Poloniex) doTicker(operation *exchange.PublicOperation) error {
+	ticker := make(map[string]*PairsData)
+
+	get := &utils.HttpGet{
+		URI:       fmt.Sprintf("%s/public?command=returnTicker", API_URL),
+		Proxy:     operation.Proxy,
+		DebugMode: operation.DebugMode,
+	}
+	if err := utils.HttpGetRequest(get); err != nil {
+		operation.Error = err
+		return operation.Error
 	}
 
 	if operation.DebugMode {
@@ -83,34 +132,46 @@ func (e *Poloniex) doSpotKline(operation *exchange.PublicOperation) error {
 		operation.CallResponce = string(get.ResponseBody)
 	}
 
-	kLine := Kline{}
-	if err := json.Unmarshal(get.ResponseBody, &kLine); err != nil {
-		operation.Error = fmt.Errorf("%s doSpotKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+	if err := json.Unmarshal(get.ResponseBody, &ticker); err != nil {
+		operation.Error = fmt.Errorf("%s doTicker Json Unmarshal Err: %v %s", e.GetName(), err, get.ResponseBody)
 		return operation.Error
 	}
 
-	operation.Kline = []*exchange.KlineDetail{}
-	for _, k := range kLine {
+	timestamp := time.Now().UnixNano() / 1e6
+	operation.Ticker = []*exchange.TickerDetail{}
+	for symbol, t := range ticker {
+		p := e.GetPairBySymbol(symbol)
+		if p == nil {
+			continue
+		}
 
-		detail := &exchange.KlineDetail{
-			Exchange: e.GetName(),
-			Pair:     operation.Pair.Name,
-			OpenTime: float64(k.Date),
-			Open:     k.Open,
-			High:     k.High,
-			Low:      k.Low,
-			Close:    k.Close,
-			Volume:   k.Volume,
-			// QuoteAssetVolume:    k.QuoteVolume,
+		// BTC_ETH: baseVolume is in BTC, the Base coin, quoteVolume in ETH, percentChange 0.05 is +5%
+		values, err := exchange.ParseFloats(t.Last, t.HighestBid, t.LowestAsk, t.High24Hr, t.Low24Hr,
+			t.QuoteVolume, t.BaseVolume, t.PercentChange)
+		if err != nil {
+			operation.Error = fmt.Errorf("%s doTicker %s %v", e.GetName(), symbol, err)
+			return operation.Error
 		}
 
-		operation.Kline = append(operation.Kline, detail)
+		detail := &exchange.TickerDetail{
+			Pair:          p,
+			Last:          values[0],
+			BestBid:       values[1],
+			BestAsk:       values[2],
+			High:          values[3],
+			Low:           values[4],
+			Volume:        values[5],
+			QuoteVolume:   values[6],
+			ChangePercent: values[7] * 100,
+			Timestamp:     timestamp,
+		}
+		detail.Complete()
+		operation.Ticker = append(operation.Ticker, detail)
 	}
 
 	return nil
 }
 
-// timestamp 10 digit precision
 func (e *Poloniex) doTradeHistory(operation *exchange.PublicOperation) error {
 	symbol := e.GetSymbolByPair(operation.Pair)
 	strRequestUrl := fmt.Sprintf("/public?command=returnTradeHistory&currencyPair=%v", symbol)
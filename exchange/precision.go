@@ -0,0 +1,84 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"github.com/bitontop/gored/decimal"
+)
+
+/********** PairConstraint in decimal **********/
+// the constraints stay float64 for the existing API, the order path converts them with these helpers
+// a nil PairConstraint has no LotSize and PriceFilter, the values are only formatted
+
+func (pc *PairConstraint) LotSizeDecimal() decimal.Decimal {
+	if pc == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(pc.LotSize)
+}
+
+func (pc *PairConstraint) PriceFilterDecimal() decimal.Decimal {
+	if pc == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromFloat(pc.PriceFilter)
+}
+
+// QuantityPlaces is the decimals allowed by LotSize, -1 if there is no LotSize
+func (pc *PairConstraint) QuantityPlaces() int32 {
+	if lotSize := pc.LotSizeDecimal(); lotSize.Sign() > 0 {
+		return lotSize.Places()
+	}
+	return -1
+}
+
+// RatePlaces is the decimals allowed by PriceFilter, -1 if there is no PriceFilter
+func (pc *PairConstraint) RatePlaces() int32 {
+	if priceFilter := pc.PriceFilterDecimal(); priceFilter.Sign() > 0 {
+		return priceFilter.Places()
+	}
+	return -1
+}
+
+// QuantityDecimal rounds the quantity down to LotSize
+func (pc *PairConstraint) QuantityDecimal(quantity float64) decimal.Decimal {
+	return decimal.NewFromFloat(quantity).RoundStep(pc.LotSizeDecimal(), false)
+}
+
+// RateDecimal rounds the rate to the nearest PriceFilter
+func (pc *PairConstraint) RateDecimal(rate float64) decimal.Decimal {
+	d := decimal.NewFromFloat(rate)
+	step := pc.PriceFilterDecimal()
+	if step.Sign() <= 0 {
+		return d
+	}
+	down := d.RoundStep(step, false)
+	if d.Sub(down).Mul(decimal.New(2, 0)).Cmp(step) >= 0 {
+		return down.Add(step)
+	}
+	return down
+}
+
+// FormatQuantity is the quantity for the request, with exactly the decimals of LotSize
+func (pc *PairConstraint) FormatQuantity(quantity float64) string {
+	return formatPlaces(pc.QuantityDecimal(quantity), pc.QuantityPlaces())
+}
+
+// FormatRate is the rate for the request, with exactly the decimals of PriceFilter
+func (pc *PairConstraint) FormatRate(rate float64) string {
+	return formatPlaces(pc.RateDecimal(rate), pc.RatePlaces())
+}
+
+func formatPlaces(d decimal.Decimal, places int32) string {
+	if places < 0 {
+		return d.String()
+	}
+	return d.StringFixed(places)
+}
+
+// FormatDecimal formats a float without the float noise and the scientific notation, 0.1+0.2 is "0.3"
+func FormatDecimal(value float64) string {
+	return decimal.NewFromFloat(value).String()
+}
@@ -249,6 +249,19 @@ func (e *Probit) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFe
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Probit) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Probit) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -20,9 +20,10 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL  string = "https://app.stex.com/api2"
 	API3_URL string = "https://api3.stex.com"
+	WEB_URL  string = "https://app.stex.com"
 )
 
 /*API Base Knowledge
@@ -273,8 +274,8 @@ func (e *Stex) webpageOrderBook(pair *pair.Pair) (*exchange.Maker, error) {
 	orderBookSell := WebOrderBook{}
 
 	// strRequestUrl := fmt.Sprintf("/public/orderbook/%v", e.GetIDByPair(pair))
-	strUrlBuy := fmt.Sprintf("https://app.stex.com/en/basic-trade/buy-glass/%v", e.GetIDByPair(pair))
-	strUrlSell := fmt.Sprintf("https://app.stex.com/en/basic-trade/sell-glass/%v", e.GetIDByPair(pair))
+	strUrlBuy := fmt.Sprintf("%s/en/basic-trade/buy-glass/%v", WEB_URL, e.GetIDByPair(pair))
+	strUrlSell := fmt.Sprintf("%s/en/basic-trade/sell-glass/%v", WEB_URL, e.GetIDByPair(pair))
 
 	maker := &exchange.Maker{
 		WorkerIP:        exchange.GetExternalIP(),
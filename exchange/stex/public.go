@@ -119,8 +119,8 @@ func (e *Stex) doWebOrderBook(op *exchange.PublicOperation) (*exchange.Maker, er
 	orderBookSell := WebOrderBook{}
 
 	// strRequestUrl := fmt.Sprintf("/public/orderbook/%v", e.GetIDByPair(pair))
-	strUrlBuy := fmt.Sprintf("https://app.stex.com/en/basic-trade/buy-glass/%v", e.GetIDByPair(pair))
-	strUrlSell := fmt.Sprintf("https://app.stex.com/en/basic-trade/sell-glass/%v", e.GetIDByPair(pair))
+	strUrlBuy := fmt.Sprintf("%s/en/basic-trade/buy-glass/%v", WEB_URL, e.GetIDByPair(pair))
+	strUrlSell := fmt.Sprintf("%s/en/basic-trade/sell-glass/%v", WEB_URL, e.GetIDByPair(pair))
 
 	maker := &exchange.Maker{
 		WorkerIP:        exchange.GetExternalIP(),
@@ -253,6 +253,19 @@ func (e *Tagz) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFetc
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Tagz) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Tagz) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
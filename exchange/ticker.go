@@ -0,0 +1,54 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ParseFloats parses the string values of a response, an empty value is 0
+func ParseFloats(values ...string) ([]float64, error) {
+	result := make([]float64, len(values))
+	for i, value := range values {
+		if value == "" {
+			continue
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse Err: %v %v", err, value)
+		}
+		result[i] = f
+	}
+	return result, nil
+}
+
+// Complete fills Open, Change and ChangePercent from the others, for the exchanges without all of them
+func (t *TickerDetail) Complete() {
+	if t.Open == 0 && t.Last != 0 {
+		if t.Change != 0 {
+			t.Open = t.Last - t.Change
+		} else if t.ChangePercent != 0 {
+			t.Open = t.Last / (1 + t.ChangePercent/100)
+		}
+	}
+	if t.Open == 0 {
+		return
+	}
+	if t.Change == 0 {
+		t.Change = t.Last - t.Open
+	}
+	if t.ChangePercent == 0 {
+		t.ChangePercent = t.Change / t.Open * 100
+	}
+}
+
+// Spread is BestAsk - BestBid, 0 without one of them
+func (t *TickerDetail) Spread() float64 {
+	if t.BestBid == 0 || t.BestAsk == 0 {
+		return 0
+	}
+	return t.BestAsk - t.BestBid
+}
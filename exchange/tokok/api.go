@@ -20,7 +20,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://www.tokok.com/api/v1"
 )
 
@@ -320,8 +320,8 @@ func (e *Tokok) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	order := &exchange.Order{
 		Pair:         pair,
 		OrderID:      placeOrder,
-		Rate:         rate,
-		Quantity:     quantity,
+		Rate:         price,
+		Quantity:     amount,
 		Direction:    exchange.Sell,
 		Status:       exchange.New,
 		JsonResponse: jsonPlaceReturn,
@@ -363,8 +363,8 @@ func (e *Tokok) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	order := &exchange.Order{
 		Pair:         pair,
 		OrderID:      placeOrder,
-		Rate:         rate,
-		Quantity:     quantity,
+		Rate:         price,
+		Quantity:     amount,
 		Direction:    exchange.Buy,
 		Status:       exchange.New,
 		JsonResponse: jsonPlaceReturn,
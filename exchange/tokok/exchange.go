@@ -247,6 +247,19 @@ func (e *Tokok) GetConstraintFetchMethod(pair *pair.Pair) *exchange.ConstrainFet
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Tokok) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Tokok) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -0,0 +1,488 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/pair"
+)
+
+const (
+	DEFAULT_TRACKER_INTERVAL     = 2 * time.Second
+	DEFAULT_TRACKER_MAX_INTERVAL = time.Minute
+	DEFAULT_TRACKER_MISS_LIMIT   = 5
+)
+
+// OrderEvent is a change of a tracked order. Order is a copy of the order after the change.
+// FilledQuantity is the quantity filled since the previous event.
+type OrderEvent struct {
+	Exchange       ExchangeName `json:"exchange"`
+	Order          *Order       `json:"order"`
+	From           OrderStatus  `json:"from"`
+	To             OrderStatus  `json:"to"`
+	FilledQuantity float64      `json:"filled_quantity"`
+	Disappeared    bool         `json:"disappeared"`     // not found on the exchange anymore, tracking stops
+	ExternalCancel bool         `json:"external_cancel"` // cancelled without OrderTracker.Cancel
+	Timestamp      int64        `json:"timestamp"`       // ms
+}
+
+// OrderTracker polls the registered orders with OrderStatus and sends every change to Events until the order is done.
+// The poll interval of an order doubles after every failed poll up to MaxInterval.
+// Updates from a stream are passed with Update. If StatePath is set the tracked orders are saved to the file,
+// Resume loads them back after a restart. Events has to be read, polling waits while it is full.
+type OrderTracker struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+	MissLimit   int    // failed polls before the order is looked up in the open orders
+	StatePath   string // json file of the tracked orders, not saved if empty
+
+	Events chan *OrderEvent
+
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+	stop   chan struct{}
+	saveMu sync.Mutex // one save at a time, a snapshot is never renamed over a newer one
+}
+
+type trackedOrder struct {
+	exchange        Exchange
+	order           *Order
+	wallet          WalletType
+	cancelRequested bool
+	misses          int
+	interval        time.Duration
+	nextPoll        time.Time
+}
+
+// persisted form of a tracked order, the pair is saved by its key
+type trackerState struct {
+	Exchange        ExchangeName   `json:"exchange"`
+	Wallet          WalletType     `json:"wallet"`
+	Pair            string         `json:"pair"`
+	OrderID         string         `json:"order_id"`
+	Rate            float64        `json:"rate"`
+	Quantity        float64        `json:"quantity"`
+	Direction       TradeDirection `json:"direction"`
+	Status          OrderStatus    `json:"status"`
+	DealRate        float64        `json:"deal_rate"`
+	DealQuantity    float64        `json:"deal_quantity"`
+	Timestamp       int64          `json:"timestamp"`
+	CancelRequested bool           `json:"cancel_requested"`
+}
+
+func NewOrderTracker() *OrderTracker {
+	return &OrderTracker{
+		Interval:    DEFAULT_TRACKER_INTERVAL,
+		MaxInterval: DEFAULT_TRACKER_MAX_INTERVAL,
+		MissLimit:   DEFAULT_TRACKER_MISS_LIMIT,
+		Events:      make(chan *OrderEvent, 100),
+		orders:      make(map[string]*trackedOrder),
+	}
+}
+
+func trackerKey(name ExchangeName, orderID string) string {
+	return fmt.Sprintf("%s|%s", name, orderID)
+}
+
+func isDone(status OrderStatus) bool {
+	return status == Filled || status == Cancelled || status == Rejected || status == Expired
+}
+
+// Track registers a placed order, wallet is the wallet of the order, SpotWallet if empty.
+// The order is copied, the changes are only sent on Events.
+func (t *OrderTracker) Track(e Exchange, order *Order, wallet WalletType) error {
+	if e == nil || order == nil || order.OrderID == "" {
+		return fmt.Errorf("OrderTracker Track invalid order: %+v", order)
+	}
+	if wallet == "" {
+		wallet = SpotWallet
+	}
+	o := *order
+	if o.Status == "" {
+		o.Status = New
+	}
+
+	t.mu.Lock()
+	t.orders[trackerKey(e.GetName(), o.OrderID)] = &trackedOrder{
+		exchange: e,
+		order:    &o,
+		wallet:   wallet,
+		interval: t.Interval,
+		nextPoll: time.Now(),
+	}
+	t.mu.Unlock()
+
+	return t.save()
+}
+
+// Untrack stops tracking the order without an event
+func (t *OrderTracker) Untrack(name ExchangeName, orderID string) {
+	t.mu.Lock()
+	delete(t.orders, trackerKey(name, orderID))
+	t.mu.Unlock()
+
+	if err := t.save(); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+// Orders returns a copy of the tracked orders
+func (t *OrderTracker) Orders() []*Order {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	orders := []*Order{}
+	for _, tracked := range t.orders {
+		o := *tracked.order
+		orders = append(orders, &o)
+	}
+	return orders
+}
+
+// Cancel cancels a tracked order, the cancellation is not reported as ExternalCancel
+func (t *OrderTracker) Cancel(name ExchangeName, orderID string) error {
+	t.mu.Lock()
+	tracked, ok := t.orders[trackerKey(name, orderID)]
+	if !ok {
+		t.mu.Unlock()
+		return fmt.Errorf("OrderTracker Cancel %s order %s is not tracked", name, orderID)
+	}
+	tracked.cancelRequested = true
+	o := *tracked.order
+	t.mu.Unlock()
+
+	if tracked.wallet == SpotWallet {
+		if err := tracked.exchange.CancelOrder(&o); err != nil {
+			return err
+		}
+	} else {
+		operation := &AccountOperation{
+			Type:   CancelOrder,
+			Wallet: tracked.wallet,
+			Ex:     name,
+			Pair:   o.Pair,
+			Order:  &o,
+		}
+		if err := tracked.exchange.DoAccountOperation(operation); err != nil {
+			return err
+		}
+	}
+	return t.save()
+}
+
+// Update applies the order from a stream, eg. a websocket order update. It is ignored if the order is not tracked
+func (t *OrderTracker) Update(name ExchangeName, update *Order) {
+	t.mu.Lock()
+	tracked, ok := t.orders[trackerKey(name, update.OrderID)]
+	t.mu.Unlock()
+
+	if ok {
+		t.apply(tracked, update)
+	}
+}
+
+// Start polls the tracked orders until Stop
+func (t *OrderTracker) Start() {
+	t.mu.Lock()
+	if t.stop != nil {
+		t.mu.Unlock()
+		return
+	}
+	t.stop = make(chan struct{})
+	stop := t.stop
+	t.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(t.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				t.Poll()
+			}
+		}
+	}()
+}
+
+func (t *OrderTracker) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stop != nil {
+		close(t.stop)
+		t.stop = nil
+	}
+}
+
+// Poll checks the status of every order due for polling, Start calls it every Interval
+func (t *OrderTracker) Poll() {
+	now := time.Now()
+
+	t.mu.Lock()
+	due := []*trackedOrder{}
+	updates := []*Order{}
+	for _, tracked := range t.orders {
+		if !tracked.nextPoll.After(now) {
+			// poll a copy, adapters set the fields of the order they get
+			update := *tracked.order
+			due = append(due, tracked)
+			updates = append(updates, &update)
+		}
+	}
+	t.mu.Unlock()
+
+	for i, tracked := range due {
+		if err := t.status(tracked, updates[i]); err != nil {
+			t.miss(tracked, err)
+			continue
+		}
+		t.apply(tracked, updates[i])
+	}
+}
+
+// status is OrderStatus for the spot orders, the other wallets are checked with GetOrderStatus
+func (t *OrderTracker) status(tracked *trackedOrder, update *Order) error {
+	if tracked.wallet == SpotWallet {
+		return tracked.exchange.OrderStatus(update)
+	}
+	operation := &AccountOperation{
+		Type:   GetOrderStatus,
+		Wallet: tracked.wallet,
+		Ex:     tracked.exchange.GetName(),
+		Pair:   update.Pair,
+		Order:  update,
+	}
+	if err := tracked.exchange.DoAccountOperation(operation); err != nil {
+		return err
+	}
+	return operation.Error
+}
+
+// miss backs off the order, after MissLimit failures the order is looked up in the open orders
+func (t *OrderTracker) miss(tracked *trackedOrder, err error) {
+	t.mu.Lock()
+	tracked.misses++
+	tracked.interval *= 2
+	if tracked.interval > t.MaxInterval {
+		tracked.interval = t.MaxInterval
+	}
+	tracked.nextPoll = time.Now().Add(tracked.interval)
+	misses := tracked.misses
+	orderID := tracked.order.OrderID
+	p := tracked.order.Pair
+	t.mu.Unlock()
+
+	log.Printf("OrderTracker %s order %s status err: %v", tracked.exchange.GetName(), orderID, err)
+	if misses < t.MissLimit {
+		return
+	}
+
+	operation := &AccountOperation{
+		Type:   GetOpenOrder,
+		Wallet: tracked.wallet,
+		Ex:     tracked.exchange.GetName(),
+		Pair:   p,
+	}
+	if err := tracked.exchange.DoAccountOperation(operation); err != nil {
+		return
+	}
+	for _, open := range operation.OpenOrders {
+		if open.OrderID == orderID {
+			t.apply(tracked, open)
+			return
+		}
+	}
+
+	// not open and no status
+	t.mu.Lock()
+	o := *tracked.order
+	delete(t.orders, trackerKey(tracked.exchange.GetName(), o.OrderID))
+	t.mu.Unlock()
+
+	t.emit(&OrderEvent{
+		Exchange:    tracked.exchange.GetName(),
+		Order:       &o,
+		From:        o.Status,
+		To:          o.Status,
+		Disappeared: true,
+		Timestamp:   time.Now().UnixNano() / 1e6,
+	})
+	if err := t.save(); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+// apply compares the update with the tracked order and emits the change
+func (t *OrderTracker) apply(tracked *trackedOrder, update *Order) {
+	t.mu.Lock()
+	order := tracked.order
+	tracked.misses = 0
+	tracked.interval = t.Interval
+	tracked.nextPoll = time.Now().Add(t.Interval)
+
+	status := update.Status
+	if status == "" || status == Other {
+		status = order.Status
+	}
+	dealQuantity := update.DealQuantity
+	dealRate := update.DealRate
+	// some adapters don't report the fill of a filled order
+	if status == Filled && dealQuantity == 0 {
+		dealQuantity = order.Quantity
+	}
+	if dealQuantity > 0 && dealRate == 0 {
+		dealRate = order.Rate
+	}
+	// the deal quantity is cumulative, never goes back
+	if dealQuantity < order.DealQuantity {
+		dealQuantity = order.DealQuantity
+		dealRate = order.DealRate
+	}
+
+	event := &OrderEvent{
+		Exchange:       tracked.exchange.GetName(),
+		From:           order.Status,
+		To:             status,
+		FilledQuantity: dealQuantity - order.DealQuantity,
+		ExternalCancel: status == Cancelled && order.Status != Cancelled && !tracked.cancelRequested,
+		Timestamp:      time.Now().UnixNano() / 1e6,
+	}
+	if event.From == event.To && event.FilledQuantity == 0 {
+		t.mu.Unlock()
+		return
+	}
+
+	order.Status = status
+	order.DealQuantity = dealQuantity
+	order.DealRate = dealRate
+	if status == Cancelled {
+		order.Canceled = true
+	}
+	o := *order
+	event.Order = &o
+	if isDone(status) {
+		delete(t.orders, trackerKey(tracked.exchange.GetName(), order.OrderID))
+	}
+	t.mu.Unlock()
+
+	t.emit(event)
+	if err := t.save(); err != nil {
+		log.Printf("%v", err)
+	}
+}
+
+func (t *OrderTracker) emit(event *OrderEvent) {
+	if t.Events != nil {
+		t.Events <- event
+	}
+}
+
+func (t *OrderTracker) save() error {
+	if t.StatePath == "" {
+		return nil
+	}
+	t.saveMu.Lock()
+	defer t.saveMu.Unlock()
+
+	t.mu.Lock()
+	states := []*trackerState{}
+	for _, tracked := range t.orders {
+		o := tracked.order
+		state := &trackerState{
+			Exchange:        tracked.exchange.GetName(),
+			Wallet:          tracked.wallet,
+			OrderID:         o.OrderID,
+			Rate:            o.Rate,
+			Quantity:        o.Quantity,
+			Direction:       o.Direction,
+			Status:          o.Status,
+			DealRate:        o.DealRate,
+			DealQuantity:    o.DealQuantity,
+			Timestamp:       o.Timestamp,
+			CancelRequested: tracked.cancelRequested,
+		}
+		if o.Pair != nil {
+			state.Pair = o.Pair.Name
+		}
+		states = append(states, state)
+	}
+	t.mu.Unlock()
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		return fmt.Errorf("OrderTracker save err: %v", err)
+	}
+	// replace the file at once, a crash never leaves half a file
+	tmp := t.StatePath + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("OrderTracker save err: %v", err)
+	}
+	if err := os.Rename(tmp, t.StatePath); err != nil {
+		return fmt.Errorf("OrderTracker save err: %v", err)
+	}
+	return nil
+}
+
+// Resume loads the orders saved in StatePath, the exchanges must be added to the ExchangeManager.
+// Orders of unknown exchanges or pairs are skipped.
+func (t *OrderTracker) Resume() error {
+	data, err := ioutil.ReadFile(t.StatePath)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("OrderTracker Resume err: %v", err)
+	}
+
+	states := []*trackerState{}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return fmt.Errorf("OrderTracker Resume Json Unmarshal Err: %v %s", err, data)
+	}
+
+	manager := CreateExchangeManager()
+	t.mu.Lock()
+	for _, state := range states {
+		e := manager.Get(state.Exchange)
+		p := pair.GetPairByKey(state.Pair)
+		if e == nil || p == nil {
+			log.Printf("OrderTracker Resume skip %s order %s, pair: %s", state.Exchange, state.OrderID, state.Pair)
+			continue
+		}
+
+		if state.Wallet == "" {
+			state.Wallet = SpotWallet
+		}
+		t.orders[trackerKey(state.Exchange, state.OrderID)] = &trackedOrder{
+			exchange: e,
+			order: &Order{
+				EX:           state.Exchange,
+				Pair:         p,
+				OrderID:      state.OrderID,
+				Rate:         state.Rate,
+				Quantity:     state.Quantity,
+				Direction:    state.Direction,
+				Status:       state.Status,
+				DealRate:     state.DealRate,
+				DealQuantity: state.DealQuantity,
+				Timestamp:    state.Timestamp,
+			},
+			wallet:          state.Wallet,
+			cancelRequested: state.CancelRequested,
+			interval:        t.Interval,
+			nextPoll:        time.Now(),
+		}
+	}
+	t.mu.Unlock()
+
+	return nil
+}
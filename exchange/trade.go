@@ -0,0 +1,130 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ErrTradeRange is a TradeHistory range the exchange can't get: older than its latest trades without pages,
+// or without a lower bound on pages going back
+var ErrTradeRange = errors.New("trade history range not available")
+
+// IsTradePaged reports whether TradeHistory has to follow the pages of the exchange,
+// without a range or TradeFromID only the latest trades are returned
+func (operation *PublicOperation) IsTradePaged() bool {
+	return operation.TradeStartTime != 0 || operation.TradeEndTime != 0 || operation.TradeFromID != ""
+}
+
+// FetchTrades sets operation.TradeHistory from the pages of fetch. fetch gets the cursor of the page, "" for the first one,
+// and returns its trades and the cursor of the next page, "" if there is none.
+// backward is the direction of the pages of the exchange, newer to older. The pages are followed until they pass
+// TradeEndTime (forward) or TradeStartTime and TradeFromID (backward), or the cursor doesn't change.
+// The trades are sorted by TimeStamp, without duplicates, outside the range and up to TradeFromID.
+// Without a range and TradeFromID fetch is called once. Backward pages need TradeStartTime or TradeFromID,
+// the pages would go back to the first trade of the pair.
+func FetchTrades(operation *PublicOperation, backward bool, fetch func(cursor string) ([]*TradeDetail, string, error)) error {
+	start, end, fromID := operation.TradeStartTime, operation.TradeEndTime, operation.TradeFromID
+	if backward && operation.IsTradePaged() && start == 0 && fromID == "" {
+		operation.Error = fmt.Errorf("%w: %s pages go back from TradeEndTime, TradeStartTime or TradeFromID is required", ErrTradeRange, operation.EX)
+		return operation.Error
+	}
+
+	seen := make(map[string]bool)
+	trades := []*TradeDetail{}
+	for cursor := ""; ; {
+		page, next, err := fetch(cursor)
+		if err != nil {
+			operation.Error = err
+			return err
+		}
+
+		passed := false
+		for _, trade := range page {
+			key := fmt.Sprintf("%s|%d", trade.ID, trade.TimeStamp)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			trades = append(trades, trade)
+
+			if !backward && end != 0 && trade.TimeStamp > end {
+				passed = true
+			} else if backward && ((start != 0 && trade.TimeStamp < start) || (fromID != "" && !tradeIDAfter(trade.ID, fromID))) {
+				passed = true
+			}
+		}
+		if !operation.IsTradePaged() || passed || next == "" || next == cursor {
+			break
+		}
+		cursor = next
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		if trades[i].TimeStamp != trades[j].TimeStamp {
+			return trades[i].TimeStamp < trades[j].TimeStamp
+		}
+		return tradeIDAfter(trades[j].ID, trades[i].ID)
+	})
+
+	operation.TradeHistory = []*TradeDetail{}
+	after := fromID == ""
+	for _, trade := range trades {
+		if !after {
+			// ids compared as numbers, other ids by their position
+			if _, err := strconv.ParseInt(fromID, 10, 64); err == nil {
+				if !tradeIDAfter(trade.ID, fromID) {
+					continue
+				}
+			} else if trade.ID == fromID {
+				after = true
+				continue
+			} else {
+				continue
+			}
+		}
+		if (start != 0 && trade.TimeStamp < start) || (end != 0 && trade.TimeStamp > end) {
+			continue
+		}
+		operation.TradeHistory = append(operation.TradeHistory, trade)
+	}
+	return nil
+}
+
+// LatestTrades sets TradeHistory from latest, the latest trades of an exchange without pages, as FetchTrades.
+// A range or TradeFromID which starts before them fails with ErrTradeRange, the older trades can't be fetched.
+func LatestTrades(operation *PublicOperation, latest []*TradeDetail) error {
+	if operation.IsTradePaged() && len(latest) > 0 {
+		start, fromID := operation.TradeStartTime, operation.TradeFromID
+		covered := false
+		for _, trade := range latest {
+			if (start != 0 && trade.TimeStamp <= start) || (fromID != "" && (trade.ID == fromID || !tradeIDAfter(trade.ID, fromID))) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			operation.Error = fmt.Errorf("%w: %s has only its latest %d trades, no pages", ErrTradeRange, operation.EX, len(latest))
+			return operation.Error
+		}
+	}
+
+	return FetchTrades(operation, false, func(cursor string) ([]*TradeDetail, string, error) {
+		return latest, "", nil
+	})
+}
+
+// tradeIDAfter reports whether id is after from, as numbers if both are
+func tradeIDAfter(id, from string) bool {
+	a, errA := strconv.ParseInt(id, 10, 64)
+	b, errB := strconv.ParseInt(from, 10, 64)
+	if errA == nil && errB == nil {
+		return a > b
+	}
+	return id > from
+}
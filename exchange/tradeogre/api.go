@@ -20,7 +20,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://tradeogre.com/api/v1"
 )
 
@@ -306,12 +306,17 @@ func (e *Tradeogre) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchang
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/order/sell"
 
 	mapParams := make(map[string]string)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["market"] = e.GetSymbolByPair(pair)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
@@ -339,12 +344,17 @@ func (e *Tradeogre) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	placeOrder := PlaceOrder{}
 	strRequest := "/order/buy"
 
 	mapParams := make(map[string]string)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["market"] = e.GetSymbolByPair(pair)
 
 	jsonPlaceReturn := e.ApiKeyRequest("POST", strRequest, mapParams)
@@ -459,7 +469,8 @@ Step 2: Create mapParams Depend on API Signature request
 Step 3: Add HttpGetRequest below strUrl if API has different requests*/
 func (e *Tradeogre) ApiKeyRequest(strMethod, strRequestPath string, mapParams map[string]string) string {
 
-	strUrl := "https://" + e.API_KEY + ":" + e.API_SECRET + "@tradeogre.com/api/v1" + strRequestPath
+	// basic auth in the url
+	strUrl := strings.Replace(API_URL, "://", "://"+e.API_KEY+":"+e.API_SECRET+"@", 1) + strRequestPath
 
 	if strMethod == "GET" {
 		return exchange.HttpGetRequest(strUrl, mapParams)
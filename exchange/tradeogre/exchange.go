@@ -247,6 +247,19 @@ func (e *Tradeogre) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Constrai
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *Tradeogre) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *Tradeogre) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
@@ -25,7 +25,7 @@ import (
 	"github.com/bitontop/gored/pair"
 )
 
-const (
+var (
 	API_URL string = "https://tradesatoshi.com/api"
 )
 
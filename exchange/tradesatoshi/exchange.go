@@ -247,6 +247,22 @@ func (e *TradeSatoshi) GetConstraintFetchMethod(pair *pair.Pair) *exchange.Const
 	return constrainFetchMethod
 }
 
+var capabilities = &exchange.Capabilities{
+	Account: map[exchange.OperationType][]exchange.WalletType{
+		exchange.Withdraw: {exchange.SpotWallet},
+	},
+	OrderTypes: map[exchange.WalletType][]exchange.OrderTradeType{
+		exchange.SpotWallet: {exchange.TRADE_LIMIT},
+	},
+	TimeInForce: map[exchange.WalletType][]exchange.OrderPriceType{
+		exchange.SpotWallet: {exchange.GTC},
+	},
+}
+
+func (e *TradeSatoshi) GetCapabilities() *exchange.Capabilities {
+	return capabilities
+}
+
 func (e *TradeSatoshi) UpdateConstraint() {
 	e.GetCoinsData()
 	e.GetPairsData()
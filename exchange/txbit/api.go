@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
-	"math"
 	"net/http"
 	"strings"
 	"time"
@@ -21,7 +20,7 @@ import (
 	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://api.txbit.io/api"
 )
 
@@ -350,13 +349,15 @@ func (e *Txbit) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.Or
 	uuid := Uuid{}
 	strRequest := "/market/selllimit"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyGET(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
@@ -390,13 +391,15 @@ func (e *Txbit) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.Ord
 	uuid := Uuid{}
 	strRequest := "/market/buylimit"
 
-	priceFilter := int(math.Round(math.Log10(e.GetPriceFilter(pair)) * -1))
-	lotSize := int(math.Round(math.Log10(e.GetLotSize(pair)) * -1))
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
 
 	mapParams := make(map[string]string)
 	mapParams["market"] = e.GetSymbolByPair(pair)
-	mapParams["quantity"] = strconv.FormatFloat(quantity, 'f', lotSize, 64)
-	mapParams["rate"] = strconv.FormatFloat(rate, 'f', priceFilter, 64)
+	mapParams["quantity"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["rate"] = pairConstraint.FormatRate(rate)
 
 	jsonPlaceReturn := e.ApiKeyGET(strRequest, mapParams)
 	if err := json.Unmarshal([]byte(jsonPlaceReturn), &jsonResponse); err != nil {
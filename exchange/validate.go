@@ -0,0 +1,135 @@
+package exchange
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/pair"
+)
+
+// ErrInvalidOrder is wrapped by every OrderValidationError
+var ErrInvalidOrder = errors.New("invalid order")
+
+// OrderValidationError is returned before the request is sent, Field is one of:
+// pair, quantity, rate, notional
+type OrderValidationError struct {
+	Exchange ExchangeName
+	Pair     *pair.Pair
+	Field    string
+	Value    float64
+	Limit    float64
+	Reason   string
+}
+
+func (e *OrderValidationError) Error() string {
+	pairName := ""
+	if e.Pair != nil {
+		pairName = e.Pair.Name
+	}
+	return fmt.Sprintf("%s %s %v: %s %v %s %v", e.Exchange, pairName, ErrInvalidOrder, e.Field, e.Value, e.Reason, e.Limit)
+}
+
+func (e *OrderValidationError) Unwrap() error {
+	return ErrInvalidOrder
+}
+
+// ValidateOrder checks the quantity and rate of a limit order against the PairConstraint of the pair.
+// With round the quantity is rounded down to LotSize and the rate to PriceFilter, down for buy and up for sell,
+// otherwise they have to be on the step already. Zero constraints are not checked.
+// It returns the quantity and rate to send, the error is an *OrderValidationError.
+func ValidateOrder(e Exchange, p *pair.Pair, direction TradeDirection, quantity, rate float64, round bool) (float64, float64, error) {
+	invalid := func(field string, value, limit float64, reason string) (float64, float64, error) {
+		return quantity, rate, &OrderValidationError{
+			Exchange: e.GetName(),
+			Pair:     p,
+			Field:    field,
+			Value:    value,
+			Limit:    limit,
+			Reason:   reason,
+		}
+	}
+
+	constraint := e.GetPairConstraint(p)
+	if constraint == nil {
+		return invalid("pair", 0, 0, "has no constraint")
+	}
+	if quantity <= 0 {
+		return invalid("quantity", quantity, 0, "must be greater than")
+	} else if rate < 0 {
+		return invalid("rate", rate, 0, "must not be less than")
+	}
+
+	quantityDecimal := decimal.NewFromFloat(quantity)
+	rateDecimal := decimal.NewFromFloat(rate)
+	if round {
+		quantity = quantityDecimal.RoundStep(constraint.LotSizeDecimal(), false).Float64()
+		if rate > 0 {
+			rate = rateDecimal.RoundStep(constraint.PriceFilterDecimal(), direction == Sell).Float64()
+		}
+	} else if !quantityDecimal.IsMultipleOf(constraint.LotSizeDecimal()) {
+		return invalid("quantity", quantity, constraint.LotSize, "is not a multiple of LotSize")
+	} else if rate > 0 && !rateDecimal.IsMultipleOf(constraint.PriceFilterDecimal()) {
+		return invalid("rate", rate, constraint.PriceFilter, "is not a multiple of PriceFilter")
+	}
+
+	notional := decimal.NewFromFloat(quantity).Mul(decimal.NewFromFloat(rate))
+	if quantity <= 0 {
+		return invalid("quantity", quantity, constraint.LotSize, "rounded to 0 by LotSize")
+	} else if quantity < constraint.MinTradeQuantity {
+		return invalid("quantity", quantity, constraint.MinTradeQuantity, "is less than MinTradeQuantity")
+	} else if rate > 0 && notional.LessThan(decimal.NewFromFloat(constraint.MinTradeBaseQuantity)) {
+		return invalid("notional", notional.Float64(), constraint.MinTradeBaseQuantity, "is less than MinTradeBaseQuantity")
+	}
+
+	return quantity, rate, nil
+}
+
+// ValidatedExchange validates LimitBuy, LimitSell and PlaceOrder operations with ValidateOrder before they are sent.
+// With AutoRound the quantity and rate are rounded to the constraint instead of rejected.
+type ValidatedExchange struct {
+	Exchange
+	AutoRound bool
+}
+
+func NewValidatedExchange(e Exchange, autoRound bool) *ValidatedExchange {
+	return &ValidatedExchange{
+		Exchange:  e,
+		AutoRound: autoRound,
+	}
+}
+
+func (v *ValidatedExchange) LimitBuy(p *pair.Pair, quantity, rate float64) (*Order, error) {
+	quantity, rate, err := ValidateOrder(v.Exchange, p, Buy, quantity, rate, v.AutoRound)
+	if err != nil {
+		return nil, err
+	}
+	return v.Exchange.LimitBuy(p, quantity, rate)
+}
+
+func (v *ValidatedExchange) LimitSell(p *pair.Pair, quantity, rate float64) (*Order, error) {
+	quantity, rate, err := ValidateOrder(v.Exchange, p, Sell, quantity, rate, v.AutoRound)
+	if err != nil {
+		return nil, err
+	}
+	return v.Exchange.LimitSell(p, quantity, rate)
+}
+
+// DoAccountOperation validates spot and margin PlaceOrder, the constraints are of the spot pairs.
+// The rate of a market order is only used for the notional check if it is set
+func (v *ValidatedExchange) DoAccountOperation(operation *AccountOperation) error {
+	if operation.Type == PlaceOrder && (operation.Wallet == SpotWallet || operation.Wallet == MarginWallet) {
+		quantity, rate, err := ValidateOrder(v.Exchange, operation.Pair, operation.OrderDirection, operation.Quantity, operation.Rate, v.AutoRound)
+		if err != nil {
+			operation.Error = err
+			return err
+		}
+		operation.Quantity = quantity
+		operation.Rate = rate
+	}
+	return v.Exchange.DoAccountOperation(operation)
+}
@@ -18,10 +18,9 @@ import (
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/pair"
 
-	"strconv"
 )
 
-const (
+var (
 	API_URL string = "https://www.virgocx.ca/api"
 )
 
@@ -321,10 +320,15 @@ func (e *Virgocx) LimitSell(pair *pair.Pair, quantity, rate float64) (*exchange.
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["qty"] = strconv.FormatFloat(quantity, 'f', -1, 64)
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)
+	mapParams["qty"] = pairConstraint.FormatQuantity(quantity)
+	mapParams["price"] = pairConstraint.FormatRate(rate)
 	mapParams["category"] = "1" // limit
 	mapParams["type"] = "2"     // buy: 1, sell: 2
 
@@ -360,12 +364,17 @@ func (e *Virgocx) LimitBuy(pair *pair.Pair, quantity, rate float64) (*exchange.O
 		return nil, fmt.Errorf("%s API Key or Secret Key are nil", e.GetName())
 	}
 
+	// rounded to LotSize and PriceFilter, the returned order has the quantity and rate sent
+	pairConstraint := e.GetPairConstraint(pair)
+	quantity = pairConstraint.QuantityDecimal(quantity).Float64()
+	rate = pairConstraint.RateDecimal(rate).Float64()
+
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(pair)
-	mapParams["qty"] = strconv.FormatFloat(quantity, 'f', -1, 64) // "1.2"
-	mapParams["price"] = strconv.FormatFloat(rate, 'f', -1, 64)   // "9000.1"
-	mapParams["category"] = "1"                                   // limit
-	mapParams["type"] = "1"                                       // buy: 1, sell: 2
+	mapParams["qty"] = pairConstraint.FormatQuantity(quantity) // "1.2"
+	mapParams["price"] = pairConstraint.FormatRate(rate)       // "9000.1"
+	mapParams["category"] = "1"                                // limit
+	mapParams["type"] = "1"                                    // buy: 1, sell: 2
 
 	jsonResponse := &JsonResponse{}
 	placeOrder := PlaceOrder{}
@@ -26,8 +26,9 @@ func (e *Virgocx) doPlaceOrder(operation *exchange.AccountOperation) error {
 
 	mapParams := make(map[string]string)
 	mapParams["symbol"] = e.GetSymbolByPair(operation.Pair)
-	mapParams["qty"] = fmt.Sprintf("%v", operation.Quantity)
-	mapParams["price"] = fmt.Sprintf("%v", operation.Rate)
+	pairConstraint := e.GetPairConstraint(operation.Pair)
+	mapParams["qty"] = pairConstraint.FormatQuantity(operation.Quantity)
+	mapParams["price"] = pairConstraint.FormatRate(operation.Rate)
 
 	if operation.TradeType == exchange.TRADE_LIMIT {
 		mapParams["category"] = "1" // limit
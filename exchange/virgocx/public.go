@@ -17,63 +17,74 @@ func (e *Virgocx) LoadPublicData(operation *exchange.PublicOperation) error {
 	return fmt.Errorf("LoadPublicData :: Operation type invalid: %+v", operation.Type)
 }
 
-// interval: 1, 5, 10, 30, 60, 240, 1440, 7200, 10080, 43200
-func (e *Virgocx) doKline(operation *exchange.PublicOperation) error {
-	interval := "5"
-	if operation.KlineInterval != "" {
-		interval = operation.KlineInterval
-	}
-
-	get := &utils.HttpGet{
-		URI: fmt.Sprintf("https://www.virgocx.ca/api/market/history/kline?symbol=%v&period=%v",
-			e.GetSymbolByPair(operation.Pair), // BTC/CAD
-			interval,
-		),
-		Proxy: operation.Proxy,
-	}
-
-	err := utils.HttpGetRequest(get)
+var klineIntervals = exchange.KlineIntervals{
+	exchange.KLINE_1MIN:   "1",
+	exchange.KLINE_5MIN:   "5",
+	exchange.KLINE_30MIN:  "30",
+	exchange.KLINE_1HOUR:  "60",
+	exchange.KLINE_4HOUR:  "240",
+	exchange.KLINE_1DAY:   "1440",
+	exchange.KLINE_1WEEK:  "10080",
+	exchange.KLINE_1MONTH: "43200",
+}
 
+func (e *Virgocx) doKline(operation *exchange.PublicOperation) error {
+	interval, err := klineIntervals.Get(e.GetName(), operation.KlineInterval)
 	if err != nil {
-		log.Printf("%+v", err)
 		operation.Error = err
 		return err
-
 	}
 
-	if operation.DebugMode {
-		operation.RequestURI = get.URI
-		operation.CallResponce = string(get.ResponseBody)
-	}
+	// no time range
+	return exchange.FetchKline(operation, 0, func(start, end int64) ([]*exchange.KlineDetail, error) {
+		get := &utils.HttpGet{
+			URI: fmt.Sprintf("%s/market/history/kline?symbol=%v&period=%v",
+				API_URL,
+				e.GetSymbolByPair(operation.Pair), // BTC/CAD
+				interval,
+			),
+			Proxy: operation.Proxy,
+		}
 
-	jsonResponse := JsonResponse{}
-	rawKline := RawKline{}
+		err := utils.HttpGetRequest(get)
 
-	if err := json.Unmarshal([]byte(get.ResponseBody), &jsonResponse); err != nil {
-		operation.Error = fmt.Errorf("%s doKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
-		return operation.Error
-	} else if jsonResponse.Code != 0 {
-		operation.Error = fmt.Errorf("%s doKline Failed: %v", e.GetName(), string(get.ResponseBody))
-		return operation.Error
-	}
-	if err := json.Unmarshal(jsonResponse.Data, &rawKline); err != nil {
-		operation.Error = fmt.Errorf("%s doKline Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
-		return operation.Error
-	}
+		if err != nil {
+			log.Printf("%+v", err)
+			return nil, err
 
-	operation.Kline = []*exchange.KlineDetail{}
-	for _, k := range rawKline {
-		detail := &exchange.KlineDetail{
-			OpenTime: float64(k.CreateTime),
-			Open:     k.Open,
-			High:     k.High,
-			Low:      k.Low,
-			Close:    k.Close,
-			Volume:   k.Qty,
 		}
 
-		operation.Kline = append(operation.Kline, detail)
-	}
+		if operation.DebugMode {
+			operation.RequestURI = get.URI
+			operation.CallResponce = string(get.ResponseBody)
+		}
+
+		jsonResponse := JsonResponse{}
+		rawKline := RawKline{}
+
+		if err := json.Unmarshal([]byte(get.ResponseBody), &jsonResponse); err != nil {
+			return nil, fmt.Errorf("%s doKline Json Unmarshal Err: %v %v", e.GetName(), err, string(get.ResponseBody))
+		} else if jsonResponse.Code != 0 {
+			return nil, fmt.Errorf("%s doKline Failed: %v", e.GetName(), string(get.ResponseBody))
+		}
+		if err := json.Unmarshal(jsonResponse.Data, &rawKline); err != nil {
+			return nil, fmt.Errorf("%s doKline Data Unmarshal Err: %v %s", e.GetName(), err, jsonResponse.Data)
+		}
+
+		details := []*exchange.KlineDetail{}
+		for _, k := range rawKline {
+			detail := &exchange.KlineDetail{
+				OpenTime: float64(k.CreateTime),
+				Open:     k.Open,
+				High:     k.High,
+				Low:      k.Low,
+				Close:    k.Close,
+				Volume:   k.Qty,
+			}
+
+			details = append(details, detail)
+		}
 
-	return nil
+		return details, nil
+	})
 }
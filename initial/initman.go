@@ -586,3 +586,78 @@ func (e *InitManager) Init(config *exchange.Config) exchange.Exchange {
 	}
 	return nil
 }
+
+// Adapters is every exchange of Init, not initialized: only what doesn't need the data or the keys works, eg. GetCapabilities
+func Adapters() []exchange.Exchange {
+	return []exchange.Exchange{
+		&abcc.Abcc{},
+		&bcex.Bcex{},
+		&bgogo.Bgogo{},
+		&bibox.Bibox{},
+		&bigone.Bigone{},
+		&biki.Biki{},
+		&binance.Binance{},
+		&binancedex.BinanceDex{},
+		&bitbay.Bitbay{},
+		&bitbns.Bitbns{},
+		&bitfinex.Bitfinex{},
+		&bitforex.Bitforex{},
+		&bithumb.Bithumb{},
+		&bitmart.Bitmart{},
+		&bitmax.Bitmax{},
+		&bitmex.Bitmex{},
+		&bitpie.Bitpie{},
+		&bitrue.Bitrue{},
+		&bitstamp.Bitstamp{},
+		&bittrex.Bittrex{},
+		&bitz.Bitz{},
+		&bkex.Bkex{},
+		&blocktrade.Blocktrade{},
+		&bw.Bw{},
+		&bybit.Bybit{},
+		&coinbase.Coinbase{},
+		&coinbene.Coinbene{},
+		&coindeal.Coindeal{},
+		&coineal.Coineal{},
+		&coinex.Coinex{},
+		&cointiger.Cointiger{},
+		&dcoin.Dcoin{},
+		&deribit.Deribit{},
+		&digifinex.Digifinex{},
+		&dragonex.Dragonex{},
+		&ftx.Ftx{},
+		&gateio.Gateio{},
+		&goko.Goko{},
+		&hibitex.Hibitex{},
+		&hitbtc.Hitbtc{},
+		&homiex.Homiex{},
+		&hoo.Hoo{},
+		&huobi.Huobi{},
+		&huobidm.Huobidm{},
+		&huobiotc.HuobiOTC{},
+		&ibankdigital.Ibankdigital{},
+		&idcm.Idcm{},
+		&idex.Idex{},
+		&kraken.Kraken{},
+		&kucoin.Kucoin{},
+		&latoken.Latoken{},
+		&lbank.Lbank{},
+		&liquid.Liquid{},
+		&mxc.Mxc{},
+		&newcapital.Newcapital{},
+		&okex.Okex{},
+		&okexdm.Okexdm{},
+		&oksim.Oksim{},
+		&otcbtc.Otcbtc{},
+		&poloniex.Poloniex{},
+		&probit.Probit{},
+		&stex.Stex{},
+		&switcheo.Switcheo{},
+		&tagz.Tagz{},
+		&tokok.Tokok{},
+		&tradeogre.Tradeogre{},
+		&txbit.Txbit{},
+		&virgocx.Virgocx{},
+		&zebitex.Zebitex{},
+	}
+}
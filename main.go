@@ -5,6 +5,7 @@ package main
 // file COPYING or http://www.opensource.org/licenses/mit-license.php.
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"time"
@@ -75,6 +76,7 @@ import (
 	"github.com/bitontop/gored/exchange/txbit"
 	"github.com/bitontop/gored/exchange/virgocx"
 	"github.com/bitontop/gored/exchange/zebitex"
+	"github.com/bitontop/gored/initial"
 	"github.com/bitontop/gored/pair"
 	"github.com/bitontop/gored/test/conf"
 	"github.com/bitontop/gored/utils"
@@ -113,6 +115,10 @@ func main() {
 			}
 			exMan.UpdateExData(updateConfig)
 			break
+		case "capabilities":
+			// go run . capabilities > CAPABILITIES.md
+			fmt.Print(exchange.NewCoverage(initial.Adapters()...).Markdown())
+			break
 		case "test":
 			base := coin.Coin{
 				Code: "BTC",
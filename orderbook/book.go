@@ -0,0 +1,224 @@
+package orderbook
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Level is a level of the orderbook of an exchange. Price is Rate with the taker fee of the exchange,
+// what a taker pays for an ask and gets for a bid.
+type Level struct {
+	Exchange exchange.ExchangeName `json:"exchange"`
+	Rate     float64               `json:"rate"`
+	Price    float64               `json:"price"`
+	Quantity float64               `json:"quantity"`
+}
+
+// Book is the orderbooks of a pair on several exchanges merged in one depth ladder,
+// bids by Price descending and asks ascending. A Price of several exchanges has a Level per exchange.
+type Book struct {
+	Pair      *pair.Pair                                `json:"pair"`
+	Bids      []*Level                                  `json:"bids"`
+	Asks      []*Level                                  `json:"asks"`
+	Timestamp int64                                     `json:"timestamp"` // ms
+	Errors    map[exchange.ExchangeName]error           `json:"-"`         // of the exchanges without a book
+	Fees      map[exchange.ExchangeName]float64         `json:"fees"`      // taker fee of the merged exchanges
+	Makers    map[exchange.ExchangeName]*exchange.Maker `json:"-"`
+}
+
+// Execution is the levels a taker order of Quantity takes in a Book
+type Execution struct {
+	Direction exchange.TradeDirection `json:"direction"`
+	Quantity  float64                 `json:"quantity"` // filled, less than asked without enough depth
+	Cost      float64                 `json:"cost"`     // in the Base coin with the fees, paid for a buy and received for a sell
+	Price     float64                 `json:"price"`    // Cost / Quantity
+	Fills     []*Level                `json:"fills"`    // Quantity of a fill is what is taken from the level
+}
+
+// Merge merges the makers of the exchanges, the rates are adjusted with fees, the taker fee of each exchange
+func Merge(p *pair.Pair, makers map[exchange.ExchangeName]*exchange.Maker, fees map[exchange.ExchangeName]float64) *Book {
+	book := &Book{
+		Pair:   p,
+		Bids:   []*Level{},
+		Asks:   []*Level{},
+		Errors: make(map[exchange.ExchangeName]error),
+		Fees:   make(map[exchange.ExchangeName]float64),
+		Makers: make(map[exchange.ExchangeName]*exchange.Maker),
+	}
+
+	for name, maker := range makers {
+		if maker == nil {
+			continue
+		}
+		fee := fees[name]
+		book.Fees[name] = fee
+		book.Makers[name] = maker
+		if timestamp := int64(maker.Timestamp); timestamp > book.Timestamp {
+			book.Timestamp = timestamp
+		}
+
+		for _, bid := range maker.Bids {
+			if bid.Rate > 0 && bid.Quantity > 0 {
+				book.Bids = append(book.Bids, &Level{Exchange: name, Rate: bid.Rate, Price: bid.Rate * (1 - fee), Quantity: bid.Quantity})
+			}
+		}
+		for _, ask := range maker.Asks {
+			if ask.Rate > 0 && ask.Quantity > 0 {
+				book.Asks = append(book.Asks, &Level{Exchange: name, Rate: ask.Rate, Price: ask.Rate * (1 + fee), Quantity: ask.Quantity})
+			}
+		}
+	}
+
+	// same Price, the exchange with the lower fee first, then by name to be stable
+	sort.Slice(book.Bids, func(i, j int) bool {
+		a, b := book.Bids[i], book.Bids[j]
+		if a.Price != b.Price {
+			return a.Price > b.Price
+		} else if a.Rate != b.Rate {
+			return a.Rate < b.Rate
+		}
+		return a.Exchange < b.Exchange
+	})
+	sort.Slice(book.Asks, func(i, j int) bool {
+		a, b := book.Asks[i], book.Asks[j]
+		if a.Price != b.Price {
+			return a.Price < b.Price
+		} else if a.Rate != b.Rate {
+			return a.Rate > b.Rate
+		}
+		return a.Exchange < b.Exchange
+	})
+	return book
+}
+
+// BestBid is the highest bid after fees, nil if there is none
+func (b *Book) BestBid() *Level {
+	if len(b.Bids) == 0 {
+		return nil
+	}
+	return b.Bids[0]
+}
+
+// BestAsk is the lowest ask after fees, nil if there is none
+func (b *Book) BestAsk() *Level {
+	if len(b.Asks) == 0 {
+		return nil
+	}
+	return b.Asks[0]
+}
+
+// Depth is the quantity of the side of a direction up to price, after fees. A Buy takes the asks.
+func (b *Book) Depth(direction exchange.TradeDirection, price float64) float64 {
+	levels, better, _ := b.side(direction)
+	depth := decimal.NewFromFloat(0)
+	for _, level := range levels {
+		if better(price, level.Price) {
+			break
+		}
+		depth = depth.Add(decimal.NewFromFloat(level.Quantity))
+	}
+	return depth.Float64()
+}
+
+// BestExecution takes quantity from the best levels across the exchanges, a Buy takes the asks and a Sell the bids.
+// Without enough depth the Execution of what is there is returned with an error.
+func (b *Book) BestExecution(direction exchange.TradeDirection, quantity float64) (*Execution, error) {
+	levels, _, ok := b.side(direction)
+	if !ok {
+		return nil, fmt.Errorf("BestExecution invalid direction: %v", direction)
+	} else if quantity <= 0 {
+		return nil, fmt.Errorf("BestExecution invalid quantity: %v", quantity)
+	}
+
+	execution := &Execution{
+		Direction: direction,
+		Fills:     []*Level{},
+	}
+	left := decimal.NewFromFloat(quantity)
+	filled, cost := decimal.NewFromFloat(0), decimal.NewFromFloat(0)
+	for _, level := range levels {
+		if left.Sign() <= 0 {
+			break
+		}
+		take := decimal.NewFromFloat(level.Quantity)
+		if take.GreaterThan(left) {
+			take = left
+		}
+		left = left.Sub(take)
+		filled = filled.Add(take)
+		cost = cost.Add(take.Mul(decimal.NewFromFloat(level.Price)))
+
+		execution.Fills = append(execution.Fills, &Level{
+			Exchange: level.Exchange,
+			Rate:     level.Rate,
+			Price:    level.Price,
+			Quantity: take.Float64(),
+		})
+	}
+
+	execution.Quantity = filled.Float64()
+	execution.Cost = cost.Float64()
+	if execution.Quantity > 0 {
+		execution.Price = execution.Cost / execution.Quantity
+	}
+	if left.Sign() > 0 {
+		return execution, fmt.Errorf("%s BestExecution not enough depth: %v of %v", b.Pair.Name, execution.Quantity, quantity)
+	}
+	return execution, nil
+}
+
+// BuyQuantity is the Target quantity cost buys from the asks after fees, all of the asks if they cost less
+func (b *Book) BuyQuantity(cost float64) float64 {
+	left := decimal.NewFromFloat(cost)
+	quantity := decimal.NewFromFloat(0)
+	for _, level := range b.Asks {
+		if left.Sign() <= 0 {
+			break
+		}
+		price := decimal.NewFromFloat(level.Price)
+		levelCost := decimal.NewFromFloat(level.Quantity).Mul(price)
+		if levelCost.GreaterThan(left) {
+			quantity = quantity.Add(decimal.NewFromFloat(left.Float64() / level.Price))
+			break
+		}
+		quantity = quantity.Add(decimal.NewFromFloat(level.Quantity))
+		left = left.Sub(levelCost)
+	}
+	return quantity.Float64()
+}
+
+// Venues is the quantity of an Execution on each exchange
+func (e *Execution) Venues() map[exchange.ExchangeName]float64 {
+	venues := make(map[exchange.ExchangeName]decimal.Decimal)
+	for _, fill := range e.Fills {
+		if _, ok := venues[fill.Exchange]; !ok {
+			venues[fill.Exchange] = decimal.NewFromFloat(0)
+		}
+		venues[fill.Exchange] = venues[fill.Exchange].Add(decimal.NewFromFloat(fill.Quantity))
+	}
+
+	result := make(map[exchange.ExchangeName]float64)
+	for name, quantity := range venues {
+		result[name] = quantity.Float64()
+	}
+	return result
+}
+
+// side is the levels a direction takes and how a price compares to a better one
+func (b *Book) side(direction exchange.TradeDirection) ([]*Level, func(price, than float64) bool, bool) {
+	switch direction {
+	case exchange.Buy:
+		return b.Asks, func(price, than float64) bool { return price < than }, true
+	case exchange.Sell:
+		return b.Bids, func(price, than float64) bool { return price > than }, true
+	}
+	return nil, func(price, than float64) bool { return true }, false
+}
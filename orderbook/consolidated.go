@@ -0,0 +1,150 @@
+package orderbook
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Venue is an exchange of a Consolidated book. Its books come from Feed, the Exchange if Feed is nil.
+// Fee is the taker fee applied to its levels, GetFee of the Exchange by default.
+type Venue struct {
+	Name     exchange.ExchangeName
+	Exchange exchange.Exchange
+	Feed     exchange.BookFeed
+	Fee      float64
+}
+
+// Consolidated keeps the latest orderbook of a pair on every venue and merges them in a Book.
+// Update fetches all the feeds, Set gives a book streamed by the caller.
+//
+//	c := orderbook.NewConsolidated(p, binance, huobi, okex)
+//	b, err := c.Update()
+//	execution, err := b.BestExecution(exchange.Buy, 2.5)
+type Consolidated struct {
+	Pair   *pair.Pair
+	Venues []*Venue
+	MaxAge time.Duration // a book older than MaxAge is left out of the merge, 0 keeps them
+
+	mutex  sync.Mutex
+	makers map[exchange.ExchangeName]*exchange.Maker
+	times  map[exchange.ExchangeName]time.Time
+	errors map[exchange.ExchangeName]error
+}
+
+// NewConsolidated makes a venue of every exchange with the pair
+func NewConsolidated(p *pair.Pair, exchanges ...exchange.Exchange) *Consolidated {
+	c := &Consolidated{
+		Pair:   p,
+		Venues: []*Venue{},
+	}
+	for _, e := range exchanges {
+		if e == nil || !e.HasPair(p) {
+			continue
+		}
+		c.Venues = append(c.Venues, &Venue{
+			Name:     e.GetName(),
+			Exchange: e,
+			Fee:      e.GetFee(p),
+		})
+	}
+	return c
+}
+
+// Update fetches the book of every venue with a feed in parallel and merges them.
+// A venue failing keeps its previous book, its error is in Book.Errors; the error is returned if no venue has a book.
+func (c *Consolidated) Update() (*Book, error) {
+	var wg sync.WaitGroup
+	for _, venue := range c.Venues {
+		feed := venue.Feed
+		if feed == nil && venue.Exchange != nil {
+			feed = venue.Exchange
+		}
+		if feed == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(venue *Venue, feed exchange.BookFeed) {
+			defer wg.Done()
+			maker, err := feed.OrderBook(c.Pair)
+			if err == nil && maker == nil {
+				err = fmt.Errorf("%s %s empty orderbook", venue.Name, c.Pair.Name)
+			}
+			if err != nil {
+				c.setError(venue.Name, err)
+				return
+			}
+			c.Set(venue.Name, maker)
+		}(venue, feed)
+	}
+	wg.Wait()
+
+	book := c.Book()
+	if len(book.Makers) == 0 {
+		return book, fmt.Errorf("%s no orderbook of any venue: %v", c.Pair.Name, book.Errors)
+	}
+	return book, nil
+}
+
+// Set is the latest book of a venue, e.g. from a websocket
+func (c *Consolidated) Set(name exchange.ExchangeName, maker *exchange.Maker) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.init()
+	c.makers[name] = maker
+	c.times[name] = time.Now()
+	delete(c.errors, name)
+}
+
+func (c *Consolidated) setError(name exchange.ExchangeName, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.init()
+	c.errors[name] = err
+}
+
+// Book merges the latest book of every venue
+func (c *Consolidated) Book() *Book {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.init()
+
+	makers := make(map[exchange.ExchangeName]*exchange.Maker)
+	fees := make(map[exchange.ExchangeName]float64)
+	errors := make(map[exchange.ExchangeName]error)
+	for _, venue := range c.Venues {
+		if err, ok := c.errors[venue.Name]; ok {
+			errors[venue.Name] = err
+		}
+		maker, ok := c.makers[venue.Name]
+		if !ok {
+			continue
+		}
+		if c.MaxAge > 0 && time.Since(c.times[venue.Name]) > c.MaxAge {
+			errors[venue.Name] = fmt.Errorf("%s %s orderbook older than %v", venue.Name, c.Pair.Name, c.MaxAge)
+			continue
+		}
+		makers[venue.Name] = maker
+		fees[venue.Name] = venue.Fee
+	}
+
+	book := Merge(c.Pair, makers, fees)
+	book.Errors = errors
+	return book
+}
+
+func (c *Consolidated) init() {
+	if c.makers == nil {
+		c.makers = make(map[exchange.ExchangeName]*exchange.Maker)
+		c.times = make(map[exchange.ExchangeName]time.Time)
+		c.errors = make(map[exchange.ExchangeName]error)
+	}
+}
@@ -0,0 +1,175 @@
+package analytics
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/bitontop/gored/analytics"
+	"github.com/bitontop/gored/exchange"
+)
+
+// an adapter book out of order with an empty level, normalized then measured
+func TestAnalytics(t *testing.T) {
+	maker := book(
+		[][2]float64{{99, 2}, {100, 1}, {98.5, 0}, {97, 4}},
+		[][2]float64{{102, 2}, {101, 1}, {104, 5}},
+	)
+
+	err := analytics.Validate(maker)
+	var invalid *analytics.BookValidationError
+	if !errors.As(err, &invalid) || !errors.Is(err, analytics.ErrInvalidBook) {
+		t.Fatalf("Validate %v", err)
+	} else if !invalid.UnsortedBids || !invalid.UnsortedAsks || invalid.Crossed || invalid.Empty != 1 {
+		t.Errorf("Validate %+v", invalid)
+	}
+
+	normalized, err := analytics.Normalize(maker)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if err := analytics.Validate(normalized); err != nil {
+		t.Fatalf("normalized %v", err)
+	} else if len(normalized.Bids) != 3 || len(maker.Bids) != 4 {
+		t.Fatalf("%d bids normalized from %d", len(normalized.Bids), len(maker.Bids))
+	}
+
+	if mid := analytics.Mid(normalized); !near(mid, 100.5) {
+		t.Errorf("mid %v", mid)
+	}
+	if bps := analytics.SpreadBps(normalized); !near(bps, 1/100.5*10000) {
+		t.Errorf("spread %v bps", bps)
+	}
+
+	// 1 @ 101, 1.5 @ 102
+	vwap, err := analytics.VWAP(normalized, exchange.Buy, 2.5)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if !near(vwap, (101+1.5*102)/2.5) {
+		t.Errorf("vwap %v", vwap)
+	}
+	slippage, err := analytics.Slippage(normalized, exchange.Buy, 2.5)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if !near(slippage, (vwap-101)/101*10000) {
+		t.Errorf("slippage %v bps", slippage)
+	}
+	if _, err := analytics.VWAP(normalized, exchange.Sell, 10); err == nil {
+		t.Errorf("VWAP sell of 10 with 7 of bids")
+	}
+
+	// 100.5 * 1.02 = 102.51, 101 and 102
+	if quantity, value := analytics.Depth(normalized, exchange.Buy, 2); !near(quantity, 3) || !near(value, 305) {
+		t.Errorf("buy depth %v %v", quantity, value)
+	}
+	// 100.5 * 0.98 = 98.49, 100 and 99
+	if quantity, value := analytics.Depth(normalized, exchange.Sell, 2); !near(quantity, 3) || !near(value, 298) {
+		t.Errorf("sell depth %v %v", quantity, value)
+	}
+	if imbalance := analytics.Imbalance(normalized, 2); !near(imbalance, 0) {
+		t.Errorf("imbalance %v", imbalance)
+	} else if imbalance := analytics.Imbalance(normalized, 0); !near(imbalance, (7.0-8)/15) {
+		t.Errorf("imbalance %v", imbalance)
+	}
+
+	crossed := book([][2]float64{{101.5, 1}}, [][2]float64{{101, 1}})
+	if _, err := analytics.Normalize(crossed); err == nil || !analytics.Crossed(crossed) {
+		t.Errorf("crossed book normalized")
+	} else if bps := analytics.SpreadBps(crossed); bps >= 0 {
+		t.Errorf("crossed spread %v bps", bps)
+	}
+}
+
+// a market order of the depth of a direction takes the same levels: its VWAP is the value of the depth by its quantity
+func TestDepthVWAP(t *testing.T) {
+	maker, err := analytics.Normalize(benchmarkBook())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	for _, direction := range []exchange.TradeDirection{exchange.Buy, exchange.Sell} {
+		for _, percent := range []float64{0.01, 0.5, 1, 2} {
+			quantity, value := analytics.Depth(maker, direction, percent)
+			if quantity == 0 {
+				t.Fatalf("%v depth of %v%% empty", direction, percent)
+			}
+			vwap, err := analytics.VWAP(maker, direction, quantity)
+			if err != nil {
+				t.Fatalf("%v", err)
+			} else if !near(vwap, value/quantity) {
+				t.Errorf("%v %v%%: VWAP %v of the depth %v, expected %v", direction, percent, vwap, quantity, value/quantity)
+			}
+		}
+	}
+}
+
+// a book of 500 levels a side in random order
+func benchmarkBook() *exchange.Maker {
+	r := rand.New(rand.NewSource(1))
+	maker := &exchange.Maker{}
+	for i := 0; i < 500; i++ {
+		maker.Bids = append(maker.Bids, exchange.Order{Rate: 9999 - float64(i)*0.5, Quantity: r.Float64()})
+		maker.Asks = append(maker.Asks, exchange.Order{Rate: 10001 + float64(i)*0.5, Quantity: r.Float64()})
+	}
+	r.Shuffle(len(maker.Bids), func(i, j int) { maker.Bids[i], maker.Bids[j] = maker.Bids[j], maker.Bids[i] })
+	r.Shuffle(len(maker.Asks), func(i, j int) { maker.Asks[i], maker.Asks[j] = maker.Asks[j], maker.Asks[i] })
+	return maker
+}
+
+func BenchmarkNormalize(b *testing.B) {
+	maker := benchmarkBook()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analytics.Normalize(maker)
+	}
+}
+
+func BenchmarkValidate(b *testing.B) {
+	maker := benchmarkBook()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analytics.Validate(maker)
+	}
+}
+
+func BenchmarkVWAP(b *testing.B) {
+	maker, _ := analytics.Normalize(benchmarkBook())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analytics.VWAP(maker, exchange.Buy, 100)
+	}
+}
+
+func BenchmarkDepth(b *testing.B) {
+	maker, _ := analytics.Normalize(benchmarkBook())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analytics.Depth(maker, exchange.Sell, 1)
+	}
+}
+
+func BenchmarkImbalance(b *testing.B) {
+	maker, _ := analytics.Normalize(benchmarkBook())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		analytics.Imbalance(maker, 20)
+	}
+}
+
+func book(bids, asks [][2]float64) *exchange.Maker {
+	maker := &exchange.Maker{}
+	for _, bid := range bids {
+		maker.Bids = append(maker.Bids, exchange.Order{Rate: bid[0], Quantity: bid[1]})
+	}
+	for _, ask := range asks {
+		maker.Asks = append(maker.Asks, exchange.Order{Rate: ask[0], Quantity: ask[1]})
+	}
+	return maker
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
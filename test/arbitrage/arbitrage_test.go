@@ -0,0 +1,138 @@
+package arbitrage
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/arbitrage"
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// stubExchange has the constraints and the books of a test, the methods not overridden panic
+type stubExchange struct {
+	exchange.Exchange
+	name          exchange.ExchangeName
+	fee           float64
+	txFee         float64
+	withdraw      bool
+	deposit       bool
+	confirmations int
+	books         map[int]*exchange.Maker // key: pair id
+}
+
+func (e *stubExchange) GetName() exchange.ExchangeName                          { return e.name }
+func (e *stubExchange) GetFee(p *pair.Pair) float64                             { return e.fee }
+func (e *stubExchange) GetTxFee(c *coin.Coin) float64                           { return e.txFee }
+func (e *stubExchange) CanWithdraw(c *coin.Coin) bool                           { return e.withdraw }
+func (e *stubExchange) CanDeposit(c *coin.Coin) bool                            { return e.deposit }
+func (e *stubExchange) GetConfirmation(c *coin.Coin) int                        { return e.confirmations }
+func (e *stubExchange) GetCoinConstraint(c *coin.Coin) *exchange.CoinConstraint { return nil }
+
+func (e *stubExchange) GetPairs() []*pair.Pair {
+	pairs := []*pair.Pair{}
+	for id := range e.books {
+		pairs = append(pairs, pair.GetPairByID(id))
+	}
+	return pairs
+}
+
+func (e *stubExchange) HasPair(p *pair.Pair) bool {
+	_, ok := e.books[p.ID]
+	return ok
+}
+
+func (e *stubExchange) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
+	return e.books[p.ID], nil
+}
+
+// ETH cheaper on binance than on huobi after the fees and the withdraw fee, the other way and
+// the coin huobi can't deposit are not reported
+func TestArbitrageScanner(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	eth := testPair("USDT", "ETH")
+	ltc := testPair("USDT", "LTC")
+
+	cheap := &stubExchange{
+		name: exchange.BINANCE, fee: 0.001, txFee: 0.01, withdraw: true, deposit: true, confirmations: 12,
+		books: map[int]*exchange.Maker{
+			eth.ID: book([][2]float64{{99, 10}}, [][2]float64{{100, 10}}),
+			ltc.ID: book([][2]float64{{49, 10}}, [][2]float64{{50, 10}}),
+		},
+	}
+	expensive := &stubExchange{
+		name: exchange.HUOBI, fee: 0.002, txFee: 0.01, withdraw: true, deposit: true, confirmations: 30,
+		books: map[int]*exchange.Maker{
+			eth.ID: book([][2]float64{{105, 3}, {104, 10}}, [][2]float64{{106, 10}}),
+			ltc.ID: book([][2]float64{{60, 10}}, [][2]float64{{61, 10}}),
+		},
+	}
+	s := arbitrage.NewScanner(map[string]float64{"USDT": 700.7})
+	// LTC is cheaper on binance too, its deposit is disabled on huobi
+	s.Exchanges = []exchange.Exchange{cheap, &noLTCDeposit{expensive, ltc}}
+	s.WithdrawDelay = time.Minute
+
+	opportunities, err := s.Scan()
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if len(opportunities) != 1 {
+		t.Fatalf("%d opportunities: %+v", len(opportunities), opportunities)
+	}
+
+	o := opportunities[0]
+	if o.Pair != eth || o.Buy != exchange.BINANCE || o.Sell != exchange.HUOBI {
+		t.Fatalf("opportunity %v %v -> %v", o.Pair.Name, o.Buy, o.Sell)
+	}
+	// 700.7 buys 7 ETH at 100.1, 6.99 sold: 3 @ 104.79, 3.99 @ 103.792
+	proceeds := 3*105*0.998 + 3.99*104*0.998
+	if !near(o.Quantity, 7) || !near(o.Cost, 700.7) || !near(o.Proceeds, proceeds) || !near(o.Profit, proceeds-700.7) {
+		t.Errorf("opportunity %+v", o)
+	}
+	if !near(o.ProfitPercent, (proceeds-700.7)/700.7*100) {
+		t.Errorf("profit %v%%", o.ProfitPercent)
+	}
+	if o.Confirmations != 30 || o.TransferTime != 30*13*time.Second+time.Minute {
+		t.Errorf("%d confirmations in %v", o.Confirmations, o.TransferTime)
+	}
+}
+
+type noLTCDeposit struct {
+	*stubExchange
+	ltc *pair.Pair
+}
+
+func (e *noLTCDeposit) CanDeposit(c *coin.Coin) bool {
+	return c != e.ltc.Target
+}
+
+// testPair adds the coins if no exchange loaded them
+func testPair(base, target string) *pair.Pair {
+	for _, code := range []string{base, target} {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+	return pair.GetPair(coin.GetCoin(base), coin.GetCoin(target))
+}
+
+func book(bids, asks [][2]float64) *exchange.Maker {
+	maker := &exchange.Maker{}
+	for _, bid := range bids {
+		maker.Bids = append(maker.Bids, exchange.Order{Rate: bid[0], Quantity: bid[1]})
+	}
+	for _, ask := range asks {
+		maker.Asks = append(maker.Asks, exchange.Order{Rate: ask[0], Quantity: ask[1]})
+	}
+	return maker
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
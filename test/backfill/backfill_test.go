@@ -0,0 +1,254 @@
+package backfill
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/backfill"
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+// backfill the binance klines and trades from the fixture server twice, the second run only adds what is new.
+// The trades are walked back a day at a time, a day is more than one page.
+func TestBackfill(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	c, err := conformance.LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	hour := int64(time.Hour / time.Millisecond)
+	listed := (time.Now().UnixNano()/1e6)/hour*hour - 72*hour
+
+	server := conformance.NewServer(dir)
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	server.HandleFunc("GET", "/api/v3/klines", conformance.BinanceKlines(listed))
+	server.HandleFunc("GET", "/api/v3/aggTrades", conformance.BinanceAggTrades(listed))
+	defer conformance.PointTo(exchange.BINANCE, server.URL)()
+
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	if e == nil {
+		t.Fatalf("Init failed")
+	}
+	p, err := c.Pair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tmp, err := ioutil.TempDir("", "backfill")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmp)
+	store, err := backfill.NewFileStore(tmp)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	b := backfill.NewBackfill(store)
+	b.Delay = 0
+	b.PageSize = 24
+	klineJob := &backfill.Job{Exchange: e, Pair: p, Interval: exchange.KLINE_1HOUR, Since: listed - 240*hour}
+	tradeJob := &backfill.Job{Exchange: e, Pair: p}
+
+	check := func(run int) {
+		klines, err := store.Klines(klineJob.Series())
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		}
+		if len(klines) == 0 || int64(klines[0].OpenTime) != listed {
+			t.Fatalf("run %d: first kline %+v, expected %v", run, klines, listed)
+		}
+		for i, kline := range klines {
+			if expected := float64(listed + int64(i)*hour); kline.OpenTime != expected {
+				t.Fatalf("run %d: kline %d open %v, expected %v", run, i, kline.OpenTime, expected)
+			}
+		}
+		if last := int64(klines[len(klines)-1].OpenTime); last+hour > time.Now().UnixNano()/1e6 {
+			t.Errorf("run %d: kline %v saved before it closed", run, last)
+		}
+
+		trades, err := store.Trades(tradeJob.Series())
+		if err != nil {
+			t.Fatalf("run %d: %v", run, err)
+		} else if len(trades) == 0 || trades[0].TimeStamp != listed {
+			t.Fatalf("run %d: first trade %+v, expected %v", run, trades, listed)
+		}
+		for i, trade := range trades {
+			if expected := fmt.Sprintf("%d", i+1); trade.ID != expected {
+				t.Fatalf("run %d: trade %d id %v, expected %v", run, i, trade.ID, expected)
+			}
+		}
+	}
+
+	if err := b.Run(klineJob, tradeJob); err != nil {
+		t.Fatalf("%v", err)
+	}
+	check(1)
+
+	// resume with a new store of the same files
+	store, err = backfill.NewFileStore(tmp)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	b.Store = store
+	if err := b.Run(klineJob, tradeJob); err != nil {
+		t.Fatalf("%v", err)
+	}
+	check(2)
+}
+
+// tradeSource has the trades of a list, filtered by the range of the operation
+type tradeSource struct {
+	exchange.Exchange
+	trades []*exchange.TradeDetail
+}
+
+func (e *tradeSource) GetName() exchange.ExchangeName { return exchange.BINANCE }
+
+func (e *tradeSource) LoadPublicData(operation *exchange.PublicOperation) error {
+	operation.TradeHistory = []*exchange.TradeDetail{}
+	for _, trade := range e.trades {
+		if trade.TimeStamp >= operation.TradeStartTime && trade.TimeStamp <= operation.TradeEndTime {
+			operation.TradeHistory = append(operation.TradeHistory, trade)
+		}
+	}
+	return nil
+}
+
+// a trade of the last ms done after the previous run is saved by the next one, the ones saved are not saved again
+func TestBackfillSameTimestamp(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	tmp, err := ioutil.TempDir("", "backfill")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmp)
+	store, err := backfill.NewFileStore(tmp)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	hour := int64(time.Hour / time.Millisecond)
+	last := time.Now().UnixNano()/1e6 - hour
+	e := &tradeSource{trades: []*exchange.TradeDetail{
+		{ID: "1", TimeStamp: last - 1000},
+		{ID: "2", TimeStamp: last},
+	}}
+	b := backfill.NewBackfill(store)
+	b.Delay = 0
+	b.TradeWindow = time.Hour
+	job := &backfill.Job{Exchange: e, Pair: testPair("USDT", "BTC"), Since: last - 2*hour}
+	if err := b.Run(job); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	e.trades = append(e.trades, &exchange.TradeDetail{ID: "3", TimeStamp: last}, &exchange.TradeDetail{ID: "4", TimeStamp: last + 1000})
+	if err := b.Run(job); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	trades, err := store.Trades(job.Series())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ids := []string{}
+	for _, trade := range trades {
+		ids = append(ids, trade.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3 4]" {
+		t.Errorf("trades %v, expected [1 2 3 4]", ids)
+	}
+}
+
+// latestSource only has its latest trades, like the exchanges without trade pages
+type latestSource struct {
+	exchange.Exchange
+	latest []*exchange.TradeDetail
+	calls  int
+}
+
+func (e *latestSource) GetName() exchange.ExchangeName { return exchange.HUOBI }
+
+func (e *latestSource) LoadPublicData(operation *exchange.PublicOperation) error {
+	e.calls++
+	return exchange.LatestTrades(operation, e.latest)
+}
+
+// the latest trades are saved, the window before them ends the walk back without a retry
+func TestBackfillLatestTrades(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	tmp, err := ioutil.TempDir("", "backfill")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmp)
+	store, err := backfill.NewFileStore(tmp)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	now := time.Now().UnixNano() / 1e6
+	e := &latestSource{latest: []*exchange.TradeDetail{
+		{ID: "1", TimeStamp: now - 3000},
+		{ID: "2", TimeStamp: now - 2000},
+	}}
+	b := backfill.NewBackfill(store)
+	b.Delay = 0
+	job := &backfill.Job{Exchange: e, Pair: testPair("USDT", "BTC"), Since: now - 7*24*int64(time.Hour/time.Millisecond)}
+	if err := b.Run(job); err != nil {
+		t.Fatalf("%v", err)
+	} else if e.calls != 2 {
+		t.Errorf("%d requests, expected the window and the latest trades", e.calls)
+	}
+
+	e.latest = append(e.latest, &exchange.TradeDetail{ID: "3", TimeStamp: now - 1000})
+	if err := b.Run(job); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	trades, err := store.Trades(job.Series())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	ids := []string{}
+	for _, trade := range trades {
+		ids = append(ids, trade.ID)
+	}
+	if fmt.Sprint(ids) != "[1 2 3]" {
+		t.Errorf("trades %v, expected [1 2 3]", ids)
+	}
+}
+
+func testPair(base, target string) *pair.Pair {
+	for _, code := range []string{base, target} {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+	return pair.GetPair(coin.GetCoin(base), coin.GetCoin(target))
+}
@@ -0,0 +1,170 @@
+package capability
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/exchange/papertrade"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+var capabilityWallets = []exchange.WalletType{exchange.AssetWallet, exchange.SpotWallet, exchange.MarginWallet, exchange.ContractWallet, exchange.OptionWallet}
+
+// call is the error of the operation, a panic is past the switch as well
+func call(f func() error) (err error, panicked bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = true
+		}
+	}()
+	return f(), false
+}
+
+// an operation not declared on any wallet is not done by the adapter, it doesn't reach the server.
+// An operation without a wallet check is declared of SpotWallet, whatever the wallet.
+// The adapters are not initialized, the declared operations are called by the conformance suite.
+func TestCapabilities(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	server := conformance.NewServer(conformance.FixtureDir("../conformance/testdata", "none"))
+	defer server.Close()
+
+	adapters := initial.Adapters()
+	coverage := exchange.NewCoverage(adapters...)
+	ops := []exchange.OperationType{}
+	for _, row := range coverage.Rows {
+		if len(ops) == 0 || ops[len(ops)-1] != exchange.OperationType(row.Name) {
+			ops = append(ops, exchange.OperationType(row.Name))
+		}
+	}
+
+	for _, e := range adapters {
+		restore := conformance.PointTo(e.GetName(), server.URL)
+		caps := e.GetCapabilities()
+		for _, op := range ops {
+			if len(caps.Wallets(op)) > 0 {
+				continue
+			}
+			for _, w := range capabilityWallets {
+				requests := len(server.Requests())
+				_, publicPanic := call(func() error {
+					return e.LoadPublicData(&exchange.PublicOperation{Type: op, EX: e.GetName(), Wallet: w})
+				})
+				_, accountPanic := call(func() error {
+					return e.DoAccountOperation(&exchange.AccountOperation{Type: op, Ex: e.GetName(), Wallet: w})
+				})
+				if publicPanic || accountPanic || len(server.Requests()) != requests {
+					t.Errorf("%s %v %v not declared but done", e.GetName(), op, w)
+				}
+			}
+		}
+		restore()
+	}
+
+	report, err := ioutil.ReadFile("../../CAPABILITIES.md")
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if string(report) != coverage.Markdown() {
+		t.Errorf("CAPABILITIES.md is out of date: go run . capabilities > CAPABILITIES.md")
+	}
+}
+
+// the manager routes to the exchanges which declare the operation, papertrade has the public data of its source
+func TestCapabilityRouting(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	server := conformance.NewServer(dir)
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	defer conformance.PointTo(exchange.BINANCE, server.URL)()
+
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	if e == nil {
+		t.Fatalf("Init failed, missing: %v", server.Missing())
+	}
+
+	// the declared operations get past the switch, to the server or an error of their own
+	caps := e.GetCapabilities()
+	for _, op := range caps.Types() {
+		for _, w := range caps.Wallets(op) {
+			invalid := func(err error, panicked bool) bool {
+				return !panicked && err != nil && strings.Contains(err.Error(), "Operation type invalid")
+			}
+			if caps.SupportsPublic(op, w) {
+				if err, panicked := call(func() error {
+					return e.LoadPublicData(&exchange.PublicOperation{Type: op, EX: e.GetName(), Wallet: w})
+				}); invalid(err, panicked) {
+					t.Errorf("LoadPublicData %v %v declared: %v", op, w, err)
+				}
+			}
+			if caps.SupportsAccount(op, w) {
+				if err, panicked := call(func() error {
+					return e.DoAccountOperation(&exchange.AccountOperation{Type: op, Ex: e.GetName(), Wallet: w})
+				}); invalid(err, panicked) {
+					t.Errorf("DoAccountOperation %v %v declared: %v", op, w, err)
+				}
+			}
+		}
+	}
+
+	if !caps.Supports(exchange.Orderbook, exchange.ContractWallet) || caps.Supports(exchange.OptionChain, exchange.OptionWallet) {
+		t.Errorf("binance operations %+v", caps)
+	}
+	if !caps.Supports(exchange.Withdraw, "") || !caps.SupportsAccount(exchange.Withdraw, exchange.SpotWallet) || caps.SupportsPublic(exchange.Withdraw, exchange.SpotWallet) {
+		t.Errorf("binance Withdraw %v", caps.Wallets(exchange.Withdraw))
+	}
+	if !caps.SupportsOrder(exchange.ContractWallet, exchange.Trade_STOP_LIMIT, exchange.GTX) || caps.SupportsOrder("", exchange.TRADE_MARKET, "") {
+		t.Errorf("binance orders %+v %+v", caps.OrderTypes, caps.TimeInForce)
+	}
+
+	m := exchange.CreateExchangeManager()
+	found := false
+	for _, ex := range m.Supporting(exchange.FundingRate, exchange.ContractWallet) {
+		if !ex.GetCapabilities().Supports(exchange.FundingRate, exchange.ContractWallet) {
+			t.Errorf("%s routed FundingRate", ex.GetName())
+		}
+		found = found || ex.GetName() == exchange.BINANCE
+	}
+	if !found {
+		t.Errorf("binance not supporting FundingRate")
+	}
+	if ex, err := m.Route(exchange.SystemStatus, "", exchange.OKEX, exchange.BINANCE); err != nil || ex.GetName() != exchange.BINANCE {
+		t.Errorf("SystemStatus routed to %v %v", ex, err)
+	}
+	if _, err := m.Route(exchange.OptionChain, exchange.OptionWallet, exchange.BINANCE); !errors.Is(err, exchange.ErrNotSupported) {
+		t.Errorf("OptionChain routed %v", err)
+	}
+
+	p := papertrade.CreatePapertrade(&papertrade.Config{Source: e, Feed: papertrade.NewRecordedBooks()})
+	if p == nil {
+		t.Fatalf("CreatePapertrade failed")
+	}
+	paper := p.GetCapabilities()
+	if !paper.SupportsPublic(exchange.KLine, exchange.ContractWallet) || paper.SupportsAccount(exchange.PlaceOrder, exchange.ContractWallet) {
+		t.Errorf("papertrade %+v", paper)
+	} else if !paper.SupportsOrder("", exchange.TRADE_MARKET, exchange.FOK) || paper.Supports(exchange.Withdraw, "") {
+		t.Errorf("papertrade orders %+v", paper)
+	}
+	if report := exchange.NewCoverage(e, p).Markdown(); !strings.Contains(report, fmt.Sprintf("| PlaceOrder | SpotWallet | 1 | %s |", p.GetName())) {
+		t.Errorf("report %s", report)
+	}
+}
@@ -0,0 +1,102 @@
+package cassette
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bitontop/gored/cassette"
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+// record the binance fixtures through a cassette, then replay them with the server closed
+func TestCassette(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	c, err := conformance.LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tmp, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "binance.json")
+
+	server := conformance.NewServer(dir)
+	server.Handle("GET", "/raw", "127.0.0.1")
+	defer conformance.PointTo(exchange.BINANCE, server.URL)()
+
+	recorder, err := cassette.Start(path, cassette.Record)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	recorder.AddSecret("key", "secret")
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	if e == nil {
+		t.Fatalf("Init failed")
+	}
+	p, err := c.Pair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	recorded, err := e.OrderBook(p)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := conformance.CheckOrders(e, c); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := recorder.Stop(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if strings.Contains(string(data), "signature=") && !strings.Contains(string(data), "signature="+cassette.REDACTED) {
+		t.Errorf("signature not redacted: %s", data)
+	}
+	if strings.Contains(string(data), `"X-Mbx-Apikey": "key"`) {
+		t.Errorf("api key not redacted: %s", data)
+	}
+
+	player, err := cassette.Start(path, cassette.Replay)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer player.Stop()
+
+	replayed, err := e.OrderBook(p)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !reflect.DeepEqual(recorded.Bids, replayed.Bids) || !reflect.DeepEqual(recorded.Asks, replayed.Asks) {
+		t.Errorf("replayed orderbook %+v, recorded %+v", replayed, recorded)
+	}
+	if err := conformance.CheckOrders(e, c); err != nil {
+		t.Errorf("replay: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package conformance
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// the series of binance generated for a listing time, served with Server.HandleFunc
+
+// BinanceKlines serves the 1 hour klines from listed to now, like GET /api/v3/klines
+func BinanceKlines(listed int64) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		hour := int64(time.Hour / time.Millisecond)
+		start, _ := strconv.ParseInt(r.URL.Query().Get("startTime"), 10, 64)
+		end, _ := strconv.ParseInt(r.URL.Query().Get("endTime"), 10, 64)
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		now := time.Now().UnixNano() / 1e6
+		if end == 0 || end > now {
+			end = now
+		}
+		if start < listed {
+			start = listed
+		}
+
+		rows := [][]interface{}{}
+		for openTime := (start + hour - 1) / hour * hour; openTime <= end && len(rows) < limit; openTime += hour {
+			price := fmt.Sprintf("%.2f", 9000+float64(openTime/hour%100))
+			rows = append(rows, []interface{}{openTime, price, price, price, price, "1.5", openTime + hour - 1, "13500", 10, "0.5", "4500", "0"})
+		}
+		data, _ := json.Marshal(rows)
+		return string(data)
+	}
+}
+
+// BinanceAggTrades serves a trade every 10 seconds from listed to now, like GET /api/v3/aggTrades, the latest without
+// fromId and startTime.
+// The aggregate trade id of the trade at listed + i*10s is i+1.
+func BinanceAggTrades(listed int64) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		period := int64(10 * time.Second / time.Millisecond)
+		query := r.URL.Query()
+		limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+		end, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		if now := time.Now().UnixNano() / 1e6; end == 0 || end > now {
+			end = now
+		}
+
+		i := int64(0)
+		if fromID := query.Get("fromId"); fromID != "" {
+			i, _ = strconv.ParseInt(fromID, 10, 64)
+			i--
+		} else if start, _ := strconv.ParseInt(query.Get("startTime"), 10, 64); start > listed {
+			i = (start - listed + period - 1) / period
+		} else if start == 0 && (end-listed)/period+1 > limit {
+			// the latest trades
+			i = (end-listed)/period + 1 - limit
+		}
+
+		rows := []map[string]interface{}{}
+		for ; listed+i*period <= end && int64(len(rows)) < limit; i++ {
+			rows = append(rows, map[string]interface{}{
+				"a": i + 1, "p": "9000.00000000", "q": "0.01000000", "f": i + 1, "l": i + 1,
+				"T": listed + i*period, "m": i%2 == 0, "M": true,
+			})
+		}
+		data, _ := json.Marshal(rows)
+		return string(data)
+	}
+}
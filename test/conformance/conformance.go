@@ -0,0 +1,202 @@
+package conformance
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// Case is the case.json of a fixture directory, it describes what the fixtures contain.
+// Balances, OrderStatus and Positions are optional, without them the checks are skipped.
+// Coins are added before Init, for an exchange which only reads coins loaded by the others, eg. USD on deribit.
+type Case struct {
+	Coins       []string             `json:"coins"`
+	Base        string               `json:"base"`
+	Target      string               `json:"target"`
+	Rate        float64              `json:"rate"`
+	Quantity    float64              `json:"quantity"`
+	Balances    map[string]float64   `json:"balances"`
+	OrderStatus exchange.OrderStatus `json:"orderStatus"`
+	Positions   []CasePosition       `json:"positions"`
+}
+
+// CasePosition is an open position of the ContractWallet, the fields of exchange.Position the fixtures fix
+type CasePosition struct {
+	Instrument string                `json:"instrument"`
+	Side       exchange.PositionSide `json:"side"`
+	Size       float64               `json:"size"`
+	EntryPrice float64               `json:"entryPrice"`
+	MarkPrice  float64               `json:"markPrice"`
+}
+
+// FixtureDir is testdata/<exchange name in lower case>
+func FixtureDir(root string, name exchange.ExchangeName) string {
+	return filepath.Join(root, strings.ToLower(string(name)))
+}
+
+func LoadCase(dir string) (*Case, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, "case.json"))
+	if err != nil {
+		return nil, err
+	}
+	c := &Case{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("case.json Unmarshal Err: %v", err)
+	}
+	return c, nil
+}
+
+func (c *Case) Pair() (*pair.Pair, error) {
+	base, target := coin.GetCoin(c.Base), coin.GetCoin(c.Target)
+	if base == nil || target == nil {
+		return nil, fmt.Errorf("coins %s %s not loaded", c.Base, c.Target)
+	}
+	return pair.GetPair(base, target), nil
+}
+
+// CheckPairs checks the coins, the pairs and the constraint of the pair of the case
+func CheckPairs(e exchange.Exchange, c *Case) error {
+	if len(e.GetCoins()) == 0 {
+		return fmt.Errorf("%s no coins loaded", e.GetName())
+	} else if len(e.GetPairs()) == 0 {
+		return fmt.Errorf("%s no pairs loaded", e.GetName())
+	}
+
+	p, err := c.Pair()
+	if err != nil {
+		return fmt.Errorf("%s %v", e.GetName(), err)
+	}
+	if e.GetPairConstraint(p) == nil {
+		return fmt.Errorf("%s no constraint of %s", e.GetName(), p.Name)
+	} else if e.GetSymbolByPair(p) == "" {
+		return fmt.Errorf("%s no symbol of %s", e.GetName(), p.Name)
+	}
+	return nil
+}
+
+// CheckOrderBook checks that both sides are not empty, bids are descending, asks ascending,
+// rates and quantities positive and the book is not crossed
+func CheckOrderBook(e exchange.Exchange, p *pair.Pair) error {
+	maker, err := e.OrderBook(p)
+	if err != nil {
+		return err
+	} else if maker == nil || len(maker.Bids) == 0 || len(maker.Asks) == 0 {
+		return fmt.Errorf("%s %s empty orderbook: %+v", e.GetName(), p.Name, maker)
+	}
+
+	check := func(side string, orders []exchange.Order, descending bool) error {
+		for i, order := range orders {
+			if order.Rate <= 0 || order.Quantity <= 0 {
+				return fmt.Errorf("%s %s %s[%d] not positive: %v @ %v", e.GetName(), p.Name, side, i, order.Quantity, order.Rate)
+			}
+			if i == 0 {
+				continue
+			}
+			if prev := orders[i-1].Rate; (descending && order.Rate > prev) || (!descending && order.Rate < prev) {
+				return fmt.Errorf("%s %s %s[%d] not sorted: %v after %v", e.GetName(), p.Name, side, i, order.Rate, prev)
+			}
+		}
+		return nil
+	}
+	if err := check("bids", maker.Bids, true); err != nil {
+		return err
+	} else if err := check("asks", maker.Asks, false); err != nil {
+		return err
+	}
+
+	if maker.Bids[0].Rate >= maker.Asks[0].Rate {
+		return fmt.Errorf("%s %s crossed orderbook: bid %v ask %v", e.GetName(), p.Name, maker.Bids[0].Rate, maker.Asks[0].Rate)
+	}
+	return nil
+}
+
+// CheckBalances compares the parsed balances with the ones of the case
+func CheckBalances(e exchange.Exchange, c *Case) error {
+	e.UpdateAllBalances()
+	for code, expected := range c.Balances {
+		coin := coin.GetCoin(code)
+		if coin == nil {
+			return fmt.Errorf("%s coin %s not loaded", e.GetName(), code)
+		}
+		if balance := e.GetBalance(coin); balance != expected {
+			return fmt.Errorf("%s %s balance %v, expected %v", e.GetName(), code, balance, expected)
+		}
+	}
+	return nil
+}
+
+// CheckOrders places a limit buy, gets its status and cancels it.
+// The order placed has the quantity and the rate sent, rounded to the constraint of the pair.
+func CheckOrders(e exchange.Exchange, c *Case) error {
+	p, err := c.Pair()
+	if err != nil {
+		return fmt.Errorf("%s %v", e.GetName(), err)
+	}
+
+	order, err := e.LimitBuy(p, c.Quantity, c.Rate)
+	if err != nil {
+		return err
+	} else if order == nil || order.OrderID == "" {
+		return fmt.Errorf("%s LimitBuy no order id: %+v", e.GetName(), order)
+	}
+	constraint := e.GetPairConstraint(p)
+	if quantity, rate := constraint.QuantityDecimal(c.Quantity).Float64(), constraint.RateDecimal(c.Rate).Float64(); order.Quantity != quantity || order.Rate != rate {
+		return fmt.Errorf("%s LimitBuy order %v @ %v, expected the sent %v @ %v", e.GetName(), order.Quantity, order.Rate, quantity, rate)
+	}
+
+	if err := e.OrderStatus(order); err != nil {
+		return err
+	} else if order.Status != c.OrderStatus {
+		return fmt.Errorf("%s OrderStatus %v, expected %v", e.GetName(), order.Status, c.OrderStatus)
+	}
+
+	if err := e.CancelOrder(order); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CheckPositions compares the open positions of the pair of the case in the ContractWallet with the ones of the case, in order
+func CheckPositions(e exchange.Exchange, c *Case) error {
+	p, err := c.Pair()
+	if err != nil {
+		return fmt.Errorf("%s %v", e.GetName(), err)
+	}
+
+	operation := &exchange.AccountOperation{
+		Type:   exchange.GetPositions,
+		Ex:     e.GetName(),
+		Wallet: exchange.ContractWallet,
+		Pair:   p,
+	}
+	if err := e.DoAccountOperation(operation); err != nil {
+		return err
+	} else if len(operation.Positions) != len(c.Positions) {
+		return fmt.Errorf("%s GetPositions %d positions, expected %d", e.GetName(), len(operation.Positions), len(c.Positions))
+	}
+
+	for i, expected := range c.Positions {
+		position := operation.Positions[i]
+		got := CasePosition{
+			Instrument: position.Instrument,
+			Side:       position.Side,
+			Size:       position.Size,
+			EntryPrice: position.EntryPrice,
+			MarkPrice:  position.MarkPrice,
+		}
+		if got != expected {
+			return fmt.Errorf("%s GetPositions[%d] %+v, expected %+v", e.GetName(), i, got, expected)
+		}
+	}
+	return nil
+}
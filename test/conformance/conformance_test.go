@@ -0,0 +1,209 @@
+package conformance
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+)
+
+// go test ./test/conformance runs every exchange with a directory in testdata, offline,
+// and checks the endpoints of the Uncovered ones
+func TestConformance(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	names := []string{}
+	for name := range BaseURLs {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		exName := exchange.ExchangeName(name)
+		t.Run(name, func(t *testing.T) {
+			runCase(t, exName)
+		})
+	}
+}
+
+func runCase(t *testing.T, exName exchange.ExchangeName) {
+	dir := FixtureDir("testdata", exName)
+	if !HasFixtures(dir) {
+		if !uncovered(exName) {
+			t.Fatalf("no fixtures in %s and %s is not in Uncovered", dir, exName)
+		}
+		checkEndpoints(t, exName)
+		return
+	} else if uncovered(exName) {
+		t.Errorf("%s has fixtures in %s, remove it from Uncovered", exName, dir)
+	}
+	c, err := LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	server := NewServer(dir)
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	defer PointTo(exName, server.URL)()
+
+	for _, code := range c.Coins {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+
+	config := &exchange.Config{
+		ExName:     exName,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+		Passphrase: "passphrase",
+	}
+	e := initial.CreateInitManager().Init(config)
+	if e == nil {
+		t.Fatalf("%s Init failed, missing: %v", exName, server.Missing())
+	}
+
+	if err := CheckPairs(e, c); err != nil {
+		t.Fatalf("%v", err)
+	}
+	p, _ := c.Pair()
+
+	if err := CheckOrderBook(e, p); err != nil {
+		t.Errorf("%v", err)
+	}
+	if len(c.Balances) > 0 {
+		if err := CheckBalances(e, c); err != nil {
+			t.Errorf("%v", err)
+		}
+	}
+	if c.OrderStatus != "" {
+		if err := CheckOrders(e, c); err != nil {
+			t.Errorf("%v", err)
+		}
+	}
+	if len(c.Positions) > 0 {
+		if err := CheckPositions(e, c); err != nil {
+			t.Errorf("%v", err)
+		}
+	}
+
+	if missing := server.Missing(); len(missing) > 0 {
+		t.Errorf("%s requests without fixture: %v", exName, missing)
+	}
+}
+
+func uncovered(exName exchange.ExchangeName) bool {
+	for _, name := range Uncovered {
+		if name == exName {
+			return true
+		}
+	}
+	return false
+}
+
+// guard lets the requests to the server through, the others are recorded and fail
+type guard struct {
+	host string
+	base http.RoundTripper
+
+	mu      sync.Mutex
+	escaped []string
+}
+
+func (g *guard) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.URL.Host == g.host {
+		return g.base.RoundTrip(r)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.escaped = append(g.escaped, r.Method+" "+r.URL.Host+r.URL.Path)
+	return nil, fmt.Errorf("%s is not the fixture server", r.URL.Host)
+}
+
+// checkEndpoints runs Init, the order book and the public operations declared by an exchange without fixtures
+// against an empty server, the adapters use the default transport: no request may reach another host.
+// The responses are 404, the results are not checked.
+func checkEndpoints(t *testing.T, exName exchange.ExchangeName) {
+	server := NewServer(FixtureDir("testdata", "none"))
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	defer PointTo(exName, server.URL)()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	g := &guard{host: u.Host, base: http.DefaultTransport}
+	http.DefaultTransport = g
+	defer func() { http.DefaultTransport = g.base }()
+
+	// a failed Init is expected without fixtures, the operations run on the adapter not initialized
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exName,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+		Passphrase: "passphrase",
+	})
+	if e == nil {
+		for _, adapter := range initial.Adapters() {
+			if adapter.GetName() == exName {
+				e = adapter
+			}
+		}
+	}
+	if e == nil {
+		t.Fatalf("%s is not an adapter", exName)
+	}
+
+	for _, code := range []string{"USDT", "BTC"} {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+	p := pair.GetPair(coin.GetCoin("USDT"), coin.GetCoin("BTC"))
+
+	recovered(func() {
+		e.OrderBook(p)
+	})
+	caps := e.GetCapabilities()
+	for _, op := range caps.Types() {
+		for _, w := range caps.Wallets(op) {
+			if !caps.SupportsPublic(op, w) {
+				continue
+			}
+			recovered(func() {
+				e.LoadPublicData(&exchange.PublicOperation{Type: op, EX: exName, Wallet: w, Pair: p})
+			})
+		}
+	}
+
+	if len(server.Requests()) == 0 {
+		t.Errorf("%s made no request to the server", exName)
+	}
+	if len(g.escaped) > 0 {
+		t.Errorf("%s requests not to the endpoints of BaseURLs: %v", exName, g.escaped)
+	}
+}
+
+// recovered runs f, a panic on the missing data of the empty server is expected
+func recovered(f func()) {
+	defer func() {
+		recover()
+	}()
+	f()
+}
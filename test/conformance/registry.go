@@ -0,0 +1,190 @@
+package conformance
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"net/url"
+
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/exchange/abcc"
+	"github.com/bitontop/gored/exchange/bcex"
+	"github.com/bitontop/gored/exchange/bgogo"
+	"github.com/bitontop/gored/exchange/bibox"
+	"github.com/bitontop/gored/exchange/bigone"
+	"github.com/bitontop/gored/exchange/biki"
+	"github.com/bitontop/gored/exchange/binance"
+	"github.com/bitontop/gored/exchange/binancedex"
+	"github.com/bitontop/gored/exchange/bitbay"
+	"github.com/bitontop/gored/exchange/bitbns"
+	"github.com/bitontop/gored/exchange/bitfinex"
+	"github.com/bitontop/gored/exchange/bitforex"
+	"github.com/bitontop/gored/exchange/bithumb"
+	"github.com/bitontop/gored/exchange/bitmart"
+	"github.com/bitontop/gored/exchange/bitmax"
+	"github.com/bitontop/gored/exchange/bitmex"
+	"github.com/bitontop/gored/exchange/bitpie"
+	"github.com/bitontop/gored/exchange/bitrue"
+	"github.com/bitontop/gored/exchange/bitstamp"
+	"github.com/bitontop/gored/exchange/bittrex"
+	"github.com/bitontop/gored/exchange/bitz"
+	"github.com/bitontop/gored/exchange/bkex"
+	"github.com/bitontop/gored/exchange/blocktrade"
+	"github.com/bitontop/gored/exchange/bw"
+	"github.com/bitontop/gored/exchange/bybit"
+	"github.com/bitontop/gored/exchange/coinbase"
+	"github.com/bitontop/gored/exchange/coinbene"
+	"github.com/bitontop/gored/exchange/coindeal"
+	"github.com/bitontop/gored/exchange/coineal"
+	"github.com/bitontop/gored/exchange/coinex"
+	"github.com/bitontop/gored/exchange/cointiger"
+	"github.com/bitontop/gored/exchange/dcoin"
+	"github.com/bitontop/gored/exchange/deribit"
+	"github.com/bitontop/gored/exchange/digifinex"
+	"github.com/bitontop/gored/exchange/dragonex"
+	"github.com/bitontop/gored/exchange/ftx"
+	"github.com/bitontop/gored/exchange/gateio"
+	"github.com/bitontop/gored/exchange/goko"
+	"github.com/bitontop/gored/exchange/hibitex"
+	"github.com/bitontop/gored/exchange/hitbtc"
+	"github.com/bitontop/gored/exchange/homiex"
+	"github.com/bitontop/gored/exchange/hoo"
+	"github.com/bitontop/gored/exchange/huobi"
+	"github.com/bitontop/gored/exchange/huobidm"
+	"github.com/bitontop/gored/exchange/huobiotc"
+	"github.com/bitontop/gored/exchange/ibankdigital"
+	"github.com/bitontop/gored/exchange/idcm"
+	"github.com/bitontop/gored/exchange/idex"
+	"github.com/bitontop/gored/exchange/kraken"
+	"github.com/bitontop/gored/exchange/kucoin"
+	"github.com/bitontop/gored/exchange/latoken"
+	"github.com/bitontop/gored/exchange/lbank"
+	"github.com/bitontop/gored/exchange/liquid"
+	"github.com/bitontop/gored/exchange/mxc"
+	"github.com/bitontop/gored/exchange/newcapital"
+	"github.com/bitontop/gored/exchange/okex"
+	"github.com/bitontop/gored/exchange/okexdm"
+	"github.com/bitontop/gored/exchange/oksim"
+	"github.com/bitontop/gored/exchange/otcbtc"
+	"github.com/bitontop/gored/exchange/poloniex"
+	"github.com/bitontop/gored/exchange/probit"
+	"github.com/bitontop/gored/exchange/stex"
+	"github.com/bitontop/gored/exchange/switcheo"
+	"github.com/bitontop/gored/exchange/tagz"
+	"github.com/bitontop/gored/exchange/tokok"
+	"github.com/bitontop/gored/exchange/tradeogre"
+	"github.com/bitontop/gored/exchange/txbit"
+	"github.com/bitontop/gored/exchange/virgocx"
+	"github.com/bitontop/gored/exchange/zebitex"
+)
+
+// BaseURLs are the endpoint vars of every exchange of the init manager
+var BaseURLs = map[exchange.ExchangeName][]*string{
+	exchange.ABCC:         {&abcc.API_URL},
+	exchange.BCEX:         {&bcex.API_URL},
+	exchange.BGOGO:        {&bgogo.API_URL},
+	exchange.BIBOX:        {&bibox.API_URL},
+	exchange.BIGONE:       {&bigone.API_URL},
+	exchange.BIKI:         {&biki.API_URL},
+	exchange.BINANCE:      {&binance.API_URL, &binance.CONTRACT_URL, &binance.CONTRACT_TESTNET_URL, &binance.BTSE_URL},
+	exchange.BINANCEDEX:   {&binancedex.API_URL},
+	exchange.BITBAY:       {&bitbay.API_URL},
+	exchange.BITBNS:       {&bitbns.API_URL, &bitbns.WEB_URL},
+	exchange.BITFINEX:     {&bitfinex.API_URL},
+	exchange.BITFOREX:     {&bitforex.API_URL},
+	exchange.BITHUMB:      {&bithumb.API_URL},
+	exchange.BITMART:      {&bitmart.API_URL},
+	exchange.BITMAX:       {&bitmax.API_URL},
+	exchange.BITMEX:       {&bitmex.API_URL},
+	exchange.BITPIE:       {&bitpie.API_URL, &bitpie.LOGIN_URL},
+	exchange.BITRUE:       {&bitrue.API_URL},
+	exchange.BITSTAMP:     {&bitstamp.API_URL},
+	exchange.BITTREX:      {&bittrex.API_URL, &bittrex.API_V3_URL},
+	exchange.BITZ:         {&bitz.API_URL},
+	exchange.BKEX:         {&bkex.API_URL},
+	exchange.BLOCKTRADE:   {&blocktrade.API_URL},
+	exchange.BW:           {&bw.API_URL},
+	exchange.BYBIT:        {&bybit.API_URL},
+	exchange.COINBASE:     {&coinbase.API_URL},
+	exchange.COINBENE:     {&coinbene.API_URL, &coinbene.WITHDRAW_URL},
+	exchange.COINDEAL:     {&coindeal.API_URL, &coindeal.TICKER_URL},
+	exchange.COINEAL:      {&coineal.API_URL},
+	exchange.COINEX:       {&coinex.API_URL},
+	exchange.COINTIGER:    {&cointiger.API_URL},
+	exchange.DCOIN:        {&dcoin.API_URL},
+	exchange.DERIBIT:      {&deribit.API_URL},
+	exchange.DIGIFINEX:    {&digifinex.API_URL, &digifinex.API_V3_URL},
+	exchange.DRAGONEX:     {&dragonex.API_URL},
+	exchange.FTX:          {&ftx.API_URL},
+	exchange.GATEIO:       {&gateio.API_URL, &gateio.Private_URL, &gateio.API_V4_URL},
+	exchange.GOKO:         {&goko.API_URL},
+	exchange.HIBITEX:      {&hibitex.API_URL},
+	exchange.HITBTC:       {&hitbtc.API_URL},
+	exchange.HOMIEX:       {&homiex.API_URL},
+	exchange.HOO:          {&hoo.API_URL},
+	exchange.HUOBI:        {&huobi.API_URL},
+	exchange.HUOBIDM:      {&huobidm.API_URL, &huobidm.SPOT_API_URL},
+	exchange.HUOBIOTC:     {&huobiotc.API_URL},
+	exchange.IBANKDIGITAL: {&ibankdigital.API_URL, &ibankdigital.HUOBI_URL},
+	exchange.IDCM:         {&idcm.API_URL_PUB, &idcm.API_URL},
+	exchange.IDEX:         {&idex.API_URL},
+	exchange.KRAKEN:       {&kraken.API_URL},
+	exchange.KUCOIN:       {&kucoin.API_URL, &kucoin.SANDBOX_API_URL},
+	exchange.LATOKEN:      {&latoken.API_URL},
+	exchange.LBANK:        {&lbank.API_URL},
+	exchange.LIQUID:       {&liquid.API_URL},
+	exchange.MXC:          {&mxc.API_URL},
+	exchange.NEWCAPITAL:   {&newcapital.API_URL},
+	exchange.OKEX:         {&okex.API_URL},
+	exchange.OKEXDM:       {&okexdm.API_URL},
+	exchange.OKSIM:        {&oksim.API_URL},
+	exchange.OTCBTC:       {&otcbtc.API_URL},
+	exchange.POLONIEX:     {&poloniex.API_URL},
+	exchange.PROBIT:       {&probit.API_URL},
+	exchange.STEX:         {&stex.API_URL, &stex.API3_URL, &stex.WEB_URL},
+	exchange.SWITCHEO:     {&switcheo.API_URL},
+	exchange.TAGZ:         {&tagz.API_URL},
+	exchange.TOKOK:        {&tokok.API_URL},
+	exchange.TRADEOGRE:    {&tradeogre.API_URL},
+	exchange.TXBIT:        {&txbit.API_URL},
+	exchange.VIRGOCX:      {&virgocx.API_URL},
+	exchange.ZEBITEX:      {&zebitex.API_URL},
+}
+
+// Uncovered are the exchanges without fixtures in testdata, their case only checks that Init, the order book
+// and the public operations they declare call no host but their endpoints. Remove an exchange when adding its fixtures.
+var Uncovered = []exchange.ExchangeName{
+	exchange.ABCC, exchange.BCEX, exchange.BGOGO, exchange.BIBOX, exchange.BIGONE, exchange.BIKI,
+	exchange.BINANCEDEX, exchange.BITBAY, exchange.BITBNS, exchange.BITFINEX, exchange.BITFOREX,
+	exchange.BITHUMB, exchange.BITMART, exchange.BITMAX, exchange.BITPIE, exchange.BITRUE, exchange.BITSTAMP,
+	exchange.BITTREX, exchange.BITZ, exchange.BKEX, exchange.BLOCKTRADE, exchange.BW, exchange.COINBASE,
+	exchange.COINBENE, exchange.COINDEAL, exchange.COINEAL, exchange.COINEX, exchange.COINTIGER, exchange.DCOIN,
+	exchange.DIGIFINEX, exchange.DRAGONEX, exchange.GATEIO, exchange.GOKO, exchange.HIBITEX, exchange.HITBTC,
+	exchange.HOMIEX, exchange.HOO, exchange.HUOBI, exchange.HUOBIOTC, exchange.IBANKDIGITAL, exchange.IDCM,
+	exchange.IDEX, exchange.KRAKEN, exchange.KUCOIN, exchange.LATOKEN, exchange.LBANK, exchange.LIQUID,
+	exchange.MXC, exchange.NEWCAPITAL, exchange.OKSIM, exchange.OTCBTC, exchange.POLONIEX, exchange.PROBIT,
+	exchange.STEX, exchange.SWITCHEO, exchange.TAGZ, exchange.TOKOK, exchange.TRADEOGRE, exchange.TXBIT,
+	exchange.VIRGOCX, exchange.ZEBITEX,
+}
+
+// PointTo replaces the host of the endpoints of the exchange and of the external IP with the server,
+// the path of each endpoint is kept. The returned func restores them.
+func PointTo(name exchange.ExchangeName, serverURL string) func() {
+	urls := append([]*string{&exchange.EXTERNAL_IP_URL}, BaseURLs[name]...)
+	saved := make([]string, len(urls))
+	for i, u := range urls {
+		saved[i] = *u
+		path := ""
+		if parsed, err := url.Parse(*u); err == nil {
+			path = parsed.Path
+		}
+		*u = serverURL + path
+	}
+
+	return func() {
+		for i, u := range urls {
+			*u = saved[i]
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package conformance
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Server replays the recorded responses of a fixture directory.
+// A request is matched by method and path only, the query and the body are ignored so signed requests match too.
+// GET /api/v3/order is served from GET_api_v3_order.json
+type Server struct {
+	*httptest.Server
+	Dir string
+
+	mutex    sync.Mutex
+	static   map[string]string
+	handlers map[string]func(r *http.Request) string
+	requests []string
+	missing  []string
+}
+
+func NewServer(dir string) *Server {
+	s := &Server{
+		Dir:      dir,
+		static:   make(map[string]string),
+		handlers: make(map[string]func(r *http.Request) string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	return s
+}
+
+// FixtureName is the file of the response of a request
+func FixtureName(method, path string) string {
+	name := strings.Trim(path, "/")
+	name = strings.NewReplacer("/", "_", ".", "_", ":", "_").Replace(name)
+	return fmt.Sprintf("%s_%s.json", strings.ToUpper(method), name)
+}
+
+// Handle serves body for the request without a fixture file, e.g. the external IP
+func (s *Server) Handle(method, path, body string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.static[FixtureName(method, path)] = body
+}
+
+// HandleFunc serves the body returned by handler, for a response that depends on the query
+func (s *Server) HandleFunc(method, path string, handler func(r *http.Request) string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.handlers[FixtureName(method, path)] = handler
+}
+
+// Requests are the fixture names of all the served requests, in order
+func (s *Server) Requests() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// Missing are the requests without a fixture, answered with 404
+func (s *Server) Missing() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string{}, s.missing...)
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	name := FixtureName(r.Method, r.URL.Path)
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, name)
+	body, ok := s.static[name]
+	handler := s.handlers[name]
+	s.mutex.Unlock()
+
+	if handler != nil {
+		body, ok = handler(r), true
+	}
+
+	if !ok {
+		data, err := ioutil.ReadFile(filepath.Join(s.Dir, name))
+		if err != nil {
+			s.mutex.Lock()
+			s.missing = append(s.missing, name)
+			s.mutex.Unlock()
+
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"msg":"no fixture %s"}`, name)
+			return
+		}
+		body = string(data)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprint(w, body)
+}
+
+// HasFixtures reports whether the directory exists
+func HasFixtures(dir string) bool {
+	info, err := os.Stat(dir)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,235 @@
+package decimal
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/bitontop/gored/decimal"
+	"github.com/bitontop/gored/exchange"
+)
+
+func TestNewFromString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		places   int32
+	}{
+		{"1.23", "1.23", 2},
+		{"0", "0", 0},
+		{"-0.001", "-0.001", 3},
+		{"+5", "5", 0},
+		{" 42 ", "42", 0},
+		{"0.00100", "0.001", 3},
+		{"100", "100", 0},
+		{".5", "0.5", 1},
+		{"1e-8", "0.00000001", 8},
+		{"1.5E+3", "1500", 0},
+		{"-2.5e2", "-250", 0},
+		{"123e0", "123", 0},
+		{"123456789012345678901234567890.123456789", "123456789012345678901234567890.123456789", 9},
+		{"0.000000000000000000000001", "0.000000000000000000000001", 24},
+	}
+	for _, test := range tests {
+		d, err := decimal.NewFromString(test.input)
+		if err != nil {
+			t.Errorf("%q: %v", test.input, err)
+		} else if d.String() != test.expected || d.Places() != test.places {
+			t.Errorf("%q: %s with %d places, expected %s with %d", test.input, d.String(), d.Places(), test.expected, test.places)
+		}
+	}
+
+	for _, invalid := range []string{"", " ", "-", "abc", "1.2.3", "1e", "1ex", "e5", "1e99999999999"} {
+		if _, err := decimal.NewFromString(invalid); err == nil {
+			t.Errorf("%q parsed", invalid)
+		}
+	}
+}
+
+func TestNewFromFloat(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{0.1, "0.1"},
+		{0.1 + 0.2, "0.3"},
+		{0.7 - 0.1, "0.6"},
+		{-1.005, "-1.005"},
+		{1e-8, "0.00000001"},
+		{1.5e-7, "0.00000015"},
+		{123456789.123, "123456789.123"},
+		{1e21, "1000000000000000000000"},
+		{0, "0"},
+	}
+	for _, test := range tests {
+		if d := decimal.NewFromFloat(test.input); d.String() != test.expected {
+			t.Errorf("%v: %s, expected %s", test.input, d.String(), test.expected)
+		}
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		input    string
+		places   int32
+		round    string
+		truncate string
+		fixed    string
+	}{
+		{"1.234", 2, "1.23", "1.23", "1.23"},
+		{"1.235", 2, "1.24", "1.23", "1.24"},
+		{"1.5", 0, "2", "1", "2"},
+		{"-1.5", 0, "-2", "-1", "-2"},
+		{"-1.234", 2, "-1.23", "-1.23", "-1.23"},
+		{"-1.235", 2, "-1.24", "-1.23", "-1.24"},
+		{"0.0005", 3, "0.001", "0", "0.001"},
+		{"1.5", 3, "1.5", "1.5", "1.500"},
+		{"999.999", 2, "1000", "999.99", "1000.00"},
+		{"12345678901234567890.55", 1, "12345678901234567890.6", "12345678901234567890.5", "12345678901234567890.6"},
+	}
+	for _, test := range tests {
+		d := decimal.RequireFromString(test.input)
+		if round := d.Round(test.places).String(); round != test.round {
+			t.Errorf("Round(%s, %d) %s, expected %s", test.input, test.places, round, test.round)
+		}
+		if truncate := d.Truncate(test.places).String(); truncate != test.truncate {
+			t.Errorf("Truncate(%s, %d) %s, expected %s", test.input, test.places, truncate, test.truncate)
+		}
+		if fixed := d.StringFixed(test.places); fixed != test.fixed {
+			t.Errorf("StringFixed(%s, %d) %s, expected %s", test.input, test.places, fixed, test.fixed)
+		}
+	}
+}
+
+func TestRoundStep(t *testing.T) {
+	tests := []struct {
+		input    string
+		step     string
+		down     string
+		up       string
+		multiple bool
+	}{
+		{"1.2345", "0.01", "1.23", "1.24", false},
+		{"1.23", "0.01", "1.23", "1.23", true},
+		{"7", "5", "5", "10", false},
+		{"0.00000123", "0.000001", "0.000001", "0.000002", false},
+		{"-1.25", "0.1", "-1.3", "-1.2", false},
+		{"-1.2", "0.1", "-1.2", "-1.2", true},
+		{"1.05", "0.5", "1", "1.5", false},
+		{"100000000000000000000.123", "0.05", "100000000000000000000.1", "100000000000000000000.15", false},
+		{"1.2345", "0", "1.2345", "1.2345", true},
+	}
+	for _, test := range tests {
+		d, step := decimal.RequireFromString(test.input), decimal.RequireFromString(test.step)
+		if down := d.RoundStep(step, false).String(); down != test.down {
+			t.Errorf("RoundStep(%s, %s) down %s, expected %s", test.input, test.step, down, test.down)
+		}
+		if up := d.RoundStep(step, true).String(); up != test.up {
+			t.Errorf("RoundStep(%s, %s) up %s, expected %s", test.input, test.step, up, test.up)
+		}
+		if multiple := d.IsMultipleOf(step); multiple != test.multiple {
+			t.Errorf("IsMultipleOf(%s, %s) %v, expected %v", test.input, test.step, multiple, test.multiple)
+		}
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	tests := []struct {
+		a, b               string
+		add, sub, mul, quo string
+		cmp                int
+	}{
+		{"0.1", "0.2", "0.3", "-0.1", "0.02", "0.5", -1},
+		{"-1.5", "0.5", "-1", "-2", "-0.75", "-3", -1},
+		{"1e3", "0.001", "1000.001", "999.999", "1", "1000000", 1},
+		{"2", "3", "5", "-1", "6", "0.66666667", -1},
+		{"-2", "-3", "-5", "1", "6", "0.66666667", 1},
+		{"99999999999999999999", "1", "100000000000000000000", "99999999999999999998", "99999999999999999999", "99999999999999999999", 1},
+		{"1.50", "1.5", "3", "0", "2.25", "1", 0},
+	}
+	for _, test := range tests {
+		a, b := decimal.RequireFromString(test.a), decimal.RequireFromString(test.b)
+		if add := a.Add(b).String(); add != test.add {
+			t.Errorf("%s + %s = %s, expected %s", test.a, test.b, add, test.add)
+		}
+		if sub := a.Sub(b).String(); sub != test.sub {
+			t.Errorf("%s - %s = %s, expected %s", test.a, test.b, sub, test.sub)
+		}
+		if mul := a.Mul(b).String(); mul != test.mul {
+			t.Errorf("%s * %s = %s, expected %s", test.a, test.b, mul, test.mul)
+		}
+		if quo := a.DivRound(b, 8).String(); quo != test.quo {
+			t.Errorf("%s / %s = %s, expected %s", test.a, test.b, quo, test.quo)
+		}
+		if cmp := a.Cmp(b); cmp != test.cmp {
+			t.Errorf("Cmp(%s, %s) %d, expected %d", test.a, test.b, cmp, test.cmp)
+		}
+	}
+
+	if !decimal.Zero.IsZero() || decimal.Zero.String() != "0" || decimal.New(-5, 1).Abs().String() != "0.5" {
+		t.Errorf("zero value and Abs")
+	}
+	if d := decimal.New(15, -2); d.String() != "1500" {
+		t.Errorf("New(15, -2) %s, expected 1500", d)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	values := struct {
+		Quoted   decimal.Decimal `json:"quoted"`
+		Number   decimal.Decimal `json:"number"`
+		Exponent decimal.Decimal `json:"exponent"`
+		Null     decimal.Decimal `json:"null"`
+	}{}
+	if err := json.Unmarshal([]byte(`{"quoted":"-0.00012300","number":123.45,"exponent":1e-7,"null":null}`), &values); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if values.Quoted.String() != "-0.000123" || values.Number.String() != "123.45" || values.Exponent.String() != "0.0000001" || !values.Null.IsZero() {
+		t.Errorf("unmarshal %+v", values)
+	}
+
+	data, err := json.Marshal(values.Exponent)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if string(data) != `"0.0000001"` {
+		t.Errorf("marshal %s", data)
+	}
+
+	invalid := decimal.Decimal{}
+	if err := json.Unmarshal([]byte(`"1.2.3"`), &invalid); err == nil {
+		t.Errorf("invalid decimal unmarshalled")
+	}
+}
+
+// the order values in the request, the steps of the constraint and no float noise or truncation
+func TestFormatOrder(t *testing.T) {
+	tests := []struct {
+		lotSize, priceFilter float64
+		quantity, rate       float64
+		expected             [2]string
+	}{
+		{0.001, 0.01, 0.0129, 9000.005, [2]string{"0.012", "9000.01"}},
+		{0.000001, 0.01, 1.5, 9000, [2]string{"1.500000", "9000.00"}},
+		{1, 0.00000001, 12.9, 0.000000015, [2]string{"12", "0.00000002"}},
+		{0, 0, 0.1 + 0.2, 0.00000012, [2]string{"0.3", "0.00000012"}},
+		{10, 0.5, 12345678901, 1.24, [2]string{"12345678900", "1.0"}},
+	}
+	for _, test := range tests {
+		constraint := &exchange.PairConstraint{LotSize: test.lotSize, PriceFilter: test.priceFilter}
+		if quantity := constraint.FormatQuantity(test.quantity); quantity != test.expected[0] {
+			t.Errorf("FormatQuantity(%v) with LotSize %v: %s, expected %s", test.quantity, test.lotSize, quantity, test.expected[0])
+		}
+		if rate := constraint.FormatRate(test.rate); rate != test.expected[1] {
+			t.Errorf("FormatRate(%v) with PriceFilter %v: %s, expected %s", test.rate, test.priceFilter, rate, test.expected[1])
+		}
+	}
+
+	// a pair without constraint is only formatted
+	var constraint *exchange.PairConstraint
+	if quantity, rate := constraint.FormatQuantity(0.0000001), constraint.FormatRate(1e-9); quantity != "0.0000001" || rate != "0.000000001" {
+		t.Errorf("without constraint %s @ %s", quantity, rate)
+	}
+}
@@ -19,6 +19,8 @@ func Test_Binance(t *testing.T) {
 	// e := InitEx(exchange.BINANCE)
 	e := InitExFromJson(exchange.BINANCE)
 	// e := InitExFromJson(exchange.BINANCE)
+	// e, c := InitExCassette(exchange.BINANCE, "testdata/binance.json", cassette.Record)
+	// defer c.Stop()
 	pair := pair.GetPairByKey("BTC|ETH")
 
 	// Test_Coins(e)
@@ -27,18 +29,23 @@ func Test_Binance(t *testing.T) {
 	// Test_Orderbook(e, pair)
 	// Test_ConstraintFetch(e, pair)
 	Test_Constraint(e, pair)
+	// Test_ValidateOrder(e, pair, 0.012345, 0.012345)
 
 	// Test_NewOrderBook(e, pair)
 	// Test_TickerPrice(e)
+	// Test_Ticker(e)
+	// Test_SystemStatus(e)
 
 	Test_Balance(e, pair)
 	// Test_Trading(e, pair, 0.01, 0.01)
 	// Test_Trading_Sell(e, pair, 0.04, 0.01)
+	// Test_OrderTracker(e, pair, 0.01, 0.01)
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
 	// Test_DoWithdraw(e, pair.Target, "1", "0x37E0Fc27C6cDB5035B2a3d0682B4E7C05A4e6C46", "tag")
 
 	// Test_AOOpenOrder(e, pair)
 	// Test_AOOrderHistory(e, pair) // not tested with asset
+	// Test_AOMarginBalance(e, exchange.IsolatedMargin, pair)
 	// Test_AODepositAddress(e, pair.Base)
 	// Test_AODepositHistory(e, pair)
 	// Test_AOWithdrawalHistory(e, pair) // not tested with asset
@@ -287,6 +294,7 @@ func Test_Binance(t *testing.T) {
 	// // ===============================================
 
 	// Test_TradeHistory(e, pair)
+	// Test_TradePages(e, pair, 1600000000000, 1600003600000, "")
 
 	// Test_AOOpenOrder(e, pair)
 	// time.Sleep(time.Second * 5)
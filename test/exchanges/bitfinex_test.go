@@ -36,4 +36,7 @@ func Test_Bitfinex(t *testing.T) {
 	// Test_DoWithdraw(e, pair.Target, "1", "0x37E0Fc27C6cDB5035B2a3d0682B4E7C05A4e6C46", "tag")
 
 	// Test_TradeHistory(e, pair)
+	// Test_Ticker(e)
+	// Test_TradePages(e, pair, 1600000000000, 1600003600000, "")
+	// Test_Kline(e, pair, exchange.SpotWallet, exchange.KLINE_1HOUR, 0, 0)
 }
@@ -26,4 +26,8 @@ func Test_Bitmex(t *testing.T) {
 	Test_Balance(e, pair)
 	// Test_Trading(e, pair, 0.00000001, 100)
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
+	// Test_AOSetLeverage(e, pair, 10)
+	// Test_CheckAllBalance(e, exchange.ContractWallet)
+	// Test_AOExecutionHistory(e, exchange.ContractWallet, pair)
+	// Test_Kline(e, pair, exchange.ContractWallet, exchange.KLINE_1HOUR, 0, 0)
 }
@@ -32,4 +32,5 @@ func Test_Bitstamp(t *testing.T) {
 	//Test_Balance(e, pair)
 	// Test_Trading(e, pair, 0.00000001, 100)
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
+	// Test_Kline(e, pair, exchange.SpotWallet, exchange.KLINE_1HOUR, 0, 0)
 }
@@ -32,6 +32,8 @@ func Test_Bittrex(t *testing.T) {
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
 
 	// Test_TradeHistory(e, pair)
+	// Test_Ticker(e)
+	// Test_Kline(e, pair, exchange.SpotWallet, exchange.KLINE_1HOUR, 0, 0)
 
 	// // Test Withdraw
 	// opWithdraw := &exchange.AccountOperation{
@@ -22,7 +22,7 @@ func Test_Bybit(t *testing.T) {
 	Test_Coins(e)
 	Test_Pairs(e)
 	Test_Pair(e, pair)
-	// Test_Orderbook(e, pair)	// not api for now
+	Test_Orderbook(e, pair)
 	Test_ConstraintFetch(e, pair)
 	Test_Constraint(e, pair)
 
@@ -30,5 +30,8 @@ func Test_Bybit(t *testing.T) {
 	// Test_Trading(e, pair, 0.00000001, 100)
 	// Test_OrderStatus(e, pair, "1234567890")
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
+	// Test_AOSetLeverage(e, pair, 10)
+	// Test_CheckAllBalance(e, exchange.ContractWallet)
+	// Test_AOPositions(e, pair)
 	// log.Println(e.GetTradingWebURL(pair))
 }
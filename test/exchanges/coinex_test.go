@@ -28,6 +28,7 @@ func Test_Coinex(t *testing.T) {
 	Test_Constraint(e, pair)
 
 	// Test_TickerPrice(e)
+	// Test_Ticker(e)
 
 	Test_Balance(e, pair)
 	// Test_Trading(e, pair, 0.00000001, 100)
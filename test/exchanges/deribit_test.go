@@ -30,4 +30,5 @@ func Test_Deribit(t *testing.T) {
 	//Test_Balance(e, pair)
 	// Test_Trading(e, pair, 0.00000001, 100)
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
+	// Test_OptionChain(e, coin.GetCoin("BTC"))
 }
@@ -34,6 +34,9 @@ func Test_Ftx(t *testing.T) {
 	// Test_AODepositHistory(e, pair)
 	// Test_AOWithdrawalHistory(e, pair)
 	// Test_AOTransferHistory(e)
+	// Test_AOPositions(e, nil)
+	// Test_AOSetLeverage(e, nil, 10)
+	// Test_FundingRate(e, pair)
 
 	// ==============================================
 	// spot Kline
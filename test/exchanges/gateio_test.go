@@ -35,4 +35,6 @@ func Test_Gateio(t *testing.T) {
 	// Test_DoWithdraw(e, pair.Target, "0.2", "0x2d1a6a1d65ae08502a5e0ddda0be8df9874f7c14", "tag")
 
 	// Test_TradeHistory(e, pair)
+	// Test_Ticker(e)
+	// Test_Kline(e, pair, exchange.SpotWallet, exchange.KLINE_1HOUR, 0, 0)
 }
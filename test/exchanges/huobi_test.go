@@ -29,6 +29,7 @@ func Test_Huobi(t *testing.T) {
 
 	// Test_CoinChainType(e, pair.Base)
 	// Test_TradeHistory(e, pair)
+	// Test_TradePages(e, pair, 1600000000000, 1600003600000, "")
 
 	// Test_Coins(e)
 	// Test_Pairs(e)
@@ -38,6 +39,8 @@ func Test_Huobi(t *testing.T) {
 	// Test_ConstraintFetch(e, pair)
 	Test_Constraint(e, pair)
 	// Test_TickerPrice(e)
+	// Test_Ticker(e)
+	// Test_SystemStatus(e)
 
 	Test_Balance(e, pair)
 	// Test_CheckAllBalance(e, exchange.SpotWallet)
@@ -118,6 +121,7 @@ func Test_Huobi(t *testing.T) {
 	// Test_AOOpenOrder(e, pair)
 	// time.Sleep(time.Second * 5)
 	// Test_AOOrderHistory(e, pair)
+	// Test_AOMarginBalance(e, exchange.IsolatedMargin, pair)
 	// time.Sleep(time.Second * 5)
 	// Test_AODepositAddress(e, pair.Base)
 	// time.Sleep(time.Second * 5)
@@ -32,4 +32,7 @@ func Test_Huobidm(t *testing.T) {
 	//Test_Balance(e, pair)
 	//Test_Trading(e, pair, 0.0001, 100)
 	//Test_Withdraw(e, pair.Base, 1, "ADDRESS")
+	//Test_AOSetLeverage(e, pair, 10)
+	//Test_CheckAllBalance(e, exchange.ContractWallet)
+	//Test_AOPositions(e, nil)
 }
@@ -25,6 +25,7 @@ func Test_Kucoin(t *testing.T) {
 	pair := pair.GetPairByKey("USDT|ETH")
 
 	// Test_TradeHistory(e, pair)
+	// Test_TradePages(e, pair, 1600000000000, 1600003600000, "")
 	// Test_NewOrderBook(e, pair)
 
 	// Test_Coins(e)
@@ -117,7 +118,10 @@ func Test_Kucoin(t *testing.T) {
 	// ==============================================
 
 	// Test_AOOpenOrder(e, pair)
+	// Test_AOMarginBalance(e, exchange.IsolatedMargin, pair)
 	// Test_TickerPrice(e)
+	// Test_Ticker(e)
+	// Test_SystemStatus(e)
 
 	// Test_CheckBalance(e, pair.Target, exchange.AssetWallet)
 	// Test_CheckAllBalance(e, exchange.SpotWallet)
@@ -27,6 +27,7 @@ func Test_Mxc(t *testing.T) {
 	Test_Orderbook(e, pair)
 	// Test_ConstraintFetch(e, pair)
 	// Test_Constraint(e, pair)
+	// Test_Ticker(e)
 
 	var err error
 	// Test Balance
@@ -29,6 +29,7 @@ func Test_Okex(t *testing.T) {
 	// Test_ConstraintFetch(e, pair)
 	Test_Constraint(e, pair)
 	// Test_TickerPrice(e)
+	// Test_Ticker(e)
 
 	// new interface methods
 	// Test_DoWithdraw(e, pair.Target, "1", "0x37E0Fc27C6cDB5035B2a3d0682B4E7C05A4e6C46", "tag")
@@ -43,6 +44,8 @@ func Test_Okex(t *testing.T) {
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
 
 	// Test_AOOpenOrder(e, pair)
+	// Test_AOOrderHistory(e, pair)
+	// Test_AOMarginBalance(e, exchange.CrossMargin, nil)
 
 	// =====================================================================
 	// TransferHistory
@@ -71,8 +74,7 @@ func Test_Okex(t *testing.T) {
 	// ==============================================
 
 	// spot Kline
-	// interval options: 1min, 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 12hour, 1day, 1week
-	// can only get 24h data
+	// interval options: 1min, 3min, 5min, 15min, 30min, 1hour, 2hour, 4hour, 6hour, 12hour, 1day, 1week
 	// opKline := &exchange.PublicOperation{
 	// 	Wallet:         exchange.SpotWallet,
 	// 	Type:           exchange.KLine,
@@ -113,4 +115,5 @@ func Test_Okex(t *testing.T) {
 	// ===============================================
 
 	// Test_TradeHistory(e, pair)
+	// Test_TradePages(e, pair, 1600000000000, 1600003600000, "")
 }
@@ -30,4 +30,8 @@ func Test_Okexdm(t *testing.T) {
 	//Test_Balance(e, pair)
 	// Test_Trading(e, pair, 0.00000001, 100)
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
+	// Test_AOSetLeverage(e, pair, 10)
+	// Test_CheckAllBalance(e, exchange.ContractWallet)
+	// Test_AOPositions(e, nil)
+	// Test_AOOpenOrder(e, pair)
 }
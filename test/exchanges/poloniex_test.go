@@ -32,6 +32,7 @@ func Test_Poloniex(t *testing.T) {
 	// Test_Withdraw(e, pair.Base, 1, "ADDRESS")
 
 	// Test_TradeHistory(e, pair)
+	// Test_Ticker(e)
 	// Test_NewOrderBook(e, pair)
 
 	// ==============================================
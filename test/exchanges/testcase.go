@@ -6,7 +6,9 @@ package test
 
 import (
 	"log"
+	"time"
 
+	"github.com/bitontop/gored/cassette"
 	"github.com/bitontop/gored/coin"
 	"github.com/bitontop/gored/exchange"
 	"github.com/bitontop/gored/initial"
@@ -54,6 +56,21 @@ func InitExFromJson(exName exchange.ExchangeName) exchange.Exchange {
 	return e
 }
 
+// InitExCassette records the calls of the test with cassette.Record, later runs replay them offline with cassette.Replay.
+// Stop the cassette at the end of the test to save the recording.
+func InitExCassette(exName exchange.ExchangeName, path string, mode cassette.Mode) (exchange.Exchange, *cassette.Cassette) {
+	c, err := cassette.Start(path, mode)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	config := &exchange.Config{}
+	conf.Exchange(exName, config)
+	c.AddSecret(config.API_KEY, config.API_SECRET, config.Passphrase)
+
+	return InitEx(exName), c
+}
+
 /********************Public API********************/
 func Test_Coins(e exchange.Exchange) {
 	coins := e.GetCoins()
@@ -157,6 +174,40 @@ func Test_Trading_Sell(e exchange.Exchange, p *pair.Pair, rate, quantity float64
 	}
 }
 
+// place a limit buy and track it until it is done, cancelled by the tracker after 30s
+func Test_OrderTracker(e exchange.Exchange, p *pair.Pair, rate, quantity float64) {
+	order, err := e.LimitBuy(p, quantity, rate)
+	if err != nil {
+		log.Printf("%s Limit Buy Err: %s", e.GetName(), err)
+		return
+	}
+
+	tracker := exchange.NewOrderTracker()
+	if err := tracker.Track(e, order, exchange.SpotWallet); err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	tracker.Start()
+	defer tracker.Stop()
+
+	timeout := time.After(30 * time.Second)
+	for {
+		select {
+		case event := <-tracker.Events:
+			log.Printf("%s OrderTracker %v -> %v, filled: %v, %+v", e.GetName(), event.From, event.To, event.FilledQuantity, event.Order)
+			if event.Disappeared || event.To == exchange.Filled || event.To == exchange.Cancelled {
+				return
+			}
+		case <-timeout:
+			if err := tracker.Cancel(e.GetName(), order.OrderID); err != nil {
+				log.Printf("%s OrderTracker Cancel Err: %s", e.GetName(), err)
+				return
+			}
+			timeout = time.After(30 * time.Second)
+		}
+	}
+}
+
 // check auth only
 func Test_OrderStatus(e exchange.Exchange, p *pair.Pair, orderID string) {
 	order := &exchange.Order{
@@ -289,6 +340,28 @@ func Test_TradeHistory(e exchange.Exchange, pair *pair.Pair) {
 	}
 }
 
+// start, end: ms, fromID: the last trade id already known, "" if none
+func Test_TradePages(e exchange.Exchange, pair *pair.Pair, start, end int64, fromID string) {
+	opTradeHistory := &exchange.PublicOperation{
+		Type:           exchange.TradeHistory,
+		EX:             e.GetName(),
+		Pair:           pair,
+		TradeStartTime: start,
+		TradeEndTime:   end,
+		TradeFromID:    fromID,
+		DebugMode:      true,
+	}
+	err := e.LoadPublicData(opTradeHistory)
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	if n := len(opTradeHistory.TradeHistory); n > 0 {
+		log.Printf("TradeHistory: %+v ... %+v", opTradeHistory.TradeHistory[0], opTradeHistory.TradeHistory[n-1])
+	}
+	log.Printf("TradeHistory: %v trades", len(opTradeHistory.TradeHistory))
+}
+
 func Test_NewOrderBook(e exchange.Exchange, pair *pair.Pair) {
 	opOrderBook := &exchange.PublicOperation{
 		Type:      exchange.Orderbook,
@@ -359,6 +432,71 @@ func Test_AOOpenOrder(e exchange.Exchange, pair *pair.Pair) {
 	}
 }
 
+func Test_AOPositions(e exchange.Exchange, pair *pair.Pair) {
+	op := &exchange.AccountOperation{
+		Type:      exchange.GetPositions,
+		Wallet:    exchange.ContractWallet,
+		Ex:        e.GetName(),
+		Pair:      pair,
+		DebugMode: true,
+	}
+
+	if err := e.DoAccountOperation(op); err != nil {
+		log.Printf("%+v", err)
+	} else {
+		for _, p := range op.Positions {
+			log.Printf("%s Position: %v %+v", e.GetName(), p.Instrument, p)
+		}
+		if len(op.Positions) == 0 {
+			log.Printf("%s Positions Response: %v", e.GetName(), op.CallResponce)
+		}
+	}
+}
+
+func Test_AOSetLeverage(e exchange.Exchange, pair *pair.Pair, leverage int) {
+	op := &exchange.AccountOperation{
+		Type:      exchange.SetLeverage,
+		Wallet:    exchange.ContractWallet,
+		Ex:        e.GetName(),
+		Pair:      pair,
+		Leverage:  leverage,
+		DebugMode: true,
+	}
+
+	if err := e.DoAccountOperation(op); err != nil {
+		log.Printf("%+v", err)
+	} else {
+		log.Printf("%s SetLeverage: %+v", e.GetName(), op.LeverageSetting)
+	}
+}
+
+// mode: exchange.CrossMargin or exchange.IsolatedMargin, pair is required for isolated margin
+func Test_AOMarginBalance(e exchange.Exchange, mode exchange.MarginMode, pair *pair.Pair) {
+	op := &exchange.AccountOperation{
+		Type:       exchange.MarginOperation,
+		Wallet:     exchange.MarginWallet,
+		Ex:         e.GetName(),
+		MarginMode: mode,
+		Margin: &exchange.Margin{
+			Action: exchange.BALANCE,
+			Pair:   pair,
+		},
+		DebugMode: true,
+	}
+
+	if err := e.DoAccountOperation(op); err != nil {
+		log.Printf("%+v", err)
+	} else {
+		balance := op.Margin.MarginBalance
+		log.Printf("%s %v Margin RiskRate: %v, LiquidationPrice: %v", e.GetName(), mode, balance.RiskRate, balance.LiquidationPrice)
+		for _, asset := range balance.Assets {
+			if asset.Free+asset.Locked+asset.Borrowed != 0 {
+				log.Printf("%s Margin Asset: %v %+v", e.GetName(), asset.Coin.Code, asset)
+			}
+		}
+	}
+}
+
 func Test_AOOrderHistory(e exchange.Exchange, pair *pair.Pair) {
 	op := &exchange.AccountOperation{
 		Type:      exchange.GetOrderHistory,
@@ -381,6 +519,27 @@ func Test_AOOrderHistory(e exchange.Exchange, pair *pair.Pair) {
 	}
 }
 
+func Test_AOExecutionHistory(e exchange.Exchange, wallet exchange.WalletType, pair *pair.Pair) {
+	op := &exchange.AccountOperation{
+		Type:      exchange.GetExecutionHistory,
+		Wallet:    wallet,
+		Ex:        e.GetName(),
+		Pair:      pair,
+		DebugMode: true,
+	}
+
+	if err := e.DoAccountOperation(op); err != nil {
+		log.Printf("%+v", err)
+	} else {
+		for _, ex := range op.ExecutionHistory {
+			log.Printf("%s ExecutionHistory %+v", e.GetName(), ex)
+		}
+		if len(op.ExecutionHistory) == 0 {
+			log.Printf("%s ExecutionHistory Response: %v", e.GetName(), op.CallResponce)
+		}
+	}
+}
+
 func Test_AODepositAddress(e exchange.Exchange, coin *coin.Coin) {
 	op := &exchange.AccountOperation{
 		Type:      exchange.GetDepositAddress,
@@ -484,6 +643,112 @@ func Test_TickerPrice(e exchange.Exchange) {
 	}
 }
 
+func Test_Ticker(e exchange.Exchange) {
+	opTicker := &exchange.PublicOperation{
+		Type:      exchange.Ticker,
+		EX:        e.GetName(),
+		Wallet:    exchange.SpotWallet,
+		DebugMode: true,
+	}
+	err := e.LoadPublicData(opTicker)
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	for _, ticker := range opTicker.Ticker {
+		log.Printf("Ticker: %v, last: %v, bid: %v, ask: %v, volume: %v, quote volume: %v, change: %.2f%%",
+			ticker.Pair.Name, ticker.Last, ticker.BestBid, ticker.BestAsk, ticker.Volume, ticker.QuoteVolume, ticker.ChangePercent)
+	}
+}
+
+func Test_SystemStatus(e exchange.Exchange) {
+	h := exchange.NewHealthMonitor(e).Check(e)
+	log.Printf("SystemStatus: %v %v, errors: %v", h.Status, h.Message, h.Errors)
+	for _, maintenance := range h.Maintenances {
+		log.Printf("Maintenance: %v, %v - %v %v", maintenance.Title, maintenance.Start, maintenance.End, maintenance.URL)
+	}
+	log.Printf("WalletStatus: %v coins, %v suspended", h.Coins, len(h.Suspended))
+	for _, status := range h.Suspended {
+		log.Printf("Suspended: %v, %v", status.Coin.Code, status.Issue)
+	}
+}
+
+func Test_FundingRate(e exchange.Exchange, pair *pair.Pair) {
+	opFundingRate := &exchange.PublicOperation{
+		Type:      exchange.FundingRate,
+		EX:        e.GetName(),
+		Pair:      pair,
+		Wallet:    exchange.ContractWallet,
+		DebugMode: true,
+	}
+	err := e.LoadPublicData(opFundingRate)
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	log.Printf("FundingRate: %+v", opFundingRate.FundingRate)
+	for _, history := range opFundingRate.FundingRate.History {
+		log.Printf("FundingRate History: %v, %v", history.Timestamp, history.Rate)
+	}
+}
+
+// start, end: ms, 0 for the latest klines of the exchange
+func Test_Kline(e exchange.Exchange, pair *pair.Pair, wallet exchange.WalletType, interval exchange.KlineInterval, start, end int64) {
+	opKline := &exchange.PublicOperation{
+		Type:           exchange.KLine,
+		EX:             e.GetName(),
+		Pair:           pair,
+		Wallet:         wallet,
+		KlineInterval:  interval,
+		KlineStartTime: start,
+		KlineEndTime:   end,
+		DebugMode:      true,
+	}
+	err := e.LoadPublicData(opKline)
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	for _, k := range opKline.Kline {
+		log.Printf("Kline: %+v", k)
+	}
+	log.Printf("Kline: %v klines", len(opKline.Kline))
+}
+
+// underlying: coin of the option chain, eg. coin.GetCoin("BTC")
+func Test_OptionChain(e exchange.Exchange, underlying *coin.Coin) {
+	opOptionChain := &exchange.PublicOperation{
+		Type:      exchange.OptionChain,
+		EX:        e.GetName(),
+		Coin:      underlying,
+		Wallet:    exchange.OptionWallet,
+		DebugMode: true,
+	}
+	err := e.LoadPublicData(opOptionChain)
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	log.Printf("OptionChain: %v instruments", len(opOptionChain.OptionChain))
+	if len(opOptionChain.OptionChain) == 0 {
+		return
+	}
+
+	opOptionTicker := &exchange.PublicOperation{
+		Type:       exchange.OptionTicker,
+		EX:         e.GetName(),
+		Instrument: opOptionChain.OptionChain[0].Instrument,
+		Wallet:     exchange.OptionWallet,
+		DebugMode:  true,
+	}
+	err = e.LoadPublicData(opOptionTicker)
+	if err != nil {
+		log.Printf("%v", err)
+		return
+	}
+	log.Printf("OptionTicker: %+v, Greeks: %+v", opOptionTicker.OptionTicker, opOptionTicker.OptionTicker.Greeks)
+}
+
 func SubBalances(e exchange.Exchange, subID string) {
 	// Sub Spot AllBalance
 	opSubBalance := &exchange.AccountOperation{
@@ -569,3 +834,15 @@ func Test_Constraint(e exchange.Exchange, p *pair.Pair) {
 	log.Printf("%s %s Coin Constraint: %+v, %v", e.GetName(), p.Target.Code, targerConstraint, targerConstraint.Coin)
 	log.Printf("%s %s Pair Constraint: %+v", e.GetName(), p.Name, pairConstrinat)
 }
+
+// no request is sent, check the rounding and the errors of the pre-trade validation
+func Test_ValidateOrder(e exchange.Exchange, p *pair.Pair, rate, quantity float64) {
+	for _, round := range []bool{false, true} {
+		q, r, err := exchange.ValidateOrder(e, p, exchange.Buy, quantity, rate, round)
+		if err != nil {
+			log.Printf("%s ValidateOrder(round: %v) Err: %s", e.GetName(), round, err)
+			continue
+		}
+		log.Printf("%s ValidateOrder(round: %v): quantity %v -> %v, rate %v -> %v", e.GetName(), round, quantity, q, rate, r)
+	}
+}
@@ -42,7 +42,7 @@ func Test_Virgocx(t *testing.T) {
 		Type:          exchange.KLine,
 		EX:            e.GetName(),
 		Pair:          pair,
-		KlineInterval: "5", // default to 5 if not provided
+		KlineInterval: exchange.KLINE_5MIN, // default to 5min if not provided
 		DebugMode:     true,
 	}
 	err = e.LoadPublicData(opKline)
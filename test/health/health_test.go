@@ -0,0 +1,100 @@
+package health
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+// binance in maintenance with the ETH withdraw suspended, the issues reach the constraints and the coin
+func TestHealth(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	server := conformance.NewServer(dir)
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	defer conformance.PointTo(exchange.BINANCE, server.URL)()
+
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	if e == nil {
+		t.Fatalf("Init failed, missing: %v", server.Missing())
+	}
+
+	m := exchange.NewHealthMonitor(e)
+	m.Poll()
+
+	h := m.Health(exchange.BINANCE)
+	if h == nil {
+		t.Fatalf("no health")
+	} else if len(h.Errors) > 0 {
+		t.Fatalf("%v", h.Errors)
+	}
+	if h.Healthy() || h.Status != exchange.HealthMaintenance || h.Message != "system maintenance" {
+		t.Errorf("status %v %v", h.Status, h.Message)
+	}
+	if h.Coins != 3 || len(h.Suspended) != 1 || h.Suspended[0].Coin.Code != "ETH" {
+		t.Fatalf("%d coins, suspended %+v", h.Coins, h.Suspended)
+	}
+
+	eth, btc := coin.GetCoin("ETH"), coin.GetCoin("BTC")
+	if e.CanWithdraw(eth) || !e.CanDeposit(eth) {
+		t.Errorf("ETH withdraw %v deposit %v", e.CanWithdraw(eth), e.CanDeposit(eth))
+	} else if issue := e.GetCoinConstraint(eth).Issue; issue != "withdraw suspended Wallet maintenance" {
+		t.Errorf("ETH issue %q", issue)
+	}
+
+	issue := e.GetPairConstraint(pair.GetPair(btc, eth)).Issue
+	if !strings.HasPrefix(issue, "Maintenance system maintenance") || !strings.Contains(issue, "ETH withdraw suspended") {
+		t.Errorf("ETHBTC issue %q", issue)
+	}
+	if issue := e.GetPairConstraint(pair.GetPair(coin.GetCoin("USDT"), btc)).Issue; issue != "Maintenance system maintenance" {
+		t.Errorf("BTCUSDT issue %q", issue)
+	}
+
+	// binance is the only exchange checked
+	if coin.GetHealth(eth) != "BINANCE withdraw suspended Wallet maintenance" || coin.GetHealth(btc) != "" {
+		t.Errorf("health ETH %q BTC %q", coin.GetHealth(eth), coin.GetHealth(btc))
+	}
+	if report := m.Report(); len(report) != 1 || report[0] != h {
+		t.Errorf("report %+v", report)
+	}
+
+	// the constraints and the coins are read while polled, go test -race
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			m.Poll()
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_ = e.GetPairConstraint(pair.GetPair(btc, eth)).Issue
+				_ = e.GetCoinConstraint(eth).Issue
+				_ = coin.GetHealth(eth)
+			}
+		}()
+	}
+	wg.Wait()
+	if issue := e.GetCoinConstraint(eth).Issue; issue != "withdraw suspended Wallet maintenance" {
+		t.Errorf("ETH issue %q after polls", issue)
+	}
+}
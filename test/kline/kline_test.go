@@ -0,0 +1,141 @@
+package kline
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+func TestNormalize(t *testing.T) {
+	intervals := exchange.KlineIntervals{exchange.KLINE_1MIN: "1m", exchange.KLINE_1HOUR: "1h"}
+	tests := []struct {
+		input    exchange.KlineInterval
+		expected exchange.KlineInterval
+	}{
+		{"1m", exchange.KLINE_1MIN},
+		{"1h", exchange.KLINE_1HOUR},
+		{exchange.KLINE_1MIN, exchange.KLINE_1MIN},
+		{"7m", "7m"},
+		{"", ""},
+	}
+	for _, test := range tests {
+		if interval := intervals.Normalize(test.input); interval != test.expected {
+			t.Errorf("Normalize(%q) %q, expected %q", test.input, interval, test.expected)
+		}
+	}
+}
+
+// the contract klines of binance take the binance intervals, eg. "1m", and are paged on the interval
+func TestBinanceContractKline(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	c, err := conformance.LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	server := conformance.NewServer(dir)
+	defer server.Close()
+	defer conformance.PointTo(exchange.BINANCE, server.URL)()
+
+	mu := sync.Mutex{}
+	intervals := []string{}
+	server.HandleFunc("GET", "/fapi/v1/klines", func(r *http.Request) string {
+		mu.Lock()
+		intervals = append(intervals, r.URL.Query().Get("interval"))
+		mu.Unlock()
+		start, _ := strconv.ParseInt(r.URL.Query().Get("startTime"), 10, 64)
+		return fmt.Sprintf(`[[%d,"1","2","0.5","1.5","10",%d,"15",3,"5","7.5","0"]]`, start, start+59999)
+	})
+
+	e := initial.CreateInitManager().Init(&exchange.Config{ExName: exchange.BINANCE, Source: exchange.EXCHANGE_API})
+	if e == nil {
+		t.Fatalf("Init failed, missing: %v", server.Missing())
+	}
+	p, err := c.Pair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	start := int64(1600000000000)
+	operation := &exchange.PublicOperation{
+		Type:           exchange.KLine,
+		Wallet:         exchange.ContractWallet,
+		EX:             exchange.BINANCE,
+		Pair:           p,
+		KlineInterval:  "1m",
+		KlineStartTime: start,
+		KlineEndTime:   start + int64(2*1500*time.Minute/time.Millisecond) - 1,
+	}
+	if err := e.LoadPublicData(operation); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(intervals) != 2 || intervals[0] != "1m" || intervals[1] != "1m" {
+		t.Errorf("intervals requested %v, expected 2 pages of 1m", intervals)
+	}
+	if len(operation.Kline) != 2 || operation.Kline[0].OpenTime != float64(start) {
+		t.Errorf("%d klines, expected one per page from %d", len(operation.Kline), start)
+	}
+}
+
+// kraken only has its latest klines, a start before them is one request failing with ErrKlineRange
+func TestKrakenKline(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	server := conformance.NewServer(conformance.FixtureDir("../conformance/testdata", "none"))
+	defer server.Close()
+	defer conformance.PointTo(exchange.KRAKEN, server.URL)()
+
+	first := int64(1600000000)
+	server.HandleFunc("GET", "/0/public/OHLC", func(r *http.Request) string {
+		klines := []string{}
+		for i := int64(0); i < 3; i++ {
+			klines = append(klines, fmt.Sprintf(`[%d,"1","2","0.5","1.5","1.2","10",3]`, first+i*60))
+		}
+		return fmt.Sprintf(`{"error":[],"result":{"XXBTZUSD":[%s],"last":%d}}`, strings.Join(klines, ","), first+120)
+	})
+
+	server.Handle("GET", "/0/public/Assets", `{"error":[],"result":{"XXBT":{"altname":"BTC"},"ZUSD":{"altname":"USD"}}}`)
+	server.Handle("GET", "/0/public/AssetPairs", `{"error":[],"result":{"XXBTZUSD":{"altname":"XBTUSD","base":"XXBT","quote":"ZUSD"}}}`)
+
+	e := initial.CreateInitManager().Init(&exchange.Config{ExName: exchange.KRAKEN, Source: exchange.EXCHANGE_API})
+	if e == nil {
+		t.Fatalf("Init failed, missing: %v", server.Missing())
+	}
+	p := pair.GetPair(coin.GetCoin("USD"), coin.GetCoin("BTC"))
+
+	operation := &exchange.PublicOperation{Type: exchange.KLine, Wallet: exchange.SpotWallet, EX: exchange.KRAKEN, Pair: p,
+		KlineInterval: exchange.KLINE_1MIN, KlineStartTime: (first + 60) * 1000}
+	if err := e.LoadPublicData(operation); err != nil {
+		t.Fatalf("%v", err)
+	} else if len(operation.Kline) != 2 {
+		t.Errorf("%d klines, expected the 2 from the start", len(operation.Kline))
+	}
+
+	requests := len(server.Requests())
+	operation = &exchange.PublicOperation{Type: exchange.KLine, Wallet: exchange.SpotWallet, EX: exchange.KRAKEN, Pair: p,
+		KlineInterval: exchange.KLINE_1MIN, KlineStartTime: (first - 3600) * 1000}
+	if err := e.LoadPublicData(operation); !errors.Is(err, exchange.ErrKlineRange) {
+		t.Errorf("start before the latest klines: %v", err)
+	} else if len(server.Requests())-requests != 1 {
+		t.Errorf("%d requests, expected 1", len(server.Requests())-requests)
+	}
+}
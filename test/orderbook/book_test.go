@@ -0,0 +1,116 @@
+package orderbook
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/orderbook"
+	"github.com/bitontop/gored/pair"
+)
+
+type makerFeed struct {
+	maker *exchange.Maker
+	err   error
+}
+
+func (f *makerFeed) OrderBook(p *pair.Pair) (*exchange.Maker, error) {
+	return f.maker, f.err
+}
+
+// testPair adds the coins if no exchange loaded them
+func testPair(base, target string) *pair.Pair {
+	for _, code := range []string{base, target} {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+	return pair.GetPair(coin.GetCoin(base), coin.GetCoin(target))
+}
+
+// two books merged with their fees, the best execution of a buy and a sell across both
+func TestConsolidatedBook(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	p := testPair("USDT", "BTC")
+
+	c := &orderbook.Consolidated{
+		Pair: p,
+		Venues: []*orderbook.Venue{
+			{Name: exchange.BINANCE, Fee: 0.001, Feed: &makerFeed{maker: book(
+				[][2]float64{{100, 1}, {99, 2}},
+				[][2]float64{{101, 1}, {102, 2}},
+			)}},
+			{Name: exchange.HUOBI, Fee: 0.002, Feed: &makerFeed{maker: book(
+				[][2]float64{{100.05, 0.5}},
+				[][2]float64{{100.9, 0.5}, {101.5, 1}},
+			)}},
+			{Name: exchange.OKEX, Feed: &makerFeed{err: fmt.Errorf("offline")}},
+		},
+	}
+
+	ob, err := c.Update()
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if len(ob.Makers) != 2 || ob.Errors[exchange.OKEX] == nil {
+		t.Fatalf("makers %v errors %v", len(ob.Makers), ob.Errors)
+	} else if len(ob.Bids) != 3 || len(ob.Asks) != 4 {
+		t.Fatalf("%d bids %d asks", len(ob.Bids), len(ob.Asks))
+	}
+
+	// 100 * 0.999 = 99.9 is better than 100.05 * 0.998 = 99.8499
+	if best := ob.BestBid(); best.Exchange != exchange.BINANCE || !near(best.Price, 99.9) {
+		t.Errorf("best bid %+v", best)
+	}
+	// 101 * 1.001 = 101.101 is better than 100.9 * 1.002 = 101.1018
+	if best := ob.BestAsk(); best.Exchange != exchange.BINANCE || !near(best.Price, 101.101) {
+		t.Errorf("best ask %+v", best)
+	}
+	for i := 1; i < len(ob.Asks); i++ {
+		if ob.Asks[i].Price < ob.Asks[i-1].Price {
+			t.Fatalf("asks not sorted: %+v", ob.Asks)
+		}
+	}
+
+	buy, err := ob.BestExecution(exchange.Buy, 2)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	// 1 binance @ 101.101, 0.5 huobi @ 101.1018, 0.5 huobi @ 101.703
+	cost := 101.101 + 0.5*101.1018 + 0.5*101.5*1.002
+	if !near(buy.Quantity, 2) || !near(buy.Cost, cost) || !near(buy.Price, cost/2) {
+		t.Errorf("buy %+v", buy)
+	}
+	venues := buy.Venues()
+	if !near(venues[exchange.BINANCE], 1) || !near(venues[exchange.HUOBI], 1) {
+		t.Errorf("buy venues %v", venues)
+	}
+
+	if _, err := ob.BestExecution(exchange.Sell, 10); err == nil {
+		t.Errorf("sell of 10 with 3.5 of bids")
+	}
+	if depth := ob.Depth(exchange.Sell, 99.8); !near(depth, 1.5) {
+		t.Errorf("depth %v", depth)
+	}
+}
+
+func book(bids, asks [][2]float64) *exchange.Maker {
+	maker := &exchange.Maker{}
+	for _, bid := range bids {
+		maker.Bids = append(maker.Bids, exchange.Order{Rate: bid[0], Quantity: bid[1]})
+	}
+	for _, ask := range asks {
+		maker.Asks = append(maker.Asks, exchange.Order{Rate: ask[0], Quantity: ask[1]})
+	}
+	return maker
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
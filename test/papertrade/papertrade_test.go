@@ -0,0 +1,174 @@
+package papertrade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/exchange/papertrade"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+func book(bids, asks [][2]float64) *exchange.Maker {
+	maker := &exchange.Maker{}
+	for _, bid := range bids {
+		maker.Bids = append(maker.Bids, exchange.Order{Rate: bid[0], Quantity: bid[1]})
+	}
+	for _, ask := range asks {
+		maker.Asks = append(maker.Asks, exchange.Order{Rate: ask[0], Quantity: ask[1]})
+	}
+	return maker
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// papertrade with the binance constraints, fed by recorded books
+func TestPapertrade(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	c, err := conformance.LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	server := conformance.NewServer(dir)
+	server.Handle("GET", "/raw", "127.0.0.1")
+	restore := conformance.PointTo(exchange.BINANCE, server.URL)
+	source := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	restore()
+	server.Close()
+	if source == nil {
+		t.Fatalf("Init failed")
+	}
+	p, err := c.Pair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	usdt, btc := p.Base, p.Target
+
+	books := papertrade.NewRecordedBooks()
+	books.Add(p, book([][2]float64{{9000, 0.5}}, [][2]float64{{9000.01, 0.4}, {9001, 1}}))
+	books.Add(p, book([][2]float64{{9000, 1}}, [][2]float64{{9000.01, 1}}))
+	books.Add(p, book([][2]float64{{9100, 0.1}, {9050, 1}}, [][2]float64{{9100.01, 1}}))
+
+	tmp, err := ioutil.TempDir("", "papertrade")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "books.json")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	e := papertrade.CreatePapertrade(&papertrade.Config{
+		Source:   source,
+		Feed:     &papertrade.Recorder{Feed: books, Writer: file},
+		Balances: map[string]float64{"USDT": 10000},
+	})
+	if e == nil {
+		t.Fatalf("CreatePapertrade failed")
+	}
+	constraint := *e.GetPairConstraint(p)
+	constraint.MakerFee, constraint.TakerFee = 0.001, 0.002
+	e.SetPairConstraint(&constraint)
+
+	if _, err := e.LimitBuy(p, 10, 9000); err == nil {
+		t.Errorf("LimitBuy over the balance placed")
+	}
+
+	// taker through two levels
+	buy, err := e.LimitBuy(p, 0.5, 9001)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if buy.Status != exchange.Filled || !near(buy.DealRate, 9000.208) {
+		t.Errorf("buy %v @ %v, expected Filled @ 9000.208", buy.Status, buy.DealRate)
+	}
+	if balance := e.GetBalance(usdt); !near(balance, 10000-4500.104) {
+		t.Errorf("USDT %v, expected %v", balance, 10000-4500.104)
+	} else if balance := e.GetBalance(btc); !near(balance, 0.499) {
+		t.Errorf("BTC %v, expected 0.499", balance)
+	}
+
+	// rests, then partially filled as maker at its own rate
+	sell, err := e.LimitSell(p, 0.4, 9100)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if sell.Status != exchange.New {
+		t.Errorf("sell %v, expected New", sell.Status)
+	}
+	if err := e.OrderStatus(sell); err != nil {
+		t.Fatalf("%v", err)
+	} else if sell.Status != exchange.Partial || !near(sell.DealQuantity, 0.1) {
+		t.Errorf("sell %v %v, expected Partial 0.1", sell.Status, sell.DealQuantity)
+	}
+	if err := e.CancelOrder(sell); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if balance := e.GetBalance(btc); !near(balance, 0.399) {
+		t.Errorf("BTC %v after cancel, expected 0.399", balance)
+	} else if balance := e.GetBalance(usdt); !near(balance, 10000-4500.104+910*0.999) {
+		t.Errorf("USDT %v after cancel, expected %v", balance, 10000-4500.104+910*0.999)
+	}
+
+	operation := &exchange.AccountOperation{
+		Type: exchange.GetExecutionHistory,
+		Ex:   e.GetName(),
+		Pair: p,
+	}
+	if err := e.DoAccountOperation(operation); err != nil {
+		t.Fatalf("%v", err)
+	} else if len(operation.ExecutionHistory) != 3 || !operation.ExecutionHistory[2].Maker {
+		t.Errorf("executions %+v, expected 2 taker and 1 maker", operation.ExecutionHistory)
+	}
+
+	// not matched before the latency
+	slow := papertrade.CreatePapertrade(&papertrade.Config{
+		Source:   source,
+		Feed:     books,
+		Balances: map[string]float64{"USDT": 10000},
+		Latency:  time.Hour,
+	})
+	order, err := slow.LimitBuy(p, 0.5, 9200)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if err := slow.OrderStatus(order); err != nil {
+		t.Fatalf("%v", err)
+	} else if order.Status != exchange.New {
+		t.Errorf("order %v before the latency, expected New", order.Status)
+	}
+
+	file.Close()
+	recorded, err := papertrade.LoadRecordedBooks(path)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	first, err := recorded.OrderBook(p)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if expected := book(nil, [][2]float64{{9000.01, 0.4}, {9001, 1}}); !reflect.DeepEqual(first.Asks, expected.Asks) {
+		t.Errorf("recorded asks %+v, expected %+v", first.Asks, expected.Asks)
+	}
+}
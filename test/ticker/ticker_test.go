@@ -0,0 +1,111 @@
+package ticker
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+// the ticker of the pair of the case, the symbols without a pair are skipped
+func TestTicker(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	expected := map[exchange.ExchangeName]exchange.TickerDetail{
+		exchange.BINANCE: {
+			Last: 9000, BestBid: 8999.9, BestBidQuantity: 1.2, BestAsk: 9000.1, BestAskQuantity: 0.8,
+			Open: 8550, High: 9100, Low: 8500, Volume: 1500, QuoteVolume: 13200180,
+			Change: 450, ChangePercent: 5.263, Timestamp: 1600086400000,
+		},
+		exchange.OKEX: {
+			Last: 350, BestBid: 349.8, BestBidQuantity: 2.5, BestAsk: 350.2, BestAskQuantity: 3.5,
+			Open: 340, High: 355, Low: 338, Volume: 2000, QuoteVolume: 700000,
+			Change: 10, ChangePercent: 10.0 / 340 * 100, Timestamp: 1600086400000,
+		},
+	}
+
+	for _, exName := range []exchange.ExchangeName{exchange.BINANCE, exchange.OKEX} {
+		t.Run(string(exName), func(t *testing.T) {
+			dir := conformance.FixtureDir("../conformance/testdata", exName)
+			c, err := conformance.LoadCase(dir)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			server := conformance.NewServer(dir)
+			defer server.Close()
+			server.Handle("GET", "/raw", "127.0.0.1")
+			defer conformance.PointTo(exName, server.URL)()
+
+			e := initial.CreateInitManager().Init(&exchange.Config{
+				ExName:     exName,
+				Source:     exchange.EXCHANGE_API,
+				API_KEY:    "key",
+				API_SECRET: "secret",
+				Passphrase: "passphrase",
+			})
+			if e == nil {
+				t.Fatalf("%s Init failed, missing: %v", exName, server.Missing())
+			}
+			p, err := c.Pair()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			op := &exchange.PublicOperation{
+				Type:   exchange.Ticker,
+				EX:     e.GetName(),
+				Wallet: exchange.SpotWallet,
+			}
+			if err := e.LoadPublicData(op); err != nil {
+				t.Fatalf("%v", err)
+			} else if len(op.Ticker) != 1 {
+				t.Fatalf("%d tickers, expected 1", len(op.Ticker))
+			}
+
+			ticker := op.Ticker[0]
+			if ticker.Pair != p {
+				t.Fatalf("ticker of %v, expected %v", ticker.Pair.Name, p.Name)
+			}
+			want := expected[exName]
+			want.Pair = p
+			for name, values := range map[string][2]float64{
+				"Last":            {ticker.Last, want.Last},
+				"BestBid":         {ticker.BestBid, want.BestBid},
+				"BestBidQuantity": {ticker.BestBidQuantity, want.BestBidQuantity},
+				"BestAsk":         {ticker.BestAsk, want.BestAsk},
+				"BestAskQuantity": {ticker.BestAskQuantity, want.BestAskQuantity},
+				"Open":            {ticker.Open, want.Open},
+				"High":            {ticker.High, want.High},
+				"Low":             {ticker.Low, want.Low},
+				"Volume":          {ticker.Volume, want.Volume},
+				"QuoteVolume":     {ticker.QuoteVolume, want.QuoteVolume},
+				"Change":          {ticker.Change, want.Change},
+				"ChangePercent":   {ticker.ChangePercent, want.ChangePercent},
+			} {
+				if math.Abs(values[0]-values[1]) > 1e-9 {
+					t.Errorf("%s %v, expected %v", name, values[0], values[1])
+				}
+			}
+			if ticker.Timestamp != want.Timestamp {
+				t.Errorf("Timestamp %v, expected %v", ticker.Timestamp, want.Timestamp)
+			}
+			if spread := ticker.Spread(); math.Abs(spread-(want.BestAsk-want.BestBid)) > 1e-9 {
+				t.Errorf("Spread %v", spread)
+			}
+
+			if missing := server.Missing(); len(missing) > 0 {
+				t.Errorf("%s requests without fixture: %v", exName, missing)
+			}
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package tracker
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/pair"
+)
+
+// stubExchange answers the status of the orders from statuses, an order without a status is not found.
+// The methods not overridden panic.
+type stubExchange struct {
+	exchange.Exchange
+
+	mu       sync.Mutex
+	statuses map[string]*exchange.Order // key: order id
+	polls    int
+	wallets  []exchange.WalletType // wallet of every status request
+}
+
+func (e *stubExchange) GetName() exchange.ExchangeName { return exchange.BINANCE }
+func (e *stubExchange) GetID() int                     { return 1 }
+
+func (e *stubExchange) set(orderID string, status exchange.OrderStatus, dealQuantity float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if status == "" {
+		delete(e.statuses, orderID)
+		return
+	}
+	e.statuses[orderID] = &exchange.Order{OrderID: orderID, Status: status, DealQuantity: dealQuantity}
+}
+
+func (e *stubExchange) status(order *exchange.Order, wallet exchange.WalletType) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.polls++
+	e.wallets = append(e.wallets, wallet)
+	status, ok := e.statuses[order.OrderID]
+	if !ok {
+		return fmt.Errorf("order %s not found", order.OrderID)
+	}
+	order.Status = status.Status
+	order.DealQuantity = status.DealQuantity
+	return nil
+}
+
+func (e *stubExchange) OrderStatus(order *exchange.Order) error {
+	return e.status(order, exchange.SpotWallet)
+}
+
+func (e *stubExchange) DoAccountOperation(operation *exchange.AccountOperation) error {
+	switch operation.Type {
+	case exchange.GetOrderStatus:
+		return e.status(operation.Order, operation.Wallet)
+	case exchange.GetOpenOrder:
+		operation.OpenOrders = []*exchange.Order{}
+		return nil
+	}
+	return fmt.Errorf("operation %v not supported", operation.Type)
+}
+
+func events(tracker *exchange.OrderTracker) []*exchange.OrderEvent {
+	list := []*exchange.OrderEvent{}
+	for {
+		select {
+		case event := <-tracker.Events:
+			list = append(list, event)
+		default:
+			return list
+		}
+	}
+}
+
+func testPair(base, target string) *pair.Pair {
+	for _, code := range []string{base, target} {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+	return pair.GetPair(coin.GetCoin(base), coin.GetCoin(target))
+}
+
+// a spot order partially filled then filled, the order of the caller is not changed
+func TestOrderTracker(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	p := testPair("USDT", "BTC")
+
+	e := &stubExchange{statuses: make(map[string]*exchange.Order)}
+	tracker := exchange.NewOrderTracker()
+	order := &exchange.Order{Pair: p, OrderID: "1", Rate: 9000, Quantity: 2}
+	if err := tracker.Track(e, order, ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	e.set("1", exchange.Partial, 0.5)
+	tracker.Poll()
+	list := events(tracker)
+	if len(list) != 1 || list[0].From != exchange.New || list[0].To != exchange.Partial || list[0].FilledQuantity != 0.5 {
+		t.Fatalf("events %+v, expected New -> Partial 0.5", list)
+	} else if list[0].Order == order {
+		t.Errorf("event has the order of the caller")
+	}
+	if order.Status != "" || order.DealQuantity != 0 {
+		t.Errorf("order of the caller changed: %v %v", order.Status, order.DealQuantity)
+	}
+
+	// not due before the interval
+	tracker.Poll()
+	if e.polls != 1 {
+		t.Errorf("%d polls before the interval, expected 1", e.polls)
+	}
+
+	// from a stream
+	tracker.Update(exchange.BINANCE, &exchange.Order{OrderID: "1", Status: exchange.Filled, DealQuantity: 2})
+	list = events(tracker)
+	if len(list) != 1 || list[0].To != exchange.Filled || list[0].FilledQuantity != 1.5 {
+		t.Fatalf("events %+v, expected Partial -> Filled 1.5", list)
+	}
+	if orders := tracker.Orders(); len(orders) != 0 {
+		t.Errorf("%d orders tracked after Filled", len(orders))
+	}
+}
+
+// failed polls back off up to MaxInterval, after MissLimit the order not open disappears
+func TestOrderTrackerDisappeared(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	p := testPair("USDT", "BTC")
+
+	e := &stubExchange{statuses: make(map[string]*exchange.Order)}
+	tracker := exchange.NewOrderTracker()
+	tracker.Interval = 10 * time.Millisecond
+	tracker.MaxInterval = 20 * time.Millisecond
+	tracker.MissLimit = 3
+	if err := tracker.Track(e, &exchange.Order{Pair: p, OrderID: "2", Quantity: 1}, exchange.ContractWallet); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tracker.Poll()
+	tracker.Poll()
+	if e.polls != 1 {
+		t.Fatalf("%d polls during the backoff, expected 1", e.polls)
+	}
+	for i := 0; i < 2; i++ {
+		time.Sleep(25 * time.Millisecond)
+		tracker.Poll()
+	}
+	if e.polls != 3 {
+		t.Fatalf("%d polls, expected 3", e.polls)
+	}
+	for _, w := range e.wallets {
+		if w != exchange.ContractWallet {
+			t.Errorf("status of the contract order polled on %v", w)
+		}
+	}
+
+	list := events(tracker)
+	if len(list) != 1 || !list[0].Disappeared || list[0].Order.OrderID != "2" {
+		t.Fatalf("events %+v, expected order 2 disappeared", list)
+	}
+	if orders := tracker.Orders(); len(orders) != 0 {
+		t.Errorf("%d orders tracked after disappeared", len(orders))
+	}
+}
+
+// the tracked orders are saved and resumed with their wallet
+func TestOrderTrackerResume(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	p := testPair("USDT", "BTC")
+
+	tmp, err := ioutil.TempDir("", "tracker")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "orders.json")
+
+	e := &stubExchange{statuses: make(map[string]*exchange.Order)}
+	exchange.CreateExchangeManager().Add(e)
+
+	tracker := exchange.NewOrderTracker()
+	tracker.StatePath = path
+	if err := tracker.Track(e, &exchange.Order{Pair: p, OrderID: "3", Rate: 100, Quantity: 1}, exchange.MarginWallet); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	resumed := exchange.NewOrderTracker()
+	resumed.StatePath = path
+	if err := resumed.Resume(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	orders := resumed.Orders()
+	if len(orders) != 1 || orders[0].OrderID != "3" || orders[0].Pair != p || orders[0].Status != exchange.New {
+		t.Fatalf("resumed %+v", orders)
+	}
+
+	e.set("3", exchange.Cancelled, 0)
+	resumed.Poll()
+	list := events(resumed)
+	if len(list) != 1 || list[0].To != exchange.Cancelled || !list[0].ExternalCancel {
+		t.Fatalf("events %+v, expected an external cancel", list)
+	}
+	if len(e.wallets) != 1 || e.wallets[0] != exchange.MarginWallet {
+		t.Errorf("resumed order polled on %v, expected MarginWallet", e.wallets)
+	}
+
+	// nothing left to resume
+	again := exchange.NewOrderTracker()
+	again.StatePath = path
+	if err := again.Resume(); err != nil {
+		t.Fatalf("%v", err)
+	} else if orders := again.Orders(); len(orders) != 0 {
+		t.Errorf("resumed %d orders, expected none", len(orders))
+	}
+}
+
+// orders tracked while the tracker polls, the saved state has them all
+func TestOrderTrackerConcurrentSave(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	p := testPair("USDT", "BTC")
+
+	tmp, err := ioutil.TempDir("", "tracker")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer os.RemoveAll(tmp)
+	path := filepath.Join(tmp, "orders.json")
+
+	e := &stubExchange{statuses: make(map[string]*exchange.Order)}
+	exchange.CreateExchangeManager().Add(e)
+	tracker := exchange.NewOrderTracker()
+	tracker.StatePath = path
+
+	const count = 200
+	for i := 0; i < count; i++ {
+		e.set(fmt.Sprint(i), exchange.Partial, 0.5)
+	}
+
+	done := make(chan struct{})
+	polled := make(chan struct{})
+	go func() {
+		for range tracker.Events {
+		}
+	}()
+	go func() {
+		defer close(polled)
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				tracker.Poll()
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			errs <- tracker.Track(e, &exchange.Order{Pair: p, OrderID: id, Rate: 100, Quantity: 1}, "")
+		}(fmt.Sprint(i))
+	}
+	wg.Wait()
+	close(done)
+	<-polled
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("%v", err)
+		}
+	}
+
+	resumed := exchange.NewOrderTracker()
+	resumed.StatePath = path
+	if err := resumed.Resume(); err != nil {
+		t.Fatalf("%v", err)
+	} else if orders := resumed.Orders(); len(orders) != count {
+		t.Errorf("resumed %d orders, expected %d", len(orders), count)
+	}
+}
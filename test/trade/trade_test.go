@@ -0,0 +1,140 @@
+package trade
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+// the binance aggregate trades of a range, more than one page, then continued from the last id
+func TestTradeHistoryPages(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	c, err := conformance.LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	hour := int64(time.Hour / time.Millisecond)
+	listed := (time.Now().UnixNano()/1e6)/hour*hour - 24*hour
+
+	server := conformance.NewServer(dir)
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	server.HandleFunc("GET", "/api/v3/aggTrades", conformance.BinanceAggTrades(listed))
+	defer conformance.PointTo(exchange.BINANCE, server.URL)()
+
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	if e == nil {
+		t.Fatalf("Init failed")
+	}
+	p, err := c.Pair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	load := func(op *exchange.PublicOperation, firstID, count int) {
+		op.Type = exchange.TradeHistory
+		op.EX = e.GetName()
+		op.Pair = p
+		if err := e.LoadPublicData(op); err != nil {
+			t.Fatalf("%v", err)
+		}
+		if len(op.TradeHistory) != count {
+			t.Fatalf("%d trades, expected %d", len(op.TradeHistory), count)
+		}
+		for i, trade := range op.TradeHistory {
+			if expected := fmt.Sprintf("%d", firstID+i); trade.ID != expected {
+				t.Fatalf("trade %d id %v, expected %v", i, trade.ID, expected)
+			}
+		}
+	}
+
+	// 3 hours of a trade every 10s, both ends included
+	range3h := &exchange.PublicOperation{TradeStartTime: listed, TradeEndTime: listed + 3*hour}
+	load(range3h, 1, 1081)
+
+	last := range3h.TradeHistory[len(range3h.TradeHistory)-1].ID
+	load(&exchange.PublicOperation{TradeFromID: last, TradeEndTime: listed + 4*hour}, 1082, 360)
+
+	// the latest trades have the aggregate ids of the pages
+	latest := &exchange.PublicOperation{Type: exchange.TradeHistory, EX: e.GetName(), Pair: p}
+	if err := e.LoadPublicData(latest); err != nil {
+		t.Fatalf("%v", err)
+	} else if len(latest.TradeHistory) != 1000 {
+		t.Fatalf("%d latest trades, expected 1000", len(latest.TradeHistory))
+	}
+	first := latest.TradeHistory[0]
+	load(&exchange.PublicOperation{TradeStartTime: first.TimeStamp, TradeEndTime: first.TimeStamp}, mustAtoi(t, first.ID), 1)
+}
+
+func mustAtoi(t *testing.T, s string) int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return i
+}
+
+// without pages the latest trades cover a range which starts in them only, pages going back need a lower bound
+func TestTradeRange(t *testing.T) {
+	latest := []*exchange.TradeDetail{
+		{ID: "10", TimeStamp: 1000},
+		{ID: "11", TimeStamp: 2000},
+		{ID: "12", TimeStamp: 3000},
+	}
+	tests := []struct {
+		op       exchange.PublicOperation
+		expected int // -1 if ErrTradeRange
+	}{
+		{exchange.PublicOperation{}, 3},
+		{exchange.PublicOperation{TradeStartTime: 1000}, 3},
+		{exchange.PublicOperation{TradeStartTime: 1500, TradeEndTime: 2500}, 1},
+		{exchange.PublicOperation{TradeStartTime: 999}, -1},
+		{exchange.PublicOperation{TradeFromID: "10"}, 2},
+		{exchange.PublicOperation{TradeFromID: "9"}, -1},
+		{exchange.PublicOperation{TradeEndTime: 2500}, -1},
+	}
+	for _, test := range tests {
+		op := test.op
+		err := exchange.LatestTrades(&op, latest)
+		if test.expected < 0 {
+			if !errors.Is(err, exchange.ErrTradeRange) {
+				t.Errorf("%+v: %v, expected ErrTradeRange", test.op, err)
+			}
+		} else if err != nil {
+			t.Errorf("%+v: %v", test.op, err)
+		} else if len(op.TradeHistory) != test.expected {
+			t.Errorf("%+v: %d trades, expected %d", test.op, len(op.TradeHistory), test.expected)
+		}
+	}
+
+	fetched := 0
+	op := &exchange.PublicOperation{TradeEndTime: 3000}
+	err := exchange.FetchTrades(op, true, func(cursor string) ([]*exchange.TradeDetail, string, error) {
+		fetched++
+		return latest, "9", nil
+	})
+	if !errors.Is(err, exchange.ErrTradeRange) || fetched != 0 {
+		t.Errorf("pages back without a lower bound: %v after %d pages", err, fetched)
+	}
+}
@@ -0,0 +1,163 @@
+package triangular
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/bitontop/gored/arbitrage"
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/exchange/papertrade"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+// pending places the orders of the exchange, they are New until their first OrderStatus
+type pending struct {
+	exchange.Exchange
+}
+
+func (e *pending) LimitBuy(p *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	order, err := e.Exchange.LimitBuy(p, quantity, rate)
+	if order != nil {
+		order.Status = exchange.New
+	}
+	return order, err
+}
+
+func (e *pending) LimitSell(p *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	order, err := e.Exchange.LimitSell(p, quantity, rate)
+	if order != nil {
+		order.Status = exchange.New
+	}
+	return order, err
+}
+
+// USDT -> ETH -> BTC -> USDT is found on papertrade with the binance constraints, sized
+// to the ETHBTC depth and sequenced through the adapter
+func TestTriangular(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	server := conformance.NewServer(dir)
+	server.Handle("GET", "/raw", "127.0.0.1")
+	restore := conformance.PointTo(exchange.BINANCE, server.URL)
+	source := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	restore()
+	server.Close()
+	if source == nil {
+		t.Fatalf("Init failed")
+	}
+	btcusdt, ethbtc, ethusdt := testPair("USDT", "BTC"), testPair("BTC", "ETH"), testPair("USDT", "ETH")
+	usdt := btcusdt.Base
+
+	books := papertrade.NewRecordedBooks()
+	books.Add(btcusdt, book([][2]float64{{9990, 1}}, [][2]float64{{10000, 1}}))
+	books.Add(ethbtc, book([][2]float64{{0.021, 10}}, [][2]float64{{0.0211, 10}}))
+	books.Add(ethusdt, book([][2]float64{{199, 100}}, [][2]float64{{200, 100}}))
+
+	e := papertrade.CreatePapertrade(&papertrade.Config{
+		Source:   source,
+		Feed:     books,
+		Balances: map[string]float64{"USDT": 10000},
+	})
+	if e == nil {
+		t.Fatalf("CreatePapertrade failed")
+	}
+	// ETHUSDT is not TRADING in the fixture
+	constraint := *e.GetPairConstraint(ethbtc)
+	constraint.PairID, constraint.Pair, constraint.ExSymbol = ethusdt.ID, ethusdt, "ETHUSDT"
+	constraint.PriceFilter, constraint.MinTradeBaseQuantity = 0.01, 10
+	e.SetPairConstraint(&constraint)
+	for _, p := range []*pair.Pair{btcusdt, ethbtc, ethusdt} {
+		constraint := *e.GetPairConstraint(p)
+		constraint.TakerFee = 0.001
+		e.SetPairConstraint(&constraint)
+	}
+
+	if cycles := arbitrage.Cycles(e, usdt); len(cycles) != 2 {
+		t.Fatalf("%d cycles: %v", len(cycles), cycles)
+	}
+
+	f := arbitrage.NewTriangularFinder(e)
+	triangles, err := f.Find(usdt, 1000)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if len(triangles) != 1 {
+		t.Fatalf("%d triangles", len(triangles))
+	}
+	tri := triangles[0]
+	if tri.Cycle.String() != "USDT -> ETH -> BTC -> USDT" {
+		t.Fatalf("cycle %v", tri.Cycle)
+	}
+	// 5 ETH @ 200, 4.995 sold @ 0.021, 0.104790 of 0.10479010 sold @ 9990
+	expected := 0.10479 * 9990 * 0.999
+	if !near(tri.Amount, 1000) || !near(tri.Return, expected) || !near(tri.Profit, expected-1000) {
+		t.Errorf("triangle %v -> %v, expected 1000 -> %v", tri.Amount, tri.Return, expected)
+	}
+	if leg := tri.Legs[1]; leg.Direction != exchange.Sell || !near(leg.Quantity, 4.995) || !near(leg.Rate, 0.021) {
+		t.Errorf("leg %+v", leg)
+	}
+
+	// the 10 ETH of ETHBTC bids cap the size, 10.01 ETH bought are 10 after the fee, 2002.02 USDT
+	sized, err := f.Find(usdt, 5000)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if len(sized) != 1 || !near(sized[0].Amount, 2002.02) {
+		t.Fatalf("sized %+v", sized)
+	}
+
+	// without a Timeout the legs wait DEFAULT_SEQUENCE_TIMEOUT, they are filled on their first poll
+	s := &arbitrage.Sequencer{Exchange: &pending{Exchange: e}, Poll: time.Millisecond}
+	orders, err := s.Execute(tri)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if len(orders) != 3 {
+		t.Fatalf("%d orders", len(orders))
+	}
+	for _, order := range orders {
+		if order.Status != exchange.Filled {
+			t.Errorf("%s %v", order.Pair.Name, order.Status)
+		}
+	}
+	if balance := e.GetBalance(usdt); !near(balance, 10000-1000+expected) {
+		t.Errorf("USDT %v, expected %v", balance, 10000-1000+expected)
+	}
+}
+
+// testPair adds the coins if no exchange loaded them
+func testPair(base, target string) *pair.Pair {
+	for _, code := range []string{base, target} {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+	return pair.GetPair(coin.GetCoin(base), coin.GetCoin(target))
+}
+
+func book(bids, asks [][2]float64) *exchange.Maker {
+	maker := &exchange.Maker{}
+	for _, bid := range bids {
+		maker.Bids = append(maker.Bids, exchange.Order{Rate: bid[0], Quantity: bid[1]})
+	}
+	for _, ask := range asks {
+		maker.Asks = append(maker.Asks, exchange.Order{Rate: ask[0], Quantity: ask[1]})
+	}
+	return maker
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,212 @@
+package validate
+
+// Copyright (c) 2015-2019 Bitontop Technologies Inc.
+// Distributed under the MIT software license, see the accompanying
+// file COPYING or http://www.opensource.org/licenses/mit-license.php.
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bitontop/gored/coin"
+	"github.com/bitontop/gored/exchange"
+	"github.com/bitontop/gored/initial"
+	"github.com/bitontop/gored/pair"
+	"github.com/bitontop/gored/test/conformance"
+)
+
+// stubExchange has the constraint of a test and records the orders sent, the methods not overridden panic
+type stubExchange struct {
+	exchange.Exchange
+	constraint *exchange.PairConstraint
+	sent       []*exchange.Order
+}
+
+func (e *stubExchange) GetName() exchange.ExchangeName { return exchange.BINANCE }
+
+func (e *stubExchange) GetPairConstraint(p *pair.Pair) *exchange.PairConstraint { return e.constraint }
+
+func (e *stubExchange) LimitBuy(p *pair.Pair, quantity, rate float64) (*exchange.Order, error) {
+	order := &exchange.Order{Pair: p, Quantity: quantity, Rate: rate, Direction: exchange.Buy}
+	e.sent = append(e.sent, order)
+	return order, nil
+}
+
+func testPair(base, target string) *pair.Pair {
+	for _, code := range []string{base, target} {
+		if coin.GetCoin(code) == nil {
+			coin.AddCoin(&coin.Coin{Code: code})
+		}
+	}
+	return pair.GetPair(coin.GetCoin(base), coin.GetCoin(target))
+}
+
+func TestValidateOrder(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	p := testPair("USDT", "BTC")
+
+	e := &stubExchange{constraint: &exchange.PairConstraint{
+		LotSize:              0.001,
+		PriceFilter:          0.01,
+		MinTradeQuantity:     0.002,
+		MinTradeBaseQuantity: 10,
+	}}
+
+	tests := []struct {
+		name      string
+		direction exchange.TradeDirection
+		quantity  float64
+		rate      float64
+		round     bool
+		field     string // empty if valid
+		expected  [2]float64
+	}{
+		{"on the steps", exchange.Buy, 0.003, 9000.01, false, "", [2]float64{0.003, 9000.01}},
+		{"lot size", exchange.Buy, 0.0035, 9000, false, "quantity", [2]float64{}},
+		{"lot size rounded down", exchange.Buy, 0.0039, 9000, true, "", [2]float64{0.003, 9000}},
+		{"tick size", exchange.Buy, 0.003, 9000.005, false, "rate", [2]float64{}},
+		{"tick size buy rounded down", exchange.Buy, 0.003, 9000.019, true, "", [2]float64{0.003, 9000.01}},
+		{"tick size sell rounded up", exchange.Sell, 0.003, 9000.011, true, "", [2]float64{0.003, 9000.02}},
+		{"min quantity", exchange.Buy, 0.002, 9000, false, "", [2]float64{0.002, 9000}},
+		{"below min quantity", exchange.Buy, 0.001, 20000, false, "quantity", [2]float64{}},
+		{"rounded below min quantity", exchange.Buy, 0.0019, 20000, true, "quantity", [2]float64{}},
+		{"rounded to 0", exchange.Buy, 0.0009, 9000, true, "quantity", [2]float64{}},
+		{"zero quantity", exchange.Buy, 0, 9000, false, "quantity", [2]float64{}},
+		{"negative quantity", exchange.Sell, -1, 9000, false, "quantity", [2]float64{}},
+		{"negative rate", exchange.Buy, 0.003, -1, false, "rate", [2]float64{}},
+		{"min notional", exchange.Buy, 0.002, 5000, false, "", [2]float64{0.002, 5000}},
+		{"below min notional", exchange.Buy, 0.002, 4999.99, false, "notional", [2]float64{}},
+		{"market order without rate", exchange.Buy, 0.002, 0, false, "", [2]float64{0.002, 0}},
+		{"large quantity", exchange.Sell, 123456789.001, 0.01, false, "", [2]float64{123456789.001, 0.01}},
+	}
+
+	for _, test := range tests {
+		quantity, rate, err := exchange.ValidateOrder(e, p, test.direction, test.quantity, test.rate, test.round)
+		if test.field == "" {
+			if err != nil {
+				t.Errorf("%s: %v", test.name, err)
+			} else if quantity != test.expected[0] || rate != test.expected[1] {
+				t.Errorf("%s: %v @ %v, expected %v @ %v", test.name, quantity, rate, test.expected[0], test.expected[1])
+			}
+			continue
+		}
+
+		invalid := &exchange.OrderValidationError{}
+		if !errors.As(err, &invalid) {
+			t.Errorf("%s: err %v, expected an OrderValidationError", test.name, err)
+		} else if invalid.Field != test.field {
+			t.Errorf("%s: field %s, expected %s: %v", test.name, invalid.Field, test.field, err)
+		} else if !errors.Is(err, exchange.ErrInvalidOrder) {
+			t.Errorf("%s: %v doesn't wrap ErrInvalidOrder", test.name, err)
+		}
+	}
+
+	e.constraint = nil
+	if _, _, err := exchange.ValidateOrder(e, p, exchange.Buy, 1, 1, true); !errors.Is(err, exchange.ErrInvalidOrder) {
+		t.Errorf("no constraint: %v", err)
+	}
+}
+
+// rejected orders are not sent, AutoRound sends the rounded order
+func TestValidatedExchange(t *testing.T) {
+	coin.Init()
+	pair.Init()
+	p := testPair("USDT", "BTC")
+
+	e := &stubExchange{constraint: &exchange.PairConstraint{LotSize: 0.001, PriceFilter: 0.01}}
+	if _, err := exchange.NewValidatedExchange(e, false).LimitBuy(p, 0.0015, 9000); err == nil {
+		t.Errorf("quantity off LotSize not rejected")
+	} else if len(e.sent) != 0 {
+		t.Errorf("rejected order sent: %+v", e.sent[0])
+	}
+
+	order, err := exchange.NewValidatedExchange(e, true).LimitBuy(p, 0.0015, 9000.006)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if order.Quantity != 0.001 || order.Rate != 9000 {
+		t.Errorf("sent %v @ %v, expected 0.001 @ 9000", order.Quantity, order.Rate)
+	}
+}
+
+// binance rounds the order to the constraint and returns what was sent, an order below the minimum is rejected offline
+func TestBinanceLimitBuy(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.BINANCE)
+	c, err := conformance.LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	server := conformance.NewServer(dir)
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	defer conformance.PointTo(exchange.BINANCE, server.URL)()
+
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.BINANCE,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+	})
+	if e == nil {
+		t.Fatalf("Init failed, missing: %v", server.Missing())
+	}
+	p, err := c.Pair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	order, err := e.LimitBuy(p, 0.0020009, 9000.104)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if order.Quantity != 0.002 || order.Rate != 9000.1 {
+		t.Errorf("order %v @ %v, expected the sent 0.002 @ 9000.1", order.Quantity, order.Rate)
+	}
+
+	requests := len(server.Requests())
+	if _, err := e.LimitBuy(p, 0.0001, 9000); !errors.Is(err, exchange.ErrInvalidOrder) {
+		t.Errorf("order below the min notional: %v", err)
+	} else if len(server.Requests()) != requests {
+		t.Errorf("rejected order sent")
+	}
+}
+
+// okex rounds the order to the constraint and returns what was sent
+func TestOkexLimitBuy(t *testing.T) {
+	coin.Init()
+	pair.Init()
+
+	dir := conformance.FixtureDir("../conformance/testdata", exchange.OKEX)
+	c, err := conformance.LoadCase(dir)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	server := conformance.NewServer(dir)
+	defer server.Close()
+	server.Handle("GET", "/raw", "127.0.0.1")
+	defer conformance.PointTo(exchange.OKEX, server.URL)()
+
+	e := initial.CreateInitManager().Init(&exchange.Config{
+		ExName:     exchange.OKEX,
+		Source:     exchange.EXCHANGE_API,
+		API_KEY:    "key",
+		API_SECRET: "secret",
+		Passphrase: "passphrase",
+	})
+	if e == nil {
+		t.Fatalf("Init failed, missing: %v", server.Missing())
+	}
+	p, err := c.Pair()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	order, err := e.LimitBuy(p, 0.1000004, 350.504)
+	if err != nil {
+		t.Fatalf("%v", err)
+	} else if order.Quantity != 0.1 || order.Rate != 350.5 {
+		t.Errorf("order %v @ %v, expected the sent 0.1 @ 350.5", order.Quantity, order.Rate)
+	}
+}
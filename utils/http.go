@@ -18,6 +18,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/bitontop/gored/cassette"
 )
 
 type HttpPost struct {
@@ -61,7 +63,7 @@ func HttpPostRequest(httpPost *HttpPost) error {
 	if httpPost.Proxy != "" {
 		proxyUrl, err := url.Parse(httpPost.Proxy)
 		if err == nil {
-			httpClient = &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyUrl)}}
+			httpClient = &http.Client{Transport: cassette.Transport(&http.Transport{Proxy: http.ProxyURL(proxyUrl)})}
 			if httpPost.DebugMode {
 				log.Printf("Apply Proxy @ %s", proxyUrl)
 			}
@@ -202,7 +204,7 @@ func HttpGetRequest(httpGet *HttpGet) error {
 	if httpGet.Proxy != "" {
 		proxyUrl, err := url.Parse(httpGet.Proxy)
 		if err == nil {
-			httpClient = &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyUrl)}}
+			httpClient = &http.Client{Transport: cassette.Transport(&http.Transport{Proxy: http.ProxyURL(proxyUrl)})}
 			if httpGet.DebugMode {
 				log.Printf("Apply Proxy @ %s", proxyUrl)
 			}